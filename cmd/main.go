@@ -1,23 +1,45 @@
 package main
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/config"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	authinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/auth"
+	carrierinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/carrier"
+	eventinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/event"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/mongo"
+	paymentinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/payment"
+	pdfinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/pdf"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/routes"
 
+	adminusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/admin"
+	analyticsusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/analytics"
 	authusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/auth"
 	cartitemusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/cartitem"
 
 	bundleusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/bundle"
+	chatusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/chat"
+	disputeusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/dispute"
+	feeusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/fee"
+	invoiceusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/invoice"
+	ledgerusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/ledger"
+	moneyusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/money"
 	orderusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/order"
+	paymentusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/payment"
 	productusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/product"
+	ratingusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/rating"
 	reviewusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/review"
+	shipmentusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/shipment"
+	shippingusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/shipping"
 	trustusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/trust"
 	userusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/user"
 	warehouse_usecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/warehouse"
@@ -36,9 +58,37 @@ func main() {
 	// Connect to MongoDB
 	db := config.ConnectMongo(appConfig.DBURI, appConfig.DBName)
 
+	// Product search and shipment tracking need their indexes
+	indexCtx, cancelIndex := context.WithTimeout(context.Background(), 30*time.Second)
+	if err := mongo.EnsureProductIndexes(indexCtx, db); err != nil {
+		log.Printf("Failed to create product search index: %v", err)
+	}
+	if err := mongo.EnsureShipmentIndexes(indexCtx, db); err != nil {
+		log.Printf("Failed to create shipment tracking index: %v", err)
+	}
+	cancelIndex()
+
 	// Init shared services
 	jwtSvc := authinfra.NewJWTService(appConfig.JWTSecret)
 	passSvc := authinfra.NewPasswordService()
+	eventHub := eventinfra.NewHub()
+
+	var paymentGateway payment.Gateway = paymentinfra.NewFakeGateway()
+	if appConfig.Payment.Gateway == "stripe" {
+		paymentGateway = paymentinfra.NewStripeGateway(paymentinfra.StripeConfig{
+			APIBase:       appConfig.Payment.StripeAPIBase,
+			SecretKey:     appConfig.Payment.StripeSecretKey,
+			PaymentMethod: appConfig.Payment.StripePaymentMethod,
+		}, nil)
+	}
+
+	// Parcels shipped with the simulated carrier report their progress without a
+	// courier account
+	simulatorStep, err := time.ParseDuration(appConfig.Carrier.SimulatorStep)
+	if err != nil {
+		simulatorStep = time.Minute
+	}
+	carrierSimulator := carrierinfra.NewSimulator(simulatorStep)
 
 	// Init Repositories
 	userRepo := mongo.NewMongoUserRepository(db)
@@ -49,13 +99,30 @@ func main() {
 	reviewRepo := mongo.NewReviewRepository(db)            // Add review repository
 	warehouseRepo := mongo.NewMongoWarehouseRepository(db) // Add warehouse repository
 	paymentRepo := mongo.NewMongoPaymentRepository(db)     // Add payment repository
+	chatRepo := mongo.NewMongoChatRepository(db)
+	ratingRepo := mongo.NewMongoRatingRepository(db)
+	paymentEventRepo := mongo.NewMongoPaymentEventRepository(db)
+	disputeRepo := mongo.NewMongoDisputeRepository(db)
+	ledgerRepo := mongo.NewMongoLedgerRepository(db)
+	feeRuleRepo := mongo.NewMongoFeeRuleRepository(db)
+	exchangeRateRepo := mongo.NewMongoExchangeRateRepository(db)
+	invoiceRepo := mongo.NewMongoInvoiceRepository(db)
+	addressRepo := mongo.NewMongoAddressRepository(db)
+	shipmentRepo := mongo.NewMongoShipmentRepository(db)
+	analyticsRepo := mongo.NewMongoAnalyticsRepository(db)
+	adminRepo := mongo.NewMongoAdminRepository(db)
+	txManager := mongo.NewMongoTransactionManager(db)
 
 	// Init Usecases
 	userUC := userusecase.NewUserUsecase(userRepo)
 	authUC := authusecase.NewAuthUsecase(userRepo, passSvc, jwtSvc)
-	productUC := productusecase.NewProductUsecase(productRepo, bundleRepo)
-	bundleUC := bundleusecase.NewBundleUsecase(bundleRepo)
+	moneyUC := moneyusecase.NewMoneyUsecase(exchangeRateRepo)
+	productUC := productusecase.NewProductUsecase(productRepo, bundleRepo, moneyUC)
+	bundleUC := bundleusecase.NewBundleUsecase(bundleRepo, moneyUC)
 	trustUC := trustusecase.NewTrustUsecase(productRepo, bundleRepo, userRepo)
+	feeUC := feeusecase.NewFeeUsecase(feeRuleRepo, userRepo, txManager)
+	shippingUC := shippingusecase.NewShippingUsecase(addressRepo, shipping.DefaultRateTable())
+	invoiceUC := invoiceusecase.NewInvoiceUsecase(invoiceRepo, orderRepo, userRepo, productRepo, bundleRepo, pdfinfra.NewInvoiceRenderer("Afro Vintage"))
 	orderUC := orderusecase.NewOrderUsecase(
 		bundleRepo,
 		orderRepo,
@@ -63,23 +130,57 @@ func main() {
 		paymentRepo,
 		userRepo,
 		productRepo,
+		eventHub,
+		paymentGateway,
+		txManager,
+		ledgerRepo,
+		feeUC,
+		moneyUC,
+		shippingUC,
+		shipmentusecase.NewDispatcher(shipmentRepo, carrierSimulator),
+		invoiceUC,
 	)
-	cartItemUC := cartitemusecase.NewCartItemUsecase(cartItemRepo, productRepo, paymentRepo, orderUC, orderRepo)
+	cartItemUC := cartitemusecase.NewCartItemUsecase(cartItemRepo, productRepo, orderUC, orderRepo)
+	go cartitemusecase.NewReservationSweeper(productRepo, time.Minute).Run(context.Background())
 
 	reviewUC := reviewusecase.NewReviewUsecase(reviewRepo, orderRepo) // Add review usecase
-	warehouseSvc := warehouse_usecase.NewWarehouseUseCase(warehouseRepo, bundleRepo)
+	warehouseSvc := warehouse_usecase.NewWarehouseUseCase(warehouseRepo, bundleRepo, productRepo, trustUC, txManager)
+	paymentUC := paymentusecase.NewPaymentUsecase(paymentRepo, paymentEventRepo, orderUC)
+	ratingUC := ratingusecase.NewRatingUsecase(ratingRepo, orderRepo, bundleRepo, warehouseRepo)
+	chatUC := chatusecase.NewChatUsecase(chatRepo, orderRepo, bundleRepo, userRepo, eventHub)
+	ledgerUC := ledgerusecase.NewLedgerUsecase(ledgerRepo, paymentRepo, txManager)
+	disputeUC := disputeusecase.NewDisputeUsecase(disputeRepo, orderRepo, orderUC, trustUC, eventHub)
+	shipmentUC := shipmentusecase.NewShipmentUsecase(shipmentRepo, orderUC, eventHub)
+	go shipmentusecase.Consume(context.Background(), carrierSimulator.Updates(), shipmentUC)
+	analyticsUC := analyticsusecase.NewAnalyticsUsecase(analyticsRepo, moneyUC)
+	adminUC := adminusecase.NewAdminUsecase(adminRepo, moneyUC)
 
 	// Init Controllers
 	authCtrl := controllers.NewAuthController(authUC)
-	adminCtrl := controllers.NewAdminController(userUC, orderUC)
-	productCtrl := controllers.NewProductController(productUC, trustUC, bundleUC, warehouseRepo)
-	bundleCtrl := controllers.NewBundleController(bundleUC, userUC)
+	adminCtrl := controllers.NewAdminController(userUC, orderUC, adminUC)
+	productCtrl := controllers.NewProductController(productUC, trustUC, bundleUC, warehouseRepo, warehouseSvc)
+	bundleCtrl := controllers.NewBundleController(bundleUC, userUC, warehouseSvc, moneyUC)
 	consumerCtrl := controllers.NewConsumerController(orderRepo)
 	supplierCtrl := controllers.NewSupplierController(orderUC) // Add consumer controller
-	cartItemCtrl := controllers.NewCartItemController(cartItemUC, productUC)
+	cartItemCtrl := controllers.NewCartItemController(cartItemUC, productUC, moneyUC)
 	reviewCtrl := controllers.NewReviewController(reviewUC, trustUC, productUC) // Add trust and product usecases
 	warehouseCtrl := controllers.NewWarehouseController(warehouseSvc)
 	orderCtrl := controllers.NewOrderController(orderUC) // Add order controller
+	chatCtrl := controllers.NewChatController(chatUC)
+	streamCtrl := controllers.NewStreamController(eventHub)
+	ratingCtrl := controllers.NewRatingController(ratingUC)
+	disputeCtrl := controllers.NewDisputeController(disputeUC)
+	ledgerCtrl := controllers.NewLedgerController(ledgerUC)
+	feeCtrl := controllers.NewFeeController(feeUC)
+	exchangeRateCtrl := controllers.NewExchangeRateController(moneyUC)
+	invoiceCtrl := controllers.NewInvoiceController(invoiceUC)
+	analyticsCtrl := controllers.NewAnalyticsController(analyticsUC)
+	shippingCtrl := controllers.NewShippingController(shippingUC)
+	shipmentCtrl := controllers.NewShipmentController(carrierinfra.NewWebhookVerifier(appConfig.Carrier.WebhookSecret), shipmentUC)
+	webhookCtrl := controllers.NewWebhookController(
+		paymentinfra.NewStripeWebhookVerifier(appConfig.Payment.WebhookSecret, paymentinfra.DefaultWebhookTolerance),
+		paymentUC,
+	)
 
 	// Init Gin Engine and Routes
 	r := gin.Default()
@@ -100,6 +201,18 @@ func main() {
 	routes.RegisterWarehouseRoutes(r, warehouseCtrl, jwtSvc)
 	routes.RegisterResellerRoutes(r, supplierCtrl, jwtSvc)
 	routes.SetupUserRoutes(r, userUC, jwtSvc) // Add user routes
+	routes.RegisterChatRoutes(r, chatCtrl, jwtSvc)
+	routes.RegisterStreamRoutes(r, streamCtrl, jwtSvc)
+	routes.RegisterRatingRoutes(r, ratingCtrl, jwtSvc)
+	routes.RegisterDisputeRoutes(r, disputeCtrl, jwtSvc)
+	routes.RegisterLedgerRoutes(r, ledgerCtrl, jwtSvc)
+	routes.RegisterFeeRoutes(r, feeCtrl, jwtSvc)
+	routes.RegisterExchangeRateRoutes(r, exchangeRateCtrl, jwtSvc)
+	routes.RegisterInvoiceRoutes(r, invoiceCtrl, jwtSvc)
+	routes.RegisterShippingRoutes(r, shippingCtrl, jwtSvc)
+	routes.RegisterShipmentRoutes(r, shipmentCtrl, jwtSvc)
+	routes.RegisterWebhookRoutes(r, webhookCtrl)
+	routes.RegisterAnalyticsRoutes(r, analyticsCtrl, jwtSvc)
 
 	// Run server
 	r.Run(":8080")
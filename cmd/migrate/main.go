@@ -0,0 +1,30 @@
+// Command migrate converts stored amounts to the minor-unit, currency-tagged form.
+// It is safe to run more than once.
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/config"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/mongo"
+)
+
+func main() {
+	config.LoadEnv()
+	appConfig := config.LoadAppConfig()
+	db := config.ConnectMongo(appConfig.DBURI, appConfig.DBName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	updated, err := mongo.MigrateMoney(ctx, db)
+	for collection, n := range updated {
+		log.Printf("%s: %d documents converted", collection, n)
+	}
+	if err != nil {
+		log.Fatal("Migration failed: ", err)
+	}
+	log.Println("Money migration complete")
+}
@@ -9,6 +9,23 @@ type AppConfig struct {
 	DBURI     string
 	DBName    string
 	JWTSecret string
+	Payment   PaymentConfig
+	Carrier   CarrierConfig
+}
+
+type PaymentConfig struct {
+	Gateway             string // "fake" or "stripe"
+	StripeAPIBase       string
+	StripeSecretKey     string
+	StripePaymentMethod string
+	WebhookSecret       string
+}
+
+type CarrierConfig struct {
+	WebhookSecret string
+	// SimulatorStep is how long the simulated carrier takes between tracking
+	// events, as a Go duration.
+	SimulatorStep string
 }
 
 func LoadAppConfig() AppConfig {
@@ -16,5 +33,16 @@ func LoadAppConfig() AppConfig {
 		DBURI:     GetEnv("MONGO_URI", "mongodb://localhost:27017"),
 		DBName:    GetEnv("DB_NAME", "afro_vintage"),
 		JWTSecret: GetEnv("JWT_SECRET", "fallback-secret"),
+		Payment: PaymentConfig{
+			Gateway:             GetEnv("PAYMENT_GATEWAY", "fake"),
+			StripeAPIBase:       GetEnv("STRIPE_API_BASE", "https://api.stripe.com"),
+			StripeSecretKey:     GetEnv("STRIPE_SECRET_KEY", ""),
+			StripePaymentMethod: GetEnv("STRIPE_PAYMENT_METHOD", "pm_card_visa"),
+			WebhookSecret:       GetEnv("STRIPE_WEBHOOK_SECRET", ""),
+		},
+		Carrier: CarrierConfig{
+			WebhookSecret: GetEnv("CARRIER_WEBHOOK_SECRET", ""),
+			SimulatorStep: GetEnv("CARRIER_SIMULATOR_STEP", "1m"),
+		},
 	}
 }
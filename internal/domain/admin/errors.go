@@ -0,0 +1,5 @@
+package admin
+
+import "errors"
+
+var ErrInvalidLimit = errors.New("limit must be between 1 and 50")
@@ -1,9 +1,11 @@
 package admin
 
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+
 type Metrics struct {
 	TotalBundles    int
 	TotalUsers      int
-	TotalSales      float64
+	TotalSales      []money.Money
 	SkippedClothes  int
-	RevenueFromFees float64
+	RevenueFromFees []money.Money
 }
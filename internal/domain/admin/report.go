@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"math"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// DefaultTopLimit and MaxTopLimit bound how many sellers a ranking lists.
+const (
+	DefaultTopLimit = 10
+	MaxTopLimit     = 50
+)
+
+// ReportRange is the span a report covers. Amounts in the report are in Currency;
+// amounts in currencies without an exchange rate to it are left out.
+type ReportRange struct {
+	Interval analytics.Interval `json:"interval"`
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Currency money.Currency     `json:"currency"`
+}
+
+func NewReportRange(q *analytics.SeriesQuery) ReportRange {
+	return ReportRange{Interval: q.Interval, From: q.From, To: q.To, Currency: q.Currency}
+}
+
+// RevenueRow totals one type of payment in one period. Refunds count against the
+// period they were made in.
+type RevenueRow struct {
+	Start    time.Time           `json:"start" bson:"start"`
+	Type     payment.PaymentType `json:"type" bson:"type"`
+	Payments int                 `json:"payments" bson:"payments"`
+	// GMV is what buyers paid, shipping included.
+	GMV        money.Money `json:"gmv" bson:"gmv"`
+	FeeRevenue money.Money `json:"fee_revenue" bson:"fee_revenue"`
+}
+
+type RevenueReport struct {
+	ReportRange
+	// Rows is ordered by period, then type. Periods without payments are left out.
+	Rows       []*RevenueRow `json:"rows"`
+	GMV        money.Money   `json:"gmv"`
+	FeeRevenue money.Money   `json:"fee_revenue"`
+}
+
+type UserRow struct {
+	Start    time.Time `json:"start" bson:"start"`
+	Role     string    `json:"role" bson:"role"`
+	NewUsers int       `json:"new_users" bson:"new_users"`
+}
+
+// BlacklistRate is the share of a role's active users blacklisted right now.
+type BlacklistRate struct {
+	Role        string  `json:"role" bson:"_id"`
+	Active      int     `json:"active" bson:"active"`
+	Blacklisted int     `json:"blacklisted" bson:"blacklisted"`
+	Rate        float64 `json:"rate_percent" bson:"rate"`
+}
+
+type UserReport struct {
+	ReportRange
+	// Rows counts sign-ups by period and role. Periods without any are left out.
+	Rows      []*UserRow       `json:"rows"`
+	Blacklist []*BlacklistRate `json:"blacklist"`
+}
+
+// SkipRow counts the pieces resellers unpacked in a period and how many of them
+// were skipped as unsellable.
+type SkipRow struct {
+	Start    time.Time `json:"start" bson:"_id"`
+	Unpacked int       `json:"unpacked" bson:"unpacked"`
+	Skipped  int       `json:"skipped" bson:"skipped"`
+	Ratio    float64   `json:"skipped_percent" bson:"ratio"`
+}
+
+type SkipReport struct {
+	ReportRange
+	Rows     []*SkipRow `json:"rows"`
+	Unpacked int        `json:"unpacked"`
+	Skipped  int        `json:"skipped"`
+	Ratio    float64    `json:"skipped_percent"`
+}
+
+// TopSeller is a seller's sales over the range: suppliers' bundle sales or
+// resellers' product sales.
+type TopSeller struct {
+	UserID     string      `json:"user_id" bson:"_id"`
+	Username   string      `json:"username" bson:"username"`
+	Sales      int         `json:"sales" bson:"sales"`
+	GMV        money.Money `json:"gmv" bson:"gmv"`
+	FeeRevenue money.Money `json:"fee_revenue" bson:"fee_revenue"`
+}
+
+type TopSellersReport struct {
+	ReportRange
+	// Both rankings are by GMV, highest first.
+	Suppliers []*TopSeller `json:"suppliers"`
+	Resellers []*TopSeller `json:"resellers"`
+}
+
+// SkipRatio is skipped as a percentage of unpacked, 0 when nothing was unpacked.
+func SkipRatio(unpacked, skipped int) float64 {
+	if unpacked == 0 {
+		return 0
+	}
+	return math.Round(float64(skipped)/float64(unpacked)*10000) / 100
+}
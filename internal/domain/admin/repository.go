@@ -1,9 +1,21 @@
 package admin
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+)
 
 type Repository interface {
 	FetchPlatformMetrics(ctx context.Context) (*Metrics, error)
 	GetActiveUsersCount(ctx context.Context) (int, error)
-	GetRevenueReport(ctx context.Context) (float64, error)
+
+	// The reports below cover q's range in q.Interval periods, converting
+	// amounts into q.Currency with q.Factors.
+	GetRevenueReport(ctx context.Context, q *analytics.SeriesQuery) ([]*RevenueRow, error)
+	GetNewUsers(ctx context.Context, q *analytics.SeriesQuery) ([]*UserRow, error)
+	GetSkipReport(ctx context.Context, q *analytics.SeriesQuery) ([]*SkipRow, error)
+	GetTopSellers(ctx context.Context, q *analytics.SeriesQuery, limit int) (suppliers, resellers []*TopSeller, err error)
+
+	GetBlacklistRates(ctx context.Context) ([]*BlacklistRate, error)
 }
@@ -0,0 +1,15 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+)
+
+type Usecase interface {
+	GetDashboardMetrics(ctx context.Context) (*Metrics, error)
+	GetRevenueReport(ctx context.Context, q *analytics.SeriesQuery) (*RevenueReport, error)
+	GetUserReport(ctx context.Context, q *analytics.SeriesQuery) (*UserReport, error)
+	GetSkipReport(ctx context.Context, q *analytics.SeriesQuery) (*SkipReport, error)
+	GetTopSellers(ctx context.Context, q *analytics.SeriesQuery, limit int) (*TopSellersReport, error)
+}
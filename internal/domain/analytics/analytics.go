@@ -0,0 +1,55 @@
+package analytics
+
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+
+// BundleROI is how a bundle the reseller bought has paid off so far. Amounts are
+// in the report currency; sales in currencies without an exchange rate to it are
+// left out of the totals.
+type BundleROI struct {
+	BundleID    string `json:"bundle_id" bson:"bundle_id"`
+	OrderID     string `json:"order_id" bson:"order_id"`
+	SupplierID  string `json:"supplier_id" bson:"supplier_id"`
+	Title       string `json:"title" bson:"title"`
+	PurchasedAt string `json:"purchased_at" bson:"purchased_at"`
+
+	// Cost is what the reseller paid for the bundle, shipping included.
+	Cost money.Money `json:"cost" bson:"cost"`
+	// Revenue is the price of every product sold from the bundle, and
+	// PlatformFees their share of the fees charged on those sales.
+	Revenue      money.Money `json:"revenue" bson:"revenue"`
+	PlatformFees money.Money `json:"platform_fees" bson:"platform_fees"`
+	// Margin is Revenue less PlatformFees and Cost; ROI is Margin as a
+	// percentage of Cost.
+	Margin money.Money `json:"margin" bson:"margin"`
+	ROI    float64     `json:"roi_percent" bson:"roi"`
+
+	Pieces int `json:"pieces" bson:"pieces"`
+	Listed int `json:"listed" bson:"listed"`
+	Sold   int `json:"sold" bson:"sold"`
+	// SellThroughRate is the percentage of the bundle's pieces sold.
+	SellThroughRate float64 `json:"sell_through_percent" bson:"sell_through"`
+	// AvgDaysToSell counts from the bundle's purchase to each sale. It is nil
+	// until something sells.
+	AvgDaysToSell *float64 `json:"avg_days_to_sell" bson:"avg_days_to_sell"`
+	// SkippedLoss is the cost of the pieces skipped while unpacking, at the
+	// bundle's average cost per piece.
+	Skipped     int         `json:"skipped" bson:"skipped"`
+	SkippedLoss money.Money `json:"skipped_loss" bson:"skipped_loss"`
+}
+
+// SupplierMargin totals the reseller's results across one supplier's bundles.
+type SupplierMargin struct {
+	SupplierID   string      `json:"supplier_id" bson:"_id"`
+	SupplierName string      `json:"supplier_name" bson:"supplier_name"`
+	Bundles      int         `json:"bundles" bson:"bundles"`
+	Cost         money.Money `json:"cost" bson:"cost"`
+	Margin       money.Money `json:"margin" bson:"margin"`
+	ROI          float64     `json:"roi_percent" bson:"roi"`
+}
+
+type ResellerROI struct {
+	Currency money.Currency `json:"currency"`
+	Bundles  []*BundleROI   `json:"bundles"`
+	// Suppliers is ranked by realized margin, best first.
+	Suppliers []*SupplierMargin `json:"suppliers"`
+}
@@ -0,0 +1,11 @@
+package analytics
+
+import "errors"
+
+var (
+	ErrInvalidInterval = errors.New("interval must be day, week or month")
+	ErrInvalidRange    = errors.New("from must be before to")
+	// ErrRangeTooLong is returned when a range would split into more than
+	// MaxPeriods periods; a coarser interval covers it.
+	ErrRangeTooLong = errors.New("date range has too many periods for the interval")
+)
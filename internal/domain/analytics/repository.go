@@ -0,0 +1,19 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type Repository interface {
+	// ResellerROI reports on the reseller's bundle purchases in currency.
+	// factors maps each currency with an exchange rate to the minor-unit factor
+	// converting it into currency, as money.MinorFactors returns.
+	ResellerROI(ctx context.Context, resellerID string, currency money.Currency, factors map[money.Currency]float64) (*ResellerROI, error)
+	// SupplierSales buckets the supplier's listings, sales and trust score by
+	// q.Interval. Only periods with a listing, a sale or a new trust score are
+	// returned, in no particular order; a trust score recorded before q.From
+	// counts for the first period.
+	SupplierSales(ctx context.Context, supplierID string, q *SeriesQuery) ([]*SupplierPeriod, error)
+}
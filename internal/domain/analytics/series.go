@@ -0,0 +1,144 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type Interval string
+
+const (
+	Day   Interval = "day"
+	Week  Interval = "week"
+	Month Interval = "month"
+)
+
+// MaxPeriods caps how many periods one series may have.
+const MaxPeriods = 400
+
+// defaultPeriods is how far back a series goes when no start is given.
+var defaultPeriods = map[Interval]int{
+	Day:   30,
+	Week:  12,
+	Month: 12,
+}
+
+func (i Interval) Valid() bool {
+	switch i {
+	case Day, Week, Month:
+		return true
+	}
+	return false
+}
+
+// Truncate returns the start of the period t falls in. Periods are in UTC and
+// weeks start on Monday, as the $dateTrunc stages that bucket the data do.
+func (i Interval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch i {
+	case Week:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// Next returns the start of the period after the one starting at start.
+func (i Interval) Next(start time.Time) time.Time {
+	switch i {
+	case Week:
+		return start.AddDate(0, 0, 7)
+	case Month:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// SeriesQuery asks for figures bucketed by Interval over [From, To).
+type SeriesQuery struct {
+	From     time.Time
+	To       time.Time
+	Interval Interval
+	Currency money.Currency
+
+	// Factors holds, for every currency with an exchange rate, how many minor
+	// units of Currency one of its minor units is worth. The usecase fills it in;
+	// amounts in currencies missing from it are left out.
+	Factors map[money.Currency]float64
+}
+
+// SetDefaults fills in what the caller left out: daily periods in
+// money.DefaultCurrency, up to now, going back a few dozen days, weeks or months
+// to match the interval.
+func (q *SeriesQuery) SetDefaults(now time.Time) {
+	if q.Interval == "" {
+		q.Interval = Day
+	}
+	if q.Currency == "" {
+		q.Currency = money.DefaultCurrency
+	}
+	if q.To.IsZero() {
+		q.To = now.UTC()
+	}
+	if q.From.IsZero() && q.Interval.Valid() {
+		q.From = q.Interval.Truncate(q.To)
+		for i := 1; i < defaultPeriods[q.Interval]; i++ {
+			q.From = q.Interval.Truncate(q.From.AddDate(0, 0, -1))
+		}
+	}
+}
+
+func (q *SeriesQuery) Validate() error {
+	if !q.Interval.Valid() {
+		return ErrInvalidInterval
+	}
+	if !q.From.Before(q.To) {
+		return ErrInvalidRange
+	}
+	if len(q.Periods()) > MaxPeriods {
+		return ErrRangeTooLong
+	}
+	return nil
+}
+
+// Periods lists the start of every period overlapping the range. It stops just
+// past MaxPeriods, so an over-long range is cheap to detect.
+func (q *SeriesQuery) Periods() []time.Time {
+	var starts []time.Time
+	for start := q.Interval.Truncate(q.From); start.Before(q.To) && len(starts) <= MaxPeriods; start = q.Interval.Next(start) {
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// SupplierPeriod is how a supplier's bundles did in one period. Amounts are in
+// the series currency.
+type SupplierPeriod struct {
+	Start           time.Time `json:"start" bson:"_id"`
+	ListingsCreated int       `json:"listings_created" bson:"listings_created"`
+	BundlesSold     int       `json:"bundles_sold" bson:"bundles_sold"`
+	// Revenue is what the supplier earned on the period's sales after platform
+	// fees, without the shipping passed through to them.
+	Revenue      money.Money `json:"revenue" bson:"revenue"`
+	AvgSalePrice money.Money `json:"avg_sale_price" bson:"avg_sale_price"`
+	// AvgDaysToSale counts from each sold bundle's listing to its sale. It is nil
+	// when nothing sold in the period.
+	AvgDaysToSale *float64 `json:"avg_days_to_sale" bson:"avg_days_to_sale"`
+	// TrustScore is the supplier's score at the end of the period, nil before
+	// their first recorded score.
+	TrustScore *int `json:"trust_score" bson:"trust_score"`
+}
+
+type SupplierSeries struct {
+	SupplierID string            `json:"supplier_id"`
+	Interval   Interval          `json:"interval"`
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Currency   money.Currency    `json:"currency"`
+	Periods    []*SupplierPeriod `json:"periods"`
+}
@@ -0,0 +1,43 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalTruncate(t *testing.T) {
+	// A Thursday, late in the day.
+	at := time.Date(2026, 10, 15, 22, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), Day.Truncate(at))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Week.Truncate(at))
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Month.Truncate(at))
+	// Sunday belongs to the week that started the Monday before.
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Week.Truncate(time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestSeriesQueryPeriods(t *testing.T) {
+	q := &SeriesQuery{
+		From:     time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC),
+		To:       time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Interval: Month,
+	}
+
+	assert.NoError(t, q.Validate())
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}, q.Periods())
+}
+
+func TestSeriesQueryValidate(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.ErrorIs(t, (&SeriesQuery{From: from, To: from, Interval: Day}).Validate(), ErrInvalidRange)
+	assert.ErrorIs(t, (&SeriesQuery{From: from, To: from.AddDate(0, 0, 1), Interval: "year"}).Validate(), ErrInvalidInterval)
+	assert.ErrorIs(t, (&SeriesQuery{From: from, To: from.AddDate(2, 0, 0), Interval: Day}).Validate(), ErrRangeTooLong)
+	assert.NoError(t, (&SeriesQuery{From: from, To: from.AddDate(2, 0, 0), Interval: Week}).Validate())
+}
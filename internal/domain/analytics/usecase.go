@@ -0,0 +1,12 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type Usecase interface {
+	GetResellerROI(ctx context.Context, resellerID string, currency money.Currency) (*ResellerROI, error)
+	GetSupplierSeries(ctx context.Context, supplierID string, q *SeriesQuery) (*SupplierSeries, error)
+}
@@ -0,0 +1,74 @@
+package bundle
+
+import (
+	"sort"
+	"strings"
+)
+
+// CategoryBreakdown compares how many pieces of one category the supplier
+// estimated with how many the reseller actually unpacked.
+type CategoryBreakdown struct {
+	Category  string `json:"category"`
+	Estimated int    `json:"estimated"`
+	Actual    int    `json:"actual"`
+	// DeviationPercent is how far Actual is from Estimated, relative to
+	// Estimated. It is nil for categories the supplier did not estimate.
+	DeviationPercent *float64 `json:"deviation_percent"`
+}
+
+// Breakdown is a bundle's actual contents next to the supplier's estimate.
+type Breakdown struct {
+	Categories []CategoryBreakdown `json:"categories"`
+	// Unpacked counts the pieces accounted for so far, listed or skipped.
+	Unpacked int `json:"unpacked"`
+	// Complete is set once the reseller has accounted for every piece.
+	Complete bool `json:"complete"`
+	// DeviationPercent is the share of estimated pieces that did not turn up in
+	// their category, counting surplus in other categories as well. It is nil
+	// when the supplier gave no estimate.
+	DeviationPercent *float64 `json:"deviation_percent"`
+}
+
+// CompareBreakdown lines up the actual piece counts per category with the
+// estimate. Categories are matched case-insensitively.
+func CompareBreakdown(estimated, actual map[string]int) *Breakdown {
+	counts := make(map[string]*CategoryBreakdown)
+	category := func(name string) *CategoryBreakdown {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if counts[key] == nil {
+			counts[key] = &CategoryBreakdown{Category: key}
+		}
+		return counts[key]
+	}
+	for name, n := range estimated {
+		category(name).Estimated += n
+	}
+	b := &Breakdown{Categories: make([]CategoryBreakdown, 0, len(counts))}
+	for name, n := range actual {
+		category(name).Actual += n
+		b.Unpacked += n
+	}
+
+	estimatedTotal, missed := 0, 0
+	for _, c := range counts {
+		if c.Estimated > 0 {
+			deviation := float64(c.Actual-c.Estimated) / float64(c.Estimated) * 100
+			c.DeviationPercent = &deviation
+		}
+		estimatedTotal += c.Estimated
+		if c.Actual > c.Estimated {
+			missed += c.Actual - c.Estimated
+		} else {
+			missed += c.Estimated - c.Actual
+		}
+		b.Categories = append(b.Categories, *c)
+	}
+	sort.Slice(b.Categories, func(i, j int) bool {
+		return b.Categories[i].Category < b.Categories[j].Category
+	})
+	if estimatedTotal > 0 {
+		deviation := float64(missed) / float64(estimatedTotal) * 100
+		b.DeviationPercent = &deviation
+	}
+	return b
+}
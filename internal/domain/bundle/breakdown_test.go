@@ -0,0 +1,42 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareBreakdown(t *testing.T) {
+	b := CompareBreakdown(
+		map[string]int{"jackets": 20, "Jeans": 30},
+		map[string]int{"Jackets": 25, "jeans": 15, "shirts": 5},
+	)
+
+	require.Len(t, b.Categories, 3)
+	assert.Equal(t, CategoryBreakdown{Category: "jackets", Estimated: 20, Actual: 25, DeviationPercent: b.Categories[0].DeviationPercent}, b.Categories[0])
+	assert.InDelta(t, 25.0, *b.Categories[0].DeviationPercent, 0.001)
+	assert.InDelta(t, -50.0, *b.Categories[1].DeviationPercent, 0.001)
+	// Shirts were not estimated, so they have no deviation of their own
+	assert.Equal(t, "shirts", b.Categories[2].Category)
+	assert.Nil(t, b.Categories[2].DeviationPercent)
+
+	assert.Equal(t, 45, b.Unpacked)
+	// 5 surplus jackets, 15 missing jeans and 5 unexpected shirts against 50 estimated
+	require.NotNil(t, b.DeviationPercent)
+	assert.InDelta(t, 50.0, *b.DeviationPercent, 0.001)
+}
+
+func TestCompareBreakdown_NoEstimate(t *testing.T) {
+	b := CompareBreakdown(nil, map[string]int{"jackets": 3})
+
+	assert.Nil(t, b.DeviationPercent)
+	assert.Equal(t, 3, b.Unpacked)
+}
+
+func TestCompareBreakdown_ExactMatch(t *testing.T) {
+	b := CompareBreakdown(map[string]int{"jackets": 2}, map[string]int{"jackets": 2})
+
+	require.NotNil(t, b.DeviationPercent)
+	assert.Zero(t, *b.DeviationPercent)
+}
@@ -1,6 +1,10 @@
 package bundle
 
-import "time"
+import (
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
 
 type SortingLevel string
 
@@ -21,7 +25,7 @@ type Bundle struct {
 	SortingLevel       SortingLevel   `bson:"sortinglevel"`
 	EstimatedBreakdown map[string]int `bson:"estimatedBreakdown,omitempty"`
 	Type               string         `bson:"type,omitempty"`
-	Price              float64        `bson:"price"`
+	Price              money.Money    `bson:"price"`
 	Status             string         `bson:"status"`
 	CreatedAt          string         `bson:"createdat"`
 	DateListed         time.Time      `json:"dateListed" bson:"datelisted"`
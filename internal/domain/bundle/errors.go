@@ -0,0 +1,8 @@
+package bundle
+
+import "errors"
+
+// ErrBundleAlreadySold is returned when a bundle is bought by someone else first.
+var ErrBundleAlreadySold = errors.New("bundle already sold")
+
+var ErrInvalidQuery = errors.New("invalid bundle query: check sort, sorting levels and ranges")
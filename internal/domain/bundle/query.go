@@ -0,0 +1,77 @@
+package bundle
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
+
+type Sort string
+
+const (
+	SortNewest           Sort = "newest"
+	SortPricePerItemAsc  Sort = "price_per_item_asc"
+	SortPricePerItemDesc Sort = "price_per_item_desc"
+)
+
+func (s Sort) Valid() bool {
+	switch s {
+	case SortNewest, SortPricePerItemAsc, SortPricePerItemDesc:
+		return true
+	}
+	return false
+}
+
+// ByPricePerItem reports whether the sort orders bundles by their price divided
+// by their quantity.
+func (s Sort) ByPricePerItem() bool {
+	return s == SortPricePerItemAsc || s == SortPricePerItemDesc
+}
+
+// Query browses the bundles on sale. Filters on the same field match any of the
+// given values; different fields must all match. Zero bounds are not applied.
+type Query struct {
+	Grades        []string
+	SortingLevels []SortingLevel
+	Types         []string
+	SizeRanges    []string
+	// MinPrice and MaxPrice bound the bundle's total price in Currency. Bundles
+	// listed in another currency are compared at the configured exchange rate.
+	Currency    money.Currency
+	MinPrice    *money.Money
+	MaxPrice    *money.Money
+	MinRating   int
+	MinQuantity int
+	MaxQuantity int
+	// MinTrustScore keeps bundles whose supplier has at least this trust score.
+	MinTrustScore int
+	Sort          Sort
+	// A cursor is only valid with the sort it was issued for.
+	pagination.Request
+
+	// PriceFactors holds, for every listing currency with an exchange rate, how
+	// many minor units of Currency one of its minor units is worth. The usecase
+	// fills it in; bundles in currencies missing from it have no comparable price
+	// and are left out of price ranges and price sorts.
+	PriceFactors map[money.Currency]float64
+}
+
+func (q *Query) Validate() error {
+	if !q.Sort.Valid() {
+		return ErrInvalidQuery
+	}
+	for _, level := range q.SortingLevels {
+		if level != Sorted && level != SemiSorted && level != Unsorted {
+			return ErrInvalidQuery
+		}
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil && q.MinPrice.Amount > q.MaxPrice.Amount {
+		return ErrInvalidQuery
+	}
+	if q.MinQuantity < 0 || q.MaxQuantity < 0 || (q.MaxQuantity > 0 && q.MinQuantity > q.MaxQuantity) {
+		return ErrInvalidQuery
+	}
+	if q.MinRating < 0 || q.MinTrustScore < 0 {
+		return ErrInvalidQuery
+	}
+	return nil
+}
@@ -1,15 +1,20 @@
 package bundle
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Repository interface {
 	CreateBundle(ctx context.Context, b *Bundle) error
 	GetBundleByID(ctx context.Context, id string) (*Bundle, error) // Already present
-	ListBundles(ctx context.Context, supplierID string) ([]*Bundle, error)
-	ListAvailableBundles(ctx context.Context) ([]*Bundle, error)
-	ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*Bundle, error)
+	ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*Bundle], error)
+	ListAvailableBundles(ctx context.Context, q *Query) (*pagination.Page[*Bundle], error)
+	ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*Bundle], error)
 	UpdateBundleStatus(ctx context.Context, id string, status string) error
 	MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error
+	ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error
 	DeleteBundle(ctx context.Context, bundleID string) error
 	UpdateBundle(ctx context.Context, id string, updatedData map[string]interface{}) error // Added
 	DecreaseBundleQuantity(ctx context.Context, bundleID string) error
@@ -1,15 +1,18 @@
 package bundle
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Usecase interface {
 	CreateBundle(ctx context.Context, supplierID string, bundle *Bundle) error
-	ListBundles(ctx context.Context, supplierID string) ([]*Bundle, error)
+	ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*Bundle], error)
 	DeleteBundle(ctx context.Context, supplierID string, bundleID string) error
 	GetBundleByID(ctx context.Context, supplierID string, id string) (*Bundle, error)                         // Added
 	UpdateBundle(ctx context.Context, supplierID string, id string, updatedData map[string]interface{}) error // Added
-	ListAvailableBundles(ctx context.Context) ([]*Bundle, error)
-	DecreaseRemainingItemCount(ctx context.Context, bundleID string) error
+	ListAvailableBundles(ctx context.Context, q *Query) (*pagination.Page[*Bundle], error)
 	GetBundlePublicByID(ctx context.Context, bundleID string) (*Bundle, error)
 	GetBundleByTitle(ctx context.Context, title string) (*Bundle, error)
 }
@@ -1,14 +1,18 @@
 package cartitem
 
-import "time"
+import (
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
 
 type CartItem struct {
-	ID        string    `bson:"_id" json:"id"`        // UUID
-	UserID    string    `bson:"userid" json:"userid"` // consumer
-	ListingID string    `bson:"listingid" json:"listingid"`
-	Title     string    `bson:"title" json:"title"`
-	Price     float64   `bson:"price" json:"price"`
-	ImageURL  string    `bson:"imageurl" json:"imageurl"`
-	Grade     string    `bson:"grade" json:"grade"` // Reseller's assigned rating (e.g., 93)
-	CreatedAt time.Time `bson:"createdat" json:"createdat"`
+	ID        string      `bson:"_id" json:"id"`        // UUID
+	UserID    string      `bson:"userid" json:"userid"` // consumer
+	ListingID string      `bson:"listingid" json:"listingid"`
+	Title     string      `bson:"title" json:"title"`
+	Price     money.Money `bson:"price" json:"price"`
+	ImageURL  string      `bson:"imageurl" json:"imageurl"`
+	Grade     string      `bson:"grade" json:"grade"` // Reseller's assigned rating (e.g., 93)
+	CreatedAt time.Time   `bson:"createdat" json:"createdat"`
 }
@@ -3,6 +3,8 @@ package cartitem
 import (
 	"context"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 )
 
@@ -17,6 +19,9 @@ type Usecase interface {
 	// RemoveCartItem deletes a specific item from the user's cart.
 	RemoveCartItem(ctx context.Context, userID string, listingID string) error
 
-	CheckoutCart(ctx context.Context, userID string) (*models.CheckoutResponse, error)
-	CheckoutSingleItem(ctx context.Context, userID, listingID string) (*models.CheckoutResponse, error)
+	// CheckoutCart and CheckoutSingleItem charge the user in currency, or in the
+	// listing currency when it is empty, and ship to the address and with the
+	// option in delivery.
+	CheckoutCart(ctx context.Context, userID string, currency money.Currency, delivery shipping.Selection) (*models.CheckoutResponse, error)
+	CheckoutSingleItem(ctx context.Context, userID, listingID string, currency money.Currency, delivery shipping.Selection) (*models.CheckoutResponse, error)
 }
@@ -0,0 +1,14 @@
+package chat
+
+import "errors"
+
+var (
+	// ErrNotRelated is returned when two users have no order, bundle or prior conversation linking them.
+	ErrNotRelated = errors.New("you can only message users you have an order or bundle with")
+
+	// ErrMessageNotFound is returned when a message does not exist.
+	ErrMessageNotFound = errors.New("message not found")
+
+	// ErrNotReceiver is returned when a user tries to mark someone else's message as read.
+	ErrNotReceiver = errors.New("only the receiver can mark a message as read")
+)
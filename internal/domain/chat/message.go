@@ -9,11 +9,12 @@ const (
 )
 
 type ChatMessage struct {
-	ID         string
-	SenderID   string
-	ReceiverID string
-	Text       string
-	Type       MessageType
-	Timestamp  string
-	Seen       bool
+	ID         string      `bson:"_id" json:"id"`
+	SenderID   string      `bson:"sender_id" json:"sender_id"`
+	ReceiverID string      `bson:"receiver_id" json:"receiver_id"`
+	Text       string      `bson:"text" json:"text"`
+	Type       MessageType `bson:"type" json:"type"`
+	RelatedTo  string      `bson:"related_to,omitempty" json:"related_to,omitempty"` // order or bundle ID the conversation is about
+	Timestamp  string      `bson:"timestamp" json:"timestamp"`
+	Seen       bool        `bson:"seen" json:"seen"`
 }
@@ -1,10 +1,17 @@
 package chat
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Repository interface {
 	SendMessage(ctx context.Context, msg *ChatMessage) error
-	GetMessagesBetweenUsers(ctx context.Context, user1, user2 string) ([]*ChatMessage, error)
+	GetMessageByID(ctx context.Context, messageID string) (*ChatMessage, error)
+	ListMessagesBetweenUsers(ctx context.Context, user1, user2 string, req pagination.Request) (*pagination.Page[*ChatMessage], error)
+	HasConversation(ctx context.Context, user1, user2 string) (bool, error)
 	MarkAsSeen(ctx context.Context, messageID string) error
-	ListConversationsForUser(ctx context.Context, userID string) ([]*ChatMessage, error)
+	MarkConversationAsSeen(ctx context.Context, receiverID, senderID string) error
+	ListConversationsForUser(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*ChatMessage], error)
 }
@@ -0,0 +1,25 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
+
+type Usecase interface {
+	// SendMessage stores a message from senderID after checking that both users
+	// share an order or bundle (msg.RelatedTo) or an existing conversation.
+	SendMessage(ctx context.Context, senderID string, msg *ChatMessage) error
+
+	// ListConversations returns the latest message of every conversation the user is part of.
+	ListConversations(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*ChatMessage], error)
+
+	// GetConversation returns one page of the history between userID and counterpartyID, newest first.
+	GetConversation(ctx context.Context, userID, counterpartyID string, req pagination.Request) (*pagination.Page[*ChatMessage], error)
+
+	// MarkAsSeen marks a single message as read. Only the receiver may do this.
+	MarkAsSeen(ctx context.Context, userID, messageID string) error
+
+	// MarkConversationAsSeen marks every message counterpartyID sent to userID as read.
+	MarkConversationAsSeen(ctx context.Context, userID, counterpartyID string) error
+}
@@ -0,0 +1,93 @@
+package dispute
+
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+
+// Status is where a dispute is in its lifecycle:
+//
+//	open -> responded -> resolved
+//	open -> resolved
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusResponded Status = "responded"
+	StatusResolved  Status = "resolved"
+)
+
+type Reason string
+
+const (
+	ReasonMisgraded      Reason = "misgraded"
+	ReasonDamaged        Reason = "damaged"
+	ReasonNotAsDescribed Reason = "not_as_described"
+	ReasonMissingItems   Reason = "missing_items"
+)
+
+// Remedy is what the buyer asks for, and Outcome what an admin decides.
+type Remedy string
+
+const (
+	RemedyRefund        Remedy = "refund"
+	RemedyPartialRefund Remedy = "partial_refund"
+)
+
+type Outcome string
+
+const (
+	OutcomeRefund        Outcome = "refund"
+	OutcomePartialRefund Outcome = "partial_refund"
+	OutcomeRejected      Outcome = "rejected"
+)
+
+type Dispute struct {
+	ID              string      `bson:"_id" json:"id"`
+	OrderID         string      `bson:"order_id" json:"order_id"`
+	BuyerID         string      `bson:"buyer_id" json:"buyer_id"`
+	SellerID        string      `bson:"seller_id" json:"seller_id"`
+	Reason          Reason      `bson:"reason" json:"reason"`
+	Description     string      `bson:"description" json:"description"`
+	Photos          []string    `bson:"photos" json:"photos"`
+	RequestedRemedy Remedy      `bson:"requested_remedy" json:"requested_remedy"`
+	RequestedAmount money.Money `bson:"requested_amount,omitempty" json:"requested_amount,omitempty"`
+	Status          Status      `bson:"status" json:"status"`
+	CreatedAt       string      `bson:"created_at" json:"created_at"`
+
+	SellerResponse string `bson:"seller_response,omitempty" json:"seller_response,omitempty"`
+	RespondedAt    string `bson:"responded_at,omitempty" json:"responded_at,omitempty"`
+
+	Outcome      Outcome     `bson:"outcome,omitempty" json:"outcome,omitempty"`
+	RefundAmount money.Money `bson:"refund_amount,omitempty" json:"refund_amount,omitempty"`
+	AdminNote    string      `bson:"admin_note,omitempty" json:"admin_note,omitempty"`
+	ResolvedBy   string      `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	ResolvedAt   string      `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+func (r Reason) Valid() bool {
+	switch r {
+	case ReasonMisgraded, ReasonDamaged, ReasonNotAsDescribed, ReasonMissingItems:
+		return true
+	}
+	return false
+}
+
+func (r Remedy) Valid() bool {
+	return r == RemedyRefund || r == RemedyPartialRefund
+}
+
+func (o Outcome) Valid() bool {
+	return o == OutcomeRefund || o == OutcomePartialRefund || o == OutcomeRejected
+}
+
+// Validate checks what the buyer supplied when opening the dispute.
+func (d *Dispute) Validate() error {
+	if !d.Reason.Valid() {
+		return ErrInvalidReason
+	}
+	if !d.RequestedRemedy.Valid() {
+		return ErrInvalidRemedy
+	}
+	if d.RequestedRemedy == RemedyPartialRefund && !d.RequestedAmount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	return nil
+}
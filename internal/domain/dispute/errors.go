@@ -0,0 +1,17 @@
+package dispute
+
+import "errors"
+
+var (
+	ErrDisputeNotFound = errors.New("dispute not found")
+	ErrAlreadyDisputed = errors.New("this order already has a dispute")
+	ErrNotDisputable   = errors.New("only delivered orders can be disputed")
+	ErrNotDisputeParty = errors.New("you are not a party to this dispute")
+	ErrAlreadyResolved = errors.New("dispute is already resolved")
+	ErrEmptyResponse   = errors.New("response cannot be empty")
+	ErrStatusChanged   = errors.New("dispute status changed concurrently")
+	ErrInvalidReason   = errors.New("reason must be one of misgraded, damaged, not_as_described or missing_items")
+	ErrInvalidRemedy   = errors.New("requested remedy must be refund or partial_refund")
+	ErrInvalidOutcome  = errors.New("outcome must be refund, partial_refund or rejected")
+	ErrInvalidAmount   = errors.New("a partial refund needs a positive amount")
+)
@@ -0,0 +1,20 @@
+package dispute
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
+
+type Repository interface {
+	// CreateDispute returns ErrAlreadyDisputed if a dispute with the same ID, which
+	// is its order's, was stored already.
+	CreateDispute(ctx context.Context, d *Dispute) error
+	GetDisputeByID(ctx context.Context, id string) (*Dispute, error)
+	GetDisputeByOrder(ctx context.Context, orderID string) (*Dispute, error)
+	ListDisputesByUser(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*Dispute], error)
+	// ListDisputesByStatus lists disputes in every status when status is empty.
+	ListDisputesByStatus(ctx context.Context, status Status, req pagination.Request) (*pagination.Page[*Dispute], error)
+	// UpdateDispute saves the dispute if its status is still from.
+	UpdateDispute(ctx context.Context, d *Dispute, from Status) error
+}
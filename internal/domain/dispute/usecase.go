@@ -0,0 +1,21 @@
+package dispute
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
+
+type Usecase interface {
+	// OpenDispute raises a dispute from the buyer against one of their delivered orders.
+	OpenDispute(ctx context.Context, buyerID string, d *Dispute) (*Dispute, error)
+	RespondToDispute(ctx context.Context, sellerID, disputeID, response string) (*Dispute, error)
+	// ResolveDispute settles a dispute on an admin's behalf. Refunds are paid out
+	// and an upheld dispute counts against the seller's trust score. A partial
+	// refund is in the order's listing currency.
+	ResolveDispute(ctx context.Context, adminID, disputeID string, outcome Outcome, refundAmount money.Money, note string) (*Dispute, error)
+	GetDispute(ctx context.Context, userID, disputeID string) (*Dispute, error)
+	ListUserDisputes(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*Dispute], error)
+	ListDisputes(ctx context.Context, status Status, req pagination.Request) (*pagination.Page[*Dispute], error)
+}
@@ -0,0 +1,76 @@
+package event
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type Type string
+
+const (
+	ChatMessage        Type = "chat_message"
+	OrderStatusChanged Type = "order_status_changed"
+	BundleSold         Type = "bundle_sold"
+	WarehouseItemReady Type = "warehouse_item_ready"
+	DisputeUpdated     Type = "dispute_updated"
+	ShipmentUpdated    Type = "shipment_updated"
+)
+
+// Event is a notification pushed to a single user over the stream endpoint.
+type Event struct {
+	Type      Type        `json:"type"`
+	UserID    string      `json:"-"`
+	Payload   interface{} `json:"payload"`
+	CreatedAt string      `json:"created_at"`
+}
+
+type OrderStatusPayload struct {
+	OrderID string `json:"order_id"`
+	Status  string `json:"status"`
+}
+
+type BundleSoldPayload struct {
+	BundleID   string      `json:"bundle_id"`
+	OrderID    string      `json:"order_id"`
+	ResellerID string      `json:"reseller_id"`
+	Price      money.Money `json:"price"`
+}
+
+type WarehouseItemPayload struct {
+	ItemID   string `json:"item_id"`
+	BundleID string `json:"bundle_id"`
+	Status   string `json:"status"`
+}
+
+type DisputePayload struct {
+	DisputeID string `json:"dispute_id"`
+	OrderID   string `json:"order_id"`
+	Status    string `json:"status"`
+	Outcome   string `json:"outcome,omitempty"`
+}
+
+type ShipmentPayload struct {
+	OrderID        string `json:"order_id"`
+	TrackingNumber string `json:"tracking_number"`
+	Status         string `json:"status"`
+	Description    string `json:"description"`
+	Location       string `json:"location,omitempty"`
+}
+
+// Publisher is what usecases depend on to notify users. Publishing never blocks
+// the caller; events for users with no open stream are dropped.
+type Publisher interface {
+	Publish(ctx context.Context, e *Event)
+}
+
+// Subscriber hands out per-user event channels. The returned function must be
+// called once the stream is closed.
+type Subscriber interface {
+	Subscribe(userID string) (<-chan *Event, func())
+}
+
+type Hub interface {
+	Publisher
+	Subscriber
+}
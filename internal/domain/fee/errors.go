@@ -0,0 +1,15 @@
+package fee
+
+import "errors"
+
+var (
+	ErrRuleNotFound       = errors.New("fee rule not found")
+	ErrVersionConflict    = errors.New("fee rule was changed concurrently")
+	ErrNameRequired       = errors.New("fee rule name is required")
+	ErrInvalidPaymentType = errors.New("payment type must be b2b or b2c")
+	ErrInvalidTier        = errors.New("seller tier must be standard, trusted or top")
+	ErrInvalidAmounts     = errors.New("percent must be between 0 and 100 and amounts cannot be negative")
+	ErrNoFeeComponent     = errors.New("fee rule needs a percent, flat or minimum fee")
+	ErrInvalidCaps        = errors.New("maximum fee cannot be below the minimum fee")
+	ErrCurrencyRequired   = errors.New("a currency is required for flat, minimum and maximum fees")
+)
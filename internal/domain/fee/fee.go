@@ -0,0 +1,179 @@
+package fee
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// SellerTier groups sellers by trust score so rules can reward reliable sellers.
+type SellerTier string
+
+const (
+	TierStandard SellerTier = "standard"
+	TierTrusted  SellerTier = "trusted"
+	TierTop      SellerTier = "top"
+)
+
+// TierForTrustScore maps a seller's trust score (0-100) to their tier.
+func TierForTrustScore(score int) SellerTier {
+	switch {
+	case score >= 90:
+		return TierTop
+	case score >= 70:
+		return TierTrusted
+	default:
+		return TierStandard
+	}
+}
+
+func (t SellerTier) Valid() bool {
+	return t == TierStandard || t == TierTrusted || t == TierTop
+}
+
+// Rule is one version of an admin-configured fee. Editing a rule stores a new
+// version and keeps the old one, so a payment can always be traced to the exact
+// terms it was charged under.
+//
+// PaymentType, SellerTier, Category and Currency narrow what the rule applies to;
+// left empty, they match any sale. Flat, MinFee and MaxFee are in the minor unit
+// of Currency, which a rule with any of them must set.
+type Rule struct {
+	ID          string              `bson:"rule_id" json:"id"`
+	Version     int                 `bson:"version" json:"version"`
+	Name        string              `bson:"name" json:"name"`
+	PaymentType payment.PaymentType `bson:"payment_type,omitempty" json:"payment_type,omitempty"`
+	SellerTier  SellerTier          `bson:"seller_tier,omitempty" json:"seller_tier,omitempty"`
+	Category    string              `bson:"category,omitempty" json:"category,omitempty"`
+	Currency    money.Currency      `bson:"currency,omitempty" json:"currency,omitempty"`
+	Percent     float64             `bson:"percent" json:"percent"`
+	Flat        int64               `bson:"flat" json:"flat"`
+	MinFee      int64               `bson:"min_fee" json:"min_fee"`
+	MaxFee      int64               `bson:"max_fee" json:"max_fee"` // 0 means no cap
+	Priority    int                 `bson:"priority" json:"priority"`
+	Current     bool                `bson:"current" json:"current"`
+	CreatedBy   string              `bson:"created_by" json:"created_by"`
+	CreatedAt   string              `bson:"created_at" json:"created_at"`
+}
+
+// DefaultRule applies when no configured rule matches a sale. It is the flat 2%
+// the platform charged before fees were configurable.
+var DefaultRule = Rule{ID: "default", Name: "Default platform fee", Percent: 2}
+
+// Sale is what a fee is quoted for. The usecase fills in SellerTier from the
+// seller's trust score.
+type Sale struct {
+	Type       payment.PaymentType
+	SellerID   string
+	SellerTier SellerTier
+	Category   string
+	Amount     money.Money
+}
+
+// Quote is the fee for a sale and the rule version that produced it.
+type Quote struct {
+	Fee         money.Money
+	Net         money.Money
+	RuleID      string
+	RuleVersion int
+}
+
+// Validate checks the rule's terms.
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return ErrNameRequired
+	}
+	if r.PaymentType != "" && r.PaymentType != payment.B2B && r.PaymentType != payment.B2C {
+		return ErrInvalidPaymentType
+	}
+	if r.SellerTier != "" && !r.SellerTier.Valid() {
+		return ErrInvalidTier
+	}
+	if r.Percent < 0 || r.Percent > 100 || r.Flat < 0 || r.MinFee < 0 || r.MaxFee < 0 {
+		return ErrInvalidAmounts
+	}
+	if r.Percent == 0 && r.Flat == 0 && r.MinFee == 0 {
+		return ErrNoFeeComponent
+	}
+	if r.Currency != "" && !r.Currency.Valid() {
+		return money.ErrUnknownCurrency
+	}
+	if (r.Flat != 0 || r.MinFee != 0 || r.MaxFee != 0) && r.Currency == "" {
+		return ErrCurrencyRequired
+	}
+	if r.MaxFee > 0 && r.MaxFee < r.MinFee {
+		return ErrInvalidCaps
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to the sale.
+func (r *Rule) Matches(s Sale) bool {
+	return (r.PaymentType == "" || r.PaymentType == s.Type) &&
+		(r.SellerTier == "" || r.SellerTier == s.SellerTier) &&
+		(r.Category == "" || r.Category == s.Category) &&
+		(r.Currency == "" || r.Currency == s.Amount.Currency)
+}
+
+// specificity counts the conditions the rule sets; a narrower rule wins over a
+// broader one.
+func (r *Rule) specificity() int {
+	n := 0
+	for _, set := range []bool{r.PaymentType != "", r.SellerTier != "", r.Category != "", r.Currency != ""} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// Apply computes the fee on amount: the percentage plus the flat component,
+// clamped to the rule's caps and never more than the amount itself. The fee is
+// in the amount's currency, which Matches has checked against the rule's.
+func (r *Rule) Apply(amount money.Money) money.Money {
+	fee := amount.Mul(r.Percent/100).Amount + r.Flat
+	if fee < r.MinFee {
+		fee = r.MinFee
+	}
+	if r.MaxFee > 0 && fee > r.MaxFee {
+		fee = r.MaxFee
+	}
+	if fee > amount.Amount {
+		fee = amount.Amount
+	}
+	if fee < 0 {
+		fee = 0
+	}
+	return money.New(fee, amount.Currency)
+}
+
+// Select picks the rule for a sale from the current rules: the most specific
+// matching rule, then the one with the highest priority. DefaultRule is returned
+// when none match.
+func Select(rules []*Rule, s Sale) *Rule {
+	var best *Rule
+	for _, r := range rules {
+		if !r.Matches(s) {
+			continue
+		}
+		if best == nil || r.specificity() > best.specificity() ||
+			(r.specificity() == best.specificity() && r.Priority > best.Priority) {
+			best = r
+		}
+	}
+	if best == nil {
+		return &DefaultRule
+	}
+	return best
+}
+
+// QuoteSale prices a sale with the rule Select picks.
+func QuoteSale(rules []*Rule, s Sale) *Quote {
+	r := Select(rules, s)
+	fee := r.Apply(s.Amount)
+	return &Quote{
+		Fee:         fee,
+		Net:         s.Amount.Sub(fee),
+		RuleID:      r.ID,
+		RuleVersion: r.Version,
+	}
+}
@@ -0,0 +1,83 @@
+package fee
+
+import (
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTierForTrustScore(t *testing.T) {
+	assert.Equal(t, TierTop, TierForTrustScore(100))
+	assert.Equal(t, TierTop, TierForTrustScore(90))
+	assert.Equal(t, TierTrusted, TierForTrustScore(89))
+	assert.Equal(t, TierTrusted, TierForTrustScore(70))
+	assert.Equal(t, TierStandard, TierForTrustScore(69))
+}
+
+func TestRuleApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		amount int64
+		want   int64
+	}{
+		{"percentage", Rule{Percent: 2}, 10000, 200},
+		{"percentage plus flat", Rule{Percent: 3, Flat: 50, Currency: money.ETB}, 10000, 350},
+		{"minimum", Rule{Percent: 2, MinFee: 100, Currency: money.ETB}, 1000, 100},
+		{"maximum", Rule{Percent: 5, MaxFee: 2000, Currency: money.ETB}, 100000, 2000},
+		{"never above the amount", Rule{Flat: 500, Currency: money.ETB}, 300, 300},
+		{"rounds to the minor unit", Rule{Percent: 2.5}, 1999, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, money.New(tt.want, money.ETB), tt.rule.Apply(money.New(tt.amount, money.ETB)))
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	b2b := &Rule{ID: "b2b", Version: 1, PaymentType: payment.B2B, Percent: 3}
+	b2bTop := &Rule{ID: "b2b-top", Version: 2, PaymentType: payment.B2B, SellerTier: TierTop, Percent: 1}
+	denim := &Rule{ID: "denim", Version: 1, Category: "denim", Percent: 4}
+	denimPromo := &Rule{ID: "denim-promo", Version: 1, Category: "denim", Percent: 1, Priority: 10}
+	rules := []*Rule{b2b, b2bTop, denim, denimPromo}
+
+	assert.Equal(t, b2bTop, Select(rules, Sale{Type: payment.B2B, SellerTier: TierTop}))
+	assert.Equal(t, b2b, Select(rules, Sale{Type: payment.B2B, SellerTier: TierStandard}))
+	assert.Equal(t, denimPromo, Select(rules, Sale{Type: payment.B2C, Category: "denim"}))
+	assert.Equal(t, &DefaultRule, Select(rules, Sale{Type: payment.B2C, Category: "shirts"}))
+}
+
+func TestSelect_Currency(t *testing.T) {
+	general := &Rule{ID: "general", Percent: 2}
+	usdFlat := &Rule{ID: "usd-flat", Currency: money.USD, Percent: 2, Flat: 30}
+	rules := []*Rule{general, usdFlat}
+
+	assert.Equal(t, usdFlat, Select(rules, Sale{Amount: money.New(1000, money.USD)}))
+	assert.Equal(t, general, Select(rules, Sale{Amount: money.New(1000, money.ETB)}))
+}
+
+func TestQuoteSale_RecordsRuleVersion(t *testing.T) {
+	rules := []*Rule{{ID: "b2c", Version: 3, PaymentType: payment.B2C, Currency: money.ETB, Percent: 5, Flat: 100}}
+
+	q := QuoteSale(rules, Sale{Type: payment.B2C, Amount: money.New(4000, money.ETB)})
+
+	assert.Equal(t, money.New(300, money.ETB), q.Fee)
+	assert.Equal(t, money.New(3700, money.ETB), q.Net)
+	assert.Equal(t, "b2c", q.RuleID)
+	assert.Equal(t, 3, q.RuleVersion)
+}
+
+func TestRuleValidate(t *testing.T) {
+	assert.NoError(t, (&Rule{Name: "B2B", PaymentType: payment.B2B, Percent: 2}).Validate())
+	assert.ErrorIs(t, (&Rule{Percent: 2}).Validate(), ErrNameRequired)
+	assert.ErrorIs(t, (&Rule{Name: "x", PaymentType: "c2c", Percent: 2}).Validate(), ErrInvalidPaymentType)
+	assert.ErrorIs(t, (&Rule{Name: "x", SellerTier: "gold", Percent: 2}).Validate(), ErrInvalidTier)
+	assert.ErrorIs(t, (&Rule{Name: "x", Percent: 120}).Validate(), ErrInvalidAmounts)
+	assert.ErrorIs(t, (&Rule{Name: "x"}).Validate(), ErrNoFeeComponent)
+	assert.ErrorIs(t, (&Rule{Name: "x", Percent: 2, MinFee: 500, MaxFee: 100, Currency: money.ETB}).Validate(), ErrInvalidCaps)
+	assert.ErrorIs(t, (&Rule{Name: "x", Flat: 100}).Validate(), ErrCurrencyRequired)
+	assert.ErrorIs(t, (&Rule{Name: "x", Percent: 2, Currency: "XYZ"}).Validate(), money.ErrUnknownCurrency)
+}
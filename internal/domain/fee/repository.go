@@ -0,0 +1,15 @@
+package fee
+
+import "context"
+
+type Repository interface {
+	// CreateRule stores the first version of a rule.
+	CreateRule(ctx context.Context, r *Rule) error
+	// GetRule returns the current version of a rule.
+	GetRule(ctx context.Context, id string) (*Rule, error)
+	GetRuleVersion(ctx context.Context, id string, version int) (*Rule, error)
+	ListCurrentRules(ctx context.Context) ([]*Rule, error)
+	// SupersedeRule retires the rule's current version if it is still fromVersion.
+	// Storing the replacement is up to the caller.
+	SupersedeRule(ctx context.Context, id string, fromVersion int) error
+}
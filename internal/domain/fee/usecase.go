@@ -0,0 +1,19 @@
+package fee
+
+import "context"
+
+// Quoter prices the platform fee for a sale.
+type Quoter interface {
+	Quote(ctx context.Context, s Sale) (*Quote, error)
+}
+
+type Usecase interface {
+	Quoter
+	CreateRule(ctx context.Context, adminID string, r *Rule) (*Rule, error)
+	// UpdateRule stores the new terms as the next version of the rule.
+	UpdateRule(ctx context.Context, adminID, id string, r *Rule) (*Rule, error)
+	// DeleteRule retires the rule. Its versions are kept for the payments that used them.
+	DeleteRule(ctx context.Context, id string) error
+	GetRule(ctx context.Context, id string) (*Rule, error)
+	ListRules(ctx context.Context) ([]*Rule, error)
+}
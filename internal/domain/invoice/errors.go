@@ -0,0 +1,11 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrInvoiceNotFound = errors.New("invoice not found")
+	ErrInvoiceExists   = errors.New("this order already has an invoice")
+	ErrNotInvoiceParty = errors.New("you are not a party to this order")
+	ErrNotInvoiceable  = errors.New("failed and canceled orders have no invoice")
+	ErrInvoiceChanged  = errors.New("invoice was regenerated concurrently")
+)
@@ -0,0 +1,68 @@
+package invoice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// Party is a buyer or seller as printed on the invoice.
+type Party struct {
+	ID    string `bson:"id" json:"id"`
+	Name  string `bson:"name" json:"name"`
+	Email string `bson:"email" json:"email"`
+}
+
+type Line struct {
+	Description string      `bson:"description" json:"description"`
+	Quantity    int         `bson:"quantity" json:"quantity"`
+	UnitPrice   money.Money `bson:"unit_price" json:"unit_price"`
+	Amount      money.Money `bson:"amount" json:"amount"`
+}
+
+// Invoice is a snapshot of an order taken when the invoice was issued, so later
+// changes to users or listings do not alter a document already handed out. There
+// is one invoice per order and it is stored under the order's ID.
+//
+// Amounts are in the listing currency. Charged and ExchangeRate repeat what the
+// buyer paid when they checked out in another currency.
+type Invoice struct {
+	ID       string `bson:"_id" json:"id"`
+	OrderID  string `bson:"order_id" json:"order_id"`
+	Number   string `bson:"number" json:"number"`
+	Sequence int64  `bson:"sequence" json:"sequence"`
+
+	Seller Party  `bson:"seller" json:"seller"`
+	Buyer  Party  `bson:"buyer" json:"buyer"`
+	Lines  []Line `bson:"lines" json:"lines"`
+
+	Total        money.Money         `bson:"total" json:"total"`
+	PlatformFee  money.Money         `bson:"platform_fee" json:"platform_fee"`
+	NetPayable   money.Money         `bson:"net_payable" json:"net_payable"`
+	Charged      money.Money         `bson:"charged" json:"charged"`
+	ExchangeRate *money.RateSnapshot `bson:"exchange_rate,omitempty" json:"exchange_rate,omitempty"`
+
+	OrderDate string `bson:"order_date" json:"order_date"`
+	IssuedAt  string `bson:"issued_at" json:"issued_at"`
+
+	// Revision counts admin regenerations. The number and issue date never change.
+	Revision      int    `bson:"revision" json:"revision"`
+	RegeneratedBy string `bson:"regenerated_by,omitempty" json:"regenerated_by,omitempty"`
+	RegeneratedAt string `bson:"regenerated_at,omitempty" json:"regenerated_at,omitempty"`
+}
+
+// FormatNumber builds the printed invoice number from the seller's running
+// sequence. The seller part keeps numbers from different sellers apart.
+func FormatNumber(sellerID string, sequence int64) string {
+	prefix := strings.ToUpper(sellerID)
+	if len(prefix) > 6 {
+		prefix = prefix[len(prefix)-6:]
+	}
+	return fmt.Sprintf("INV-%s-%06d", prefix, sequence)
+}
+
+// FileName is what the PDF is saved as when downloaded.
+func (inv *Invoice) FileName() string {
+	return inv.Number + ".pdf"
+}
@@ -0,0 +1,6 @@
+package invoice
+
+// Renderer turns an invoice into a PDF.
+type Renderer interface {
+	Render(inv *Invoice) ([]byte, error)
+}
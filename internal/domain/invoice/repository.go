@@ -0,0 +1,13 @@
+package invoice
+
+import "context"
+
+type Repository interface {
+	// NextSequence reserves the seller's next invoice number. Numbers start at 1.
+	NextSequence(ctx context.Context, sellerID string) (int64, error)
+	// CreateInvoice returns ErrInvoiceExists if the order was invoiced already.
+	CreateInvoice(ctx context.Context, inv *Invoice) error
+	GetInvoiceByOrderID(ctx context.Context, orderID string) (*Invoice, error)
+	// ReplaceInvoice saves the invoice if its revision is still from.
+	ReplaceInvoice(ctx context.Context, inv *Invoice, from int) error
+}
@@ -0,0 +1,25 @@
+package invoice
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+)
+
+// Issuer numbers and stores the invoice of a new order. Purchases issue it in the
+// transaction that records the order, so every order has one and sellers' numbers
+// follow the order in which purchases were made.
+type Issuer interface {
+	IssueInvoice(ctx context.Context, o *order.Order) (*Invoice, error)
+}
+
+type Usecase interface {
+	Issuer
+	// GetInvoice returns the order's invoice and its rendered document for the
+	// order's buyer or seller.
+	GetInvoice(ctx context.Context, userID, orderID string) (*Invoice, []byte, error)
+	// RegenerateInvoice rebuilds an issued invoice from the current order, user and
+	// listing details on an admin's behalf, keeping its number. An order placed
+	// before invoices were issued with the purchase gets its first one.
+	RegenerateInvoice(ctx context.Context, adminID, orderID string) (*Invoice, []byte, error)
+}
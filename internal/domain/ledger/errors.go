@@ -0,0 +1,11 @@
+package ledger
+
+import "errors"
+
+var (
+	ErrUnbalanced = errors.New("ledger transaction does not balance")
+	// ErrDuplicateTransaction is returned when a transaction with the same ID was
+	// already recorded. Transaction IDs derive from what they book, so this means
+	// the purchase, refund or release has been booked before.
+	ErrDuplicateTransaction = errors.New("ledger transaction already recorded")
+)
@@ -0,0 +1,176 @@
+package ledger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// AccountType is one side of the platform's books. Seller and buyer accounts are
+// kept per user; the platform and payout accounts are shared.
+type AccountType string
+
+const (
+	// AccountBuyer is money that came from a buyer's payment method, or went back to it.
+	AccountBuyer AccountType = "buyer"
+	// AccountSellerPending holds a seller's earnings until the order is delivered.
+	AccountSellerPending AccountType = "seller_pending"
+	// AccountSellerAvailable is what the seller can be paid in the next payout.
+	AccountSellerAvailable AccountType = "seller_available"
+	AccountPlatformFees    AccountType = "platform_fees"
+	// AccountPayouts is money that has left the platform to sellers.
+	AccountPayouts AccountType = "payouts"
+)
+
+type Kind string
+
+const (
+	KindPurchase Kind = "purchase"
+	KindRefund   Kind = "refund"
+	KindRelease  Kind = "release"
+	KindPayout   Kind = "payout"
+)
+
+// Entry moves Amount into an account, or out of it when negative.
+type Entry struct {
+	Account AccountType `bson:"account" json:"account"`
+	OwnerID string      `bson:"owner_id,omitempty" json:"owner_id,omitempty"`
+	Amount  money.Money `bson:"amount" json:"amount"`
+}
+
+// Transaction is a balanced set of entries: its amounts sum to zero in every
+// currency, so money is only ever moved between accounts, never created or lost.
+type Transaction struct {
+	ID        string  `bson:"_id" json:"id"`
+	Kind      Kind    `bson:"kind" json:"kind"`
+	OrderID   string  `bson:"order_id,omitempty" json:"order_id,omitempty"`
+	PaymentID string  `bson:"payment_id,omitempty" json:"payment_id,omitempty"`
+	BatchID   string  `bson:"batch_id,omitempty" json:"batch_id,omitempty"`
+	Entries   []Entry `bson:"entries" json:"entries"`
+	CreatedAt string  `bson:"created_at" json:"created_at"`
+}
+
+// Balance is what a seller is owed, with one amount per currency they sell in.
+type Balance struct {
+	SellerID  string        `json:"seller_id"`
+	Pending   []money.Money `json:"pending"`
+	Available []money.Money `json:"available"`
+	PaidOut   []money.Money `json:"paid_out"`
+}
+
+// Payout is one transfer to a seller; sellers with balances in several currencies
+// get one per currency.
+type Payout struct {
+	SellerID      string      `bson:"seller_id" json:"seller_id"`
+	Amount        money.Money `bson:"amount" json:"amount"`
+	TransactionID string      `bson:"transaction_id" json:"transaction_id"`
+}
+
+type PayoutBatch struct {
+	ID        string        `bson:"_id" json:"id"`
+	CreatedBy string        `bson:"created_by" json:"created_by"`
+	Payouts   []Payout      `bson:"payouts" json:"payouts"`
+	Totals    []money.Money `bson:"totals" json:"totals"`
+	CreatedAt string        `bson:"created_at" json:"created_at"`
+}
+
+// Reconciliation compares the payment records with the ledger. The two are written
+// separately, so any difference points at a purchase or refund that only one saw.
+type Reconciliation struct {
+	Currencies []CurrencyReconciliation `json:"currencies"`
+	Balanced   bool                     `json:"balanced"`
+}
+
+type CurrencyReconciliation struct {
+	Currency        money.Currency `json:"currency"`
+	PaymentSales    money.Money    `json:"payment_sales"`
+	LedgerSales     money.Money    `json:"ledger_sales"`
+	PaymentFees     money.Money    `json:"payment_fees"`
+	LedgerFees      money.Money    `json:"ledger_fees"`
+	SalesDifference money.Money    `json:"sales_difference"`
+	FeesDifference  money.Money    `json:"fees_difference"`
+	Balanced        bool           `json:"balanced"`
+}
+
+func (t *Transaction) Validate() error {
+	if len(t.Entries) < 2 {
+		return ErrUnbalanced
+	}
+	sums := money.Totals{}
+	for _, e := range t.Entries {
+		sums.Add(e.Amount)
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return ErrUnbalanced
+		}
+	}
+	return nil
+}
+
+func newTransaction(kind Kind, ref string, entries ...Entry) *Transaction {
+	kept := entries[:0]
+	for _, e := range entries {
+		if !e.Amount.IsZero() {
+			kept = append(kept, e)
+		}
+	}
+	return &Transaction{
+		ID:        string(kind) + ":" + ref,
+		Kind:      kind,
+		Entries:   kept,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+}
+
+// ForPurchase books a captured payment: the buyer pays, the seller's earning is held
+// until delivery and the platform keeps its fee.
+func ForPurchase(p *payment.Payment) *Transaction {
+	t := newTransaction(KindPurchase, p.ID,
+		Entry{Account: AccountBuyer, OwnerID: p.FromUserID, Amount: p.Amount.Neg()},
+		Entry{Account: AccountSellerPending, OwnerID: p.ToUserID, Amount: p.SellerEarning},
+		Entry{Account: AccountPlatformFees, Amount: p.PlatformFee},
+	)
+	t.OrderID = p.OrderID
+	t.PaymentID = p.ID
+	return t
+}
+
+// ForRefund books a refund record, whose amounts are negative. The seller's share
+// comes out of the earning still held for the order first, then out of their
+// available balance, which may go negative if the money was already paid out.
+func ForRefund(refund *payment.Payment, heldForOrder money.Money) *Transaction {
+	owed := refund.SellerEarning.Neg()
+	fromPending := money.Min(owed, money.Max(heldForOrder, money.New(0, owed.Currency)))
+	t := newTransaction(KindRefund, refund.ID,
+		Entry{Account: AccountBuyer, OwnerID: refund.FromUserID, Amount: refund.Amount.Neg()},
+		Entry{Account: AccountSellerPending, OwnerID: refund.ToUserID, Amount: fromPending.Neg()},
+		Entry{Account: AccountSellerAvailable, OwnerID: refund.ToUserID, Amount: owed.Sub(fromPending).Neg()},
+		Entry{Account: AccountPlatformFees, Amount: refund.PlatformFee},
+	)
+	t.OrderID = refund.OrderID
+	t.PaymentID = refund.ID
+	return t
+}
+
+// ForRelease makes a seller's held earning for a delivered order available.
+func ForRelease(sellerID, orderID string, amount money.Money) *Transaction {
+	t := newTransaction(KindRelease, orderID,
+		Entry{Account: AccountSellerPending, OwnerID: sellerID, Amount: amount.Neg()},
+		Entry{Account: AccountSellerAvailable, OwnerID: sellerID, Amount: amount},
+	)
+	t.OrderID = orderID
+	return t
+}
+
+// ForPayout books money sent to a seller as part of a payout batch.
+func ForPayout(sellerID, batchID string, amount money.Money) *Transaction {
+	t := newTransaction(KindPayout, fmt.Sprintf("%s:%s:%s", batchID, sellerID, amount.Currency),
+		Entry{Account: AccountSellerAvailable, OwnerID: sellerID, Amount: amount.Neg()},
+		Entry{Account: AccountPayouts, OwnerID: sellerID, Amount: amount},
+	)
+	t.BatchID = batchID
+	return t
+}
@@ -0,0 +1,94 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+)
+
+func etb(amount int64) money.Money {
+	return money.New(amount, money.ETB)
+}
+
+func balanceOf(t *Transaction, account AccountType) int64 {
+	var sum int64
+	for _, e := range t.Entries {
+		if e.Account == account {
+			sum += e.Amount.Amount
+		}
+	}
+	return sum
+}
+
+func TestForPurchase(t *testing.T) {
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", FromUserID: "buyer1", ToUserID: "seller1", Amount: etb(10000), PlatformFee: etb(200), SellerEarning: etb(9800)}
+
+	tx := ForPurchase(p)
+
+	assert.NoError(t, tx.Validate())
+	assert.Equal(t, "purchase:pay1", tx.ID)
+	assert.Equal(t, int64(-10000), balanceOf(tx, AccountBuyer))
+	assert.Equal(t, int64(9800), balanceOf(tx, AccountSellerPending))
+	assert.Equal(t, int64(200), balanceOf(tx, AccountPlatformFees))
+}
+
+func TestForRefund(t *testing.T) {
+	refund := &payment.Payment{ID: "refund1", OrderID: "order1", FromUserID: "buyer1", ToUserID: "seller1", Amount: etb(-5000), PlatformFee: etb(-100), SellerEarning: etb(-4900), RefundOf: "pay1"}
+
+	t.Run("still held", func(t *testing.T) {
+		tx := ForRefund(refund, etb(9800))
+
+		assert.NoError(t, tx.Validate())
+		assert.Equal(t, int64(5000), balanceOf(tx, AccountBuyer))
+		assert.Equal(t, int64(-4900), balanceOf(tx, AccountSellerPending))
+		assert.Equal(t, int64(0), balanceOf(tx, AccountSellerAvailable))
+		assert.Equal(t, int64(-100), balanceOf(tx, AccountPlatformFees))
+	})
+
+	t.Run("already released", func(t *testing.T) {
+		tx := ForRefund(refund, money.Money{})
+
+		assert.NoError(t, tx.Validate())
+		assert.Equal(t, int64(0), balanceOf(tx, AccountSellerPending))
+		assert.Equal(t, int64(-4900), balanceOf(tx, AccountSellerAvailable))
+	})
+
+	t.Run("partly released", func(t *testing.T) {
+		tx := ForRefund(refund, etb(2000))
+
+		assert.NoError(t, tx.Validate())
+		assert.Equal(t, int64(-2000), balanceOf(tx, AccountSellerPending))
+		assert.Equal(t, int64(-2900), balanceOf(tx, AccountSellerAvailable))
+	})
+}
+
+func TestReleaseAndPayout(t *testing.T) {
+	release := ForRelease("seller1", "order1", etb(9800))
+	payout := ForPayout("seller1", "batch1", etb(9800))
+
+	assert.NoError(t, release.Validate())
+	assert.NoError(t, payout.Validate())
+	assert.Equal(t, "release:order1", release.ID)
+	assert.Equal(t, "payout:batch1:seller1:ETB", payout.ID)
+	assert.Equal(t, int64(9800), balanceOf(payout, AccountPayouts))
+}
+
+func TestValidate_Unbalanced(t *testing.T) {
+	tx := &Transaction{Entries: []Entry{
+		{Account: AccountBuyer, OwnerID: "buyer1", Amount: etb(-10000)},
+		{Account: AccountSellerPending, OwnerID: "seller1", Amount: etb(9000)},
+	}}
+
+	assert.ErrorIs(t, tx.Validate(), ErrUnbalanced)
+}
+
+func TestValidate_BalancedPerCurrency(t *testing.T) {
+	tx := &Transaction{Entries: []Entry{
+		{Account: AccountBuyer, OwnerID: "buyer1", Amount: etb(-10000)},
+		{Account: AccountSellerPending, OwnerID: "seller1", Amount: money.New(10000, money.USD)},
+	}}
+
+	assert.ErrorIs(t, tx.Validate(), ErrUnbalanced)
+}
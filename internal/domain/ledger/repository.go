@@ -0,0 +1,21 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// Balances are reported per currency.
+type Repository interface {
+	RecordTransaction(ctx context.Context, t *Transaction) error
+	AccountBalance(ctx context.Context, account AccountType, ownerID string) (money.Totals, error)
+	OrderAccountBalance(ctx context.Context, account AccountType, ownerID, orderID string) (money.Totals, error)
+	BalancesByOwner(ctx context.Context, account AccountType) (map[string]money.Totals, error)
+	AccountTotal(ctx context.Context, account AccountType) (money.Totals, error)
+	CreatePayoutBatch(ctx context.Context, b *PayoutBatch) error
+	// LockPayouts writes to a document every payout run shares. Called first in a
+	// run's transaction it makes overlapping runs conflict, so only one of them can
+	// commit against the balances it read.
+	LockPayouts(ctx context.Context) error
+}
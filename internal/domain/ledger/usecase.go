@@ -0,0 +1,11 @@
+package ledger
+
+import "context"
+
+type Usecase interface {
+	GetSellerBalance(ctx context.Context, sellerID string) (*Balance, error)
+	// RunPayouts pays every seller their available balance. Earnings still held
+	// for undelivered orders are left for a later batch.
+	RunPayouts(ctx context.Context, adminID string) (*PayoutBatch, error)
+	Reconcile(ctx context.Context) (*Reconciliation, error)
+}
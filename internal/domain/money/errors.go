@@ -0,0 +1,10 @@
+package money
+
+import "errors"
+
+var (
+	ErrUnknownCurrency = errors.New("unsupported currency")
+	ErrInvalidRate     = errors.New("exchange rate must be positive and between two different currencies")
+	ErrRateNotFound    = errors.New("no exchange rate between these currencies")
+	ErrInvalidAmount   = errors.New("amount must be a whole number of minor units with a currency code")
+)
@@ -0,0 +1,216 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code.
+type Currency string
+
+const (
+	ETB Currency = "ETB"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	KES Currency = "KES"
+)
+
+// DefaultCurrency is the currency of prices that were stored without one, which
+// includes everything listed before prices carried a currency.
+const DefaultCurrency = ETB
+
+// minorDigits is the number of decimal places of each supported currency.
+var minorDigits = map[Currency]int{
+	ETB: 2,
+	USD: 2,
+	EUR: 2,
+	GBP: 2,
+	KES: 2,
+}
+
+func (c Currency) Valid() bool {
+	_, ok := minorDigits[c]
+	return ok
+}
+
+// Currencies lists the supported currencies in code order.
+func Currencies() []Currency {
+	list := make([]Currency, 0, len(minorDigits))
+	for c := range minorDigits {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+// ParseCurrency reads a currency code, defaulting to DefaultCurrency when empty.
+func ParseCurrency(code string) (Currency, error) {
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.Valid() {
+		return "", fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+func (c Currency) scale() float64 {
+	return math.Pow10(minorDigits[c])
+}
+
+// Money is an amount in the currency's minor unit (cents, santim) together with
+// its currency. Amounts are whole numbers so sums never drift.
+//
+// The zero value has no currency and acts as zero in any currency, so it can be
+// used to start a sum.
+type Money struct {
+	Amount   int64    `bson:"amount" json:"amount"`
+	Currency Currency `bson:"currency" json:"currency"`
+}
+
+func New(amount int64, c Currency) Money {
+	return Money{Amount: amount, Currency: c}
+}
+
+// FromMajor converts an amount in major units, such as 12.50, rounding to the
+// nearest minor unit.
+func FromMajor(amount float64, c Currency) Money {
+	return Money{Amount: int64(math.Round(amount * c.scale())), Currency: c}
+}
+
+// Major is the amount in major units. It is meant for display and for talking
+// to systems that want decimals, not for arithmetic.
+func (m Money) Major() float64 {
+	return float64(m.Amount) / m.Currency.scale()
+}
+
+// Normalized checks an amount received from a client, filling in DefaultCurrency
+// when no currency was given.
+func (m Money) Normalized() (Money, error) {
+	c, err := ParseCurrency(string(m.Currency))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount, Currency: c}, nil
+}
+
+// FromValue reads an amount from a decoded JSON document, as found in the
+// free-form update requests: an object such as {"amount": 1250, "currency": "USD"}.
+func FromValue(v interface{}) (Money, error) {
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+	amount, ok := fields["amount"].(float64)
+	if !ok || amount != math.Trunc(amount) {
+		return Money{}, ErrInvalidAmount
+	}
+	code, _ := fields["currency"].(string)
+	return Money{Amount: int64(amount), Currency: Currency(code)}.Normalized()
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsPositive() bool { return m.Amount > 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// SameCurrency reports whether the two amounts can be added together.
+func (m Money) SameCurrency(o Money) bool {
+	return m.Currency == o.Currency || (m.Currency == "" && m.Amount == 0) || (o.Currency == "" && o.Amount == 0)
+}
+
+func (m Money) currencyWith(o Money) Currency {
+	if !m.SameCurrency(o) {
+		// Amounts in different currencies are always converted first; reaching
+		// here is a bug in the caller.
+		panic(fmt.Sprintf("money: mixing %s and %s", m.Currency, o.Currency))
+	}
+	if m.Currency != "" {
+		return m.Currency
+	}
+	return o.Currency
+}
+
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currencyWith(o)}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{Amount: m.Amount - o.Amount, Currency: m.currencyWith(o)}
+}
+
+// Cmp compares two amounts in the same currency, returning -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	m.currencyWith(o)
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+// Mul scales the amount, rounding to the nearest minor unit. It is used for
+// percentages and proportional shares.
+func (m Money) Mul(factor float64) Money {
+	return Money{Amount: int64(math.Round(float64(m.Amount) * factor)), Currency: m.Currency}
+}
+
+// Min and Max return the smaller and larger of two amounts in the same currency.
+func Min(a, b Money) Money {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+func Max(a, b Money) Money {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + string(m.Currency)
+}
+
+// Decimal is the amount in major units with the currency's decimal places and no
+// currency code, as spreadsheets expect.
+func (m Money) Decimal() string {
+	return fmt.Sprintf("%.*f", minorDigits[m.Currency], m.Major())
+}
+
+// Totals sums amounts per currency, for figures that span listings in several
+// currencies.
+type Totals map[Currency]int64
+
+func (t Totals) Add(m Money) {
+	if m.Currency == "" {
+		return
+	}
+	t[m.Currency] += m.Amount
+}
+
+// Get returns the total for one currency.
+func (t Totals) Get(c Currency) Money {
+	return Money{Amount: t[c], Currency: c}
+}
+
+// List returns the totals sorted by currency code.
+func (t Totals) List() []Money {
+	list := make([]Money, 0, len(t))
+	for c, amount := range t {
+		list = append(list, Money{Amount: amount, Currency: c})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Currency < list[j].Currency })
+	return list
+}
@@ -0,0 +1,84 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMajor(t *testing.T) {
+	assert.Equal(t, New(1250, ETB), FromMajor(12.5, ETB))
+	assert.Equal(t, New(30, USD), FromMajor(0.295, USD))
+	assert.Equal(t, 12.5, New(1250, ETB).Major())
+}
+
+func TestParseCurrency(t *testing.T) {
+	c, err := ParseCurrency("usd")
+	assert.NoError(t, err)
+	assert.Equal(t, USD, c)
+
+	c, err = ParseCurrency("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCurrency, c)
+
+	_, err = ParseCurrency("XYZ")
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestArithmetic(t *testing.T) {
+	var sum Money
+	sum = sum.Add(New(1000, ETB)).Add(New(250, ETB))
+	assert.Equal(t, New(1250, ETB), sum)
+	assert.Equal(t, New(750, ETB), sum.Sub(New(500, ETB)))
+	assert.Equal(t, New(25, ETB), sum.Mul(0.02))
+	assert.Equal(t, New(-1250, ETB), sum.Neg())
+	assert.Equal(t, New(500, ETB), Min(New(500, ETB), sum))
+
+	assert.Panics(t, func() { New(100, ETB).Add(New(100, USD)) })
+}
+
+func TestTotals(t *testing.T) {
+	totals := Totals{}
+	totals.Add(New(1000, USD))
+	totals.Add(New(5000, ETB))
+	totals.Add(New(-200, USD))
+
+	assert.Equal(t, []Money{New(5000, ETB), New(800, USD)}, totals.List())
+	assert.Equal(t, New(800, USD), totals.Get(USD))
+}
+
+func TestRateSnapshotConvert(t *testing.T) {
+	r := &Rate{From: USD, To: ETB, Rate: 57.25}
+
+	assert.Equal(t, New(572500, ETB), r.Snapshot().Convert(New(10000, USD)))
+	assert.Equal(t, New(10000, USD), r.Inverse().Convert(New(572500, ETB)))
+
+	var none *RateSnapshot
+	assert.Equal(t, New(100, ETB), none.Convert(New(100, ETB)))
+	assert.Equal(t, 1.0, none.MinorFactor())
+	assert.Equal(t, 57.25, r.Snapshot().MinorFactor())
+}
+
+func TestRateValidate(t *testing.T) {
+	assert.NoError(t, (&Rate{From: USD, To: ETB, Rate: 57}).Validate())
+	assert.ErrorIs(t, (&Rate{From: USD, To: USD, Rate: 1}).Validate(), ErrInvalidRate)
+	assert.ErrorIs(t, (&Rate{From: USD, To: ETB}).Validate(), ErrInvalidRate)
+	assert.ErrorIs(t, (&Rate{From: "XYZ", To: ETB, Rate: 2}).Validate(), ErrUnknownCurrency)
+}
+
+func TestFromValue(t *testing.T) {
+	m, err := FromValue(map[string]interface{}{"amount": 1250.0, "currency": "usd"})
+	assert.NoError(t, err)
+	assert.Equal(t, New(1250, USD), m)
+
+	m, err = FromValue(map[string]interface{}{"amount": 900.0})
+	assert.NoError(t, err)
+	assert.Equal(t, New(900, DefaultCurrency), m)
+
+	_, err = FromValue(12.5)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = FromValue(map[string]interface{}{"amount": 12.5})
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = FromValue(map[string]interface{}{"amount": 100.0, "currency": "XYZ"})
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
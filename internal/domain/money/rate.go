@@ -0,0 +1,61 @@
+package money
+
+// Rate is the admin-set price of one unit of From in To.
+type Rate struct {
+	From      Currency `bson:"from" json:"from"`
+	To        Currency `bson:"to" json:"to"`
+	Rate      float64  `bson:"rate" json:"rate"`
+	UpdatedBy string   `bson:"updated_by" json:"updated_by"`
+	UpdatedAt string   `bson:"updated_at" json:"updated_at"`
+}
+
+func (r *Rate) Validate() error {
+	if !r.From.Valid() || !r.To.Valid() {
+		return ErrUnknownCurrency
+	}
+	if r.From == r.To || r.Rate <= 0 {
+		return ErrInvalidRate
+	}
+	return nil
+}
+
+// RateSnapshot is the rate a conversion used, kept with whatever was converted so
+// it can be repeated exactly later, for example to refund a checkout.
+type RateSnapshot struct {
+	From Currency `bson:"from" json:"from"`
+	To   Currency `bson:"to" json:"to"`
+	Rate float64  `bson:"rate" json:"rate"`
+	AsOf string   `bson:"as_of" json:"as_of"`
+}
+
+// Snapshot captures the rate for converting from r.From to r.To.
+func (r *Rate) Snapshot() *RateSnapshot {
+	return &RateSnapshot{From: r.From, To: r.To, Rate: r.Rate, AsOf: r.UpdatedAt}
+}
+
+// Inverse captures the rate for converting from r.To back to r.From.
+func (r *Rate) Inverse() *RateSnapshot {
+	return &RateSnapshot{From: r.To, To: r.From, Rate: 1 / r.Rate, AsOf: r.UpdatedAt}
+}
+
+// MinorFactor is how many minor units of To one minor unit of From is worth. A nil
+// snapshot converts nothing, so its factor is 1.
+func (s *RateSnapshot) MinorFactor() float64 {
+	if s == nil {
+		return 1
+	}
+	return s.Rate * s.To.scale() / s.From.scale()
+}
+
+// Convert applies the snapshot to an amount in its From currency. A nil snapshot
+// means no conversion was needed and returns the amount unchanged.
+func (s *RateSnapshot) Convert(m Money) Money {
+	if s == nil {
+		return m
+	}
+	if m.Currency != s.From {
+		panic("money: converting " + string(m.Currency) + " with a " + string(s.From) + " rate")
+	}
+	major := m.Major() * s.Rate
+	return FromMajor(major, s.To)
+}
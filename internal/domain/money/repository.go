@@ -0,0 +1,12 @@
+package money
+
+import "context"
+
+type RateRepository interface {
+	// SaveRate stores the rate, replacing any earlier rate for the same pair.
+	SaveRate(ctx context.Context, r *Rate) error
+	// GetRate returns the rate for the pair, or ErrRateNotFound.
+	GetRate(ctx context.Context, from, to Currency) (*Rate, error)
+	ListRates(ctx context.Context) ([]*Rate, error)
+	DeleteRate(ctx context.Context, from, to Currency) error
+}
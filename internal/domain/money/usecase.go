@@ -0,0 +1,39 @@
+package money
+
+import (
+	"context"
+	"errors"
+)
+
+// Converter converts amounts between currencies with the admin-managed rates.
+type Converter interface {
+	// Rate returns the snapshot for converting from one currency to another, or
+	// nil when they are the same.
+	Rate(ctx context.Context, from, to Currency) (*RateSnapshot, error)
+	Convert(ctx context.Context, m Money, to Currency) (Money, error)
+}
+
+type Usecase interface {
+	Converter
+	SetRate(ctx context.Context, adminID string, r *Rate) (*Rate, error)
+	ListRates(ctx context.Context) ([]*Rate, error)
+	DeleteRate(ctx context.Context, from, to Currency) error
+}
+
+// MinorFactors returns, for every currency with a rate to the target, the
+// RateSnapshot.MinorFactor for converting into it. Currencies without a rate are
+// left out, so callers can tell which prices cannot be compared.
+func MinorFactors(ctx context.Context, c Converter, to Currency) (map[Currency]float64, error) {
+	factors := make(map[Currency]float64)
+	for _, from := range Currencies() {
+		rate, err := c.Rate(ctx, from, to)
+		if errors.Is(err, ErrRateNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		factors[from] = rate.MinorFactor()
+	}
+	return factors, nil
+}
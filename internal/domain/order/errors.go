@@ -0,0 +1,11 @@
+package order
+
+import "errors"
+
+var (
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrInvalidTransition = errors.New("order cannot move to the requested status")
+	ErrNotOrderParty     = errors.New("you are not allowed to update this order")
+	ErrStatusChanged     = errors.New("order status changed concurrently")
+	ErrMissingTracking   = errors.New("a carrier is required, and a tracking number unless the carrier issues one")
+)
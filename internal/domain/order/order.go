@@ -1,6 +1,10 @@
 package order
 
-import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+)
 
 type OrderStatus string
 
@@ -20,12 +24,57 @@ type Order struct {
 	ResellerID  string      `json:"reseller_id"`
 	SupplierID  string      `json:"supplier_id"`
 	BundleID    string      `json:"bundle_id"`
-	PlatformFee float64     `json:"platform_fee"`
+	PlatformFee money.Money `json:"platform_fee"`
 	ConsumerID  string      `json:"consumer_id"`
 	ProductIDs  []string    `json:"product_ids"`
-	TotalPrice  float64     `json:"total_price"`
+	TotalPrice  money.Money `json:"total_price"`
 	Status      OrderStatus `json:"status"`
 	CreatedAt   string      `json:"created_at"`
+
+	TrackingNumber string `json:"tracking_number,omitempty"`
+	Carrier        string `json:"carrier,omitempty"`
+	ShippedAt      string `json:"shipped_at,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+
+	// TotalPrice and PlatformFee are in the listing currency. Charged is what the
+	// buyer paid in the currency they checked out in, converted at ExchangeRate,
+	// which is nil when no conversion was needed.
+	Charged      money.Money         `json:"charged"`
+	ExchangeRate *money.RateSnapshot `json:"exchange_rate,omitempty"`
+
+	// ShippingAddress is a copy of the address book entry picked at checkout.
+	// ShippingCost is in the listing currency and is charged on top of TotalPrice.
+	ShippingAddress   *shipping.Address `json:"shipping_address,omitempty"`
+	DeliveryOption    shipping.Option   `json:"delivery_option,omitempty"`
+	ShippingCost      money.Money       `json:"shipping_cost"`
+	EstimatedDelivery string            `json:"estimated_delivery,omitempty"`
+}
+
+// SellerID is the user who fulfils the order: the supplier of a bundle, or the
+// reseller of individual products.
+func (o *Order) SellerID() string {
+	if o.BundleID != "" {
+		return o.SupplierID
+	}
+	return o.ResellerID
+}
+
+// SetShipping records the delivery quoted at checkout. cost is the quote's price
+// converted into the listing currency.
+func (o *Order) SetShipping(q *shipping.Quote, cost money.Money) {
+	addr := q.Address
+	o.ShippingAddress = &addr
+	o.DeliveryOption = q.Option
+	o.ShippingCost = cost
+	o.EstimatedDelivery = q.EstimatedDelivery
+}
+
+// BuyerID is the user who receives the order.
+func (o *Order) BuyerID() string {
+	if o.BundleID != "" {
+		return o.ResellerID
+	}
+	return o.ConsumerID
 }
 
 type PerformanceMetrics struct {
@@ -35,17 +84,17 @@ type PerformanceMetrics struct {
 }
 
 type DashboardMetrics struct {
-	TotalSales         float64            `json:"totalSales"`
+	TotalSales         []money.Money      `json:"totalSales"`
 	ActiveBundles      []*bundle.Bundle   `json:"activeBundles"`
 	PerformanceMetrics PerformanceMetrics `json:"performanceMetrics"`
 	Rating             int                `json:"rating"`
-	BestSelling        float64            `json:"bestSelling"`
+	BestSelling        []money.Money      `json:"bestSelling"`
 }
 
 type ResellerMetrics struct {
-	TotalBoughtBundles int               `json:"totalBoughtBundles"`
-	TotalItemsSold     int               `json:"totalItemsSold"`
-	Rating             int               `json:"rating"`
-	BestSelling        float64           `json:"bestSelling"`
-	BoughtBundles      []*bundle.Bundle  `json:"boughtBundles"`
+	TotalBoughtBundles int              `json:"totalBoughtBundles"`
+	TotalItemsSold     int              `json:"totalItemsSold"`
+	Rating             int              `json:"rating"`
+	BestSelling        []money.Money    `json:"bestSelling"`
+	BoughtBundles      []*bundle.Bundle `json:"boughtBundles"`
 }
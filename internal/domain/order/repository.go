@@ -2,14 +2,23 @@ package order
 
 import (
 	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 )
 
 type Repository interface {
 	CreateOrder(ctx context.Context, o *Order) error
-	GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*Order, error)
+	// ListOrdersByConsumer lists orders in every status when status is empty.
+	ListOrdersByConsumer(ctx context.Context, consumerID string, status OrderStatus, req pagination.Request) (*pagination.Page[*Order], error)
 	GetOrderByID(ctx context.Context, orderID string) (*Order, error)
 	UpdateOrderStatus(ctx context.Context, orderID string, status OrderStatus) error
+	// TransitionOrderStatus saves the order's status and fulfilment details, but only if
+	// the stored order is still in status from. It returns ErrStatusChanged otherwise.
+	TransitionOrderStatus(ctx context.Context, o *Order, from OrderStatus) error
 	DeleteOrder(ctx context.Context, orderID string) error
-	GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*Order, error)
-	GetOrdersByReseller(ctx context.Context, resellerID string) ([]*Order, error) // ✅ Keep this
+	ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*Order], error)
+	ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*Order], error)
+	// GetBundleOrder returns the reseller's order for the bundle that was not
+	// canceled, or nil if there is none.
+	GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*Order, error)
 }
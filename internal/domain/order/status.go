@@ -0,0 +1,37 @@
+package order
+
+// transitions lists where an order may go from each status:
+//
+//	pending -> processing -> shipped -> delivered -> completed
+//	pending/processing -> canceled (any status when forced by an admin)
+//	pending/processing -> failed
+var transitions = map[OrderStatus][]OrderStatus{
+	Pending:               {OrderStatusProcessing, Shipped, OrderStatusCanceled, Failed},
+	OrderStatusProcessing: {Shipped, OrderStatusCanceled, Failed},
+	Shipped:               {Delivered},
+	Delivered:             {OrderStatusCompleted},
+}
+
+// CanTransition reports whether an order may move from one status to another.
+// Orders created before the lifecycle was enforced were stored as completed and
+// stay that way.
+func CanTransition(from, to OrderStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CanForceCancel reports whether an admin may cancel an order in the given status.
+// Unlike the buyer, an admin may still cancel after shipment, for example when a
+// parcel is lost in transit.
+func CanForceCancel(from OrderStatus) bool {
+	return from != OrderStatusCanceled && from != Failed
+}
+
+// IsTerminal reports whether no further transitions are possible from the status.
+func IsTerminal(status OrderStatus) bool {
+	return len(transitions[status]) == 0
+}
@@ -0,0 +1,50 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		want     bool
+	}{
+		{Pending, OrderStatusProcessing, true},
+		{Pending, Shipped, true},
+		{OrderStatusProcessing, Shipped, true},
+		{Shipped, Delivered, true},
+		{Delivered, OrderStatusCompleted, true},
+		{Pending, OrderStatusCanceled, true},
+		{OrderStatusProcessing, Failed, true},
+		{Pending, Delivered, false},
+		{Shipped, OrderStatusCanceled, false},
+		{Delivered, Shipped, false},
+		{OrderStatusCompleted, OrderStatusCanceled, false},
+		{OrderStatusCanceled, Pending, false},
+		{Failed, OrderStatusProcessing, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CanTransition(tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestCanForceCancel(t *testing.T) {
+	assert.True(t, CanForceCancel(Pending))
+	assert.True(t, CanForceCancel(Shipped))
+	assert.True(t, CanForceCancel(OrderStatusCompleted))
+	assert.False(t, CanForceCancel(OrderStatusCanceled))
+	assert.False(t, CanForceCancel(Failed))
+}
+
+func TestOrderParties(t *testing.T) {
+	bundleOrder := &Order{BundleID: "b1", SupplierID: "supplier1", ResellerID: "reseller1"}
+	assert.Equal(t, "supplier1", bundleOrder.SellerID())
+	assert.Equal(t, "reseller1", bundleOrder.BuyerID())
+
+	productOrder := &Order{ProductIDs: []string{"p1"}, ResellerID: "reseller1", ConsumerID: "consumer1"}
+	assert.Equal(t, "reseller1", productOrder.SellerID())
+	assert.Equal(t, "consumer1", productOrder.BuyerID())
+}
@@ -0,0 +1,111 @@
+// Package pagination pages through lists newest first. Cursors are opaque to
+// clients: each records the creation time and ID of the last item served, so
+// items created while a client reads do not shift the pages that follow.
+//
+// Lists that are small by design, such as a user's address book and cart, fee
+// rules and exchange rates, are still returned whole.
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+)
+
+// Request asks for one page. An empty Cursor asks for the first page and a zero
+// Limit for DefaultLimit items.
+type Request struct {
+	Cursor string
+	Limit  int
+}
+
+// Normalize fills in the default limit and checks the requested one.
+func (r Request) Normalize() (Request, error) {
+	if r.Limit == 0 {
+		r.Limit = DefaultLimit
+	}
+	if r.Limit < 1 || r.Limit > MaxLimit {
+		return r, ErrInvalidLimit
+	}
+	return r, nil
+}
+
+// Page is one page of a list and the envelope every list endpoint returns.
+// NextCursor is empty on the last page; Total counts the whole list.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int64  `json:"total"`
+}
+
+// Map converts the items of a page, keeping its cursor and total.
+func Map[T, U any](p *Page[T], f func(T) U) *Page[U] {
+	items := make([]U, 0, len(p.Items))
+	for _, item := range p.Items {
+		items = append(items, f(item))
+	}
+	return &Page[U]{Items: items, NextCursor: p.NextCursor, Total: p.Total}
+}
+
+// Collect reads every page of a list, for callers that need all of it, such as
+// dashboard totals.
+func Collect[T any](list func(Request) (*Page[T], error)) ([]T, error) {
+	var all []T
+	req := Request{Limit: MaxLimit}
+	for {
+		page, err := list(req)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Items...)
+		if page.NextCursor == "" {
+			return all, nil
+		}
+		req.Cursor = page.NextCursor
+	}
+}
+
+// Cursor is the position after the last item of a page: its creation time and
+// ID as stored, whatever their BSON types.
+type Cursor struct {
+	CreatedAt bson.RawValue `bson:"c"`
+	ID        bson.RawValue `bson:"i"`
+}
+
+// Encode renders the cursor for a client. A missing field is recorded as null,
+// which sorts before every value.
+func (c Cursor) Encode() string {
+	for _, v := range []*bson.RawValue{&c.CreatedAt, &c.ID} {
+		if v.Type == 0 {
+			*v = bson.RawValue{Type: bsontype.Null}
+		}
+	}
+	raw, _ := bson.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode parses a cursor a client sent back.
+func Decode(encoded string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := bson.Unmarshal(raw, &c); err != nil || c.ID.Type == 0 || c.CreatedAt.Type == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
@@ -0,0 +1,100 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func rawValue(t *testing.T, v interface{}) bson.RawValue {
+	raw, err := bson.Marshal(bson.M{"v": v})
+	require.NoError(t, err)
+	return bson.Raw(raw).Lookup("v")
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cases := map[string]Cursor{
+		"string fields": {CreatedAt: rawValue(t, "2024-05-01T10:00:00Z"), ID: rawValue(t, "product_1")},
+		"date and object id": {
+			CreatedAt: rawValue(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)),
+			ID:        rawValue(t, primitive.NewObjectID()),
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			decoded, err := Decode(c.Encode())
+			require.NoError(t, err)
+			assert.True(t, c.CreatedAt.Equal(decoded.CreatedAt))
+			assert.True(t, c.ID.Equal(decoded.ID))
+		})
+	}
+}
+
+func TestCursorMissingCreatedAt(t *testing.T) {
+	c := Cursor{ID: rawValue(t, "order_1")}
+
+	decoded, err := Decode(c.Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, bsontype.Null, decoded.CreatedAt.Type)
+}
+
+func TestDecodeRejectsGarbage(t *testing.T) {
+	for _, encoded := range []string{"not a cursor!", "e30"} {
+		_, err := Decode(encoded)
+		assert.ErrorIs(t, err, ErrInvalidCursor, encoded)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	req, err := Request{}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLimit, req.Limit)
+
+	for _, limit := range []int{-1, MaxLimit + 1} {
+		_, err := Request{Limit: limit}.Normalize()
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	pages := map[string]*Page[int]{
+		"":  {Items: []int{1, 2}, NextCursor: "a", Total: 5},
+		"a": {Items: []int{3, 4}, NextCursor: "b", Total: 5},
+		"b": {Items: []int{5}, Total: 5},
+	}
+	var limits []int
+
+	all, err := Collect(func(req Request) (*Page[int], error) {
+		limits = append(limits, req.Limit)
+		return pages[req.Cursor], nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, all)
+	assert.Equal(t, []int{MaxLimit, MaxLimit, MaxLimit}, limits)
+}
+
+func TestCollectStopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+
+	_, err := Collect(func(req Request) (*Page[int], error) {
+		return nil, boom
+	})
+
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestMap(t *testing.T) {
+	page := &Page[int]{Items: []int{1, 2}, NextCursor: "next", Total: 7}
+
+	mapped := Map(page, func(n int) string { return string(rune('a' + n)) })
+
+	assert.Equal(t, &Page[string]{Items: []string{"b", "c"}, NextCursor: "next", Total: 7}, mapped)
+}
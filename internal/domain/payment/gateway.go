@@ -0,0 +1,55 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type ChargeStatus string
+
+const (
+	ChargeAuthorized ChargeStatus = "authorized"
+	ChargeCaptured   ChargeStatus = "captured"
+	ChargeRefunded   ChargeStatus = "refunded"
+	ChargeVoided     ChargeStatus = "voided"
+)
+
+var (
+	ErrPaymentDeclined = errors.New("payment declined")
+	ErrChargeNotFound  = errors.New("charge not found")
+
+	ErrNothingToRefund     = errors.New("order has no captured payment to refund")
+	ErrInvalidRefundAmount = errors.New("refund amount must be positive and within what is left to refund")
+)
+
+// AuthorizeRequest describes the funds to hold on the buyer's payment method.
+type AuthorizeRequest struct {
+	Amount      money.Money
+	CustomerID  string
+	Description string
+	Metadata    map[string]string
+}
+
+// Charge is the gateway's view of a single authorization and what happened to it.
+type Charge struct {
+	ID             string
+	Amount         money.Money
+	AmountRefunded money.Money
+	Status         ChargeStatus
+}
+
+// Gateway moves money through an external payment processor. Authorize places a
+// hold, Capture settles it, Void releases a hold that was never captured and
+// Refund returns captured funds.
+//
+// Refund and Void take an idempotency key: repeating a call with the same key
+// returns the first result instead of moving money again, so a reversal can be
+// retried safely.
+type Gateway interface {
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Charge, error)
+	Capture(ctx context.Context, chargeID string) (*Charge, error)
+	Refund(ctx context.Context, chargeID string, amount money.Money, idempotencyKey string) (*Charge, error)
+	Void(ctx context.Context, chargeID string, idempotencyKey string) (*Charge, error)
+}
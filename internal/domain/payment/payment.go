@@ -1,5 +1,7 @@
 package payment
 
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+
 type PaymentType string
 
 const (
@@ -8,14 +10,26 @@ const (
 )
 
 type Payment struct {
-	ID            string
-	FromUserID    string
-	ToUserID      string
-	Amount        float64
-	PlatformFee   float64
-	SellerEarning float64
-	Status        string
-	ReferenceID   string      // This is either BundleID or ProductID
-	Type          PaymentType // "b2b" or "b2c"
-	CreatedAt     string
+	ID              string
+	FromUserID      string
+	ToUserID        string
+	Amount          money.Money // In the listing currency, like the fee and earning
+	PlatformFee     money.Money
+	SellerEarning   money.Money
+	Shipping        money.Money // Part of Amount passed on to the seller, who ships the order
+	Charged         money.Money // What the buyer was charged, in the checkout currency
+	Status          Status
+	ReferenceID     string       // This is either BundleID or ProductID
+	OrderID         string       // Order this payment settles
+	GatewayChargeID string       // ID of the charge at the payment gateway
+	Type            PaymentType  // "b2b" or "b2c"
+	RefundOf        string       // For refunds, the payment being reversed
+	FeeRules        []FeeRuleRef // Fee rule versions the platform fee was charged under
+	CreatedAt       string
+}
+
+// FeeRuleRef identifies one version of a fee rule.
+type FeeRuleRef struct {
+	RuleID  string
+	Version int
 }
@@ -1,10 +1,18 @@
 package payment
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
 
 type Repository interface {
 	RecordPayment(ctx context.Context, p *Payment) error
 	GetPaymentsByUser(ctx context.Context, userID string) ([]*Payment, error)
 	GetPaymentsByType(ctx context.Context, userID string, pType PaymentType) ([]*Payment, error)
-	GetAllPlatformFees(ctx context.Context) (float64, float64, error)
+	// GetAllPlatformFees returns total sales and platform fees per currency.
+	GetAllPlatformFees(ctx context.Context) (money.Totals, money.Totals, error)
+	GetPaymentsByChargeID(ctx context.Context, chargeID string) ([]*Payment, error)
+	GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*Payment, error)
+	UpdatePaymentStatus(ctx context.Context, paymentID string, status Status) error
 }
@@ -0,0 +1,48 @@
+package payment
+
+// Status is where a payment is in its lifecycle:
+//
+//	pending -> authorized -> captured -> refunded
+//	pending/authorized -> failed
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusAuthorized Status = "authorized"
+	StatusCaptured   Status = "captured"
+	StatusRefunded   Status = "refunded"
+	StatusFailed     Status = "failed"
+)
+
+var statusRank = map[Status]int{
+	StatusPending:    0,
+	StatusAuthorized: 1,
+	StatusCaptured:   2,
+	StatusRefunded:   3,
+}
+
+// CanTransition reports whether a payment may move from one status to another.
+// Steps may be skipped, since the gateway does not guarantee that every event is
+// delivered or delivered in order, but a payment never moves backwards.
+func CanTransition(from, to Status) bool {
+	if from == to {
+		return false
+	}
+	if from == StatusFailed || from == StatusRefunded {
+		return false
+	}
+	if to == StatusFailed {
+		return from == StatusPending || from == StatusAuthorized
+	}
+	fromRank, ok := statusRank[from]
+	if !ok {
+		// Legacy free-text statuses such as "Paid" were only written once the
+		// money had been taken.
+		fromRank = statusRank[StatusCaptured]
+	}
+	toRank, ok := statusRank[to]
+	if !ok {
+		return false
+	}
+	return toRank > fromRank
+}
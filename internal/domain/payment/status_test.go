@@ -0,0 +1,31 @@
+package payment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusPending, StatusAuthorized, true},
+		{StatusAuthorized, StatusCaptured, true},
+		{StatusPending, StatusCaptured, true},
+		{StatusCaptured, StatusRefunded, true},
+		{StatusAuthorized, StatusFailed, true},
+		{StatusCaptured, StatusAuthorized, false},
+		{StatusCaptured, StatusFailed, false},
+		{StatusRefunded, StatusCaptured, false},
+		{StatusFailed, StatusCaptured, false},
+		{StatusCaptured, StatusCaptured, false},
+		{"Paid", StatusRefunded, true},
+		{"Paid", StatusCaptured, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CanTransition(tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrUnknownCharge is returned for events about charges the platform has not
+	// recorded yet. The gateway retries failed deliveries, which covers events that
+	// arrive before the purchase that created the charge has been written.
+	ErrUnknownCharge = errors.New("no payment found for charge")
+)
+
+// WebhookEvent is a gateway notification reduced to what the platform acts on.
+type WebhookEvent struct {
+	ID       string `bson:"_id"`
+	Type     string `bson:"type"`
+	ChargeID string `bson:"charge_id"`
+	// Status is the payment status the event reports, empty for event types the
+	// platform does not act on.
+	Status     Status `bson:"status"`
+	Created    int64  `bson:"created"`
+	ReceivedAt string `bson:"received_at"`
+}
+
+// WebhookVerifier checks a raw webhook delivery against its signature header and
+// parses it.
+type WebhookVerifier interface {
+	Verify(payload []byte, signatureHeader string) (*WebhookEvent, error)
+}
+
+// EventRepository remembers which webhook events were already applied.
+type EventRepository interface {
+	HasProcessedEvent(ctx context.Context, eventID string) (bool, error)
+	RecordEvent(ctx context.Context, e *WebhookEvent) error
+}
+
+type Usecase interface {
+	// HandleWebhookEvent applies a verified gateway event. Replayed events and
+	// events that would move a payment backwards are ignored.
+	HandleWebhookEvent(ctx context.Context, e *WebhookEvent) error
+}
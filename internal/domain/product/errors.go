@@ -0,0 +1,8 @@
+package product
+
+import "errors"
+
+// ErrProductUnavailable is returned when a product is no longer available for sale.
+var ErrProductUnavailable = errors.New("product is no longer available")
+
+var ErrInvalidSearch = errors.New("invalid search: check page, limit, sort and price range")
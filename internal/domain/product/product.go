@@ -2,10 +2,21 @@ package product
 
 import (
 	"errors"
+	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	StatusAvailable = "available"
+	StatusReserved  = "reserved"
+	StatusSold      = "sold"
+)
+
+// ReservationTTL is how long a checkout may hold a product before the hold lapses.
+const ReservationTTL = 10 * time.Minute
+
 type Product struct {
 	ID          string             `bson:"_id" json:"id"`
 	ResellerID  primitive.ObjectID `bson:"reseller_id" json:"reseller_id"`
@@ -16,17 +27,42 @@ type Product struct {
 	Size        string             `json:"size"`
 	Type        string             `json:"type"`
 	Grade       string             `json:"grade"`
-	Price       float64            `json:"price"`
+	Price       money.Money        `json:"price"`
 	Status      string             `json:"status"`
 	ImageURL    string             `json:"image_url"`
 	CreatedAt   string             `json:"created_at"`
 	Rating      float64            `bson:"rating" json:"rating"`
+
+	// ReservedBy and ReservedUntil are set while a consumer is checking the product out.
+	ReservedBy    string     `bson:"reserved_by,omitempty" json:"reserved_by,omitempty"`
+	ReservedUntil *time.Time `bson:"reserved_until,omitempty" json:"reserved_until,omitempty"`
 }
 
 func (p *Product) GenerateID() string {
 	return primitive.NewObjectID().Hex()
 }
 
+// IsHeldByOther reports whether another user holds an unexpired reservation on the product.
+func (p *Product) IsHeldByOther(userID string, now time.Time) bool {
+	return p.Status == StatusReserved &&
+		p.ReservedBy != userID &&
+		p.ReservedUntil != nil &&
+		now.Before(*p.ReservedUntil)
+}
+
+// AvailableTo reports whether the user may buy the product now. A lapsed hold, or the
+// user's own hold, does not stand in the way.
+func (p *Product) AvailableTo(userID string, now time.Time) bool {
+	switch p.Status {
+	case StatusAvailable:
+		return true
+	case StatusReserved:
+		return !p.IsHeldByOther(userID, now)
+	default:
+		return false
+	}
+}
+
 func (p *Product) ValidateRating() error {
 	if p.Rating < 0 || p.Rating > 5 {
 		return errors.New("rating must be between 0 and 5")
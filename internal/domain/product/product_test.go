@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -55,7 +56,7 @@ func TestProduct_NewProduct(t *testing.T) {
 		Size:        "M",
 		Type:        "T-Shirt",
 		Grade:       "A",
-		Price:       29.99,
+		Price:       money.New(2999, money.ETB),
 		Status:      "available",
 		ImageURL:    "http://example.com/image.jpg",
 		Rating:      4.5,
@@ -89,3 +90,29 @@ func TestProduct_UpdateRating(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, 4.5, p.Rating) // Rating should remain unchanged
 }
+
+func TestProduct_Reservation(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		product     Product
+		heldByOther bool
+		available   bool
+	}{
+		{"available", Product{Status: StatusAvailable}, false, true},
+		{"sold", Product{Status: StatusSold}, false, false},
+		{"held by other", Product{Status: StatusReserved, ReservedBy: "other", ReservedUntil: &future}, true, false},
+		{"held by self", Product{Status: StatusReserved, ReservedBy: "me", ReservedUntil: &future}, false, true},
+		{"lapsed hold", Product{Status: StatusReserved, ReservedBy: "other", ReservedUntil: &past}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.heldByOther, tt.product.IsHeldByOther("me", now))
+			assert.Equal(t, tt.available, tt.product.AvailableTo("me", now))
+		})
+	}
+}
@@ -1,15 +1,26 @@
 package product
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Repository interface {
 	AddProduct(ctx context.Context, p *Product) error
 	GetProductByID(ctx context.Context, id string) (*Product, error)
 	GetProductByTitle(ctx context.Context, title string) (*Product, error)
-	ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*Product, error)
-	ListAvailableProducts(ctx context.Context, page, limit int) ([]*Product, error)
+	ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*Product], error)
+	ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*Product], error)
+	SearchProducts(ctx context.Context, q *SearchQuery) (*SearchResult, error)
 	DeleteProduct(ctx context.Context, id string) error
 	UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error
 	GetProductsByBundleID(ctx context.Context, bundleID string) ([]*Product, error)
 	GetSoldProductsByReseller(ctx context.Context, resellerID string) ([]*Product, error)
+	Reserve(ctx context.Context, id, userID string, until time.Time) error
+	ReleaseReservation(ctx context.Context, id, userID string) error
+	ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error)
+	MarkAsSold(ctx context.Context, id, buyerID string) error
+	Restock(ctx context.Context, id string) error
 }
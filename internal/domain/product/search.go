@@ -0,0 +1,88 @@
+package product
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
+
+type SearchSort string
+
+const (
+	// SortRelevance ranks text matches first and falls back to newest without a
+	// search text.
+	SortRelevance SearchSort = "relevance"
+	SortPriceAsc  SearchSort = "price_asc"
+	SortPriceDesc SearchSort = "price_desc"
+	SortNewest    SearchSort = "newest"
+	SortRating    SearchSort = "rating"
+)
+
+func (s SearchSort) Valid() bool {
+	switch s {
+	case SortRelevance, SortPriceAsc, SortPriceDesc, SortNewest, SortRating:
+		return true
+	}
+	return false
+}
+
+// SearchQuery finds products on sale. Filters on the same facet match any of the
+// given values; different facets must all match.
+type SearchQuery struct {
+	Text   string
+	Sizes  []string
+	Types  []string
+	Grades []string
+	// MinPrice and MaxPrice are in Currency. Products listed in another currency
+	// are compared at the configured exchange rate.
+	Currency money.Currency
+	MinPrice *money.Money
+	MaxPrice *money.Money
+	// MinTrustScore keeps products whose reseller has at least this trust score.
+	MinTrustScore int
+	// SupplierID and BundleID narrow results to items unpacked from one
+	// supplier's bundles, or from one bundle.
+	SupplierID string
+	BundleID   string
+	Sort       SearchSort
+	// A cursor is only valid with the sort and search text it was issued for.
+	pagination.Request
+
+	// PriceFactors holds, for every listing currency with an exchange rate, how
+	// many minor units of Currency one of its minor units is worth. The usecase
+	// fills it in; products in currencies missing from it have no comparable
+	// price.
+	PriceFactors map[money.Currency]float64
+}
+
+func (q *SearchQuery) Validate() error {
+	if !q.Sort.Valid() {
+		return ErrInvalidSearch
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil && q.MinPrice.Amount > q.MaxPrice.Amount {
+		return ErrInvalidSearch
+	}
+	return nil
+}
+
+// FacetCount is how many matching products have one value of a facet.
+type FacetCount struct {
+	Value string `bson:"_id" json:"value"`
+	Count int    `bson:"count" json:"count"`
+}
+
+// Facets counts the products matching a search for every value of each facet.
+// Each facet is counted under all the other filters but not its own, so picking
+// a value never hides the alternatives.
+type Facets struct {
+	Sizes     []FacetCount `bson:"sizes" json:"sizes"`
+	Types     []FacetCount `bson:"types" json:"types"`
+	Grades    []FacetCount `bson:"grades" json:"grades"`
+	Suppliers []FacetCount `bson:"suppliers" json:"suppliers"`
+}
+
+// SearchResult is a page of matching products in the usual list envelope, with
+// the facet counts for the whole search next to it.
+type SearchResult struct {
+	pagination.Page[*Product]
+	Facets Facets `json:"facets"`
+}
@@ -1,13 +1,18 @@
 package product
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Usecase interface {
 	AddProduct(ctx context.Context, p *Product) error
 	GetProductByID(ctx context.Context, id string) (*Product, error)
 	GetProductByTitle(ctx context.Context, title string) (*Product, error)
-	ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*Product, error)
-	ListAvailableProducts(ctx context.Context, page, limit int) ([]*Product, error)
+	ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*Product], error)
+	ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*Product], error)
+	SearchProducts(ctx context.Context, q *SearchQuery) (*SearchResult, error)
 	DeleteProduct(ctx context.Context, id string) error
 	UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error
 }
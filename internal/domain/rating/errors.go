@@ -0,0 +1,9 @@
+package rating
+
+import "errors"
+
+var (
+	ErrInvalidScore       = errors.New("score must be between 1 and 5")
+	ErrBundleNotPurchased = errors.New("you can only rate the supplier of a bundle you bought")
+	ErrAlreadyRated       = errors.New("you already rated this bundle")
+)
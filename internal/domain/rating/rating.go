@@ -1,11 +1,24 @@
 package rating
 
+import "errors"
+
 type Rating struct {
-	ID         string
-	ResellerID string
-	SupplierID string
-	Score      int // e.g., 1–5
-	Comment    string
-	SkipRate   float64
-	CreatedAt  string
+	ID         string  `bson:"_id" json:"id"`
+	ResellerID string  `bson:"reseller_id" json:"reseller_id"`
+	SupplierID string  `bson:"supplier_id" json:"supplier_id"`
+	BundleID   string  `bson:"bundle_id" json:"bundle_id"`
+	Score      int     `bson:"score" json:"score"` // e.g., 1–5
+	Comment    string  `bson:"comment" json:"comment"`
+	SkipRate   float64 `bson:"skip_rate" json:"skip_rate"`
+	CreatedAt  string  `bson:"created_at" json:"created_at"`
+}
+
+func (r *Rating) Validate() error {
+	if r.Score < 1 || r.Score > 5 {
+		return ErrInvalidScore
+	}
+	if r.ResellerID == "" || r.SupplierID == "" || r.BundleID == "" {
+		return errors.New("reseller_id, supplier_id and bundle_id are required")
+	}
+	return nil
 }
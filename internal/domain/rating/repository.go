@@ -0,0 +1,15 @@
+package rating
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
+
+type Repository interface {
+	// CreateRating returns ErrAlreadyRated if a rating with the same ID, which is
+	// its reseller's and bundle's, was stored already.
+	CreateRating(ctx context.Context, r *Rating) error
+	GetRatingByResellerAndBundle(ctx context.Context, resellerID, bundleID string) (*Rating, error)
+	GetRatingsBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*Rating], error)
+}
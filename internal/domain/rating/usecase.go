@@ -0,0 +1,13 @@
+package rating
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
+
+type Usecase interface {
+	// RateSupplier records a reseller's rating of the supplier of a bundle they bought.
+	RateSupplier(ctx context.Context, resellerID, bundleID string, score int, comment string) (*Rating, error)
+	GetSupplierRatings(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*Rating], error)
+}
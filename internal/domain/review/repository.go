@@ -1,9 +1,13 @@
 package review
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Repository interface {
 	CreateReview(ctx context.Context, r *Review) error
 	GetReviewByUserAndProduct(ctx context.Context, userID, productID string) (*Review, error)
-	GetReviewsByReseller(ctx context.Context, resellerID string) ([]*Review, error)
+	ListReviewsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*Review], error)
 }
@@ -1,9 +1,13 @@
 package review
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 
 type Usecase interface {
 	SubmitReview(ctx context.Context, r *Review) error
-	GetResellerReviews(ctx context.Context, resellerID string) ([]*Review, error)
+	GetResellerReviews(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*Review], error)
 }
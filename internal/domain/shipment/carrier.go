@@ -0,0 +1,45 @@
+package shipment
+
+import (
+	"context"
+	"time"
+)
+
+// Parcel is what a carrier needs to book a pickup.
+type Parcel struct {
+	OrderID string
+	// TrackingNumber is set when the seller already labelled the parcel; the
+	// carrier issues one otherwise.
+	TrackingNumber string
+}
+
+// Carrier is an adapter to a courier's API. Carriers report a parcel's progress
+// later through the tracking webhook.
+type Carrier interface {
+	Name() string
+	// Book registers the parcel and returns its tracking number.
+	Book(ctx context.Context, p Parcel) (string, error)
+}
+
+// Update is a tracking event a carrier reported for one of its parcels.
+type Update struct {
+	Carrier        string
+	TrackingNumber string
+	Event          TrackingEvent
+}
+
+func (u *Update) Validate() error {
+	if u.TrackingNumber == "" || u.Event.ID == "" || !u.Event.Status.Valid() {
+		return ErrInvalidEvent
+	}
+	if _, err := time.Parse(time.RFC3339, u.Event.OccurredAt); err != nil {
+		return ErrInvalidEvent
+	}
+	return nil
+}
+
+// WebhookVerifier checks a raw webhook delivery against its signature header and
+// parses it.
+type WebhookVerifier interface {
+	Verify(payload []byte, signatureHeader string) (*Update, error)
+}
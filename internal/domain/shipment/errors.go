@@ -0,0 +1,15 @@
+package shipment
+
+import "errors"
+
+var (
+	ErrShipmentNotFound = errors.New("shipment not found")
+	ErrNotShipmentParty = errors.New("only the buyer or seller can track this order")
+	ErrShipmentChanged  = errors.New("shipment was changed concurrently")
+	ErrTrackingTaken    = errors.New("another shipment already has this tracking number")
+	ErrInvalidEvent     = errors.New("tracking event needs an id, a known status and an RFC 3339 time")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrUnknownParcel is returned for tracking events about parcels the platform
+	// has not recorded. Carriers retry failed deliveries.
+	ErrUnknownParcel = errors.New("no shipment found for tracking number")
+)
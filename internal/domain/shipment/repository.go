@@ -0,0 +1,14 @@
+package shipment
+
+import "context"
+
+type Repository interface {
+	// SaveShipment creates or replaces the order's shipment. It returns
+	// ErrTrackingTaken if another shipment has the same carrier and tracking number.
+	SaveShipment(ctx context.Context, s *Shipment) error
+	GetShipmentByOrderID(ctx context.Context, orderID string) (*Shipment, error)
+	GetShipmentByTracking(ctx context.Context, carrier, trackingNumber string) (*Shipment, error)
+	// UpdateShipment saves the shipment if its stored timeline still has events
+	// entries, so concurrent webhooks cannot drop each other's events.
+	UpdateShipment(ctx context.Context, s *Shipment, events int) error
+}
@@ -0,0 +1,79 @@
+package shipment
+
+import (
+	"sort"
+	"time"
+)
+
+type Status string
+
+const (
+	LabelCreated   Status = "label_created"
+	InTransit      Status = "in_transit"
+	OutForDelivery Status = "out_for_delivery"
+	Delivered      Status = "delivered"
+	Exception      Status = "exception" // e.g. a failed delivery attempt
+)
+
+func (s Status) Valid() bool {
+	switch s {
+	case LabelCreated, InTransit, OutForDelivery, Delivered, Exception:
+		return true
+	}
+	return false
+}
+
+// TrackingEvent is one step of a parcel's journey as the carrier reported it. ID is
+// the carrier's event ID, so redelivered webhooks are recorded once.
+type TrackingEvent struct {
+	ID          string `bson:"id" json:"id"`
+	Status      Status `bson:"status" json:"status"`
+	Description string `bson:"description" json:"description"`
+	Location    string `bson:"location,omitempty" json:"location,omitempty"`
+	OccurredAt  string `bson:"occurred_at" json:"occurred_at"`
+}
+
+// time parses OccurredAt, which Update.Validate has checked is RFC 3339.
+func (e *TrackingEvent) time() time.Time {
+	t, _ := time.Parse(time.RFC3339, e.OccurredAt)
+	return t
+}
+
+// Shipment tracks the parcel an order was sent in. Its ID is the order ID, so an
+// order has at most one shipment.
+type Shipment struct {
+	ID             string          `bson:"_id" json:"id"`
+	OrderID        string          `bson:"order_id" json:"order_id"`
+	SellerID       string          `bson:"seller_id" json:"seller_id"`
+	BuyerID        string          `bson:"buyer_id" json:"buyer_id"`
+	Carrier        string          `bson:"carrier" json:"carrier"`
+	TrackingNumber string          `bson:"tracking_number" json:"tracking_number"`
+	Status         Status          `bson:"status" json:"status"`
+	Events         []TrackingEvent `bson:"events" json:"events"`
+	CreatedAt      string          `bson:"created_at" json:"created_at"`
+	UpdatedAt      string          `bson:"updated_at" json:"updated_at"`
+	DeliveredAt    string          `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+}
+
+// HasEvent reports whether the carrier event was recorded already.
+func (s *Shipment) HasEvent(id string) bool {
+	for _, e := range s.Events {
+		if e.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Record adds an event to the timeline. Carriers may deliver events out of order,
+// so the timeline is kept sorted and the status follows the latest event.
+func (s *Shipment) Record(e TrackingEvent) {
+	s.Events = append(s.Events, e)
+	sort.SliceStable(s.Events, func(i, j int) bool {
+		return s.Events[i].time().Before(s.Events[j].time())
+	})
+	s.Status = s.Events[len(s.Events)-1].Status
+	if e.Status == Delivered && s.DeliveredAt == "" {
+		s.DeliveredAt = e.OccurredAt
+	}
+}
@@ -0,0 +1,21 @@
+package shipment
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+)
+
+// Dispatcher hands an order to its carrier when the seller ships it. It fills in
+// the tracking number when the carrier issues one.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, o *order.Order) (*Shipment, error)
+}
+
+type Usecase interface {
+	// GetShipment returns the order's shipment for its buyer or seller.
+	GetShipment(ctx context.Context, userID, orderID string) (*Shipment, error)
+	// HandleTrackingUpdate records a carrier event. A delivered parcel marks its
+	// order delivered.
+	HandleTrackingUpdate(ctx context.Context, u *Update) error
+}
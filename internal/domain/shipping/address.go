@@ -0,0 +1,39 @@
+package shipping
+
+import "strings"
+
+// Address is an entry in a user's address book. Orders keep a copy of the address
+// they were shipped to, so editing or deleting an entry leaves past orders alone.
+type Address struct {
+	ID            string `bson:"_id" json:"id"`
+	UserID        string `bson:"user_id" json:"user_id"`
+	Label         string `bson:"label" json:"label"` // e.g. "Home" or "Shop"
+	RecipientName string `bson:"recipient_name" json:"recipient_name"`
+	Phone         string `bson:"phone" json:"phone"`
+	Line1         string `bson:"line1" json:"line1"`
+	Line2         string `bson:"line2,omitempty" json:"line2,omitempty"`
+	City          string `bson:"city" json:"city"`
+	Region        string `bson:"region,omitempty" json:"region,omitempty"`
+	PostalCode    string `bson:"postal_code,omitempty" json:"postal_code,omitempty"`
+	Country       string `bson:"country" json:"country"` // ISO 3166 alpha-2
+	IsDefault     bool   `bson:"is_default" json:"is_default"`
+	CreatedAt     string `bson:"created_at" json:"created_at"`
+}
+
+// Normalize trims the fields and upper-cases the country code.
+func (a *Address) Normalize() {
+	for _, f := range []*string{&a.Label, &a.RecipientName, &a.Phone, &a.Line1, &a.Line2, &a.City, &a.Region, &a.PostalCode} {
+		*f = strings.TrimSpace(*f)
+	}
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+}
+
+func (a *Address) Validate() error {
+	if a.RecipientName == "" || a.Phone == "" || a.Line1 == "" || a.City == "" {
+		return ErrIncompleteAddress
+	}
+	if len(a.Country) != 2 {
+		return ErrInvalidCountry
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package shipping
+
+import "errors"
+
+var (
+	ErrAddressNotFound   = errors.New("address not found")
+	ErrAddressRequired   = errors.New("a shipping address is required")
+	ErrIncompleteAddress = errors.New("recipient name, phone, line1 and city are required")
+	ErrInvalidCountry    = errors.New("country must be a two-letter ISO code")
+	ErrInvalidOption     = errors.New("delivery option must be standard or express")
+	ErrOptionUnavailable = errors.New("this delivery option is not offered for the address")
+)
@@ -0,0 +1,165 @@
+package shipping
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+// Option is the delivery speed a buyer picks at checkout.
+type Option string
+
+const (
+	Standard Option = "standard"
+	Express  Option = "express"
+)
+
+func (o Option) Valid() bool {
+	return o == Standard || o == Express
+}
+
+// Zone is how far a parcel travels, counted from the marketplace's home city.
+type Zone string
+
+const (
+	ZoneLocal         Zone = "local"
+	ZoneDomestic      Zone = "domestic"
+	ZoneInternational Zone = "international"
+)
+
+// ItemWeightGrams is the shipping weight assumed for one garment, packaging
+// included. Listings carry no weight, so a bundle weighs its Quantity of these.
+const ItemWeightGrams = 500
+
+// WeightForItems is the shipping weight of a parcel holding n garments.
+func WeightForItems(n int) int {
+	if n < 1 {
+		n = 1
+	}
+	return n * ItemWeightGrams
+}
+
+// Band prices parcels up to a weight.
+type Band struct {
+	UpToGrams int
+	Cost      int64
+}
+
+// Rate is the price list for one option within one zone. Costs are in the table's
+// currency. Parcels heavier than the last band pay its cost plus ExtraPerKg for
+// every started kilogram above it.
+type Rate struct {
+	Zone       Zone
+	Option     Option
+	Bands      []Band
+	ExtraPerKg int64
+	Days       int
+}
+
+func (r *Rate) Cost(grams int) int64 {
+	for _, b := range r.Bands {
+		if grams <= b.UpToGrams {
+			return b.Cost
+		}
+	}
+	last := r.Bands[len(r.Bands)-1]
+	extraKg := (grams - last.UpToGrams + 999) / 1000
+	return last.Cost + int64(extraKg)*r.ExtraPerKg
+}
+
+// RateTable holds the zone and weight prices for every delivery option.
+type RateTable struct {
+	HomeCountry string
+	HomeCity    string
+	Currency    money.Currency
+	Rates       []Rate
+}
+
+// Zone places an address relative to the home city.
+func (t *RateTable) Zone(a *Address) Zone {
+	switch {
+	case !strings.EqualFold(a.Country, t.HomeCountry):
+		return ZoneInternational
+	case strings.EqualFold(strings.TrimSpace(a.City), t.HomeCity):
+		return ZoneLocal
+	default:
+		return ZoneDomestic
+	}
+}
+
+// Rate returns the price list for the option in the zone, or nil when the option
+// is not offered there.
+func (t *RateTable) Rate(zone Zone, option Option) *Rate {
+	for i := range t.Rates {
+		if t.Rates[i].Zone == zone && t.Rates[i].Option == option {
+			return &t.Rates[i]
+		}
+	}
+	return nil
+}
+
+// DefaultRateTable is the courier price list the marketplace ships with, in ETB
+// minor units, counted from Addis Ababa.
+func DefaultRateTable() *RateTable {
+	return &RateTable{
+		HomeCountry: "ET",
+		HomeCity:    "Addis Ababa",
+		Currency:    money.ETB,
+		Rates: []Rate{
+			{Zone: ZoneLocal, Option: Standard, Days: 2, ExtraPerKg: 2000,
+				Bands: []Band{{1000, 10000}, {5000, 20000}, {20000, 45000}}},
+			{Zone: ZoneLocal, Option: Express, Days: 1, ExtraPerKg: 3500,
+				Bands: []Band{{1000, 20000}, {5000, 35000}, {20000, 70000}}},
+			{Zone: ZoneDomestic, Option: Standard, Days: 5, ExtraPerKg: 4500,
+				Bands: []Band{{1000, 25000}, {5000, 45000}, {20000, 100000}}},
+			{Zone: ZoneDomestic, Option: Express, Days: 2, ExtraPerKg: 8000,
+				Bands: []Band{{1000, 45000}, {5000, 80000}, {20000, 180000}}},
+			{Zone: ZoneInternational, Option: Standard, Days: 14, ExtraPerKg: 85000,
+				Bands: []Band{{1000, 250000}, {5000, 600000}, {20000, 1800000}}},
+			{Zone: ZoneInternational, Option: Express, Days: 5, ExtraPerKg: 140000,
+				Bands: []Band{{1000, 450000}, {5000, 1000000}, {20000, 3000000}}},
+		},
+	}
+}
+
+// Selection is the address and delivery option a buyer picks at checkout.
+type Selection struct {
+	AddressID string `json:"address_id"`
+	Option    Option `json:"delivery_option"`
+}
+
+// Quote is the price of delivering a parcel to an address. Cost is in the rate
+// table's currency.
+type Quote struct {
+	Address           Address     `json:"address"`
+	Option            Option      `json:"delivery_option"`
+	Zone              Zone        `json:"zone"`
+	WeightGrams       int         `json:"weight_grams"`
+	Cost              money.Money `json:"cost"`
+	EstimatedDays     int         `json:"estimated_days"`
+	EstimatedDelivery string      `json:"estimated_delivery"`
+}
+
+// Quote prices a parcel of items garments to the address, estimating delivery
+// from now.
+func (t *RateTable) Quote(a *Address, option Option, items int, now time.Time) (*Quote, error) {
+	if !option.Valid() {
+		return nil, ErrInvalidOption
+	}
+	zone := t.Zone(a)
+	rate := t.Rate(zone, option)
+	if rate == nil || len(rate.Bands) == 0 {
+		return nil, ErrOptionUnavailable
+	}
+	grams := WeightForItems(items)
+	return &Quote{
+		Address:           *a,
+		Option:            option,
+		Zone:              zone,
+		WeightGrams:       grams,
+		Cost:              money.New(rate.Cost(grams), t.Currency),
+		EstimatedDays:     rate.Days,
+		EstimatedDelivery: now.AddDate(0, 0, rate.Days).Format(time.RFC3339),
+	}, nil
+}
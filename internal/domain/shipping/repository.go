@@ -0,0 +1,14 @@
+package shipping
+
+import "context"
+
+type AddressRepository interface {
+	CreateAddress(ctx context.Context, a *Address) error
+	// GetAddressByID returns nil if there is no such address.
+	GetAddressByID(ctx context.Context, id string) (*Address, error)
+	ListAddressesByUser(ctx context.Context, userID string) ([]*Address, error)
+	UpdateAddress(ctx context.Context, a *Address) error
+	DeleteAddress(ctx context.Context, userID, id string) error
+	// ClearDefault unsets the default flag on the user's other addresses.
+	ClearDefault(ctx context.Context, userID, exceptID string) error
+}
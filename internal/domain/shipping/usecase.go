@@ -0,0 +1,19 @@
+package shipping
+
+import "context"
+
+// Quoter prices a delivery to one of the buyer's addresses. Orders take their
+// shipping cost and address snapshot from the quote.
+type Quoter interface {
+	Quote(ctx context.Context, buyerID string, sel Selection, items int) (*Quote, error)
+}
+
+type Usecase interface {
+	Quoter
+	AddAddress(ctx context.Context, userID string, a *Address) (*Address, error)
+	ListAddresses(ctx context.Context, userID string) ([]*Address, error)
+	UpdateAddress(ctx context.Context, userID, id string, a *Address) (*Address, error)
+	DeleteAddress(ctx context.Context, userID, id string) error
+	// Options quotes every delivery option offered for the address.
+	Options(ctx context.Context, userID, addressID string, items int) ([]*Quote, error)
+}
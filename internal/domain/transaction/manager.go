@@ -0,0 +1,11 @@
+package transaction
+
+import "context"
+
+// Manager runs a unit of work atomically. Repositories called with the context
+// passed to fn take part in the transaction; if fn returns an error every write
+// it made is rolled back. fn may be retried on transient conflicts, so it must
+// not have side effects outside the database.
+type Manager interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -5,4 +5,10 @@ import "context"
 type Usecase interface {
 	UpdateSupplierTrustScoreOnNewRating(ctx context.Context, supplierID string, declaredRating float64, productRating float64) error
 	UpdateResellerTrustScoreOnNewRating(ctx context.Context, resellerID string, declaredRating float64, productRating float64) error
+	// ApplyDisputePenalty counts a dispute upheld against the seller like a rating
+	// that missed the declared grade by penalty points.
+	ApplyDisputePenalty(ctx context.Context, sellerID string, penalty float64) error
+	// ApplyBreakdownDeviation counts how far an unpacked bundle's contents were
+	// from the supplier's estimated breakdown, in percent, like a rating.
+	ApplyBreakdownDeviation(ctx context.Context, supplierID string, deviationPercent float64) error
 }
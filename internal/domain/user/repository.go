@@ -1,16 +1,22 @@
 package user
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Repository interface {
 	CreateUser(ctx context.Context, u *User) error
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id string) (*User, error)
-	ListUsersByRole(ctx context.Context, role Role) ([]*User, error)
+	// ListUsersByRole lists users in any of roles, or in every role when none
+	// are given.
+	ListUsersByRole(ctx context.Context, roles []Role, req pagination.Request) (*pagination.Page[*User], error)
 	UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error
 	DeleteUser(ctx context.Context, id string) error
 	FindUserByUsername(ctx context.Context, username string) (*User, error)
 	UpdateTrustData(ctx context.Context, user *User) error
-	GetBlacklistedUsers(ctx context.Context) ([]*User, error)
+	ListBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*User], error)
 	CountActiveUsers(ctx context.Context) (int, error)
 }
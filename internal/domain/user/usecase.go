@@ -1,12 +1,16 @@
 package user
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Usecase interface {
 	GetByID(ctx context.Context, id string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
-	ListByRole(ctx context.Context, role Role) ([]*User, error)
+	ListByRole(ctx context.Context, roles []Role, req pagination.Request) (*pagination.Page[*User], error)
 	Update(ctx context.Context, id string, updates map[string]interface{}) error
 	Delete(ctx context.Context, id string) error
-	GetBlacklistedUsers(ctx context.Context) ([]*User, error)
+	GetBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*User], error)
 }
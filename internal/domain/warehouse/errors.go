@@ -0,0 +1,15 @@
+package warehouse
+
+import "errors"
+
+var (
+	ErrItemNotFound           = errors.New("warehouse item not found")
+	ErrNotItemOwner           = errors.New("this bundle is not in your warehouse")
+	ErrNotReceivable          = errors.New("only a pending bundle can be received")
+	ErrNotUnpacking           = errors.New("the bundle must be received before it is unpacked")
+	ErrNothingToUnpack        = errors.New("every piece of this bundle is already accounted for")
+	ErrStatusChanged          = errors.New("warehouse item status changed concurrently")
+	ErrInvalidSkipReason      = errors.New("reason must be one of damaged, stained, wrong_size or other")
+	ErrProductNotFromBundle   = errors.New("the product was not created from this bundle")
+	ErrProductAlreadyUnpacked = errors.New("the product is already recorded as an unpacked piece")
+)
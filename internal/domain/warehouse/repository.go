@@ -1,13 +1,23 @@
 package warehouse
 
-import "context"
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+)
 
 type Repository interface {
 	AddItem(ctx context.Context, item *WarehouseItem) error
-	GetItemsByReseller(ctx context.Context, resellerID string) ([]*WarehouseItem, error)
+	// GetItemByID returns nil if there is no such item.
+	GetItemByID(ctx context.Context, itemID string) (*WarehouseItem, error)
+	// ListItemsByReseller lists the reseller's bundle entries, not their pieces.
+	ListItemsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*WarehouseItem], error)
 	GetItemsByBundle(ctx context.Context, bundleID string) ([]*WarehouseItem, error)
-	MarkItemAsListed(ctx context.Context, itemID string) error
-	MarkItemAsSkipped(ctx context.Context, itemID string) error
+	// UpdateItemStatus applies the updates if the item's status is still from.
+	UpdateItemStatus(ctx context.Context, itemID, from string, updates map[string]interface{}) error
+	// TakePiece counts one more piece of a received entry as accounted for and
+	// returns the entry as it is afterwards.
+	TakePiece(ctx context.Context, itemID string) (*WarehouseItem, error)
 	DeleteItem(ctx context.Context, itemID string) error
 	HasResellerReceivedBundle(ctx context.Context, resellerID string, bundleID string) (bool, error)
 	CountByStatus(ctx context.Context, status string) (int, error)
@@ -3,9 +3,24 @@ package warehouse
 import (
 	"context"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 )
 
 type WarehouseUseCase interface {
-	GetWarehouseItems(ctx context.Context, resellerID string) ([]*models.WarehouseItemResponse, error)
+	GetWarehouseItems(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*models.WarehouseItemResponse], error)
+	// ReceiveBundle confirms a pending bundle arrived, so it can be unpacked.
+	ReceiveBundle(ctx context.Context, resellerID, itemID string) (*WarehouseItem, error)
+	// UnpackPiece records a piece of a received bundle as listed under productID.
+	UnpackPiece(ctx context.Context, resellerID, itemID, productID string) (*WarehouseItem, error)
+	// ListProduct saves a product the reseller made from one of their bundles
+	// and records it as an unpacked piece of that bundle.
+	ListProduct(ctx context.Context, resellerID string, p *product.Product) (*WarehouseItem, error)
+	// SkipPiece records a piece of a received bundle that will not be listed.
+	SkipPiece(ctx context.Context, resellerID, itemID string, skip Skip) (*WarehouseItem, error)
+	// GetBundleBreakdown counts the bundle's listed products and skipped pieces
+	// by category, next to the supplier's estimate.
+	GetBundleBreakdown(ctx context.Context, bundleID string) (*bundle.Breakdown, error)
 }
@@ -1,17 +1,85 @@
 package warehouse
 
+// A bundle's warehouse entry moves through
+//
+//	pending -> received -> closed
+//
+// as the reseller confirms it arrived and then records every piece in it.
+// Each recorded piece gets its own item, linked to the entry, that is either
+// listed or skipped.
+const (
+	StatusPending  = "pending"
+	StatusReceived = "received"
+	StatusClosed   = "closed"
+
+	StatusListed  = "listed"
+	StatusSkipped = "skipped"
+
+	// StatusArrived is how entries were stored before bundles were received and
+	// unpacked. Those entries, and entries stored as listed, count as received.
+	StatusArrived = "arrived"
+)
+
+// SkipReason is why an unpacked piece was not good enough to list.
+type SkipReason string
+
+const (
+	SkipDamaged   SkipReason = "damaged"
+	SkipStained   SkipReason = "stained"
+	SkipWrongSize SkipReason = "wrong_size"
+	SkipOther     SkipReason = "other"
+)
+
+func (r SkipReason) Valid() bool {
+	switch r {
+	case SkipDamaged, SkipStained, SkipWrongSize, SkipOther:
+		return true
+	}
+	return false
+}
+
+// Skip describes an unpacked piece that will not be listed. Type is the piece's
+// category, as a product made from it would have had.
+type Skip struct {
+	Reason SkipReason
+	Type   string
+	Note   string
+}
+
 type WarehouseItem struct {
-	ID                 string `bson:"_id"`
-	ResellerID         string `bson:"reseller_id"`
-	BundleID           string `bson:"bundle_id"`
-	ProductID          string `bson:"product_id"`
-	Status             string `bson:"status"` // listed, skipped, pending
-	CreatedAt          string `bson:"created_at"`
-	DeclaredRating     int    `bson:"declared_rating"`
-	RemainingItemCount int    `bson:"remaining_item_count"`
-	Grade              string `bson:"grade"`
-	Type               string `bson:"type"`
-	Quantity           int    `bson:"quantity"`
-	SortingLevel       string `bson:"sorting_level"`
-	SampleImage        string `bson:"sample_image"`
+	ID                 string `bson:"_id" json:"id"`
+	ResellerID         string `bson:"reseller_id" json:"reseller_id"`
+	BundleID           string `bson:"bundle_id" json:"bundle_id"`
+	ProductID          string `bson:"product_id" json:"product_id,omitempty"`
+	Status             string `bson:"status" json:"status"`
+	CreatedAt          string `bson:"created_at" json:"created_at"`
+	DeclaredRating     int    `bson:"declared_rating" json:"declared_rating"`
+	RemainingItemCount int    `bson:"remaining_item_count" json:"remaining_item_count"`
+	Grade              string `bson:"grade" json:"grade"`
+	Type               string `bson:"type" json:"type"`
+	Quantity           int    `bson:"quantity" json:"quantity"`
+	SortingLevel       string `bson:"sorting_level" json:"sorting_level"`
+	SampleImage        string `bson:"sample_image" json:"sample_image"`
+
+	ReceivedAt string `bson:"received_at,omitempty" json:"received_at,omitempty"`
+	ClosedAt   string `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+
+	// EntryID is set on pieces only and points at their bundle's entry.
+	EntryID    string     `bson:"entry_id,omitempty" json:"entry_id,omitempty"`
+	SkipReason SkipReason `bson:"skip_reason,omitempty" json:"skip_reason,omitempty"`
+	SkipNote   string     `bson:"skip_note,omitempty" json:"skip_note,omitempty"`
+}
+
+// Unpacking reports whether pieces can still be recorded against the entry.
+func (i *WarehouseItem) Unpacking() bool {
+	switch i.Status {
+	case StatusReceived, StatusArrived, StatusListed:
+		return true
+	}
+	return false
+}
+
+// IsPiece reports whether the item is an unpacked piece rather than a bundle entry.
+func (i *WarehouseItem) IsPiece() bool {
+	return i.EntryID != ""
 }
@@ -0,0 +1,94 @@
+package carrierinfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCarrierSecret = "carrier_test"
+
+func TestSimulator_ReportsEachStep(t *testing.T) {
+	sim := NewSimulator(time.Millisecond)
+
+	trackingNumber, err := sim.Book(context.Background(), shipment.Parcel{OrderID: "order1"})
+	require.NoError(t, err)
+	assert.Equal(t, "SIMORDER1", trackingNumber)
+
+	var statuses []shipment.Status
+	for range simulatedRoute {
+		select {
+		case u := <-sim.Updates():
+			assert.Equal(t, trackingNumber, u.TrackingNumber)
+			assert.NoError(t, u.Validate())
+			statuses = append(statuses, u.Event.Status)
+		case <-time.After(time.Second):
+			t.Fatal("simulator did not report every step")
+		}
+	}
+	assert.ElementsMatch(t, []shipment.Status{shipment.LabelCreated, shipment.InTransit, shipment.OutForDelivery, shipment.Delivered}, statuses)
+}
+
+func TestSimulator_TrackingNumberSurvivesRestart(t *testing.T) {
+	first, err := NewSimulator(time.Hour).Book(context.Background(), shipment.Parcel{OrderID: "64b7f0c2a1e4d3b2c1a09f8e"})
+	require.NoError(t, err)
+	other, err := NewSimulator(time.Hour).Book(context.Background(), shipment.Parcel{OrderID: "64b7f0c2a1e4d3b2c1a09f8f"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "SIM64B7F0C2A1E4D3B2C1A09F8E", first)
+	assert.NotEqual(t, first, other)
+}
+
+func TestSimulator_KeepsSellerTrackingNumber(t *testing.T) {
+	sim := NewSimulator(time.Hour)
+
+	trackingNumber, err := sim.Book(context.Background(), shipment.Parcel{OrderID: "order1", TrackingNumber: "TRK123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "TRK123", trackingNumber)
+}
+
+func TestWebhookVerifier_ValidEvent(t *testing.T) {
+	v := NewWebhookVerifier(testCarrierSecret)
+	payload := []byte(`{"id":"e1","carrier":"simulator","tracking_number":"SIM0000000001","status":"in_transit","description":"Picked up","location":"Addis Ababa","occurred_at":"2025-01-02T10:00:00Z"}`)
+
+	u, err := v.Verify(payload, SignPayload([]byte(testCarrierSecret), payload))
+
+	require.NoError(t, err)
+	assert.Equal(t, "simulator", u.Carrier)
+	assert.Equal(t, "SIM0000000001", u.TrackingNumber)
+	assert.Equal(t, shipment.InTransit, u.Event.Status)
+	assert.Equal(t, "Addis Ababa", u.Event.Location)
+}
+
+func TestWebhookVerifier_TamperedPayload(t *testing.T) {
+	v := NewWebhookVerifier(testCarrierSecret)
+	payload := []byte(`{"id":"e1","carrier":"simulator","tracking_number":"SIM0000000001","status":"in_transit","occurred_at":"2025-01-02T10:00:00Z"}`)
+	signature := SignPayload([]byte(testCarrierSecret), payload)
+
+	_, err := v.Verify([]byte(`{"id":"e1","carrier":"simulator","tracking_number":"SIM0000000001","status":"delivered","occurred_at":"2025-01-02T10:00:00Z"}`), signature)
+
+	assert.ErrorIs(t, err, shipment.ErrInvalidSignature)
+}
+
+func TestWebhookVerifier_UnknownStatus(t *testing.T) {
+	v := NewWebhookVerifier(testCarrierSecret)
+	payload := []byte(`{"id":"e1","carrier":"simulator","tracking_number":"SIM0000000001","status":"lost","occurred_at":"2025-01-02T10:00:00Z"}`)
+
+	_, err := v.Verify(payload, SignPayload([]byte(testCarrierSecret), payload))
+
+	assert.ErrorIs(t, err, shipment.ErrInvalidEvent)
+}
+
+func TestWebhookVerifier_NoSecretConfigured(t *testing.T) {
+	v := NewWebhookVerifier("")
+	payload := []byte(`{"id":"e1"}`)
+
+	_, err := v.Verify(payload, SignPayload(nil, payload))
+
+	assert.ErrorIs(t, err, shipment.ErrInvalidSignature)
+}
@@ -0,0 +1,83 @@
+package carrierinfra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const SimulatorName = "simulator"
+
+// simulatedRoute is the journey every simulated parcel takes, one step apart.
+var simulatedRoute = []struct {
+	status      shipment.Status
+	description string
+	location    string
+}{
+	{shipment.LabelCreated, "Shipping label created", "Seller"},
+	{shipment.InTransit, "Parcel picked up by courier", "Sorting hub"},
+	{shipment.OutForDelivery, "Out for delivery", "Local depot"},
+	{shipment.Delivered, "Delivered to recipient", "Destination"},
+}
+
+// Simulator is a stand-in carrier for environments without a courier account. It
+// accepts every booking and reports the parcel's progress on Updates, as a real
+// carrier would through the tracking webhook.
+type Simulator struct {
+	step    time.Duration
+	updates chan *shipment.Update
+}
+
+// NewSimulator returns a simulator that moves each parcel along one step of its
+// route every step, starting one step after booking so the shipment is recorded
+// before its first event arrives.
+func NewSimulator(step time.Duration) *Simulator {
+	return &Simulator{
+		step:    step,
+		updates: make(chan *shipment.Update, 64),
+	}
+}
+
+func (s *Simulator) Name() string {
+	return SimulatorName
+}
+
+// Updates delivers the tracking events of every booked parcel.
+func (s *Simulator) Updates() <-chan *shipment.Update {
+	return s.updates
+}
+
+func (s *Simulator) Book(ctx context.Context, p shipment.Parcel) (string, error) {
+	trackingNumber := p.TrackingNumber
+	if trackingNumber == "" {
+		// Derived from the order rather than a counter, so numbers stay unique
+		// across restarts and booking the same order again issues the same one.
+		ref := p.OrderID
+		if ref == "" {
+			ref = primitive.NewObjectID().Hex()
+		}
+		trackingNumber = "SIM" + strings.ToUpper(ref)
+	}
+
+	for i, step := range simulatedRoute {
+		update := &shipment.Update{
+			Carrier:        SimulatorName,
+			TrackingNumber: trackingNumber,
+			Event: shipment.TrackingEvent{
+				ID:          fmt.Sprintf("%s-%d", trackingNumber, i),
+				Status:      step.status,
+				Description: step.description,
+				Location:    step.location,
+			},
+		}
+		time.AfterFunc(time.Duration(i+1)*s.step, func() {
+			update.Event.OccurredAt = time.Now().Format(time.RFC3339Nano)
+			s.updates <- update
+		})
+	}
+	return trackingNumber, nil
+}
@@ -0,0 +1,70 @@
+package carrierinfra
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the raw request body.
+const SignatureHeader = "X-Carrier-Signature"
+
+// trackingPayload is the body carriers post for every tracking event.
+type trackingPayload struct {
+	ID             string `json:"id"`
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"tracking_number"`
+	Status         string `json:"status"`
+	Description    string `json:"description"`
+	Location       string `json:"location"`
+	OccurredAt     string `json:"occurred_at"`
+}
+
+type webhookVerifier struct {
+	secret []byte
+}
+
+func NewWebhookVerifier(secret string) shipment.WebhookVerifier {
+	return &webhookVerifier{secret: []byte(secret)}
+}
+
+func (v *webhookVerifier) Verify(payload []byte, signatureHeader string) (*shipment.Update, error) {
+	// Without a configured secret anyone could forge a valid signature.
+	if len(v.secret) == 0 {
+		return nil, fmt.Errorf("%w: no webhook secret configured", shipment.ErrInvalidSignature)
+	}
+	if !hmac.Equal([]byte(signatureHeader), []byte(SignPayload(v.secret, payload))) {
+		return nil, shipment.ErrInvalidSignature
+	}
+
+	var p trackingPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", shipment.ErrInvalidEvent, err)
+	}
+	u := &shipment.Update{
+		Carrier:        p.Carrier,
+		TrackingNumber: p.TrackingNumber,
+		Event: shipment.TrackingEvent{
+			ID:          p.ID,
+			Status:      shipment.Status(p.Status),
+			Description: p.Description,
+			Location:    p.Location,
+			OccurredAt:  p.OccurredAt,
+		},
+	}
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// SignPayload computes the signature a carrier sends with a payload.
+func SignPayload(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,74 @@
+package eventinfra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+)
+
+const defaultBufferSize = 32
+
+type subscription struct {
+	ch chan *event.Event
+}
+
+// inProcessHub fans events out to every open stream of the target user. It only
+// lives in this process, so each instance of the API sees its own subscribers.
+type inProcessHub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*subscription]struct{}
+	bufferSize  int
+}
+
+func NewHub() event.Hub {
+	return &inProcessHub{
+		subscribers: make(map[string]map[*subscription]struct{}),
+		bufferSize:  defaultBufferSize,
+	}
+}
+
+func (h *inProcessHub) Publish(_ context.Context, e *event.Event) {
+	if e == nil || e.UserID == "" {
+		return
+	}
+	if e.CreatedAt == "" {
+		e.CreatedAt = time.Now().Format(time.RFC3339)
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.subscribers[e.UserID] {
+		// A slow client must not stall the request that produced the event.
+		select {
+		case sub.ch <- e:
+		default:
+		}
+	}
+}
+
+func (h *inProcessHub) Subscribe(userID string) (<-chan *event.Event, func()) {
+	sub := &subscription{ch: make(chan *event.Event, h.bufferSize)}
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*subscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[userID], sub)
+			if len(h.subscribers[userID]) == 0 {
+				delete(h.subscribers, userID)
+			}
+			h.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+	return sub.ch, unsubscribe
+}
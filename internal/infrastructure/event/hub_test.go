@@ -0,0 +1,65 @@
+package eventinfra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_DeliversOnlyToTargetUser(t *testing.T) {
+	hub := NewHub()
+	aliceCh, unsubAlice := hub.Subscribe("alice")
+	defer unsubAlice()
+	bobCh, unsubBob := hub.Subscribe("bob")
+	defer unsubBob()
+
+	hub.Publish(context.Background(), &event.Event{Type: event.ChatMessage, UserID: "alice", Payload: "hi"})
+
+	select {
+	case e := <-aliceCh:
+		assert.Equal(t, event.ChatMessage, e.Type)
+		assert.NotEmpty(t, e.CreatedAt)
+	default:
+		t.Fatal("expected alice to receive the event")
+	}
+	assert.Len(t, bobCh, 0)
+}
+
+func TestHub_FansOutToEveryStream(t *testing.T) {
+	hub := NewHub()
+	first, unsubFirst := hub.Subscribe("alice")
+	defer unsubFirst()
+	second, unsubSecond := hub.Subscribe("alice")
+	defer unsubSecond()
+
+	hub.Publish(context.Background(), &event.Event{Type: event.BundleSold, UserID: "alice"})
+
+	assert.Len(t, first, 1)
+	assert.Len(t, second, 1)
+}
+
+func TestHub_FullBufferDoesNotBlock(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe("alice")
+	defer unsubscribe()
+
+	for i := 0; i < defaultBufferSize+10; i++ {
+		hub.Publish(context.Background(), &event.Event{Type: event.OrderStatusChanged, UserID: "alice"})
+	}
+
+	assert.Len(t, ch, defaultBufferSize)
+}
+
+func TestHub_UnsubscribeClosesChannel(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe("alice")
+
+	unsubscribe()
+	unsubscribe()
+	hub.Publish(context.Background(), &event.Event{Type: event.ChatMessage, UserID: "alice"})
+
+	_, open := <-ch
+	assert.False(t, open)
+}
@@ -0,0 +1,82 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoAddressRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoAddressRepository(db *mongo.Database) shipping.AddressRepository {
+	return &mongoAddressRepository{
+		collection: db.Collection("addresses"),
+	}
+}
+
+func (r *mongoAddressRepository) CreateAddress(ctx context.Context, a *shipping.Address) error {
+	_, err := r.collection.InsertOne(ctx, a)
+	return err
+}
+
+func (r *mongoAddressRepository) GetAddressByID(ctx context.Context, id string) (*shipping.Address, error) {
+	var a shipping.Address
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&a)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *mongoAddressRepository) ListAddressesByUser(ctx context.Context, userID string) ([]*shipping.Address, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "is_default", Value: -1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	addresses := []*shipping.Address{}
+	if err := cursor.All(ctx, &addresses); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (r *mongoAddressRepository) UpdateAddress(ctx context.Context, a *shipping.Address) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": a.ID, "user_id": a.UserID}, a)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return shipping.ErrAddressNotFound
+	}
+	return nil
+}
+
+func (r *mongoAddressRepository) DeleteAddress(ctx context.Context, userID, id string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return shipping.ErrAddressNotFound
+	}
+	return nil
+}
+
+func (r *mongoAddressRepository) ClearDefault(ctx context.Context, userID, exceptID string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "_id": bson.M{"$ne": exceptID}, "is_default": true},
+		bson.M{"$set": bson.M{"is_default": false}},
+	)
+	return err
+}
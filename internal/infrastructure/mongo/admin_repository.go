@@ -0,0 +1,244 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoAdminRepository struct {
+	users       *mongo.Collection
+	bundles     *mongo.Collection
+	payments    *mongo.Collection
+	warehouses  *mongo.Collection
+	paymentRepo payment.Repository
+}
+
+func NewMongoAdminRepository(db *mongo.Database) admin.Repository {
+	return &mongoAdminRepository{
+		users:       db.Collection("users"),
+		bundles:     db.Collection("bundles"),
+		payments:    db.Collection("payments"),
+		warehouses:  db.Collection("warehouses"),
+		paymentRepo: NewMongoPaymentRepository(db),
+	}
+}
+
+func (r *mongoAdminRepository) FetchPlatformMetrics(ctx context.Context) (*admin.Metrics, error) {
+	bundles, err := r.bundles.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	users, err := r.GetActiveUsersCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sales, fees, err := r.paymentRepo.GetAllPlatformFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := r.warehouses.CountDocuments(ctx, bson.M{"status": warehouse.StatusSkipped})
+	if err != nil {
+		return nil, err
+	}
+	return &admin.Metrics{
+		TotalBundles:    int(bundles),
+		TotalUsers:      users,
+		TotalSales:      sales.List(),
+		SkippedClothes:  int(skipped),
+		RevenueFromFees: fees.List(),
+	}, nil
+}
+
+func (r *mongoAdminRepository) GetActiveUsersCount(ctx context.Context) (int, error) {
+	count, err := r.users.CountDocuments(ctx, bson.M{"is_deleted": false})
+	return int(count), err
+}
+
+// settledPaymentsIn matches the payments that moved money during q's range,
+// refunds included, after stamping each with paid_at.
+func settledPaymentsIn(q *analytics.SeriesQuery) []bson.D {
+	return []bson.D{
+		{{Key: "$match", Value: bson.M{
+			"status": bson.M{"$in": settledPaymentStatuses},
+			"type":   bson.M{"$in": bson.A{payment.B2B, payment.B2C}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"paid_at": dateFromString("$createdat")}}},
+		{{Key: "$match", Value: bson.M{"paid_at": bson.M{"$gte": q.From, "$lt": q.To}}}},
+	}
+}
+
+// paymentTotals are the $group accumulators shared by the revenue reports. Only
+// original payments count as sales; refunds just reduce the amounts.
+func paymentTotals(q *analytics.SeriesQuery) bson.M {
+	return bson.M{
+		"payments": bson.M{"$sum": bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$refundof", ""}}, ""}}, 1, 0,
+		}}},
+		"gmv":  bson.M{"$sum": convertedAmount("$amount.amount", "$amount.currency", q.Factors)},
+		"fees": bson.M{"$sum": convertedAmount("$platformfee.amount", "$platformfee.currency", q.Factors)},
+	}
+}
+
+func (r *mongoAdminRepository) GetRevenueReport(ctx context.Context, q *analytics.SeriesQuery) ([]*admin.RevenueRow, error) {
+	group := paymentTotals(q)
+	group["_id"] = bson.M{"start": periodStart("$paid_at", q.Interval), "type": "$type"}
+
+	pipeline := mongo.Pipeline(settledPaymentsIn(q))
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: group}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"start":       "$_id.start",
+			"type":        "$_id.type",
+			"payments":    1,
+			"gmv":         reportMoney("$gmv", q.Currency),
+			"fee_revenue": reportMoney("$fees", q.Currency),
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "start", Value: 1}, {Key: "type", Value: 1}}}},
+	)
+
+	var rows []*admin.RevenueRow
+	if err := aggregateAll(ctx, r.payments, pipeline, &rows); err != nil {
+		return nil, fmt.Errorf("revenue report failed: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *mongoAdminRepository) GetNewUsers(ctx context.Context, q *analytics.SeriesQuery) ([]*admin.UserRow, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": q.From, "$lt": q.To}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"start": periodStart("$created_at", q.Interval), "role": "$role"},
+			"new_users": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"start":     "$_id.start",
+			"role":      "$_id.role",
+			"new_users": 1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "start", Value: 1}, {Key: "role", Value: 1}}}},
+	}
+
+	var rows []*admin.UserRow
+	if err := aggregateAll(ctx, r.users, pipeline, &rows); err != nil {
+		return nil, fmt.Errorf("user report failed: %w", err)
+	}
+	return rows, nil
+}
+
+// GetBlacklistRates covers suppliers and resellers, the roles trust scores apply to.
+func (r *mongoAdminRepository) GetBlacklistRates(ctx context.Context) ([]*admin.BlacklistRate, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"role":       bson.M{"$in": bson.A{user.RoleSupplier, user.RoleReseller}},
+			"is_deleted": bson.M{"$ne": true},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$role",
+			"active":      bson.M{"$sum": 1},
+			"blacklisted": bson.M{"$sum": bson.M{"$cond": bson.A{"$is_blacklisted", 1, 0}}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"rate": percentOf("$blacklisted", "$active")}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var rates []*admin.BlacklistRate
+	if err := aggregateAll(ctx, r.users, pipeline, &rates); err != nil {
+		return nil, fmt.Errorf("blacklist report failed: %w", err)
+	}
+	return rates, nil
+}
+
+// GetSkipReport counts the pieces recorded while unpacking, by when they were
+// recorded.
+func (r *mongoAdminRepository) GetSkipReport(ctx context.Context, q *analytics.SeriesQuery) ([]*admin.SkipRow, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"entry_id": bson.M{"$exists": true},
+			"status":   bson.M{"$in": bson.A{warehouse.StatusListed, warehouse.StatusSkipped}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"unpacked_at": dateFromString("$created_at")}}},
+		{{Key: "$match", Value: bson.M{"unpacked_at": bson.M{"$gte": q.From, "$lt": q.To}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      periodStart("$unpacked_at", q.Interval),
+			"unpacked": bson.M{"$sum": 1},
+			"skipped": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$status", warehouse.StatusSkipped}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"ratio": percentOf("$skipped", "$unpacked")}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var rows []*admin.SkipRow
+	if err := aggregateAll(ctx, r.warehouses, pipeline, &rows); err != nil {
+		return nil, fmt.Errorf("skip report failed: %w", err)
+	}
+	return rows, nil
+}
+
+// GetTopSellers ranks the payees of bundle payments, who are suppliers, and of
+// product payments, who are resellers.
+func (r *mongoAdminRepository) GetTopSellers(ctx context.Context, q *analytics.SeriesQuery, limit int) ([]*admin.TopSeller, []*admin.TopSeller, error) {
+	group := paymentTotals(q)
+	group["_id"] = bson.M{"seller": "$touserid", "type": "$type"}
+
+	ranking := func(t payment.PaymentType) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{"_id.type": t}},
+			bson.M{"$limit": int64(limit)},
+			bson.M{"$lookup": bson.M{
+				"from":         "users",
+				"localField":   "_id.seller",
+				"foreignField": "_id",
+				"as":           "seller",
+			}},
+			bson.M{"$project": bson.M{
+				"_id":         "$_id.seller",
+				"username":    bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$seller.username", 0}}, ""}},
+				"sales":       "$payments",
+				"gmv":         reportMoney("$gmv", q.Currency),
+				"fee_revenue": reportMoney("$fees", q.Currency),
+			}},
+		}
+	}
+
+	pipeline := mongo.Pipeline(settledPaymentsIn(q))
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: group}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "gmv", Value: -1}, {Key: "_id.seller", Value: 1}}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"suppliers": ranking(payment.B2B),
+			"resellers": ranking(payment.B2C),
+		}}},
+	)
+
+	var result []struct {
+		Suppliers []*admin.TopSeller `bson:"suppliers"`
+		Resellers []*admin.TopSeller `bson:"resellers"`
+	}
+	if err := aggregateAll(ctx, r.payments, pipeline, &result); err != nil {
+		return nil, nil, fmt.Errorf("top sellers report failed: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, nil, nil
+	}
+	return result[0].Suppliers, result[0].Resellers, nil
+}
+
+func aggregateAll(ctx context.Context, coll *mongo.Collection, pipeline mongo.Pipeline, results interface{}) error {
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, results)
+}
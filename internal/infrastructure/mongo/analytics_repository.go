@@ -0,0 +1,436 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const msPerDay = 24 * 60 * 60 * 1000
+
+type mongoAnalyticsRepository struct {
+	orders       *mongo.Collection
+	bundles      *mongo.Collection
+	trustHistory *mongo.Collection
+}
+
+func NewMongoAnalyticsRepository(db *mongo.Database) analytics.Repository {
+	return &mongoAnalyticsRepository{
+		orders:       db.Collection("orders"),
+		bundles:      db.Collection("bundles"),
+		trustHistory: db.Collection("trust_history"),
+	}
+}
+
+// ResellerROI starts from the reseller's bundle purchases and joins, for each,
+// the products listed from the bundle, the order and payment that sold each of
+// them, and the pieces skipped while unpacking.
+func (r *mongoAnalyticsRepository) ResellerROI(ctx context.Context, resellerID string, currency money.Currency, factors map[money.Currency]float64) (*analytics.ResellerROI, error) {
+	// Products store their reseller as an ObjectID.
+	resellerOID, err := primitive.ObjectIDFromHex(resellerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reseller ID: %w", err)
+	}
+
+	pipeline := mongo.Pipeline{
+		// Bundle purchases have a bundle and no consumer.
+		{{Key: "$match", Value: bson.M{
+			"resellerid": resellerID,
+			"bundleid":   bson.M{"$nin": bson.A{"", nil}},
+			"consumerid": bson.M{"$in": bson.A{"", nil}},
+			"status":     bson.M{"$ne": order.OrderStatusCanceled},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "bundles",
+			"localField":   "bundleid",
+			"foreignField": "_id",
+			"as":           "bundle",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$bundle", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":     "products",
+			"let":      bson.M{"bundle": "$bundleid"},
+			"pipeline": bundleProductsPipeline(resellerOID, factors),
+			"as":       "products",
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "warehouses",
+			"let":  bson.M{"bundle": "$bundleid"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":       bson.M{"$eq": bson.A{"$bundle_id", "$$bundle"}},
+					"reseller_id": resellerID,
+					"status":      warehouse.StatusSkipped,
+					"entry_id":    bson.M{"$exists": true},
+				}},
+				bson.M{"$count": "n"},
+			},
+			"as": "skipped",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"sold_products": bson.M{"$filter": bson.M{
+				"input": "$products",
+				"as":    "p",
+				"cond":  bson.M{"$eq": bson.A{"$$p.status", product.StatusSold}},
+			}},
+			"cost": convertedAmount(
+				bson.M{"$add": bson.A{"$totalprice.amount", bson.M{"$ifNull": bson.A{"$shippingcost.amount", 0}}}},
+				"$totalprice.currency", factors),
+			"pieces":       bson.M{"$ifNull": bson.A{"$bundle.quantity", 0}},
+			"skipped":      bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$skipped.n", 0}}, 0}},
+			"purchased_on": dateFromString("$createdat"),
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"revenue": bson.M{"$sum": "$sold_products.price"},
+			"fees":    bson.M{"$sum": "$sold_products.fee"},
+			"sold":    bson.M{"$size": "$sold_products"},
+			"days_to_sell": bson.M{"$avg": bson.M{"$map": bson.M{
+				"input": "$sold_products",
+				"as":    "p",
+				"in": bson.M{"$divide": bson.A{
+					bson.M{"$subtract": bson.A{
+						dateFromString("$$p.sold_at"),
+						"$purchased_on",
+					}},
+					msPerDay,
+				}},
+			}}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"margin": bson.M{"$subtract": bson.A{bson.M{"$subtract": bson.A{"$revenue", "$fees"}}, "$cost"}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":           0,
+			"bundle_id":     "$bundleid",
+			"order_id":      "$id",
+			"supplier_id":   "$supplierid",
+			"title":         bson.M{"$ifNull": bson.A{"$bundle.title", ""}},
+			"purchased_at":  "$createdat",
+			"cost":          reportMoney("$cost", currency),
+			"revenue":       reportMoney("$revenue", currency),
+			"platform_fees": reportMoney("$fees", currency),
+			"margin":        reportMoney("$margin", currency),
+			"roi":           percentOf("$margin", "$cost"),
+			"pieces":        "$pieces",
+			"listed":        bson.M{"$size": "$products"},
+			"sold":          "$sold",
+			"sell_through":  percentOf("$sold", "$pieces"),
+			"avg_days_to_sell": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$days_to_sell", nil}}, nil, bson.M{"$round": bson.A{"$days_to_sell", 1}},
+			}},
+			"skipped": "$skipped",
+			"skipped_loss": reportMoney(bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$pieces", 0}},
+				bson.M{"$multiply": bson.A{bson.M{"$divide": bson.A{"$cost", "$pieces"}}, "$skipped"}},
+				0,
+			}}, currency),
+		}}},
+		{{Key: "$facet", Value: bson.M{
+			"bundles": bson.A{
+				bson.M{"$sort": bson.D{{Key: "purchased_at", Value: -1}, {Key: "bundle_id", Value: 1}}},
+			},
+			"suppliers": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":     "$supplier_id",
+					"bundles": bson.M{"$sum": 1},
+					"cost":    bson.M{"$sum": "$cost.amount"},
+					"margin":  bson.M{"$sum": "$margin.amount"},
+				}},
+				bson.M{"$lookup": bson.M{
+					"from":         "users",
+					"localField":   "_id",
+					"foreignField": "_id",
+					"as":           "supplier",
+				}},
+				bson.M{"$project": bson.M{
+					"supplier_name": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$supplier.username", 0}}, ""}},
+					"bundles":       1,
+					"cost":          bson.M{"amount": "$cost", "currency": string(currency)},
+					"margin":        bson.M{"amount": "$margin", "currency": string(currency)},
+					"roi":           percentOf("$margin", "$cost"),
+				}},
+				bson.M{"$sort": bson.D{{Key: "margin.amount", Value: -1}, {Key: "_id", Value: 1}}},
+			},
+		}}},
+	}
+
+	cursor, err := r.orders.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("reseller ROI query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	report := &analytics.ResellerROI{
+		Currency:  currency,
+		Bundles:   []*analytics.BundleROI{},
+		Suppliers: []*analytics.SupplierMargin{},
+	}
+	if cursor.Next(ctx) {
+		var result struct {
+			Bundles   []*analytics.BundleROI      `bson:"bundles"`
+			Suppliers []*analytics.SupplierMargin `bson:"suppliers"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode reseller ROI: %w", err)
+		}
+		if result.Bundles != nil {
+			report.Bundles = result.Bundles
+		}
+		if result.Suppliers != nil {
+			report.Suppliers = result.Suppliers
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// SupplierSales runs one pipeline per source, each grouping by period, and merges
+// their rows by period start.
+func (r *mongoAnalyticsRepository) SupplierSales(ctx context.Context, supplierID string, q *analytics.SeriesQuery) ([]*analytics.SupplierPeriod, error) {
+	period := func(date interface{}) bson.M { return periodStart(date, q.Interval) }
+	inRange := bson.M{"$gte": q.From, "$lt": q.To}
+
+	listings := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"supplierid": supplierID}}},
+		{{Key: "$addFields", Value: bson.M{"listed_at": bundleListedAt("$")}}},
+		{{Key: "$match", Value: bson.M{"listed_at": inRange}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              period("$listed_at"),
+			"listings_created": bson.M{"$sum": 1},
+		}}},
+	}
+
+	// A sale earns the supplier the seller earnings of its bundle payment, less
+	// the shipping they pay on.
+	earned := bson.M{"$sum": bson.M{"$map": bson.M{
+		"input": "$payments",
+		"as":    "p",
+		"in": bson.M{"$subtract": bson.A{
+			"$$p.sellerearning.amount",
+			bson.M{"$ifNull": bson.A{"$$p.shipping.amount", 0}},
+		}},
+	}}}
+	sales := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"supplierid": supplierID,
+			"bundleid":   bson.M{"$nin": bson.A{"", nil}},
+			"consumerid": bson.M{"$in": bson.A{"", nil}},
+			"status":     bson.M{"$ne": order.OrderStatusCanceled},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"sold_at": dateFromString("$createdat")}}},
+		{{Key: "$match", Value: bson.M{"sold_at": inRange}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "bundles",
+			"localField":   "bundleid",
+			"foreignField": "_id",
+			"as":           "bundle",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$bundle", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "payments",
+			"let":  bson.M{"order": "$id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":    bson.M{"$eq": bson.A{"$orderid", "$$order"}},
+					"type":     payment.B2B,
+					"refundof": bson.M{"$in": bson.A{"", nil}},
+					"status":   bson.M{"$nin": bson.A{payment.StatusFailed, payment.StatusRefunded}},
+				}},
+			},
+			"as": "payments",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"sale_price": convertedAmount("$totalprice.amount", "$totalprice.currency", q.Factors),
+			"earned":     convertedAmount(earned, "$totalprice.currency", q.Factors),
+			"days_to_sale": bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{"$sold_at", bundleListedAt("$bundle.")}},
+				msPerDay,
+			}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              period("$sold_at"),
+			"bundles_sold":     bson.M{"$sum": 1},
+			"revenue":          bson.M{"$sum": "$earned"},
+			"avg_sale_price":   bson.M{"$avg": "$sale_price"},
+			"avg_days_to_sale": bson.M{"$avg": "$days_to_sale"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"bundles_sold":   1,
+			"revenue":        reportMoney("$revenue", q.Currency),
+			"avg_sale_price": reportMoney("$avg_sale_price", q.Currency),
+			"avg_days_to_sale": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$avg_days_to_sale", nil}}, nil, bson.M{"$round": bson.A{"$avg_days_to_sale", 1}},
+			}},
+		}}},
+	}
+
+	// Scores from before the range fold into the first period, so it starts from
+	// the score the supplier had then.
+	trust := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": supplierID, "recorded_at": bson.M{"$lt": q.To}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "recorded_at", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         bson.M{"$max": bson.A{period("$recorded_at"), q.Interval.Truncate(q.From)}},
+			"trust_score": bson.M{"$last": "$score"},
+		}}},
+	}
+
+	byStart := make(map[int64]*analytics.SupplierPeriod)
+	var periods []*analytics.SupplierPeriod
+	merge := func(coll *mongo.Collection, pipeline mongo.Pipeline, apply func(dst, src *analytics.SupplierPeriod)) error {
+		var rows []*analytics.SupplierPeriod
+		cursor, err := coll.Aggregate(ctx, pipeline)
+		if err != nil {
+			return fmt.Errorf("supplier sales query failed: %w", err)
+		}
+		if err := cursor.All(ctx, &rows); err != nil {
+			return fmt.Errorf("failed to decode supplier sales: %w", err)
+		}
+		for _, row := range rows {
+			p, ok := byStart[row.Start.Unix()]
+			if !ok {
+				p = &analytics.SupplierPeriod{
+					Start:        row.Start,
+					Revenue:      money.New(0, q.Currency),
+					AvgSalePrice: money.New(0, q.Currency),
+				}
+				byStart[row.Start.Unix()] = p
+				periods = append(periods, p)
+			}
+			apply(p, row)
+		}
+		return nil
+	}
+
+	if err := merge(r.bundles, listings, func(dst, src *analytics.SupplierPeriod) {
+		dst.ListingsCreated = src.ListingsCreated
+	}); err != nil {
+		return nil, err
+	}
+	if err := merge(r.orders, sales, func(dst, src *analytics.SupplierPeriod) {
+		dst.BundlesSold = src.BundlesSold
+		dst.Revenue = src.Revenue
+		dst.AvgSalePrice = src.AvgSalePrice
+		dst.AvgDaysToSale = src.AvgDaysToSale
+	}); err != nil {
+		return nil, err
+	}
+	if err := merge(r.trustHistory, trust, func(dst, src *analytics.SupplierPeriod) {
+		dst.TrustScore = src.TrustScore
+	}); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// periodStart is the start of the interval date falls in, matching
+// analytics.Interval.Truncate.
+func periodStart(date interface{}, interval analytics.Interval) bson.M {
+	trunc := bson.M{"date": date, "unit": string(interval), "timezone": "UTC"}
+	if interval == analytics.Week {
+		trunc["startOfWeek"] = "monday"
+	}
+	return bson.M{"$dateTrunc": trunc}
+}
+
+// bundleListedAt is when the bundle at prefix went on sale. Bundles listed before
+// datelisted was recorded fall back to their creation time.
+func bundleListedAt(prefix string) bson.M {
+	return bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{prefix + "datelisted", time.Unix(0, 0)}},
+		prefix + "datelisted",
+		dateFromString(prefix + "createdat"),
+	}}
+}
+
+// dateFromString parses an RFC 3339 timestamp stored as a string, null when it
+// is missing or malformed.
+func dateFromString(field string) bson.M {
+	return bson.M{"$dateFromString": bson.M{"dateString": field, "onError": nil, "onNull": nil}}
+}
+
+// bundleProductsPipeline lists the reseller's products from the bundle in $$bundle
+// with their converted price and, once sold, when they sold and their share of
+// the platform fee charged on the sale.
+func bundleProductsPipeline(resellerID primitive.ObjectID, factors map[money.Currency]float64) bson.A {
+	// A sale's fee covers all of its products; each carries its price's share.
+	saleFee := bson.M{"$sum": bson.M{"$map": bson.M{
+		"input": bson.M{"$filter": bson.M{
+			"input": "$sale.payments",
+			"as":    "p",
+			"cond": bson.M{"$and": bson.A{
+				bson.M{"$eq": bson.A{"$$p.type", payment.B2C}},
+				bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$$p.refundof", ""}}, ""}},
+			}},
+		}},
+		"as": "p",
+		"in": "$$p.platformfee.amount",
+	}}}
+	feeShare := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{"$sale.totalprice.amount", 0}},
+		bson.M{"$divide": bson.A{bson.M{"$multiply": bson.A{saleFee, "$price.amount"}}, "$sale.totalprice.amount"}},
+		0,
+	}}
+
+	return bson.A{
+		bson.M{"$match": bson.M{
+			"$expr":       bson.M{"$eq": bson.A{"$bundle_id", "$$bundle"}},
+			"reseller_id": resellerID,
+		}},
+		bson.M{"$lookup": bson.M{
+			"from": "orders",
+			"let":  bson.M{"product": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$expr":  bson.M{"$in": bson.A{"$$product", bson.M{"$ifNull": bson.A{"$productids", bson.A{}}}}},
+					"status": bson.M{"$ne": order.OrderStatusCanceled},
+				}},
+				bson.M{"$sort": bson.M{"createdat": -1}},
+				bson.M{"$limit": 1},
+				bson.M{"$lookup": bson.M{
+					"from":         "payments",
+					"localField":   "id",
+					"foreignField": "orderid",
+					"as":           "payments",
+				}},
+			},
+			"as": "sale",
+		}},
+		bson.M{"$unwind": bson.M{"path": "$sale", "preserveNullAndEmptyArrays": true}},
+		bson.M{"$project": bson.M{
+			"status":  1,
+			"price":   searchPrice(factors),
+			"fee":     convertedAmount(feeShare, "$price.currency", factors),
+			"sold_at": "$sale.createdat",
+		}},
+	}
+}
+
+// reportMoney rounds a converted amount to whole minor units of currency.
+func reportMoney(amount interface{}, currency money.Currency) bson.M {
+	return bson.M{
+		"amount":   bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$ifNull": bson.A{amount, 0}}, 0}}},
+		"currency": string(currency),
+	}
+}
+
+// percentOf is part as a percentage of whole, or 0 when whole is not positive.
+func percentOf(part, whole interface{}) bson.M {
+	return bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{whole, 0}},
+		bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{bson.M{"$divide": bson.A{part, whole}}, 100}}, 2}},
+		0,
+	}}
+}
@@ -0,0 +1,213 @@
+package mongo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// bundleCursor is where a page of available bundles ended: the sort key of its
+// last bundle, with the ID to break ties.
+type bundleCursor struct {
+	Sort      bundle.Sort `json:"s"`
+	ListedAt  string      `json:"l,omitempty"`
+	UnitPrice float64     `json:"p,omitempty"`
+	ID        string      `json:"id"`
+}
+
+func (c bundleCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeBundleCursor(encoded string, sort bundle.Sort) (*bundleCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+	var c bundleCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" || c.Sort != sort {
+		return nil, pagination.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+type listedBundle struct {
+	bundle.Bundle `bson:",inline"`
+	ListedAt      string   `bson:"listed_at"`
+	UnitPrice     *float64 `bson:"unit_price"`
+}
+
+// ListAvailableBundles pages through the bundles on sale with keyset pagination,
+// so pages stay stable while new bundles are listed. The cursor records the sort
+// key, which is not always the creation time, so it is not a pagination.Cursor.
+func (r *BundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	req, err := q.Request.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	after := bson.M{}
+	if req.Cursor != "" {
+		c, err := decodeBundleCursor(req.Cursor, q.Sort)
+		if err != nil {
+			return nil, err
+		}
+		after = bundleAfterFilter(q.Sort, c)
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bundleQueryFilter(q)}}}
+
+	if q.MinTrustScore > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{
+				"from": "users",
+				"let":  bson.M{"supplier": "$supplierid"},
+				"pipeline": bson.A{
+					bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$supplier"}}}},
+					bson.M{"$project": bson.M{"trust_score": 1}},
+				},
+				"as": "supplier",
+			}}},
+			bson.D{{Key: "$match", Value: bson.M{"supplier.trust_score": bson.M{"$gte": q.MinTrustScore}}}},
+			bson.D{{Key: "$unset", Value: "supplier"}},
+		)
+	}
+
+	// Bundles listed before createdat was recorded sort as the oldest.
+	pipeline = append(pipeline,
+		bson.D{{Key: "$addFields", Value: bson.M{
+			"listed_at":     bson.M{"$ifNull": bson.A{"$createdat", ""}},
+			"listing_price": searchPrice(q.PriceFactors),
+		}}},
+		bson.D{{Key: "$addFields", Value: bson.M{
+			"unit_price": bson.M{"$divide": bson.A{"$listing_price", bson.M{"$max": bson.A{"$quantity", 1}}}},
+		}}},
+	)
+	if priceFilter := bundlePriceFilter(q); len(priceFilter) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"listing_price": priceFilter}}})
+	}
+	if q.Sort.ByPricePerItem() {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"unit_price": bson.M{"$ne": nil}}}})
+	}
+	// One bundle past the page tells whether there is a next one.
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"items": bson.A{
+			bson.M{"$match": after},
+			bson.M{"$sort": bundleSort(q.Sort)},
+			bson.M{"$limit": int64(req.Limit + 1)},
+		},
+		"total": bson.A{bson.M{"$count": "n"}},
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("bundle query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Items []listedBundle `bson:"items"`
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode bundles: %w", err)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &pagination.Page[*bundle.Bundle]{Items: make([]*bundle.Bundle, 0, len(result.Items))}
+	for i := range result.Items {
+		if i == req.Limit {
+			last := result.Items[i-1]
+			next := bundleCursor{Sort: q.Sort, ListedAt: last.ListedAt, ID: last.ID}
+			if last.UnitPrice != nil {
+				next.UnitPrice = *last.UnitPrice
+			}
+			page.NextCursor = next.encode()
+			break
+		}
+		page.Items = append(page.Items, &result.Items[i].Bundle)
+	}
+	if len(result.Total) > 0 {
+		page.Total = result.Total[0].N
+	}
+	return page, nil
+}
+
+func bundleQueryFilter(q *bundle.Query) bson.M {
+	filter := bson.M{"status": "available"}
+	for field, values := range map[string][]string{"grade": q.Grades, "type": q.Types, "size_range": q.SizeRanges} {
+		if len(values) > 0 {
+			filter[field] = bson.M{"$in": values}
+		}
+	}
+	if len(q.SortingLevels) > 0 {
+		filter["sortinglevel"] = bson.M{"$in": q.SortingLevels}
+	}
+	if q.MinRating > 0 {
+		filter["declared_rating"] = bson.M{"$gte": q.MinRating}
+	}
+	quantity := bson.M{}
+	if q.MinQuantity > 0 {
+		quantity["$gte"] = q.MinQuantity
+	}
+	if q.MaxQuantity > 0 {
+		quantity["$lte"] = q.MaxQuantity
+	}
+	if len(quantity) > 0 {
+		filter["quantity"] = quantity
+	}
+	return filter
+}
+
+func bundlePriceFilter(q *bundle.Query) bson.M {
+	filter := bson.M{}
+	if q.MinPrice != nil {
+		filter["$gte"] = q.MinPrice.Amount
+	}
+	if q.MaxPrice != nil {
+		filter["$lte"] = q.MaxPrice.Amount
+	}
+	if len(filter) > 0 {
+		// A null price would otherwise satisfy $lte.
+		filter["$ne"] = nil
+	}
+	return filter
+}
+
+// bundleAfterFilter matches the bundles that sort after the cursor.
+func bundleAfterFilter(sort bundle.Sort, after *bundleCursor) bson.M {
+	field, op, value := "listed_at", "$lt", interface{}(after.ListedAt)
+	switch sort {
+	case bundle.SortPricePerItemAsc:
+		field, op, value = "unit_price", "$gt", after.UnitPrice
+	case bundle.SortPricePerItemDesc:
+		field, op, value = "unit_price", "$lt", after.UnitPrice
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{"$gt": after.ID}},
+	}}
+}
+
+func bundleSort(sort bundle.Sort) bson.D {
+	switch sort {
+	case bundle.SortPricePerItemAsc:
+		return bson.D{{Key: "unit_price", Value: 1}, {Key: "_id", Value: 1}}
+	case bundle.SortPricePerItemDesc:
+		return bson.D{{Key: "unit_price", Value: -1}, {Key: "_id", Value: 1}}
+	default:
+		return bson.D{{Key: "listed_at", Value: -1}, {Key: "_id", Value: 1}}
+	}
+}
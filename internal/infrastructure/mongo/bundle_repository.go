@@ -3,9 +3,9 @@ package mongo
 import (
 	"context"
 	"errors" // Added
-	"fmt"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -37,79 +37,12 @@ func (r *BundleRepository) GetBundleByID(ctx context.Context, id string) (*bundl
 	return &bundle, nil
 }
 
-func (r *BundleRepository) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	var bundles []*bundle.Bundle
-	cursor, err := r.collection.Find(ctx, bson.M{"supplierid": supplierID})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var b bundle.Bundle
-		if err := cursor.Decode(&b); err != nil {
-			return nil, err
-		}
-		bundles = append(bundles, &b)
-	}
-
-	if err := cursor.Err(); err != nil {
-		return nil, err
-	}
-
-	return bundles, nil
+func (r *BundleRepository) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	return findPage[*bundle.Bundle](ctx, r.collection, bson.M{"supplierid": supplierID}, req, "createdat")
 }
 
-func (r *BundleRepository) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	var bundles []*bundle.Bundle
-	cursor, err := r.collection.Find(ctx, bson.M{"status": "available"})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var b bundle.Bundle
-		if err := cursor.Decode(&b); err != nil {
-			return nil, err
-		}
-		bundles = append(bundles, &b)
-	}
-
-	if err := cursor.Err(); err != nil {
-		return nil, err
-	}
-
-	return bundles, nil
-}
-
-func (r *BundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
-	fmt.Printf("🔍 Fetching purchased bundles for reseller: %s\n", resellerID)
-	
-	var bundles []*bundle.Bundle
-	cursor, err := r.collection.Find(ctx, bson.M{"resellerid": resellerID, "status": "purchased"})
-	if err != nil {
-		fmt.Printf("❌ Error querying bundles: %v\n", err)
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var b bundle.Bundle
-		if err := cursor.Decode(&b); err != nil {
-			fmt.Printf("❌ Error decoding bundle: %v\n", err)
-			return nil, err
-		}
-		bundles = append(bundles, &b)
-	}
-
-	if err := cursor.Err(); err != nil {
-		fmt.Printf("❌ Cursor error: %v\n", err)
-		return nil, err
-	}
-
-	fmt.Printf("✅ Found %d purchased bundles for reseller %s\n", len(bundles), resellerID)
-	return bundles, nil
+func (r *BundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	return findPage[*bundle.Bundle](ctx, r.collection, bson.M{"resellerid": resellerID, "status": "purchased"}, req, "createdat")
 }
 
 func (r *BundleRepository) UpdateBundleStatus(ctx context.Context, id string, status string) error {
@@ -117,12 +50,31 @@ func (r *BundleRepository) UpdateBundleStatus(ctx context.Context, id string, st
 	return err
 }
 
+// MarkAsPurchased claims an available bundle for the reseller. The status check is
+// part of the update, so of two concurrent buyers only one can succeed.
 func (r *BundleRepository) MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error {
-	_, err := r.collection.UpdateOne(
+	result, err := r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": bundleID},
+		bson.M{"_id": bundleID, "status": "available"},
 		bson.M{"$set": bson.M{"status": "purchased", "resellerid": resellerID}},
 	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return bundle.ErrBundleAlreadySold
+	}
+	return nil
+}
+
+// ReleasePurchase puts a bundle bought by the reseller back on sale. Bundles that
+// have since changed hands or been removed are left alone.
+func (r *BundleRepository) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": bundleID, "status": "purchased", "resellerid": resellerID},
+		bson.M{"$set": bson.M{"status": "available"}, "$unset": bson.M{"resellerid": ""}},
+	)
 	return err
 }
 
@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/chat"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoChatRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoChatRepository(db *mongo.Database) chat.Repository {
+	return &mongoChatRepository{
+		collection: db.Collection("messages"),
+	}
+}
+
+// betweenUsers matches every message exchanged by the two users, in either direction.
+func betweenUsers(user1, user2 string) bson.M {
+	return bson.M{"$or": []bson.M{
+		{"sender_id": user1, "receiver_id": user2},
+		{"sender_id": user2, "receiver_id": user1},
+	}}
+}
+
+func (r *mongoChatRepository) SendMessage(ctx context.Context, msg *chat.ChatMessage) error {
+	// ObjectID hex strings sort in creation order, which the history queries rely on.
+	if msg.ID == "" {
+		msg.ID = primitive.NewObjectID().Hex()
+	}
+	if msg.Timestamp == "" {
+		msg.Timestamp = time.Now().Format(time.RFC3339)
+	}
+	_, err := r.collection.InsertOne(ctx, msg)
+	return err
+}
+
+func (r *mongoChatRepository) GetMessageByID(ctx context.Context, messageID string) (*chat.ChatMessage, error) {
+	var msg chat.ChatMessage
+	err := r.collection.FindOne(ctx, bson.M{"_id": messageID}).Decode(&msg)
+	if err == mongo.ErrNoDocuments {
+		return nil, chat.ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (r *mongoChatRepository) ListMessagesBetweenUsers(ctx context.Context, user1, user2 string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	return findPage[*chat.ChatMessage](ctx, r.collection, betweenUsers(user1, user2), req, "_id")
+}
+
+func (r *mongoChatRepository) HasConversation(ctx context.Context, user1, user2 string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, betweenUsers(user1, user2), options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *mongoChatRepository) MarkAsSeen(ctx context.Context, messageID string) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": messageID}, bson.M{"$set": bson.M{"seen": true}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return chat.ErrMessageNotFound
+	}
+	return nil
+}
+
+func (r *mongoChatRepository) MarkConversationAsSeen(ctx context.Context, receiverID, senderID string) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"sender_id": senderID, "receiver_id": receiverID, "seen": false},
+		bson.M{"$set": bson.M{"seen": true}},
+	)
+	return err
+}
+
+// ListConversationsForUser returns the most recent message of each conversation, newest first.
+func (r *mongoChatRepository) ListConversationsForUser(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": []bson.M{
+			{"sender_id": userID},
+			{"receiver_id": userID},
+		}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$sender_id", userID}}, "$receiver_id", "$sender_id",
+			}}},
+			{Key: "last", Value: bson.M{"$first": "$$ROOT"}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$last"}}},
+	}
+	return aggregatePage[*chat.ChatMessage](ctx, r.collection, pipeline, req, "_id")
+}
@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoDisputeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoDisputeRepository(db *mongo.Database) dispute.Repository {
+	return &mongoDisputeRepository{
+		collection: db.Collection("disputes"),
+	}
+}
+
+func (r *mongoDisputeRepository) CreateDispute(ctx context.Context, d *dispute.Dispute) error {
+	_, err := r.collection.InsertOne(ctx, d)
+	if mongo.IsDuplicateKeyError(err) {
+		return dispute.ErrAlreadyDisputed
+	}
+	return err
+}
+
+func (r *mongoDisputeRepository) GetDisputeByID(ctx context.Context, id string) (*dispute.Dispute, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *mongoDisputeRepository) GetDisputeByOrder(ctx context.Context, orderID string) (*dispute.Dispute, error) {
+	return r.findOne(ctx, bson.M{"order_id": orderID})
+}
+
+func (r *mongoDisputeRepository) findOne(ctx context.Context, filter bson.M) (*dispute.Dispute, error) {
+	var d dispute.Dispute
+	err := r.collection.FindOne(ctx, filter).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *mongoDisputeRepository) ListDisputesByUser(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	return findPage[*dispute.Dispute](ctx, r.collection, bson.M{"$or": []bson.M{
+		{"buyer_id": userID},
+		{"seller_id": userID},
+	}}, req, "created_at")
+}
+
+func (r *mongoDisputeRepository) ListDisputesByStatus(ctx context.Context, status dispute.Status, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return findPage[*dispute.Dispute](ctx, r.collection, filter, req, "created_at")
+}
+
+// UpdateDispute replaces the dispute only while its status is still from, so a
+// seller's response and an admin's ruling cannot overwrite each other.
+func (r *mongoDisputeRepository) UpdateDispute(ctx context.Context, d *dispute.Dispute, from dispute.Status) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": d.ID, "status": from}, d)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return dispute.ErrStatusChanged
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoExchangeRateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoExchangeRateRepository(db *mongo.Database) money.RateRepository {
+	return &mongoExchangeRateRepository{
+		collection: db.Collection("exchange_rates"),
+	}
+}
+
+func pairFilter(from, to money.Currency) bson.M {
+	return bson.M{"from": from, "to": to}
+}
+
+func (r *mongoExchangeRateRepository) SaveRate(ctx context.Context, rate *money.Rate) error {
+	_, err := r.collection.ReplaceOne(ctx, pairFilter(rate.From, rate.To), rate, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *mongoExchangeRateRepository) GetRate(ctx context.Context, from, to money.Currency) (*money.Rate, error) {
+	var rate money.Rate
+	err := r.collection.FindOne(ctx, pairFilter(from, to)).Decode(&rate)
+	if err == mongo.ErrNoDocuments {
+		return nil, money.ErrRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *mongoExchangeRateRepository) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "from", Value: 1}, {Key: "to", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rates := []*money.Rate{}
+	if err := cursor.All(ctx, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *mongoExchangeRateRepository) DeleteRate(ctx context.Context, from, to money.Currency) error {
+	result, err := r.collection.DeleteOne(ctx, pairFilter(from, to))
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return money.ErrRateNotFound
+	}
+	return nil
+}
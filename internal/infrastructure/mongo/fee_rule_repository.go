@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoFeeRuleRepository stores one document per rule version. Only the latest
+// version of a live rule is marked current.
+type mongoFeeRuleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoFeeRuleRepository(db *mongo.Database) fee.Repository {
+	return &mongoFeeRuleRepository{
+		collection: db.Collection("fee_rules"),
+	}
+}
+
+func (r *mongoFeeRuleRepository) CreateRule(ctx context.Context, rule *fee.Rule) error {
+	_, err := r.collection.InsertOne(ctx, rule)
+	return err
+}
+
+func (r *mongoFeeRuleRepository) GetRule(ctx context.Context, id string) (*fee.Rule, error) {
+	return r.findOne(ctx, bson.M{"rule_id": id, "current": true})
+}
+
+func (r *mongoFeeRuleRepository) GetRuleVersion(ctx context.Context, id string, version int) (*fee.Rule, error) {
+	return r.findOne(ctx, bson.M{"rule_id": id, "version": version})
+}
+
+func (r *mongoFeeRuleRepository) findOne(ctx context.Context, filter bson.M) (*fee.Rule, error) {
+	var rule fee.Rule
+	err := r.collection.FindOne(ctx, filter).Decode(&rule)
+	if err == mongo.ErrNoDocuments {
+		return nil, fee.ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *mongoFeeRuleRepository) ListCurrentRules(ctx context.Context) ([]*fee.Rule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"current": true}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rules := []*fee.Rule{}
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *mongoFeeRuleRepository) SupersedeRule(ctx context.Context, id string, fromVersion int) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"rule_id": id, "version": fromVersion, "current": true},
+		bson.M{"$set": bson.M{"current": false}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fee.ErrVersionConflict
+	}
+	return nil
+}
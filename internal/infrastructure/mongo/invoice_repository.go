@@ -0,0 +1,69 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoInvoiceRepository struct {
+	invoices *mongo.Collection
+	counters *mongo.Collection
+}
+
+func NewMongoInvoiceRepository(db *mongo.Database) invoice.Repository {
+	return &mongoInvoiceRepository{
+		invoices: db.Collection("invoices"),
+		counters: db.Collection("invoice_counters"),
+	}
+}
+
+// NextSequence increments the seller's counter in a single update, so concurrent
+// checkouts never share a number.
+func (r *mongoInvoiceRepository) NextSequence(ctx context.Context, sellerID string) (int64, error) {
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := r.counters.FindOneAndUpdate(ctx, bson.M{"_id": sellerID}, bson.M{"$inc": bson.M{"seq": 1}}, opts).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
+
+func (r *mongoInvoiceRepository) CreateInvoice(ctx context.Context, inv *invoice.Invoice) error {
+	_, err := r.invoices.InsertOne(ctx, inv)
+	if mongo.IsDuplicateKeyError(err) {
+		return invoice.ErrInvoiceExists
+	}
+	return err
+}
+
+func (r *mongoInvoiceRepository) GetInvoiceByOrderID(ctx context.Context, orderID string) (*invoice.Invoice, error) {
+	var inv invoice.Invoice
+	err := r.invoices.FindOne(ctx, bson.M{"_id": orderID}).Decode(&inv)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// ReplaceInvoice replaces the invoice only while its revision is still from, so two
+// regenerations cannot overwrite each other.
+func (r *mongoInvoiceRepository) ReplaceInvoice(ctx context.Context, inv *invoice.Invoice, from int) error {
+	result, err := r.invoices.ReplaceOne(ctx, bson.M{"_id": inv.ID, "revision": from}, inv)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return invoice.ErrInvoiceChanged
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoLedgerRepository struct {
+	transactions *mongo.Collection
+	batches      *mongo.Collection
+	locks        *mongo.Collection
+}
+
+func NewMongoLedgerRepository(db *mongo.Database) ledger.Repository {
+	return &mongoLedgerRepository{
+		transactions: db.Collection("ledger_transactions"),
+		batches:      db.Collection("payout_batches"),
+		locks:        db.Collection("ledger_locks"),
+	}
+}
+
+// RecordTransaction stores the transaction with its entries as one document, so a
+// transaction is never half written.
+func (r *mongoLedgerRepository) RecordTransaction(ctx context.Context, t *ledger.Transaction) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	_, err := r.transactions.InsertOne(ctx, t)
+	if mongo.IsDuplicateKeyError(err) {
+		return ledger.ErrDuplicateTransaction
+	}
+	return err
+}
+
+func (r *mongoLedgerRepository) AccountBalance(ctx context.Context, account ledger.AccountType, ownerID string) (money.Totals, error) {
+	return r.sumEntries(ctx, bson.M{}, bson.M{"entries.account": account, "entries.owner_id": ownerID})
+}
+
+func (r *mongoLedgerRepository) OrderAccountBalance(ctx context.Context, account ledger.AccountType, ownerID, orderID string) (money.Totals, error) {
+	return r.sumEntries(ctx, bson.M{"order_id": orderID}, bson.M{"entries.account": account, "entries.owner_id": ownerID})
+}
+
+func (r *mongoLedgerRepository) AccountTotal(ctx context.Context, account ledger.AccountType) (money.Totals, error) {
+	return r.sumEntries(ctx, bson.M{}, bson.M{"entries.account": account})
+}
+
+// sumEntries adds up, per currency, the entries matching entryFilter across the
+// transactions matching txFilter.
+func (r *mongoLedgerRepository) sumEntries(ctx context.Context, txFilter, entryFilter bson.M) (money.Totals, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: txFilter}},
+		{{Key: "$unwind", Value: "$entries"}},
+		{{Key: "$match", Value: entryFilter}},
+		{{Key: "$group", Value: bson.M{"_id": "$entries.amount.currency", "total": bson.M{"$sum": "$entries.amount.amount"}}}},
+	}
+	cursor, err := r.transactions.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Currency money.Currency `bson:"_id"`
+		Total    int64          `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	totals := money.Totals{}
+	for _, row := range result {
+		totals.Add(money.New(row.Total, row.Currency))
+	}
+	return totals, nil
+}
+
+func (r *mongoLedgerRepository) BalancesByOwner(ctx context.Context, account ledger.AccountType) (map[string]money.Totals, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$entries"}},
+		{{Key: "$match", Value: bson.M{"entries.account": account}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"owner": "$entries.owner_id", "currency": "$entries.amount.currency"},
+			"total": bson.M{"$sum": "$entries.amount.amount"},
+		}}},
+	}
+	cursor, err := r.transactions.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Key struct {
+			OwnerID  string         `bson:"owner"`
+			Currency money.Currency `bson:"currency"`
+		} `bson:"_id"`
+		Total int64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	balances := make(map[string]money.Totals)
+	for _, row := range result {
+		if balances[row.Key.OwnerID] == nil {
+			balances[row.Key.OwnerID] = money.Totals{}
+		}
+		balances[row.Key.OwnerID].Add(money.New(row.Total, row.Key.Currency))
+	}
+	return balances, nil
+}
+
+func (r *mongoLedgerRepository) CreatePayoutBatch(ctx context.Context, b *ledger.PayoutBatch) error {
+	_, err := r.batches.InsertOne(ctx, b)
+	return err
+}
+
+// LockPayouts bumps a counter on the payouts lock document. Two transactions that
+// both write it cannot both commit: the later one fails with a write conflict and
+// is retried against the balances the first one left.
+func (r *mongoLedgerRepository) LockPayouts(ctx context.Context) error {
+	_, err := r.locks.UpdateOne(ctx,
+		bson.M{"_id": "payouts"},
+		bson.M{"$inc": bson.M{"runs": 1}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,153 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// legacyMoney builds the expression turning a float amount in major units, as every
+// amount was stored before currencies were added, into a money document in the
+// default currency.
+func legacyMoney(field string) bson.M {
+	return bson.M{
+		"amount": bson.M{"$toLong": bson.M{"$round": bson.A{
+			bson.M{"$multiply": bson.A{field, 100}}, 0,
+		}}},
+		"currency": money.DefaultCurrency,
+	}
+}
+
+// legacyMoneyIn is legacyMoney for a field that may already have been converted.
+func legacyMoneyIn(field string) bson.M {
+	return bson.M{"$cond": bson.A{bson.M{"$isNumber": field}, legacyMoney(field), field}}
+}
+
+// legacyMinor converts a float amount in major units to minor units.
+func legacyMinor(field string) bson.M {
+	return bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{field, 100}}, 0}}}
+}
+
+type moneyMigration struct {
+	collection string
+	filter     bson.M
+	pipeline   mongo.Pipeline
+}
+
+// convertFields converts top-level amount fields, each one on its own so that a
+// document holding a mix of converted and legacy fields is still finished.
+func convertFields(collection string, fields ...string) []moneyMigration {
+	var steps []moneyMigration
+	for _, f := range fields {
+		steps = append(steps, moneyMigration{
+			collection: collection,
+			filter:     bson.M{f: bson.M{"$type": "number"}},
+			pipeline: mongo.Pipeline{
+				{{Key: "$set", Value: bson.M{f: legacyMoney("$" + f)}}},
+			},
+		})
+	}
+	return steps
+}
+
+// convertArray converts the amount of each element of an array field.
+func convertArray(collection, array, field string) moneyMigration {
+	return moneyMigration{
+		collection: collection,
+		filter:     bson.M{array + "." + field: bson.M{"$type": "number"}},
+		pipeline: mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{array: bson.M{"$map": bson.M{
+				"input": "$" + array,
+				"as":    "e",
+				"in": bson.M{"$mergeObjects": bson.A{
+					"$$e", bson.M{field: legacyMoneyIn("$$e." + field)},
+				}},
+			}}}}},
+		},
+	}
+}
+
+// chargedFromAmount records what was charged on records that predate checkout
+// conversion, when the charge was always in the listing currency.
+func chargedFromAmount(collection, amountField string) moneyMigration {
+	return moneyMigration{
+		collection: collection,
+		filter: bson.M{
+			"charged":                 bson.M{"$exists": false},
+			amountField + ".currency": bson.M{"$exists": true},
+		},
+		pipeline: mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"charged": "$" + amountField}}},
+		},
+	}
+}
+
+func moneyMigrations() []moneyMigration {
+	var steps []moneyMigration
+	steps = append(steps, convertFields("bundles", "price")...)
+	steps = append(steps, convertFields("products", "price")...)
+	steps = append(steps, convertFields("cartitems", "price")...)
+	steps = append(steps, convertFields("orders", "totalprice", "platformfee")...)
+	steps = append(steps, chargedFromAmount("orders", "totalprice"))
+	steps = append(steps, convertFields("payments", "amount", "platformfee", "sellerearning")...)
+	steps = append(steps, chargedFromAmount("payments", "amount"))
+	steps = append(steps, convertFields("disputes", "requested_amount", "refund_amount")...)
+	steps = append(steps,
+		convertArray("ledger_transactions", "entries", "amount"),
+		convertArray("payout_batches", "payouts", "amount"),
+		moneyMigration{
+			collection: "payout_batches",
+			filter:     bson.M{"total": bson.M{"$exists": true}},
+			pipeline: mongo.Pipeline{
+				{{Key: "$set", Value: bson.M{"totals": bson.A{legacyMoney("$total")}}}},
+				{{Key: "$unset", Value: "total"}},
+			},
+		},
+		// Fee rules keep bare minor-unit amounts. Only rules with a fixed component
+		// get a currency, since a currency also narrows which sales a rule matches.
+		moneyMigration{
+			collection: "fee_rules",
+			filter: bson.M{"$or": bson.A{
+				bson.M{"flat": bson.M{"$type": "double"}},
+				bson.M{"min_fee": bson.M{"$type": "double"}},
+				bson.M{"max_fee": bson.M{"$type": "double"}},
+			}},
+			pipeline: mongo.Pipeline{
+				{{Key: "$set", Value: bson.M{
+					"flat":    legacyMinor("$flat"),
+					"min_fee": legacyMinor("$min_fee"),
+					"max_fee": legacyMinor("$max_fee"),
+					"currency": bson.M{"$cond": bson.A{
+						bson.M{"$or": bson.A{
+							bson.M{"$ne": bson.A{"$flat", 0}},
+							bson.M{"$ne": bson.A{"$min_fee", 0}},
+							bson.M{"$ne": bson.A{"$max_fee", 0}},
+						}},
+						money.DefaultCurrency,
+						"$$REMOVE",
+					}},
+				}}},
+			},
+		},
+	)
+	return steps
+}
+
+// MigrateMoney converts amounts stored as floats in major units to minor-unit money
+// documents in the default currency, which is what every amount was in before
+// prices carried a currency. Converted documents no longer match the filters, so
+// running it again is harmless.
+func MigrateMoney(ctx context.Context, db *mongo.Database) (map[string]int64, error) {
+	updated := map[string]int64{}
+	for _, step := range moneyMigrations() {
+		result, err := db.Collection(step.collection).UpdateMany(ctx, step.filter, step.pipeline)
+		if err != nil {
+			return updated, fmt.Errorf("migrating %s: %w", step.collection, err)
+		}
+		updated[step.collection] += result.ModifiedCount
+	}
+	return updated, nil
+}
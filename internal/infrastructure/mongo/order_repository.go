@@ -2,8 +2,8 @@ package mongo
 
 import (
     "context"
-    "fmt"
     "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+    "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
     "go.mongodb.org/mongo-driver/bson"
     "go.mongodb.org/mongo-driver/mongo"
 )
@@ -23,36 +23,12 @@ func (r *mongoOrderRepository) CreateOrder(ctx context.Context, o *order.Order)
     return err
 }
 
-func (r *mongoOrderRepository) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, error) {
-    fmt.Printf("🔍 Querying orders for consumer: %s\n", consumerID)
-    
-    var orders []*order.Order
+func (r *mongoOrderRepository) ListOrdersByConsumer(ctx context.Context, consumerID string, status order.OrderStatus, req pagination.Request) (*pagination.Page[*order.Order], error) {
     filter := bson.M{"consumerid": consumerID}
-    fmt.Printf("🔍 Using filter: %+v\n", filter)
-    
-    cursor, err := r.collection.Find(ctx, filter)
-    if err != nil {
-        fmt.Printf("❌ Error querying orders: %v\n", err)
-        return nil, err
-    }
-    defer cursor.Close(ctx)
-
-    for cursor.Next(ctx) {
-        var o order.Order
-        if err := cursor.Decode(&o); err != nil {
-            fmt.Printf("❌ Error decoding order: %v\n", err)
-            return nil, err
-        }
-        orders = append(orders, &o)
-    }
-
-    if err := cursor.Err(); err != nil {
-        fmt.Printf("❌ Cursor error: %v\n", err)
-        return nil, err
+    if status != "" {
+        filter["status"] = status
     }
-
-    fmt.Printf("✅ Found %d orders for consumer %s\n", len(orders), consumerID)
-    return orders, nil
+    return findPage[*order.Order](ctx, r.collection, filter, req, "createdat")
 }
 
 func (r *mongoOrderRepository) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
@@ -78,78 +54,64 @@ func (r *mongoOrderRepository) GetOrderByID(ctx context.Context, orderID string)
 }
 
 func (r *mongoOrderRepository) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
-    filter := bson.M{"_id": orderID} // Fixed: Corrected filter key from "order_id" to "_id"
+    // Orders are stored without bson tags, so their string ID lives in "id" while
+    // "_id" is the ObjectID generated on insert.
+    filter := bson.M{"id": orderID}
     update := bson.M{"$set": bson.M{"status": status}}
 
     _, err := r.collection.UpdateOne(ctx, filter, update)
     return err
 }
 
+func (r *mongoOrderRepository) TransitionOrderStatus(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+    filter := bson.M{"id": o.ID, "status": from}
+    update := bson.M{"$set": bson.M{
+        "status":         o.Status,
+        "trackingnumber": o.TrackingNumber,
+        "carrier":        o.Carrier,
+        "shippedat":      o.ShippedAt,
+        "deliveredat":    o.DeliveredAt,
+    }}
+
+    result, err := r.collection.UpdateOne(ctx, filter, update)
+    if err != nil {
+        return err
+    }
+    if result.MatchedCount == 0 {
+        return order.ErrStatusChanged
+    }
+    return nil
+}
+
 func (r *mongoOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
     _, err := r.collection.DeleteOne(ctx, bson.M{"_id": orderID})
     return err
 }
 
-func (r *mongoOrderRepository) GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*order.Order, error) {
-    fmt.Printf("🔍 Querying orders for supplier: %s\n", supplierID)
-    
-    var orders []*order.Order
-    filter := bson.M{"supplierid": supplierID}
-    fmt.Printf("🔍 Using filter: %+v\n", filter)
-    
-    cursor, err := r.collection.Find(ctx, filter)
-    if err != nil {
-        fmt.Printf("❌ Error querying orders: %v\n", err)
-        return nil, err
-    }
-    defer cursor.Close(ctx)
-
-    for cursor.Next(ctx) {
-        var o order.Order
-        if err := cursor.Decode(&o); err != nil {
-            fmt.Printf("❌ Error decoding order: %v\n", err)
-            return nil, err
-        }
-        orders = append(orders, &o)
-    }
-
-    if err := cursor.Err(); err != nil {
-        fmt.Printf("❌ Cursor error: %v\n", err)
-        return nil, err
-    }
+func (r *mongoOrderRepository) ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+    return findPage[*order.Order](ctx, r.collection, bson.M{"supplierid": supplierID}, req, "createdat")
+}
 
-    fmt.Printf("✅ Found %d orders for supplier %s\n", len(orders), supplierID)
-    return orders, nil
+// ListOrdersByReseller covers both sides of a reseller's trade: bundles bought from
+// suppliers and items sold to consumers.
+func (r *mongoOrderRepository) ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+    return findPage[*order.Order](ctx, r.collection, bson.M{"resellerid": resellerID}, req, "createdat")
 }
 
-func (r *mongoOrderRepository) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, error) {
-    fmt.Printf("🔍 Querying orders for reseller: %s\n", resellerID)
-    
-    var orders []*order.Order
-    filter := bson.M{"resellerid": resellerID}
-    fmt.Printf("🔍 Using filter: %+v\n", filter)
-    
-    cursor, err := r.collection.Find(ctx, filter)
-    if err != nil {
-        fmt.Printf("❌ Error querying orders: %v\n", err)
-        return nil, err
+func (r *mongoOrderRepository) GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*order.Order, error) {
+    filter := bson.M{
+        "resellerid": resellerID,
+        "bundleid":   bundleID,
+        "consumerid": bson.M{"$in": bson.A{"", nil}},
+        "status":     bson.M{"$ne": order.OrderStatusCanceled},
     }
-    defer cursor.Close(ctx)
-
-    for cursor.Next(ctx) {
-        var o order.Order
-        if err := cursor.Decode(&o); err != nil {
-            fmt.Printf("❌ Error decoding order: %v\n", err)
-            return nil, err
-        }
-        orders = append(orders, &o)
+    var o order.Order
+    err := r.collection.FindOne(ctx, filter).Decode(&o)
+    if err == mongo.ErrNoDocuments {
+        return nil, nil
     }
-
-    if err := cursor.Err(); err != nil {
-        fmt.Printf("❌ Cursor error: %v\n", err)
+    if err != nil {
         return nil, err
     }
-
-    fmt.Printf("✅ Found %d orders for reseller %s\n", len(orders), resellerID)
-    return orders, nil
-}
\ No newline at end of file
+    return &o, nil
+}
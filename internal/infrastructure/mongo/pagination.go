@@ -0,0 +1,166 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// findPage reads one page of the documents matching filter, newest first by
+// createdField with ties broken by _id. Collections store their creation time
+// under different names and types; the cursor keeps whatever is stored.
+func findPage[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, req pagination.Request, createdField string) (*pagination.Page[T], error) {
+	req, err := req.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	query, err := afterCursor(filter, req.Cursor, createdField)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().
+		SetSort(pageSort(createdField)).
+		SetLimit(int64(req.Limit + 1))
+	cursor, err := coll.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	page, err := readPage[T](ctx, cursor, req.Limit, createdField)
+	if err != nil {
+		return nil, err
+	}
+	page.Total = total
+	return page, nil
+}
+
+// aggregatePage is findPage for documents produced by a pipeline. The pipeline
+// must end with the documents to page through, each with an _id.
+func aggregatePage[T any](ctx context.Context, coll *mongo.Collection, pipeline mongo.Pipeline, req pagination.Request, createdField string) (*pagination.Page[T], error) {
+	req, err := req.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	after, err := afterCursor(bson.M{}, req.Cursor, createdField)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := append(mongo.Pipeline{}, pipeline...)
+	stages = append(stages, bson.D{{Key: "$facet", Value: bson.M{
+		"items": bson.A{
+			bson.M{"$match": after},
+			bson.M{"$sort": pageSort(createdField)},
+			bson.M{"$limit": int64(req.Limit + 1)},
+		},
+		"total": bson.A{bson.M{"$count": "n"}},
+	}}})
+
+	cursor, err := coll.Aggregate(ctx, stages)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Items []bson.Raw `bson:"items"`
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode page: %w", err)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &pagination.Page[T]{Items: make([]T, 0, len(result.Items))}
+	for i, raw := range result.Items {
+		if i == req.Limit {
+			page.NextCursor = cursorAfter(result.Items[i-1], createdField)
+			break
+		}
+		var item T
+		if err := bson.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("failed to decode page: %w", err)
+		}
+		page.Items = append(page.Items, item)
+	}
+	if len(result.Total) > 0 {
+		page.Total = result.Total[0].N
+	}
+	return page, nil
+}
+
+// pageSort orders newest first. Collections whose _id grows with creation time
+// page on _id alone.
+func pageSort(createdField string) bson.D {
+	if createdField == "_id" {
+		return bson.D{{Key: "_id", Value: -1}}
+	}
+	return bson.D{{Key: createdField, Value: -1}, {Key: "_id", Value: -1}}
+}
+
+// afterCursor narrows filter to the documents sorting after the cursor.
+func afterCursor(filter bson.M, encoded, createdField string) (bson.M, error) {
+	if encoded == "" {
+		return filter, nil
+	}
+	c, err := pagination.Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	after := bson.M{"_id": bson.M{"$lt": c.ID}}
+	if createdField != "_id" {
+		after = bson.M{"$or": bson.A{
+			bson.M{createdField: bson.M{"$lt": c.CreatedAt}},
+			bson.M{createdField: c.CreatedAt, "_id": bson.M{"$lt": c.ID}},
+		}}
+	}
+	if len(filter) == 0 {
+		return after, nil
+	}
+	return bson.M{"$and": bson.A{filter, after}}, nil
+}
+
+func readPage[T any](ctx context.Context, cursor *mongo.Cursor, limit int, createdField string) (*pagination.Page[T], error) {
+	defer cursor.Close(ctx)
+
+	page := &pagination.Page[T]{Items: make([]T, 0, limit)}
+	var last bson.Raw
+	for cursor.Next(ctx) {
+		if len(page.Items) == limit {
+			page.NextCursor = cursorAfter(last, createdField)
+			break
+		}
+		var item T
+		if err := cursor.Decode(&item); err != nil {
+			return nil, fmt.Errorf("failed to decode page: %w", err)
+		}
+		page.Items = append(page.Items, item)
+		// The cursor reuses its buffer for the next document.
+		last = append(last[:0], cursor.Current...)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func cursorAfter(last bson.Raw, createdField string) string {
+	return pagination.Cursor{
+		CreatedAt: last.Lookup(createdField),
+		ID:        last.Lookup("_id"),
+	}.Encode()
+}
@@ -0,0 +1,41 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoPaymentEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoPaymentEventRepository(db *mongo.Database) payment.EventRepository {
+	return &mongoPaymentEventRepository{
+		collection: db.Collection("payment_events"),
+	}
+}
+
+func (r *mongoPaymentEventRepository) HasProcessedEvent(ctx context.Context, eventID string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": eventID})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *mongoPaymentEventRepository) RecordEvent(ctx context.Context, e *payment.WebhookEvent) error {
+	if e.ReceivedAt == "" {
+		e.ReceivedAt = time.Now().Format(time.RFC3339)
+	}
+	_, err := r.collection.InsertOne(ctx, e)
+	// The event ID is the document key, so a concurrent delivery of the same
+	// event loses the race here and can be treated as already recorded.
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
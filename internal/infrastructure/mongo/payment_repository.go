@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -63,38 +64,86 @@ func (repo *mongoPaymentRepository) GetPaymentsByType(ctx context.Context, userI
 	}
 	return payments, nil
 }
-func (repo *mongoPaymentRepository) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
+
+// settledPaymentStatuses are the payments that moved money, including refunded ones
+// and their refund records. Older payments were stored as "paid".
+var settledPaymentStatuses = bson.A{"paid", "Paid", payment.StatusCaptured, payment.StatusRefunded}
+
+// GetAllPlatformFees totals sales and fees net of refunds. Refunded payments are
+// counted together with their negative refund records so that the two cancel out,
+// which keeps the totals in line with the ledger's buyer and platform accounts.
+// Totals are kept per listing currency.
+func (repo *mongoPaymentRepository) GetAllPlatformFees(ctx context.Context) (money.Totals, money.Totals, error) {
 	pipeline := mongo.Pipeline{
 		bson.D{
 			{Key: "$match", Value: bson.D{
 				{Key: "status", Value: bson.D{
-					{Key: "$in", Value: bson.A{"paid", "Paid"}},
+					{Key: "$in", Value: settledPaymentStatuses},
 				}},
 			}},
 		},
 		bson.D{
 			{Key: "$group", Value: bson.D{
-				{Key: "_id", Value: nil},
-				{Key: "totalSales", Value: bson.D{{Key: "$sum", Value: "$amount"}}},
-				{Key: "platformFees", Value: bson.D{{Key: "$sum", Value: "$platformfee"}}},
+				{Key: "_id", Value: "$amount.currency"},
+				{Key: "totalSales", Value: bson.D{{Key: "$sum", Value: "$amount.amount"}}},
+				{Key: "platformFees", Value: bson.D{{Key: "$sum", Value: "$platformfee.amount"}}},
 			}},
 		},
 	}
 
 	cursor, err := repo.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return 0, 0, err
+		return nil, nil, err
 	}
 	defer cursor.Close(ctx)
 
 	var result []struct {
-		TotalSales   float64 `bson:"totalSales"`
-		PlatformFees float64 `bson:"platformFees"`
+		Currency     money.Currency `bson:"_id"`
+		TotalSales   int64          `bson:"totalSales"`
+		PlatformFees int64          `bson:"platformFees"`
+	}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, nil, err
+	}
+
+	sales, fees := money.Totals{}, money.Totals{}
+	for _, row := range result {
+		sales.Add(money.New(row.TotalSales, row.Currency))
+		fees.Add(money.New(row.PlatformFees, row.Currency))
+	}
+	return sales, fees, nil
+}
+
+func (repo *mongoPaymentRepository) GetPaymentsByChargeID(ctx context.Context, chargeID string) ([]*payment.Payment, error) {
+	cursor, err := repo.collection.Find(ctx, bson.M{"gatewaychargeid": chargeID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var payments []*payment.Payment
+	if err = cursor.All(ctx, &payments); err != nil {
+		return nil, err
 	}
+	return payments, nil
+}
 
-	if err := cursor.All(ctx, &result); err != nil || len(result) == 0 {
-		return 0, 0, err
+func (repo *mongoPaymentRepository) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	cursor, err := repo.collection.Find(ctx, bson.M{"orderid": orderID})
+	if err != nil {
+		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	return result[0].TotalSales, result[0].PlatformFees, nil
+	var payments []*payment.Payment
+	if err = cursor.All(ctx, &payments); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (repo *mongoPaymentRepository) UpdatePaymentStatus(ctx context.Context, paymentID string, status payment.Status) error {
+	_, err := repo.collection.UpdateOne(ctx, bson.M{"id": paymentID}, bson.M{"$set": bson.M{"status": status}})
+	return err
 }
@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type mongoProductRepository struct {
@@ -37,35 +37,12 @@ func (r *mongoProductRepository) GetProductByID(ctx context.Context, id string)
 	return &p, nil
 }
 
-func (r *mongoProductRepository) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
-	var products []*product.Product
-	skip := (page - 1) * limit
-	opts := options.Find().SetSkip(int64(skip)).SetLimit(int64(limit))
-
+func (r *mongoProductRepository) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
 	resellerObjectID, err := primitive.ObjectIDFromHex(resellerID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid reseller ID: %w", err)
 	}
-
-	cursor, err := r.collection.Find(ctx, bson.M{"reseller_id": resellerObjectID}, opts)
-	if err != nil {
-		return nil, fmt.Errorf("database query failed: %w", err)
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var p product.Product
-		if err := cursor.Decode(&p); err != nil {
-			return nil, fmt.Errorf("failed to decode product: %w", err)
-		}
-		products = append(products, &p)
-	}
-
-	if err := cursor.Err(); err != nil {
-		return nil, fmt.Errorf("cursor error: %w", err)
-	}
-
-	return products, nil
+	return findPage[*product.Product](ctx, r.collection, bson.M{"reseller_id": resellerObjectID}, req, "createdat")
 }
 
 func (r *mongoProductRepository) GetProductByTitle(ctx context.Context, title string) (*product.Product, error) {
@@ -80,41 +57,105 @@ func (r *mongoProductRepository) GetProductByTitle(ctx context.Context, title st
 	return &p, nil
 }
 
-func (r *mongoProductRepository) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
-	var products []*product.Product
-	skip := (page - 1) * limit
-	opts := options.Find().SetSkip(int64(skip)).SetLimit(int64(limit))
+func (r *mongoProductRepository) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	return findPage[*product.Product](ctx, r.collection, bson.M{"status": "available"}, req, "createdat")
+}
+
+func (r *mongoProductRepository) DeleteProduct(ctx context.Context, id string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
 
-	cursor, err := r.collection.Find(ctx, bson.M{"status": "available"}, opts)
+func (r *mongoProductRepository) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updates})
+	return err
+}
+
+// Reserve places a checkout hold on the product for the user. It succeeds if the
+// product is available, already held by the same user, or held by a lapsed reservation.
+func (r *mongoProductRepository) Reserve(ctx context.Context, id, userID string, until time.Time) error {
+	filter := bson.M{
+		"_id": id,
+		"$or": []bson.M{
+			{"status": product.StatusAvailable},
+			{"status": product.StatusReserved, "reserved_by": userID},
+			{"status": product.StatusReserved, "reserved_until": bson.M{"$lte": time.Now()}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":         product.StatusReserved,
+		"reserved_by":    userID,
+		"reserved_until": until,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
-		return nil, fmt.Errorf("database query failed: %w", err)
+		return err
 	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var p product.Product
-		if err := cursor.Decode(&p); err != nil {
-			return nil, fmt.Errorf("failed to decode product: %w", err)
-		}
-		products = append(products, &p)
+	if result.MatchedCount == 0 {
+		return product.ErrProductUnavailable
 	}
+	return nil
+}
 
-	if err := cursor.Err(); err != nil {
-		return nil, fmt.Errorf("cursor error: %w", err)
+// ReleaseReservation returns a product held by the user to sale. It is a no-op if the
+// user no longer holds it.
+func (r *mongoProductRepository) ReleaseReservation(ctx context.Context, id, userID string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": product.StatusReserved, "reserved_by": userID},
+		releaseReservation,
+	)
+	return err
+}
+
+func (r *mongoProductRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"status": product.StatusReserved, "reserved_until": bson.M{"$lte": now}},
+		releaseReservation,
+	)
+	if err != nil {
+		return 0, err
 	}
+	return result.ModifiedCount, nil
+}
 
-	return products, nil
+var releaseReservation = bson.M{
+	"$set":   bson.M{"status": product.StatusAvailable},
+	"$unset": bson.M{"reserved_by": "", "reserved_until": ""},
 }
 
-func (r *mongoProductRepository) DeleteProduct(ctx context.Context, id string) error {
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
-	return err
+// MarkAsSold completes the sale of a product the buyer has reserved. The reservation
+// check is part of the update, so a product can only be sold to the holder.
+func (r *mongoProductRepository) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": product.StatusReserved, "reserved_by": buyerID},
+		bson.M{
+			"$set":   bson.M{"status": product.StatusSold},
+			"$unset": bson.M{"reserved_by": "", "reserved_until": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return product.ErrProductUnavailable
+	}
+	return nil
 }
 
-func (r *mongoProductRepository) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updates})
+// Restock puts a sold product back on sale after its order was canceled.
+func (r *mongoProductRepository) Restock(ctx context.Context, id string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": product.StatusSold},
+		bson.M{"$set": bson.M{"status": product.StatusAvailable}},
+	)
 	return err
 }
+
 func (r *mongoProductRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
 	var products []*product.Product
 
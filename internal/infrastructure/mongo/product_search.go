@@ -0,0 +1,329 @@
+package mongo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureProductIndexes creates the text index product search runs on. Titles
+// weigh most, then the garment type, then the description.
+func EnsureProductIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("products").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "type", Value: "text"},
+			{Key: "description", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("product_search").
+			SetWeights(bson.M{"title": 10, "type": 5, "description": 1}),
+	})
+	return err
+}
+
+// searchFacets maps each facet to the product field it counts.
+var searchFacets = []struct {
+	name  string
+	field string
+}{
+	{"sizes", "size"},
+	{"types", "type"},
+	{"grades", "grade"},
+	{"suppliers", "supplier_id"},
+}
+
+// searchCursor is where a page of search results ended: the sort key of its
+// last product, with the ID to break ties. Like the bundle cursor it records a
+// sort key rather than the creation time, so it is not a pagination.Cursor.
+type searchCursor struct {
+	Sort      product.SearchSort `json:"s"`
+	Score     float64            `json:"sc,omitempty"`
+	Price     *float64           `json:"p,omitempty"`
+	Rating    float64            `json:"r,omitempty"`
+	CreatedAt string             `json:"c,omitempty"`
+	ID        string             `json:"id"`
+}
+
+func (c searchCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeSearchCursor(encoded string, sort product.SearchSort) (*searchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+	var c searchCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" || c.Sort != sort {
+		return nil, pagination.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// searchHit is a matching product with the sort keys computed for it.
+type searchHit struct {
+	product.Product `bson:",inline"`
+	Score           float64  `bson:"score"`
+	SearchPrice     *float64 `bson:"search_price"`
+}
+
+type searchPage struct {
+	Results []searchHit `bson:"results"`
+	Total   []struct {
+		N int64 `bson:"n"`
+	} `bson:"total"`
+	product.Facets `bson:",inline"`
+}
+
+// SearchProducts runs the whole search as one aggregation. Filters shared by every
+// facet are applied up front; the facet filters are applied inside each $facet
+// branch, leaving out the facet being counted. Results are paged by keyset on the
+// sort key, so a page is not shifted by products listed in the meantime.
+func (r *mongoProductRepository) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	req, err := q.Request.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	after := bson.M{}
+	if req.Cursor != "" {
+		c, err := decodeSearchCursor(req.Cursor, q.Sort)
+		if err != nil {
+			return nil, err
+		}
+		after = searchAfterFilter(q, c)
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: searchBaseFilter(q)}}}
+
+	if q.MinTrustScore > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{
+				"from": "users",
+				"let":  bson.M{"reseller": bson.M{"$toString": "$reseller_id"}},
+				"pipeline": bson.A{
+					bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$reseller"}}}},
+					bson.M{"$project": bson.M{"trust_score": 1}},
+				},
+				"as": "reseller",
+			}}},
+			bson.D{{Key: "$match", Value: bson.M{"reseller.trust_score": bson.M{"$gte": q.MinTrustScore}}}},
+			bson.D{{Key: "$unset", Value: "reseller"}},
+		)
+	}
+
+	if q.Text != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}})
+	}
+	// Prices in different currencies are compared in the search currency.
+	// Products without a rate get no comparable price and sort last.
+	pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"search_price": searchPrice(q.PriceFactors)}}})
+	if priceFilter := searchPriceFilter(q); len(priceFilter) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"search_price": priceFilter}}})
+	}
+	if q.Sort == product.SortPriceAsc || q.Sort == product.SortPriceDesc {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{
+			"priced": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$search_price", nil}}, 0, 1}},
+		}}})
+	}
+
+	// One product past the page tells whether there is a next one.
+	all := searchFacetFilter(q, "")
+	facets := bson.M{
+		"results": bson.A{
+			bson.M{"$match": all},
+			bson.M{"$match": after},
+			bson.M{"$sort": searchSort(q)},
+			bson.M{"$limit": int64(req.Limit + 1)},
+			bson.M{"$unset": "priced"},
+		},
+		"total": bson.A{
+			bson.M{"$match": all},
+			bson.M{"$count": "n"},
+		},
+	}
+	for _, f := range searchFacets {
+		facets[f.name] = bson.A{
+			bson.M{"$match": searchFacetFilter(q, f.field)},
+			bson.M{"$group": bson.M{"_id": "$" + f.field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$match": bson.M{"_id": bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		}
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: facets}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("product search failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var page searchPage
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to decode search results: %w", err)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	result := &product.SearchResult{Facets: page.Facets}
+	result.Items = make([]*product.Product, 0, len(page.Results))
+	for i := range page.Results {
+		if i == req.Limit {
+			result.NextCursor = nextSearchCursor(q, &page.Results[i-1]).encode()
+			break
+		}
+		result.Items = append(result.Items, &page.Results[i].Product)
+	}
+	if len(page.Total) > 0 {
+		result.Total = page.Total[0].N
+	}
+	return result, nil
+}
+
+func nextSearchCursor(q *product.SearchQuery, last *searchHit) searchCursor {
+	c := searchCursor{Sort: q.Sort, ID: last.ID}
+	switch searchSortField(q) {
+	case "score":
+		c.Score = last.Score
+	case "search_price":
+		c.Price = last.SearchPrice
+	case "rating":
+		c.Rating = last.Rating
+	default:
+		c.CreatedAt = last.CreatedAt
+	}
+	return c
+}
+
+// searchAfterFilter matches the products that sort after the cursor. Products
+// without a comparable price come last in either price order.
+func searchAfterFilter(q *product.SearchQuery, after *searchCursor) bson.M {
+	field := searchSortField(q)
+	op, value := "$lt", interface{}(after.CreatedAt)
+	switch field {
+	case "score":
+		value = after.Score
+	case "rating":
+		value = after.Rating
+	case "search_price":
+		if after.Price == nil {
+			return bson.M{"search_price": nil, "_id": bson.M{"$gt": after.ID}}
+		}
+		if q.Sort == product.SortPriceAsc {
+			op = "$gt"
+		}
+		return bson.M{"$or": bson.A{
+			bson.M{field: bson.M{op: *after.Price}},
+			bson.M{field: *after.Price, "_id": bson.M{"$gt": after.ID}},
+			bson.M{field: nil},
+		}}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{"$gt": after.ID}},
+	}}
+}
+
+// searchBaseFilter matches what every facet is counted under. A text search has
+// to be part of the pipeline's first stage.
+func searchBaseFilter(q *product.SearchQuery) bson.M {
+	filter := bson.M{"status": product.StatusAvailable}
+	if q.Text != "" {
+		filter["$text"] = bson.M{"$search": q.Text}
+	}
+	if q.SupplierID != "" {
+		filter["supplier_id"] = q.SupplierID
+	}
+	if q.BundleID != "" {
+		filter["bundle_id"] = q.BundleID
+	}
+	return filter
+}
+
+// searchFacetFilter matches the facet filters, except the one on the skip field.
+func searchFacetFilter(q *product.SearchQuery, skip string) bson.M {
+	filter := bson.M{}
+	for field, values := range map[string][]string{"size": q.Sizes, "type": q.Types, "grade": q.Grades} {
+		if field != skip && len(values) > 0 {
+			filter[field] = bson.M{"$in": values}
+		}
+	}
+	return filter
+}
+
+// searchPrice is the expression for a product's price in the search currency.
+func searchPrice(factors map[money.Currency]float64) bson.M {
+	return convertedAmount("$price.amount", "$price.currency", factors)
+}
+
+// convertedAmount converts amount, in the currency the currency expression
+// evaluates to, with the matching factor. It is null for currencies without one.
+func convertedAmount(amount interface{}, currency string, factors map[money.Currency]float64) bson.M {
+	branches := bson.A{}
+	for c, factor := range factors {
+		branches = append(branches, bson.M{
+			"case": bson.M{"$eq": bson.A{currency, string(c)}},
+			"then": bson.M{"$multiply": bson.A{amount, factor}},
+		})
+	}
+	if len(branches) == 0 {
+		return bson.M{"$literal": nil}
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": nil}}
+}
+
+func searchPriceFilter(q *product.SearchQuery) bson.M {
+	filter := bson.M{}
+	if q.MinPrice != nil {
+		filter["$gte"] = q.MinPrice.Amount
+	}
+	if q.MaxPrice != nil {
+		filter["$lte"] = q.MaxPrice.Amount
+	}
+	if len(filter) > 0 {
+		// A null price would otherwise satisfy $lte.
+		filter["$ne"] = nil
+	}
+	return filter
+}
+
+// searchSortField is the field results are ordered by, before the _id that
+// breaks ties.
+func searchSortField(q *product.SearchQuery) string {
+	switch q.Sort {
+	case product.SortPriceAsc, product.SortPriceDesc:
+		return "search_price"
+	case product.SortRating:
+		return "rating"
+	case product.SortRelevance:
+		if q.Text != "" {
+			return "score"
+		}
+	}
+	return "createdat"
+}
+
+func searchSort(q *product.SearchQuery) bson.D {
+	var sort bson.D
+	switch q.Sort {
+	case product.SortPriceAsc:
+		sort = bson.D{{Key: "priced", Value: -1}, {Key: "search_price", Value: 1}}
+	case product.SortPriceDesc:
+		sort = bson.D{{Key: "priced", Value: -1}, {Key: "search_price", Value: -1}}
+	default:
+		sort = bson.D{{Key: searchSortField(q), Value: -1}}
+	}
+	return append(sort, bson.E{Key: "_id", Value: 1})
+}
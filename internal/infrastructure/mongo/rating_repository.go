@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/rating"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoRatingRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoRatingRepository(db *mongo.Database) rating.Repository {
+	return &mongoRatingRepository{
+		collection: db.Collection("ratings"),
+	}
+}
+
+func (r *mongoRatingRepository) CreateRating(ctx context.Context, rt *rating.Rating) error {
+	_, err := r.collection.InsertOne(ctx, rt)
+	if mongo.IsDuplicateKeyError(err) {
+		return rating.ErrAlreadyRated
+	}
+	return err
+}
+
+func (r *mongoRatingRepository) GetRatingByResellerAndBundle(ctx context.Context, resellerID, bundleID string) (*rating.Rating, error) {
+	var rt rating.Rating
+	err := r.collection.FindOne(ctx, bson.M{"reseller_id": resellerID, "bundle_id": bundleID}).Decode(&rt)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rt, nil
+}
+
+func (r *mongoRatingRepository) GetRatingsBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*rating.Rating], error) {
+	return findPage[*rating.Rating](ctx, r.collection, bson.M{"supplier_id": supplierID}, req, "created_at")
+}
@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/review"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -35,17 +36,6 @@ func (r *ReviewRepository) GetReviewByUserAndProduct(ctx context.Context, userID
 	return &rev, nil
 }
 
-func (r *ReviewRepository) GetReviewsByReseller(ctx context.Context, resellerID string) ([]*review.Review, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"reseller_id": resellerID})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var reviews []*review.Review
-	if err = cursor.All(ctx, &reviews); err != nil {
-		return nil, err
-	}
-
-	return reviews, nil
+func (r *ReviewRepository) ListReviewsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*review.Review], error) {
+	return findPage[*review.Review](ctx, r.collection, bson.M{"reseller_id": resellerID}, req, "created_at")
 }
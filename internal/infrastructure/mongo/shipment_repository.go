@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoShipmentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoShipmentRepository(db *mongo.Database) shipment.Repository {
+	return &mongoShipmentRepository{
+		collection: db.Collection("shipments"),
+	}
+}
+
+// EnsureShipmentIndexes makes tracking numbers unique per carrier, so tracking
+// events can only ever match one shipment.
+func EnsureShipmentIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("shipments").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "carrier", Value: 1},
+			{Key: "tracking_number", Value: 1},
+		},
+		Options: options.Index().
+			SetName("shipment_tracking").
+			SetUnique(true),
+	})
+	return err
+}
+
+func (r *mongoShipmentRepository) SaveShipment(ctx context.Context, s *shipment.Shipment) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": s.ID}, s, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return shipment.ErrTrackingTaken
+	}
+	return err
+}
+
+func (r *mongoShipmentRepository) GetShipmentByOrderID(ctx context.Context, orderID string) (*shipment.Shipment, error) {
+	return r.findOne(ctx, bson.M{"_id": orderID})
+}
+
+func (r *mongoShipmentRepository) GetShipmentByTracking(ctx context.Context, carrier, trackingNumber string) (*shipment.Shipment, error) {
+	return r.findOne(ctx, bson.M{"carrier": carrier, "tracking_number": trackingNumber})
+}
+
+func (r *mongoShipmentRepository) findOne(ctx context.Context, filter bson.M) (*shipment.Shipment, error) {
+	var s shipment.Shipment
+	err := r.collection.FindOne(ctx, filter).Decode(&s)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateShipment replaces the shipment only while its stored timeline still has
+// the given number of events.
+func (r *mongoShipmentRepository) UpdateShipment(ctx context.Context, s *shipment.Shipment, events int) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": s.ID, "events": bson.M{"$size": events}}, s)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return shipment.ErrShipmentChanged
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/transaction"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoTransactionManager struct {
+	client *mongo.Client
+}
+
+// NewMongoTransactionManager needs the database to run as a replica set, since
+// standalone servers do not support multi-document transactions.
+func NewMongoTransactionManager(db *mongo.Database) transaction.Manager {
+	return &mongoTransactionManager{client: db.Client()}
+}
+
+func (m *mongoTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := m.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// The driver retries the callback on transient errors such as write conflicts
+	// between two buyers of the same bundle.
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
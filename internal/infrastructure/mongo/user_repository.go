@@ -6,18 +6,21 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type mongoUserRepository struct {
-	collection *mongo.Collection
+	collection   *mongo.Collection
+	trustHistory *mongo.Collection
 }
 
 func NewMongoUserRepository(db *mongo.Database) user.Repository {
 	return &mongoUserRepository{
-		collection: db.Collection("users"),
+		collection:   db.Collection("users"),
+		trustHistory: db.Collection("trust_history"),
 	}
 }
 
@@ -67,28 +70,14 @@ func (r *mongoUserRepository) UpdateUser(ctx context.Context, id string, updated
 	return err
 }
 
-func (r *mongoUserRepository) ListUsersByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
-	var users []*user.User
-	cursor, err := r.collection.Find(ctx, bson.M{"role": string(role), "is_deleted": bson.M{"$ne": true}})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var u user.User
-		if err := cursor.Decode(&u); err != nil {
-			return nil, err
-		}
-		users = append(users, &u)
-	}
-
-	if err := cursor.Err(); err != nil {
-		return nil, err
+func (r *mongoUserRepository) ListUsersByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
+	if len(roles) > 0 {
+		filter["role"] = bson.M{"$in": roles}
 	}
-
-	return users, nil
+	return findPage[*user.User](ctx, r.collection, filter, req, "created_at")
 }
+
 func (r *mongoUserRepository) UpdateTrustData(ctx context.Context, user *user.User) error {
 	fmt.Println("💾 Updating trust data for:", user.ID)
 	fmt.Printf("🧠 ID type: %T\n", user.ID)
@@ -120,28 +109,16 @@ func (r *mongoUserRepository) UpdateTrustData(ctx context.Context, user *user.Us
 		fmt.Println("⚠️ Warning: No document was modified. Check if the ID matches an existing user.")
 	}
 
-	return nil
+	// Every score is kept with its time so dashboards can chart the trend.
+	_, err = r.trustHistory.InsertOne(ctx, bson.M{
+		"user_id":     user.ID,
+		"score":       user.TrustScore,
+		"recorded_at": time.Now(),
+	})
+	return err
 }
-func (r *mongoUserRepository) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
-	var users []*user.User
-	cursor, err := r.collection.Find(ctx, bson.M{"is_blacklisted": true})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var u user.User
-		if err := cursor.Decode(&u); err != nil {
-			return nil, err
-		}
-		users = append(users, &u)
-	}
-	if err := cursor.Err(); err != nil {
-		return nil, err
-	}
-
-	return users, nil
+func (r *mongoUserRepository) ListBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	return findPage[*user.User](ctx, r.collection, bson.M{"is_blacklisted": true}, req, "created_at")
 }
 func (r *mongoUserRepository) CountActiveUsers(ctx context.Context) (int, error) {
 	filter := bson.M{"is_deleted": false}
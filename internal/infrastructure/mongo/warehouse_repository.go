@@ -3,9 +3,11 @@ package mongo
 import (
 	"context"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type mongoRepository struct {
@@ -23,17 +25,21 @@ func (r *mongoRepository) AddItem(ctx context.Context, item *warehouse.Warehouse
 	return err
 }
 
-func (r *mongoRepository) GetItemsByReseller(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"reseller_id": resellerID})
-	if err != nil {
-		return nil, err
+func (r *mongoRepository) ListItemsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*warehouse.WarehouseItem], error) {
+	filter := bson.M{"reseller_id": resellerID, "entry_id": bson.M{"$exists": false}}
+	return findPage[*warehouse.WarehouseItem](ctx, r.collection, filter, req, "created_at")
+}
+
+func (r *mongoRepository) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	var item warehouse.WarehouseItem
+	err := r.collection.FindOne(ctx, bson.M{"_id": itemID}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
 	}
-	defer cursor.Close(ctx)
-	var items []*warehouse.WarehouseItem
-	if err = cursor.All(ctx, &items); err != nil {
+	if err != nil {
 		return nil, err
 	}
-	return items, nil
+	return &item, nil
 }
 
 func (r *mongoRepository) GetItemsByBundle(ctx context.Context, bundleID string) ([]*warehouse.WarehouseItem, error) {
@@ -49,25 +55,53 @@ func (r *mongoRepository) GetItemsByBundle(ctx context.Context, bundleID string)
 	return items, nil
 }
 
-func (r *mongoRepository) MarkItemAsListed(ctx context.Context, itemID string) error {
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": itemID}, bson.M{"$set": bson.M{"status": "listed"}})
-	return err
+func (r *mongoRepository) UpdateItemStatus(ctx context.Context, itemID, from string, updates map[string]interface{}) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": itemID, "status": from}, bson.M{"$set": updates})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return warehouse.ErrStatusChanged
+	}
+	return nil
 }
 
-func (r *mongoRepository) MarkItemAsSkipped(ctx context.Context, itemID string) error {
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": itemID}, bson.M{"$set": bson.M{"status": "skipped"}})
-	return err
+// TakePiece decrements the count in the same update that checks there is one
+// left, so two pieces recorded at once cannot both take the last.
+func (r *mongoRepository) TakePiece(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	filter := bson.M{
+		"_id": itemID,
+		"status": bson.M{"$in": []string{
+			warehouse.StatusReceived, warehouse.StatusArrived, warehouse.StatusListed,
+		}},
+		"remaining_item_count": bson.M{"$gt": 0},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var item warehouse.WarehouseItem
+	err := r.collection.FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"remaining_item_count": -1}}, opts).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, warehouse.ErrNothingToUnpack
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
 }
 
 func (r *mongoRepository) DeleteItem(ctx context.Context, itemID string) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": itemID})
 	return err
 }
+
+// HasResellerReceivedBundle also accepts closed entries and those stored before
+// the receive step existed.
 func (r *mongoRepository) HasResellerReceivedBundle(ctx context.Context, resellerID string, bundleID string) (bool, error) {
 	filter := bson.M{
 		"reseller_id": resellerID,
 		"bundle_id":   bundleID,
-		"status":      bson.M{"$in": []string{"arrived", "listed"}},
+		"status": bson.M{"$in": []string{
+			warehouse.StatusReceived, warehouse.StatusClosed, warehouse.StatusArrived, warehouse.StatusListed,
+		}},
 	}
 	count, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -0,0 +1,141 @@
+package paymentinfra
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+// FakeGateway is a deterministic in-memory gateway for tests and local runs.
+// Charge IDs are sequential and no call ever sleeps or reaches the network.
+type FakeGateway struct {
+	mu       sync.Mutex
+	seq      int
+	charges  map[string]*payment.Charge
+	replies  map[string]*payment.Charge
+	declines bool
+	failCaps bool
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{
+		charges: make(map[string]*payment.Charge),
+		replies: make(map[string]*payment.Charge),
+	}
+}
+
+// DeclineAll makes every following Authorize fail with payment.ErrPaymentDeclined.
+func (g *FakeGateway) DeclineAll(decline bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.declines = decline
+}
+
+// Charge returns a copy of the charge as the gateway currently sees it.
+func (g *FakeGateway) Charge(chargeID string) (*payment.Charge, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c, ok := g.charges[chargeID]
+	if !ok {
+		return nil, false
+	}
+	copied := *c
+	return &copied, true
+}
+
+func (g *FakeGateway) Authorize(_ context.Context, req payment.AuthorizeRequest) (*payment.Charge, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.declines || !req.Amount.IsPositive() {
+		return nil, payment.ErrPaymentDeclined
+	}
+
+	g.seq++
+	c := &payment.Charge{
+		ID:             fmt.Sprintf("ch_fake_%06d", g.seq),
+		Amount:         req.Amount,
+		AmountRefunded: money.New(0, req.Amount.Currency),
+		Status:         payment.ChargeAuthorized,
+	}
+	g.charges[c.ID] = c
+	copied := *c
+	return &copied, nil
+}
+
+// FailCaptures makes every following Capture fail, leaving the hold in place.
+func (g *FakeGateway) FailCaptures(fail bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failCaps = fail
+}
+
+func (g *FakeGateway) Capture(_ context.Context, chargeID string) (*payment.Charge, error) {
+	return g.transition(chargeID, "", func(c *payment.Charge) error {
+		if g.failCaps {
+			return fmt.Errorf("capture of %s failed", c.ID)
+		}
+		if c.Status != payment.ChargeAuthorized {
+			return fmt.Errorf("cannot capture a %s charge", c.Status)
+		}
+		c.Status = payment.ChargeCaptured
+		return nil
+	})
+}
+
+func (g *FakeGateway) Refund(_ context.Context, chargeID string, amount money.Money, idempotencyKey string) (*payment.Charge, error) {
+	return g.transition(chargeID, idempotencyKey, func(c *payment.Charge) error {
+		if c.Status != payment.ChargeCaptured && c.Status != payment.ChargeRefunded {
+			return fmt.Errorf("cannot refund a %s charge", c.Status)
+		}
+		if amount.Currency != c.Amount.Currency {
+			return fmt.Errorf("cannot refund %s on a %s charge", amount.Currency, c.Amount.Currency)
+		}
+		if !amount.IsPositive() || c.AmountRefunded.Add(amount).Cmp(c.Amount) > 0 {
+			return fmt.Errorf("refund amount %s exceeds the refundable balance", amount)
+		}
+		c.AmountRefunded = c.AmountRefunded.Add(amount)
+		if c.AmountRefunded == c.Amount {
+			c.Status = payment.ChargeRefunded
+		}
+		return nil
+	})
+}
+
+func (g *FakeGateway) Void(_ context.Context, chargeID string, idempotencyKey string) (*payment.Charge, error) {
+	return g.transition(chargeID, idempotencyKey, func(c *payment.Charge) error {
+		if c.Status != payment.ChargeAuthorized {
+			return fmt.Errorf("cannot void a %s charge", c.Status)
+		}
+		c.Status = payment.ChargeVoided
+		return nil
+	})
+}
+
+// transition applies a change to a charge. A call repeating an earlier
+// idempotency key gets the earlier result back and changes nothing.
+func (g *FakeGateway) transition(chargeID, idempotencyKey string, apply func(c *payment.Charge) error) (*payment.Charge, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if reply, ok := g.replies[idempotencyKey]; ok && idempotencyKey != "" {
+		copied := *reply
+		return &copied, nil
+	}
+	c, ok := g.charges[chargeID]
+	if !ok {
+		return nil, payment.ErrChargeNotFound
+	}
+	if err := apply(c); err != nil {
+		return nil, err
+	}
+	copied := *c
+	if idempotencyKey != "" {
+		reply := copied
+		g.replies[idempotencyKey] = &reply
+	}
+	return &copied, nil
+}
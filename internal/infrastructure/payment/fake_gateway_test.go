@@ -0,0 +1,82 @@
+package paymentinfra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeGateway_AuthorizeCaptureRefund(t *testing.T) {
+	ctx := context.Background()
+	g := NewFakeGateway()
+
+	c, err := g.Authorize(ctx, payment.AuthorizeRequest{Amount: money.New(5000, money.USD)})
+	require.NoError(t, err)
+	assert.Equal(t, "ch_fake_000001", c.ID)
+	assert.Equal(t, payment.ChargeAuthorized, c.Status)
+
+	c, err = g.Capture(ctx, c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ChargeCaptured, c.Status)
+
+	c, err = g.Refund(ctx, c.ID, money.New(2000, money.USD), "re_1")
+	require.NoError(t, err)
+	assert.Equal(t, payment.ChargeCaptured, c.Status)
+	assert.Equal(t, money.New(2000, money.USD), c.AmountRefunded)
+
+	c, err = g.Refund(ctx, c.ID, money.New(3000, money.USD), "re_2")
+	require.NoError(t, err)
+	assert.Equal(t, payment.ChargeRefunded, c.Status)
+
+	_, err = g.Refund(ctx, c.ID, money.New(100, money.USD), "re_3")
+	assert.Error(t, err)
+}
+
+func TestFakeGateway_Void(t *testing.T) {
+	ctx := context.Background()
+	g := NewFakeGateway()
+
+	c, _ := g.Authorize(ctx, payment.AuthorizeRequest{Amount: money.New(1000, money.ETB)})
+	c, err := g.Void(ctx, c.ID, "void_1")
+	require.NoError(t, err)
+	assert.Equal(t, payment.ChargeVoided, c.Status)
+
+	_, err = g.Capture(ctx, c.ID)
+	assert.Error(t, err)
+}
+
+func TestFakeGateway_RefundIdempotent(t *testing.T) {
+	ctx := context.Background()
+	g := NewFakeGateway()
+
+	c, _ := g.Authorize(ctx, payment.AuthorizeRequest{Amount: money.New(5000, money.USD)})
+	g.Capture(ctx, c.ID)
+
+	_, err := g.Refund(ctx, c.ID, money.New(2000, money.USD), "re_1")
+	require.NoError(t, err)
+	c, err = g.Refund(ctx, c.ID, money.New(2000, money.USD), "re_1")
+	require.NoError(t, err)
+	assert.Equal(t, money.New(2000, money.USD), c.AmountRefunded)
+
+	charge, _ := g.Charge(c.ID)
+	assert.Equal(t, money.New(2000, money.USD), charge.AmountRefunded)
+}
+
+func TestFakeGateway_Decline(t *testing.T) {
+	g := NewFakeGateway()
+	g.DeclineAll(true)
+
+	_, err := g.Authorize(context.Background(), payment.AuthorizeRequest{Amount: money.New(1000, money.ETB)})
+
+	assert.ErrorIs(t, err, payment.ErrPaymentDeclined)
+}
+
+func TestFakeGateway_UnknownCharge(t *testing.T) {
+	_, err := NewFakeGateway().Capture(context.Background(), "ch_missing")
+
+	assert.ErrorIs(t, err, payment.ErrChargeNotFound)
+}
@@ -0,0 +1,194 @@
+package paymentinfra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+)
+
+const DefaultStripeAPIBase = "https://api.stripe.com"
+
+type StripeConfig struct {
+	// APIBase can point at stripe-mock or any other compatible server.
+	APIBase   string
+	SecretKey string
+	// PaymentMethod is attached to every authorization. Until the clients collect
+	// card details themselves this is a test method such as pm_card_visa.
+	PaymentMethod string
+	// Currency is used for amounts that carry none.
+	Currency string
+}
+
+// stripeGateway talks to the Stripe PaymentIntents API. An authorization is a
+// confirmed intent with manual capture, so Capture and Void map to the intent's
+// capture and cancel endpoints.
+type stripeGateway struct {
+	cfg    StripeConfig
+	client *http.Client
+}
+
+func NewStripeGateway(cfg StripeConfig, client *http.Client) payment.Gateway {
+	if cfg.APIBase == "" {
+		cfg.APIBase = DefaultStripeAPIBase
+	}
+	cfg.APIBase = strings.TrimRight(cfg.APIBase, "/")
+	if cfg.Currency == "" {
+		cfg.Currency = "usd"
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	return &stripeGateway{cfg: cfg, client: client}
+}
+
+type stripePaymentIntent struct {
+	ID             string `json:"id"`
+	Amount         int64  `json:"amount"`
+	AmountReceived int64  `json:"amount_received"`
+	Currency       string `json:"currency"`
+	Status         string `json:"status"`
+}
+
+type stripeRefund struct {
+	ID            string `json:"id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	PaymentIntent string `json:"payment_intent"`
+	Status        string `json:"status"`
+}
+
+type stripeError struct {
+	Error struct {
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// fromStripe reads an amount as Stripe reports it: in minor units with a
+// lowercase currency code, which is how money.Money already stores amounts.
+func fromStripe(amount int64, currency string) money.Money {
+	return money.New(amount, money.Currency(strings.ToUpper(currency)))
+}
+
+func (g *stripeGateway) Authorize(ctx context.Context, req payment.AuthorizeRequest) (*payment.Charge, error) {
+	currency := string(req.Amount.Currency)
+	if currency == "" {
+		currency = g.cfg.Currency
+	}
+
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.Amount.Amount, 10))
+	form.Set("currency", strings.ToLower(currency))
+	form.Set("capture_method", "manual")
+	if g.cfg.PaymentMethod != "" {
+		form.Set("payment_method", g.cfg.PaymentMethod)
+		form.Set("confirm", "true")
+	}
+	if req.Description != "" {
+		form.Set("description", req.Description)
+	}
+	if req.CustomerID != "" {
+		form.Set("metadata[customer_id]", req.CustomerID)
+	}
+	for k, v := range req.Metadata {
+		form.Set("metadata["+k+"]", v)
+	}
+
+	var intent stripePaymentIntent
+	if err := g.post(ctx, "/v1/payment_intents", form, "", &intent); err != nil {
+		return nil, err
+	}
+	return intent.toCharge(), nil
+}
+
+func (g *stripeGateway) Capture(ctx context.Context, chargeID string) (*payment.Charge, error) {
+	var intent stripePaymentIntent
+	if err := g.post(ctx, "/v1/payment_intents/"+url.PathEscape(chargeID)+"/capture", url.Values{}, "", &intent); err != nil {
+		return nil, err
+	}
+	return intent.toCharge(), nil
+}
+
+func (g *stripeGateway) Void(ctx context.Context, chargeID string, idempotencyKey string) (*payment.Charge, error) {
+	var intent stripePaymentIntent
+	if err := g.post(ctx, "/v1/payment_intents/"+url.PathEscape(chargeID)+"/cancel", url.Values{}, idempotencyKey, &intent); err != nil {
+		return nil, err
+	}
+	return intent.toCharge(), nil
+}
+
+func (g *stripeGateway) Refund(ctx context.Context, chargeID string, amount money.Money, idempotencyKey string) (*payment.Charge, error) {
+	form := url.Values{}
+	form.Set("payment_intent", chargeID)
+	form.Set("amount", strconv.FormatInt(amount.Amount, 10))
+
+	var refund stripeRefund
+	if err := g.post(ctx, "/v1/refunds", form, idempotencyKey, &refund); err != nil {
+		return nil, err
+	}
+	return &payment.Charge{
+		ID:             chargeID,
+		AmountRefunded: fromStripe(refund.Amount, refund.Currency),
+		Status:         payment.ChargeRefunded,
+	}, nil
+}
+
+// post sends a form to the API. A non-empty idempotencyKey is passed on so Stripe
+// replays the first response for a repeated request.
+func (g *stripeGateway) post(ctx context.Context, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.cfg.APIBase+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(g.cfg.SecretKey, "")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("payment gateway unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var se stripeError
+		_ = json.NewDecoder(resp.Body).Decode(&se)
+		if se.Error.Type == "card_error" {
+			return fmt.Errorf("%w: %s", payment.ErrPaymentDeclined, se.Error.Message)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return payment.ErrChargeNotFound
+		}
+		return fmt.Errorf("payment gateway error (%d): %s", resp.StatusCode, se.Error.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (pi *stripePaymentIntent) toCharge() *payment.Charge {
+	c := &payment.Charge{
+		ID:     pi.ID,
+		Amount: fromStripe(pi.Amount, pi.Currency),
+	}
+	switch pi.Status {
+	case "succeeded":
+		c.Status = payment.ChargeCaptured
+	case "canceled":
+		c.Status = payment.ChargeVoided
+	default:
+		// requires_capture and the intermediate states all mean funds are on hold
+		// or about to be.
+		c.Status = payment.ChargeAuthorized
+	}
+	return c
+}
@@ -0,0 +1,101 @@
+package paymentinfra
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStripeServer(t *testing.T, handler http.HandlerFunc) (payment.Gateway, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	gw := NewStripeGateway(StripeConfig{
+		APIBase:       server.URL,
+		SecretKey:     "sk_test_123",
+		PaymentMethod: "pm_card_visa",
+	}, server.Client())
+	return gw, server
+}
+
+func TestStripeGateway_Authorize(t *testing.T) {
+	gw, _ := newStripeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, "sk_test_123", user)
+		assert.Equal(t, "/v1/payment_intents", r.URL.Path)
+		assert.Equal(t, "1999", r.PostForm.Get("amount"))
+		assert.Equal(t, "usd", r.PostForm.Get("currency"))
+		assert.Equal(t, "manual", r.PostForm.Get("capture_method"))
+		assert.Equal(t, "true", r.PostForm.Get("confirm"))
+		assert.Equal(t, "bundle1", r.PostForm.Get("metadata[bundle_id]"))
+		w.Write([]byte(`{"id":"pi_123","amount":1999,"currency":"usd","status":"requires_capture"}`))
+	})
+
+	c, err := gw.Authorize(context.Background(), payment.AuthorizeRequest{
+		Amount:   money.New(1999, money.USD),
+		Metadata: map[string]string{"bundle_id": "bundle1"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "pi_123", c.ID)
+	assert.Equal(t, money.New(1999, money.USD), c.Amount)
+	assert.Equal(t, payment.ChargeAuthorized, c.Status)
+}
+
+func TestStripeGateway_CaptureAndVoid(t *testing.T) {
+	gw, _ := newStripeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/payment_intents/pi_123/capture":
+			w.Write([]byte(`{"id":"pi_123","amount":1000,"status":"succeeded"}`))
+		case "/v1/payment_intents/pi_456/cancel":
+			w.Write([]byte(`{"id":"pi_456","amount":1000,"status":"canceled"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"No such payment_intent"}}`))
+		}
+	})
+
+	c, err := gw.Capture(context.Background(), "pi_123")
+	require.NoError(t, err)
+	assert.Equal(t, payment.ChargeCaptured, c.Status)
+
+	c, err = gw.Void(context.Background(), "pi_456", "void_pay1")
+	require.NoError(t, err)
+	assert.Equal(t, payment.ChargeVoided, c.Status)
+
+	_, err = gw.Capture(context.Background(), "pi_missing")
+	assert.ErrorIs(t, err, payment.ErrChargeNotFound)
+}
+
+func TestStripeGateway_Refund(t *testing.T) {
+	gw, _ := newStripeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "/v1/refunds", r.URL.Path)
+		assert.Equal(t, "pi_123", r.PostForm.Get("payment_intent"))
+		assert.Equal(t, "500", r.PostForm.Get("amount"))
+		assert.Equal(t, "refund1", r.Header.Get("Idempotency-Key"))
+		w.Write([]byte(`{"id":"re_1","amount":500,"currency":"usd","payment_intent":"pi_123","status":"succeeded"}`))
+	})
+
+	c, err := gw.Refund(context.Background(), "pi_123", money.New(500, money.USD), "refund1")
+
+	require.NoError(t, err)
+	assert.Equal(t, money.New(500, money.USD), c.AmountRefunded)
+}
+
+func TestStripeGateway_CardDeclined(t *testing.T) {
+	gw, _ := newStripeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"error":{"type":"card_error","code":"card_declined","message":"Your card was declined."}}`))
+	})
+
+	_, err := gw.Authorize(context.Background(), payment.AuthorizeRequest{Amount: money.New(1000, money.USD)})
+
+	assert.ErrorIs(t, err, payment.ErrPaymentDeclined)
+}
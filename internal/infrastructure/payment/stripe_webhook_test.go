@@ -0,0 +1,83 @@
+package paymentinfra
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "whsec_test"
+
+func signedHeader(payload []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, SignWebhookPayload([]byte(testWebhookSecret), ts, payload))
+}
+
+func TestStripeWebhookVerifier_ValidEvent(t *testing.T) {
+	v := NewStripeWebhookVerifier(testWebhookSecret, DefaultWebhookTolerance)
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","created":1700000000,"data":{"object":{"id":"pi_1"}}}`)
+
+	e, err := v.Verify(payload, signedHeader(payload, time.Now()))
+
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", e.ID)
+	assert.Equal(t, "pi_1", e.ChargeID)
+	assert.Equal(t, payment.StatusCaptured, e.Status)
+}
+
+func TestStripeWebhookVerifier_Refund(t *testing.T) {
+	v := NewStripeWebhookVerifier(testWebhookSecret, DefaultWebhookTolerance)
+	full := []byte(`{"id":"evt_2","type":"charge.refunded","data":{"object":{"id":"ch_1","payment_intent":"pi_1","refunded":true}}}`)
+	partial := []byte(`{"id":"evt_3","type":"charge.refunded","data":{"object":{"id":"ch_1","payment_intent":"pi_1","refunded":false}}}`)
+
+	e, err := v.Verify(full, signedHeader(full, time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, "pi_1", e.ChargeID)
+	assert.Equal(t, payment.StatusRefunded, e.Status)
+
+	e, err = v.Verify(partial, signedHeader(partial, time.Now()))
+	require.NoError(t, err)
+	assert.Empty(t, e.Status)
+}
+
+func TestStripeWebhookVerifier_TamperedPayload(t *testing.T) {
+	v := NewStripeWebhookVerifier(testWebhookSecret, DefaultWebhookTolerance)
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","data":{"object":{"id":"pi_1"}}}`)
+	header := signedHeader(payload, time.Now())
+
+	_, err := v.Verify([]byte(`{"id":"evt_1","type":"charge.refunded","data":{"object":{"id":"pi_1"}}}`), header)
+
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+}
+
+func TestStripeWebhookVerifier_StaleTimestamp(t *testing.T) {
+	v := NewStripeWebhookVerifier(testWebhookSecret, DefaultWebhookTolerance)
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","data":{"object":{"id":"pi_1"}}}`)
+
+	_, err := v.Verify(payload, signedHeader(payload, time.Now().Add(-time.Hour)))
+
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+}
+
+func TestStripeWebhookVerifier_MissingHeader(t *testing.T) {
+	v := NewStripeWebhookVerifier(testWebhookSecret, DefaultWebhookTolerance)
+
+	_, err := v.Verify([]byte(`{}`), "")
+
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+}
+
+func TestStripeWebhookVerifier_NoSecretConfigured(t *testing.T) {
+	v := NewStripeWebhookVerifier("", DefaultWebhookTolerance)
+	payload := []byte(`{"id":"evt_1"}`)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	_, err := v.Verify(payload, "t="+ts+",v1="+SignWebhookPayload(nil, ts, payload))
+
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+}
@@ -0,0 +1,52 @@
+package pdfinfra
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_CrossReferenceTable(t *testing.T) {
+	doc := NewDocument(A4Width, A4Height)
+	doc.AddPage().Text(50, 800, Helvetica, 12, "first page")
+	doc.AddPage().Text(50, 800, HelveticaBold, 12, "second page")
+	out := doc.Bytes()
+
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	// startxref must point at the table, and every entry at its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 9\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 8)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		assert.True(t, bytes.HasPrefix(out[off:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+	assert.Contains(t, string(out), "/Count 2")
+}
+
+func TestDocument_EscapesText(t *testing.T) {
+	doc := NewDocument(A4Width, A4Height)
+	doc.AddPage().Text(0, 0, Helvetica, 10, `Levi's (501) \ Café 日本`)
+
+	assert.Contains(t, string(doc.Bytes()), "(Levi's \\(501\\) \\\\ Caf\xe9 ??) Tj")
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate(10, 100, "short"))
+
+	long := "A very long product title that will not fit in the description column"
+	cut := Truncate(10, 100, long)
+	assert.LessOrEqual(t, TextWidth(10, cut), 100.0)
+	assert.Regexp(t, `^A very long.*\.\.\.$`, cut)
+}
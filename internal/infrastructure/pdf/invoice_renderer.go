@@ -0,0 +1,121 @@
+package pdfinfra
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+)
+
+const (
+	margin    = 50.0
+	bodySize  = 10.0
+	lineGap   = 14.0
+	footerY   = 40.0
+	lastRowY  = 110.0
+	qtyX      = 360.0
+	unitX     = 455.0
+	amountX   = A4Width - margin
+	descWidth = 270.0
+)
+
+type invoiceRenderer struct {
+	issuer string
+}
+
+// NewInvoiceRenderer renders invoices as A4 PDFs. The issuer is printed as the
+// marketplace the sale went through.
+func NewInvoiceRenderer(issuer string) invoice.Renderer {
+	return &invoiceRenderer{issuer: issuer}
+}
+
+func (r *invoiceRenderer) Render(inv *invoice.Invoice) ([]byte, error) {
+	doc := NewDocument(A4Width, A4Height)
+	page := doc.AddPage()
+	y := A4Height - margin
+
+	page.Text(margin, y, HelveticaBold, 20, "INVOICE")
+	page.TextRight(amountX, y, bodySize, r.issuer)
+	y -= 2 * lineGap
+
+	details := [][2]string{
+		{"Invoice number", inv.Number},
+		{"Issued", inv.IssuedAt},
+		{"Order", inv.OrderID},
+		{"Order date", inv.OrderDate},
+	}
+	if inv.Revision > 0 {
+		details = append(details, [2]string{"Revision", fmt.Sprintf("%d, regenerated %s", inv.Revision, inv.RegeneratedAt)})
+	}
+	for _, d := range details {
+		page.Text(margin, y, HelveticaBold, bodySize, d[0])
+		page.Text(margin+100, y, Helvetica, bodySize, d[1])
+		y -= lineGap
+	}
+	y -= lineGap
+
+	r.party(page, margin, y, "Seller", inv.Seller)
+	r.party(page, A4Width/2, y, "Bill to", inv.Buyer)
+	y -= 5 * lineGap
+
+	y = r.tableHeader(page, y)
+	for _, l := range inv.Lines {
+		if y < lastRowY {
+			page = doc.AddPage()
+			y = r.tableHeader(page, A4Height-margin)
+		}
+		page.Text(margin, y, Helvetica, bodySize, Truncate(bodySize, descWidth, l.Description))
+		page.TextRight(qtyX, y, bodySize, strconv.Itoa(l.Quantity))
+		page.TextRight(unitX, y, bodySize, l.UnitPrice.String())
+		page.TextRight(amountX, y, bodySize, l.Amount.String())
+		y -= lineGap
+	}
+	page.Line(margin, y+lineGap-4, amountX, y+lineGap-4)
+	y -= lineGap / 2
+
+	totals := [][2]string{{"Total", inv.Total.String()}}
+	if inv.ExchangeRate != nil {
+		totals = append(totals,
+			[2]string{"Charged", inv.Charged.String()},
+			[2]string{"Exchange rate", fmt.Sprintf("1 %s = %s %s (as of %s)",
+				inv.ExchangeRate.From, strconv.FormatFloat(inv.ExchangeRate.Rate, 'f', -1, 64), inv.ExchangeRate.To, inv.ExchangeRate.AsOf)},
+		)
+	}
+	totals = append(totals,
+		[2]string{"Platform fee (paid by seller)", inv.PlatformFee.String()},
+		[2]string{"Net payable to seller", inv.NetPayable.String()},
+	)
+	if y-float64(len(totals))*lineGap < footerY+lineGap {
+		page = doc.AddPage()
+		y = A4Height - margin
+	}
+	for _, t := range totals {
+		page.Text(margin, y, HelveticaBold, bodySize, t[0])
+		page.TextRight(amountX, y, bodySize, t[1])
+		y -= lineGap
+	}
+
+	pages := doc.Pages()
+	for i, p := range pages {
+		p.Text(margin, footerY, Helvetica, 8, inv.Number)
+		p.TextRight(amountX, footerY, 8, fmt.Sprintf("Page %d of %d", i+1, len(pages)))
+	}
+	return doc.Bytes(), nil
+}
+
+func (r *invoiceRenderer) party(page *Page, x, y float64, heading string, p invoice.Party) {
+	page.Text(x, y, HelveticaBold, bodySize, heading)
+	for _, line := range []string{p.Name, p.Email, "ID " + p.ID} {
+		y -= lineGap
+		page.Text(x, y, Helvetica, bodySize, Truncate(bodySize, A4Width/2-margin, line))
+	}
+}
+
+func (r *invoiceRenderer) tableHeader(page *Page, y float64) float64 {
+	page.Text(margin, y, Helvetica, bodySize, "Description")
+	page.TextRight(qtyX, y, bodySize, "Qty")
+	page.TextRight(unitX, y, bodySize, "Unit price")
+	page.TextRight(amountX, y, bodySize, "Amount")
+	page.Line(margin, y-4, amountX, y-4)
+	return y - lineGap - 4
+}
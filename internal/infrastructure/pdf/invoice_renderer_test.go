@@ -0,0 +1,70 @@
+package pdfinfra
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInvoice(lines int) *invoice.Invoice {
+	inv := &invoice.Invoice{
+		ID:          "order1",
+		OrderID:     "order1",
+		Number:      "INV-ABC123-000007",
+		Seller:      invoice.Party{ID: "seller1", Name: "Addis Vintage", Email: "seller@example.com"},
+		Buyer:       invoice.Party{ID: "buyer1", Name: "Sara", Email: "sara@example.com"},
+		Total:       money.New(int64(lines)*2500, money.ETB),
+		PlatformFee: money.New(int64(lines)*50, money.ETB),
+		NetPayable:  money.New(int64(lines)*2450, money.ETB),
+		IssuedAt:    "2025-03-01T10:00:00Z",
+		OrderDate:   "2025-02-28T09:00:00Z",
+	}
+	for i := 0; i < lines; i++ {
+		inv.Lines = append(inv.Lines, invoice.Line{
+			Description: fmt.Sprintf("Denim jacket %d (M, A)", i+1),
+			Quantity:    1,
+			UnitPrice:   money.New(2500, money.ETB),
+			Amount:      money.New(2500, money.ETB),
+		})
+	}
+	return inv
+}
+
+func TestInvoiceRenderer_Render(t *testing.T) {
+	out, err := NewInvoiceRenderer("Afro Vintage").Render(testInvoice(2))
+	require.NoError(t, err)
+
+	pdf := string(out)
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-"))
+	for _, want := range []string{"INV-ABC123-000007", "Addis Vintage", "sara@example.com", "Denim jacket 2 \\(M, A\\)", "1.00 ETB", "49.00 ETB", "Page 1 of 1"} {
+		assert.Contains(t, pdf, want)
+	}
+	assert.NotContains(t, pdf, "Exchange rate")
+}
+
+func TestInvoiceRenderer_ShowsConversion(t *testing.T) {
+	inv := testInvoice(1)
+	inv.Charged = money.New(50, money.USD)
+	inv.ExchangeRate = &money.RateSnapshot{From: money.ETB, To: money.USD, Rate: 0.02, AsOf: "2025-02-01T00:00:00Z"}
+
+	out, err := NewInvoiceRenderer("Afro Vintage").Render(inv)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(out), "0.50 USD")
+	assert.Contains(t, string(out), "1 ETB = 0.02 USD")
+}
+
+func TestInvoiceRenderer_ContinuesOnNewPages(t *testing.T) {
+	out, err := NewInvoiceRenderer("Afro Vintage").Render(testInvoice(100))
+	require.NoError(t, err)
+
+	pdf := string(out)
+	assert.Contains(t, pdf, "/Count 3")
+	assert.Contains(t, pdf, "Page 3 of 3")
+	assert.Contains(t, pdf, "Denim jacket 100")
+}
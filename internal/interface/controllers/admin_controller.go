@@ -3,7 +3,9 @@ package controllers
 import (
 	"net/http"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/gin-gonic/gin"
 )
@@ -11,48 +13,34 @@ import (
 type AdminController struct {
 	userUC  user.Usecase
 	orderUC order.Usecase
+	adminUC admin.Usecase
 }
 
-func NewAdminController(userUC user.Usecase, orderUC order.Usecase) *AdminController {
-	return &AdminController{userUC: userUC, orderUC: orderUC}
+func NewAdminController(userUC user.Usecase, orderUC order.Usecase, adminUC admin.Usecase) *AdminController {
+	return &AdminController{userUC: userUC, orderUC: orderUC, adminUC: adminUC}
 }
 
 // GET /api/admin/users
 func (a *AdminController) GetAllUsers(c *gin.Context) {
-	roleParam := c.Query("role")
-
-	// If no role is passed, return all users by calling ListByRole for each role
-	if roleParam == "" {
-		allRoles := []user.Role{
-			user.RoleSupplier,
-			user.RoleReseller,
-			user.RoleConsumer,
-			user.RoleAdmin,
-		}
-
-		var allUsers []*user.User
-		for _, role := range allRoles {
-			usersByRole, err := a.userUC.ListByRole(c.Request.Context(), role)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users by role"})
-				return
-			}
-			allUsers = append(allUsers, usersByRole...)
-		}
-
-		c.JSON(http.StatusOK, allUsers)
+	req, err := pageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Otherwise, get users by specified role
-	role := user.Role(roleParam)
-	users, err := a.userUC.ListByRole(c.Request.Context(), role)
+	// Without a role, users of every role are listed
+	var roles []user.Role
+	if roleParam := c.Query("role"); roleParam != "" {
+		roles = append(roles, user.Role(roleParam))
+	}
+
+	page, err := a.userUC.ListByRole(c.Request.Context(), roles, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		c.JSON(listErrorStatus(err), gin.H{"error": "Failed to fetch users"})
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	c.JSON(http.StatusOK, page)
 }
 func (a *AdminController) DeleteUserIfBlacklisted(c *gin.Context) {
 	userID := c.Param("userId")
@@ -84,53 +72,55 @@ func (a *AdminController) DeleteUserIfBlacklisted(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "User successfully deactivated"})
 }
 func (a *AdminController) GetTrustScores(c *gin.Context) {
-	roleParam := c.Query("role")
+	req, err := pageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	var roles []user.Role
-	if roleParam != "" {
-		roles = append(roles, user.Role(roleParam))
-	} else {
-		roles = []user.Role{user.RoleSupplier, user.RoleReseller}
+	roles := []user.Role{user.RoleSupplier, user.RoleReseller}
+	if roleParam := c.Query("role"); roleParam != "" {
+		roles = []user.Role{user.Role(roleParam)}
 	}
 
-	var result []gin.H
+	page, err := a.userUC.ListByRole(c.Request.Context(), roles, req)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{"error": "Failed to fetch users"})
+		return
+	}
 
-	for _, r := range roles {
-		users, err := a.userUC.ListByRole(c.Request.Context(), r)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
-			return
+	c.JSON(http.StatusOK, pagination.Map(page, func(u *user.User) gin.H {
+		status := "active"
+		if u.TrustScore < 60 {
+			status = "blacklisted"
 		}
-
-		for _, u := range users {
-			status := "active"
-			if u.TrustScore < 60 {
-				status = "blacklisted"
-			}
-			result = append(result, gin.H{
-				"userId":     u.ID,
-				"name":       u.Name,
-				"role":       u.Role,
-				"trustScore": u.TrustScore,
-				"status":     status,
-			})
+		return gin.H{
+			"userId":     u.ID,
+			"name":       u.Name,
+			"role":       u.Role,
+			"trustScore": u.TrustScore,
+			"status":     status,
 		}
-	}
-
-	c.JSON(http.StatusOK, result)
+	}))
 }
 
 // GET /api/admin/blacklisted-users
 func (a *AdminController) GetBlacklistedUsers(c *gin.Context) {
-	users, err := a.userUC.GetBlacklistedUsers(c.Request.Context())
+	req, err := pageRequest(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch blacklisted users"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, users)
+
+	page, err := a.userUC.GetBlacklistedUsers(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{"error": "failed to fetch blacklisted users"})
+		return
+	}
+	c.JSON(http.StatusOK, page)
 }
 func (a *AdminController) GetDashboardMetrics(c *gin.Context) {
-	metrics, err := a.orderUC.GetAdminDashboardMetrics(c.Request.Context())
+	metrics, err := a.adminUC.GetDashboardMetrics(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -146,3 +136,22 @@ func (a *AdminController) GetDashboardMetrics(c *gin.Context) {
 		"data":    metrics,
 	})
 }
+
+// POST /admin/orders/:id/cancel
+func (a *AdminController) CancelOrder(c *gin.Context) {
+	o, err := a.orderUC.ForceCancelOrder(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(orderErrorStatus(err), gin.H{
+			"success": false,
+			"message": "Failed to cancel order",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Order canceled and refunded",
+		"data":    o,
+	})
+}
@@ -5,10 +5,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/gin-gonic/gin"
@@ -23,9 +28,12 @@ type MockUserUsecase struct {
 	mock.Mock
 }
 
-func (m *MockUserUsecase) ListByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
-	args := m.Called(ctx, role)
-	return args.Get(0).([]*user.User), args.Error(1)
+func (m *MockUserUsecase) ListByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, roles, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
 }
 
 func (m *MockUserUsecase) GetByID(ctx context.Context, id string) (*user.User, error) {
@@ -38,9 +46,12 @@ func (m *MockUserUsecase) Update(ctx context.Context, id string, updates map[str
 	return args.Error(0)
 }
 
-func (m *MockUserUsecase) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*user.User), args.Error(1)
+func (m *MockUserUsecase) GetBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
 }
 
 func (m *MockUserUsecase) Delete(ctx context.Context, id string) error {
@@ -60,8 +71,8 @@ type AdminMockOrderUsecase struct {
 	mock.Mock
 }
 
-func (m *AdminMockOrderUsecase) PurchaseBundle(ctx context.Context, bundleID, resellerID string) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
-	args := m.Called(ctx, bundleID, resellerID)
+func (m *AdminMockOrderUsecase) PurchaseBundle(ctx context.Context, bundleID, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID, resellerID, currency, delivery)
 	return nil, nil, nil, args.Error(3)
 }
 
@@ -75,20 +86,20 @@ func (m *AdminMockOrderUsecase) GetOrderByID(ctx context.Context, orderID string
 	return nil, args.Error(1)
 }
 
-func (m *AdminMockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, map[string]string, error) {
-	args := m.Called(ctx, supplierID)
+func (m *AdminMockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Error(2)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
 }
 
-func (m *AdminMockOrderUsecase) GetAdminDashboardMetrics(ctx context.Context) (*admin.Metrics, error) {
-	args := m.Called(ctx)
+func (m *AdminMockOrderUsecase) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*admin.Metrics), args.Error(1)
+	return args.Get(0).(*order.Order), args.Error(1)
 }
 func (m *AdminMockOrderUsecase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
 	args := m.Called(ctx, resellerID)
@@ -100,18 +111,66 @@ func (m *AdminMockOrderUsecase) GetResellerMetrics(ctx context.Context, reseller
 
 // -------------------- Test Suite --------------------
 
+// -------------------- Mock Admin Usecase --------------------
+
+type MockAdminUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAdminUsecase) GetDashboardMetrics(ctx context.Context) (*admin.Metrics, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Metrics), args.Error(1)
+}
+
+func (m *MockAdminUsecase) GetRevenueReport(ctx context.Context, q *analytics.SeriesQuery) (*admin.RevenueReport, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.RevenueReport), args.Error(1)
+}
+
+func (m *MockAdminUsecase) GetUserReport(ctx context.Context, q *analytics.SeriesQuery) (*admin.UserReport, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.UserReport), args.Error(1)
+}
+
+func (m *MockAdminUsecase) GetSkipReport(ctx context.Context, q *analytics.SeriesQuery) (*admin.SkipReport, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.SkipReport), args.Error(1)
+}
+
+func (m *MockAdminUsecase) GetTopSellers(ctx context.Context, q *analytics.SeriesQuery, limit int) (*admin.TopSellersReport, error) {
+	args := m.Called(ctx, q, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.TopSellersReport), args.Error(1)
+}
+
 type AdminControllerTestSuite struct {
 	suite.Suite
 	controller  *AdminController
 	mockUC      *MockUserUsecase
 	mockOrderUC *AdminMockOrderUsecase
+	mockAdminUC *MockAdminUsecase
 	router      *gin.Engine
 }
 
 func (suite *AdminControllerTestSuite) SetupTest() {
 	suite.mockUC = new(MockUserUsecase)
 	suite.mockOrderUC = new(AdminMockOrderUsecase)
-	suite.controller = NewAdminController(suite.mockUC, suite.mockOrderUC)
+	suite.mockAdminUC = new(MockAdminUsecase)
+	suite.controller = NewAdminController(suite.mockUC, suite.mockOrderUC, suite.mockAdminUC)
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.Default()
 }
@@ -122,10 +181,8 @@ func (suite *AdminControllerTestSuite) TestGetAllUsers_NoRoleParam() {
 		{ID: "2", Name: "User2", Role: string(user.RoleConsumer)},
 	}
 
-	suite.mockUC.On("ListByRole", mock.Anything, user.RoleSupplier).Return(users[:1], nil)
-	suite.mockUC.On("ListByRole", mock.Anything, user.RoleReseller).Return([]*user.User{}, nil)
-	suite.mockUC.On("ListByRole", mock.Anything, user.RoleConsumer).Return(users[1:], nil)
-	suite.mockUC.On("ListByRole", mock.Anything, user.RoleAdmin).Return([]*user.User{}, nil)
+	suite.mockUC.On("ListByRole", mock.Anything, []user.Role(nil), pagination.Request{Limit: pagination.DefaultLimit}).
+		Return(&pagination.Page[*user.User]{Items: users, Total: 2}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/api/admin/users", nil)
@@ -140,14 +197,16 @@ func (suite *AdminControllerTestSuite) TestGetAllUsers_WithRoleParam() {
 	users := []*user.User{
 		{ID: "1", Name: "User1", Role: string(user.RoleSupplier)},
 	}
-	suite.mockUC.On("ListByRole", mock.Anything, user.RoleSupplier).Return(users, nil)
+	suite.mockUC.On("ListByRole", mock.Anything, []user.Role{user.RoleSupplier}, pagination.Request{Cursor: "abc", Limit: 5}).
+		Return(&pagination.Page[*user.User]{Items: users, NextCursor: "def", Total: 6}, nil)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/admin/users?role=supplier", nil)
+	req, _ := http.NewRequest("GET", "/api/admin/users?role=supplier&cursor=abc&limit=5", nil)
 	suite.router.GET("/api/admin/users", suite.controller.GetAllUsers)
 	suite.router.ServeHTTP(w, req)
 
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"next_cursor":"def"`)
 	suite.mockUC.AssertExpectations(suite.T())
 }
 
@@ -193,8 +252,8 @@ func (suite *AdminControllerTestSuite) TestGetTrustScores() {
 		{ID: "1", Name: "User1", Role: string(user.RoleSupplier), TrustScore: 70},
 		{ID: "2", Name: "User2", Role: string(user.RoleReseller), TrustScore: 40},
 	}
-	suite.mockUC.On("ListByRole", mock.Anything, user.RoleSupplier).Return(users[:1], nil)
-	suite.mockUC.On("ListByRole", mock.Anything, user.RoleReseller).Return(users[1:], nil)
+	suite.mockUC.On("ListByRole", mock.Anything, []user.Role{user.RoleSupplier, user.RoleReseller}, mock.Anything).
+		Return(&pagination.Page[*user.User]{Items: users, Total: 2}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/api/admin/users/trust-scores", nil)
@@ -202,13 +261,14 @@ func (suite *AdminControllerTestSuite) TestGetTrustScores() {
 	suite.router.ServeHTTP(w, req)
 
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"blacklisted"`)
 }
 
 func (suite *AdminControllerTestSuite) TestGetBlacklistedUsers() {
 	users := []*user.User{
 		{ID: "1", Name: "User1", Role: string(user.RoleSupplier), TrustScore: 40, IsBlacklisted: true},
 	}
-	suite.mockUC.On("GetBlacklistedUsers", mock.Anything).Return(users, nil)
+	suite.mockUC.On("GetBlacklistedUsers", mock.Anything, mock.Anything).Return(&pagination.Page[*user.User]{Items: users, Total: 1}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/api/admin/blacklisted-users", nil)
@@ -222,21 +282,130 @@ func (suite *AdminControllerTestSuite) TestGetDashboardMetrics() {
 	metrics := &admin.Metrics{
 		TotalBundles:    10,
 		TotalUsers:      20,
-		TotalSales:      1500,
-		RevenueFromFees: 30,
+		TotalSales:      []money.Money{money.New(150000, money.ETB)},
+		RevenueFromFees: []money.Money{money.New(3000, money.ETB)},
 		SkippedClothes:  0,
 	}
-	suite.mockOrderUC.On("GetAdminDashboardMetrics", mock.Anything).Return(metrics, nil)
+	suite.mockAdminUC.On("GetDashboardMetrics", mock.Anything).Return(metrics, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/api/admin/dashboard", nil)
 	suite.router.GET("/api/admin/dashboard", suite.controller.GetDashboardMetrics)
 	suite.router.ServeHTTP(w, req)
 
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.mockAdminUC.AssertExpectations(suite.T())
+}
+
+func (suite *AdminControllerTestSuite) TestCancelOrder() {
+	canceled := &order.Order{ID: "order1", Status: order.OrderStatusCanceled}
+	suite.mockOrderUC.On("ForceCancelOrder", mock.Anything, "order1").Return(canceled, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/admin/orders/order1/cancel", nil)
+	suite.router.POST("/api/admin/orders/:id/cancel", suite.controller.CancelOrder)
+	suite.router.ServeHTTP(w, req)
+
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
 	suite.mockOrderUC.AssertExpectations(suite.T())
 }
 
+func (suite *AdminControllerTestSuite) TestCancelOrder_NotFound() {
+	suite.mockOrderUC.On("ForceCancelOrder", mock.Anything, "missing").Return(nil, order.ErrOrderNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/admin/orders/missing/cancel", nil)
+	suite.router.POST("/api/admin/orders/:id/cancel", suite.controller.CancelOrder)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
 func TestAdminControllerSuite(t *testing.T) {
 	suite.Run(t, new(AdminControllerTestSuite))
 }
+
+func (suite *AdminControllerTestSuite) revenueReport() *admin.RevenueReport {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	return &admin.RevenueReport{
+		ReportRange: admin.ReportRange{Interval: analytics.Month, From: start, To: start.AddDate(0, 1, 0), Currency: money.ETB},
+		Rows: []*admin.RevenueRow{
+			{Start: start, Type: payment.B2B, Payments: 2, GMV: money.New(1200000, money.ETB), FeeRevenue: money.New(60000, money.ETB)},
+			{Start: start, Type: payment.B2C, Payments: 5, GMV: money.New(250000, money.ETB), FeeRevenue: money.New(12500, money.ETB)},
+		},
+		GMV:        money.New(1450000, money.ETB),
+		FeeRevenue: money.New(72500, money.ETB),
+	}
+}
+
+func (suite *AdminControllerTestSuite) TestGetRevenueReport() {
+	suite.mockAdminUC.On("GetRevenueReport", mock.Anything, mock.MatchedBy(func(q *analytics.SeriesQuery) bool {
+		return q.Interval == analytics.Month && q.From.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	})).Return(suite.revenueReport(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/reports/revenue?from=2026-10-01&interval=month", nil)
+	suite.router.GET("/admin/reports/revenue", suite.controller.GetRevenueReport)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"interval":"month"`)
+	assert.Contains(suite.T(), w.Body.String(), `"fee_revenue":{"amount":72500,"currency":"ETB"}`)
+	suite.mockAdminUC.AssertExpectations(suite.T())
+}
+
+func (suite *AdminControllerTestSuite) TestGetRevenueReport_CSV() {
+	suite.mockAdminUC.On("GetRevenueReport", mock.Anything, mock.Anything).Return(suite.revenueReport(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/reports/revenue?interval=month&format=csv", nil)
+	suite.router.GET("/admin/reports/revenue", suite.controller.GetRevenueReport)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `attachment; filename="revenue-report.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(suite.T(), "period_start,type,payments,gmv,fee_revenue,currency\n"+
+		"2026-10-01,b2b,2,12000.00,600.00,ETB\n"+
+		"2026-10-01,b2c,5,2500.00,125.00,ETB\n", w.Body.String())
+}
+
+func (suite *AdminControllerTestSuite) TestGetSkipReport_InvalidInterval() {
+	suite.mockAdminUC.On("GetSkipReport", mock.Anything, mock.Anything).Return(nil, analytics.ErrInvalidInterval)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/reports/skips?interval=hour", nil)
+	suite.router.GET("/admin/reports/skips", suite.controller.GetSkipReport)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *AdminControllerTestSuite) TestGetTopSellers_CSV() {
+	report := &admin.TopSellersReport{
+		ReportRange: admin.ReportRange{Currency: money.ETB},
+		Suppliers:   []*admin.TopSeller{{UserID: "s1", Username: "bales", Sales: 3, GMV: money.New(900000, money.ETB), FeeRevenue: money.New(45000, money.ETB)}},
+		Resellers:   []*admin.TopSeller{{UserID: "r1", Username: "thrift", Sales: 9, GMV: money.New(80000, money.ETB), FeeRevenue: money.New(4000, money.ETB)}},
+	}
+	suite.mockAdminUC.On("GetTopSellers", mock.Anything, mock.Anything, 5).Return(report, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/reports/top-sellers?limit=5&format=csv", nil)
+	suite.router.GET("/admin/reports/top-sellers", suite.controller.GetTopSellers)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "role,rank,user_id,username,sales,gmv,fee_revenue,currency\n"+
+		"supplier,1,s1,bales,3,9000.00,450.00,ETB\n"+
+		"reseller,1,r1,thrift,9,800.00,40.00,ETB\n", w.Body.String())
+}
+
+func (suite *AdminControllerTestSuite) TestGetTopSellers_InvalidLimit() {
+	suite.mockAdminUC.On("GetTopSellers", mock.Anything, mock.Anything, 500).Return(nil, admin.ErrInvalidLimit)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/reports/top-sellers?limit=500", nil)
+	suite.router.GET("/admin/reports/top-sellers", suite.controller.GetTopSellers)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/gin-gonic/gin"
+)
+
+func reportErrorStatus(err error) int {
+	if errors.Is(err, admin.ErrInvalidLimit) {
+		return http.StatusBadRequest
+	}
+	return analyticsErrorStatus(err)
+}
+
+// sendReport writes report as JSON, or as CSV rows when ?format=csv is given.
+func sendReport(c *gin.Context, name string, report interface{}, rows func() [][]string) {
+	if wantsCSV(c) {
+		sendCSV(c, fmt.Sprintf("%s-report.csv", name), rows())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Report generated successfully",
+		"data":    report,
+	})
+}
+
+func reportFailed(c *gin.Context, status int, err error) {
+	c.JSON(status, gin.H{
+		"success": false,
+		"message": "Failed to generate report",
+		"error":   err.Error(),
+	})
+}
+
+// GET /admin/reports/revenue
+func (a *AdminController) GetRevenueReport(c *gin.Context) {
+	q, err := seriesQuery(c)
+	if err != nil {
+		reportFailed(c, http.StatusBadRequest, err)
+		return
+	}
+	report, err := a.adminUC.GetRevenueReport(c.Request.Context(), q)
+	if err != nil {
+		reportFailed(c, reportErrorStatus(err), err)
+		return
+	}
+
+	sendReport(c, "revenue", report, func() [][]string {
+		rows := [][]string{{"period_start", "type", "payments", "gmv", "fee_revenue", "currency"}}
+		for _, r := range report.Rows {
+			rows = append(rows, []string{
+				r.Start.Format(time.DateOnly), string(r.Type), strconv.Itoa(r.Payments),
+				r.GMV.Decimal(), r.FeeRevenue.Decimal(), string(report.Currency),
+			})
+		}
+		return rows
+	})
+}
+
+// GET /admin/reports/users
+func (a *AdminController) GetUserReport(c *gin.Context) {
+	q, err := seriesQuery(c)
+	if err != nil {
+		reportFailed(c, http.StatusBadRequest, err)
+		return
+	}
+	report, err := a.adminUC.GetUserReport(c.Request.Context(), q)
+	if err != nil {
+		reportFailed(c, reportErrorStatus(err), err)
+		return
+	}
+
+	// The CSV lists sign-ups only; blacklist rates are a snapshot, not a series.
+	sendReport(c, "users", report, func() [][]string {
+		rows := [][]string{{"period_start", "role", "new_users"}}
+		for _, r := range report.Rows {
+			rows = append(rows, []string{r.Start.Format(time.DateOnly), r.Role, strconv.Itoa(r.NewUsers)})
+		}
+		return rows
+	})
+}
+
+// GET /admin/reports/skips
+func (a *AdminController) GetSkipReport(c *gin.Context) {
+	q, err := seriesQuery(c)
+	if err != nil {
+		reportFailed(c, http.StatusBadRequest, err)
+		return
+	}
+	report, err := a.adminUC.GetSkipReport(c.Request.Context(), q)
+	if err != nil {
+		reportFailed(c, reportErrorStatus(err), err)
+		return
+	}
+
+	sendReport(c, "skips", report, func() [][]string {
+		rows := [][]string{{"period_start", "unpacked", "skipped", "skipped_percent"}}
+		for _, r := range report.Rows {
+			rows = append(rows, []string{
+				r.Start.Format(time.DateOnly), strconv.Itoa(r.Unpacked), strconv.Itoa(r.Skipped),
+				strconv.FormatFloat(r.Ratio, 'f', 2, 64),
+			})
+		}
+		return rows
+	})
+}
+
+// GET /admin/reports/top-sellers?limit=
+func (a *AdminController) GetTopSellers(c *gin.Context) {
+	q, err := seriesQuery(c)
+	if err != nil {
+		reportFailed(c, http.StatusBadRequest, err)
+		return
+	}
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		reportFailed(c, http.StatusBadRequest, err)
+		return
+	}
+	report, err := a.adminUC.GetTopSellers(c.Request.Context(), q, limit)
+	if err != nil {
+		reportFailed(c, reportErrorStatus(err), err)
+		return
+	}
+
+	sendReport(c, "top-sellers", report, func() [][]string {
+		rows := [][]string{{"role", "rank", "user_id", "username", "sales", "gmv", "fee_revenue", "currency"}}
+		rankings := []struct {
+			role    string
+			sellers []*admin.TopSeller
+		}{{"supplier", report.Suppliers}, {"reseller", report.Resellers}}
+		for _, ranking := range rankings {
+			for i, s := range ranking.sellers {
+				rows = append(rows, []string{
+					ranking.role, strconv.Itoa(i + 1), s.UserID, s.Username,
+					strconv.Itoa(s.Sales), s.GMV.Decimal(), s.FeeRevenue.Decimal(), string(report.Currency),
+				})
+			}
+		}
+		return rows
+	})
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsController struct {
+	analyticsUsecase analytics.Usecase
+}
+
+func NewAnalyticsController(analyticsUsecase analytics.Usecase) *AnalyticsController {
+	return &AnalyticsController{analyticsUsecase: analyticsUsecase}
+}
+
+func analyticsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, analytics.ErrInvalidInterval), errors.Is(err, analytics.ErrInvalidRange),
+		errors.Is(err, analytics.ErrRangeTooLong), errors.Is(err, money.ErrUnknownCurrency):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// seriesQuery reads the ?from=&to=&interval=&currency= parameters of a time
+// series. Missing values are left for the usecase to default.
+func seriesQuery(c *gin.Context) (*analytics.SeriesQuery, error) {
+	from, err := queryTime(c, "from", false)
+	if err != nil {
+		return nil, err
+	}
+	to, err := queryTime(c, "to", true)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := queryCurrency(c)
+	if err != nil {
+		return nil, err
+	}
+	return &analytics.SeriesQuery{
+		From:     from,
+		To:       to,
+		Interval: analytics.Interval(c.Query("interval")),
+		Currency: currency,
+	}, nil
+}
+
+// wantsCSV reports whether the caller asked for ?format=csv instead of JSON.
+func wantsCSV(c *gin.Context) bool {
+	return c.Query("format") == "csv"
+}
+
+func sendCSV(c *gin.Context, filename string, rows [][]string) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(rows); err != nil {
+		c.JSON(http.StatusInternalServerError, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+func supplierSeriesCSV(series *analytics.SupplierSeries) [][]string {
+	rows := [][]string{{
+		"period_start", "listings_created", "bundles_sold", "revenue", "avg_sale_price",
+		"currency", "avg_days_to_sale", "trust_score",
+	}}
+	for _, p := range series.Periods {
+		days, trust := "", ""
+		if p.AvgDaysToSale != nil {
+			days = strconv.FormatFloat(*p.AvgDaysToSale, 'f', 1, 64)
+		}
+		if p.TrustScore != nil {
+			trust = strconv.Itoa(*p.TrustScore)
+		}
+		rows = append(rows, []string{
+			p.Start.Format(time.DateOnly),
+			strconv.Itoa(p.ListingsCreated),
+			strconv.Itoa(p.BundlesSold),
+			p.Revenue.Decimal(),
+			p.AvgSalePrice.Decimal(),
+			string(series.Currency),
+			days,
+			trust,
+		})
+	}
+	return rows
+}
+
+// GetResellerROI handles GET /analytics/reseller/bundles
+func (c *AnalyticsController) GetResellerROI(ctx *gin.Context) {
+	resellerID := ctx.GetString("userID")
+	if resellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+	currency, err := queryCurrency(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	report, err := c.analyticsUsecase.GetResellerROI(ctx.Request.Context(), resellerID, currency)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Bundle analytics retrieved successfully",
+		Data:    report,
+	})
+}
+
+// GetSupplierSeries handles GET /analytics/supplier/sales
+func (c *AnalyticsController) GetSupplierSeries(ctx *gin.Context) {
+	supplierID := ctx.GetString("userID")
+	if supplierID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+	q, err := seriesQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	series, err := c.analyticsUsecase.GetSupplierSeries(ctx.Request.Context(), supplierID, q)
+	if err != nil {
+		ctx.JSON(analyticsErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if wantsCSV(ctx) {
+		sendCSV(ctx, fmt.Sprintf("supplier-sales-%s.csv", series.Interval), supplierSeriesCSV(series))
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Sales series retrieved successfully",
+		Data:    series,
+	})
+}
@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockAnalyticsUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsUsecase) GetResellerROI(ctx context.Context, resellerID string, currency money.Currency) (*analytics.ResellerROI, error) {
+	args := m.Called(ctx, resellerID, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.ResellerROI), args.Error(1)
+}
+
+func (m *MockAnalyticsUsecase) GetSupplierSeries(ctx context.Context, supplierID string, q *analytics.SeriesQuery) (*analytics.SupplierSeries, error) {
+	args := m.Called(ctx, supplierID, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.SupplierSeries), args.Error(1)
+}
+
+type AnalyticsControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockAnalyticsUsecase
+	controller *AnalyticsController
+}
+
+func (suite *AnalyticsControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockAnalyticsUsecase)
+	suite.controller = NewAnalyticsController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestAnalyticsControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsControllerTestSuite))
+}
+
+func (suite *AnalyticsControllerTestSuite) newContext(path, userID string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	if userID != "" {
+		c.Set("userID", userID)
+	}
+	c.Request = httptest.NewRequest("GET", path, nil)
+	return c, w
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetResellerROI_Success() {
+	report := &analytics.ResellerROI{
+		Currency: money.USD,
+		Bundles: []*analytics.BundleROI{{
+			BundleID: "bundle1",
+			Cost:     money.New(10000, money.USD),
+			Margin:   money.New(2500, money.USD),
+			ROI:      25,
+		}},
+		Suppliers: []*analytics.SupplierMargin{{SupplierID: "supplier1", SupplierName: "threads", Bundles: 1}},
+	}
+	suite.usecase.On("GetResellerROI", mock.Anything, "reseller1", money.USD).Return(report, nil)
+
+	c, w := suite.newContext("/analytics/reseller/bundles?currency=usd", "reseller1")
+	suite.controller.GetResellerROI(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"roi_percent":25`)
+	assert.Contains(suite.T(), w.Body.String(), `"supplier_name":"threads"`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetResellerROI_Unauthorized() {
+	c, w := suite.newContext("/analytics/reseller/bundles", "")
+	suite.controller.GetResellerROI(c)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "GetResellerROI", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetResellerROI_InvalidCurrency() {
+	c, w := suite.newContext("/analytics/reseller/bundles?currency=zzz", "reseller1")
+	suite.controller.GetResellerROI(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "GetResellerROI", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetResellerROI_Error() {
+	suite.usecase.On("GetResellerROI", mock.Anything, "reseller1", money.Currency("")).Return(nil, errors.New("db down"))
+
+	c, w := suite.newContext("/analytics/reseller/bundles", "reseller1")
+	suite.controller.GetResellerROI(c)
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func (suite *AnalyticsControllerTestSuite) supplierSeries() *analytics.SupplierSeries {
+	days, trust := 3.5, 88
+	return &analytics.SupplierSeries{
+		SupplierID: "supplier1",
+		Interval:   analytics.Week,
+		Currency:   money.ETB,
+		Periods: []*analytics.SupplierPeriod{
+			{
+				Start:           time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC),
+				ListingsCreated: 2,
+				BundlesSold:     1,
+				Revenue:         money.New(450000, money.ETB),
+				AvgSalePrice:    money.New(500000, money.ETB),
+				AvgDaysToSale:   &days,
+				TrustScore:      &trust,
+			},
+			{
+				Start:        time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+				Revenue:      money.New(0, money.ETB),
+				AvgSalePrice: money.New(0, money.ETB),
+			},
+		},
+	}
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetSupplierSeries_ParsesRange() {
+	suite.usecase.On("GetSupplierSeries", mock.Anything, "supplier1", mock.MatchedBy(func(q *analytics.SeriesQuery) bool {
+		return q.Interval == analytics.Week &&
+			q.From.Equal(time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC)) &&
+			q.To.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC))
+	})).Return(suite.supplierSeries(), nil)
+
+	c, w := suite.newContext("/analytics/supplier/sales?from=2026-09-28&to=2026-10-11&interval=week", "supplier1")
+	suite.controller.GetSupplierSeries(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"listings_created":2`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetSupplierSeries_CSV() {
+	suite.usecase.On("GetSupplierSeries", mock.Anything, "supplier1", mock.Anything).Return(suite.supplierSeries(), nil)
+
+	c, w := suite.newContext("/analytics/supplier/sales?interval=week&format=csv", "supplier1")
+	suite.controller.GetSupplierSeries(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), `attachment; filename="supplier-sales-week.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(suite.T(), "period_start,listings_created,bundles_sold,revenue,avg_sale_price,currency,avg_days_to_sale,trust_score\n"+
+		"2026-09-28,2,1,4500.00,5000.00,ETB,3.5,88\n"+
+		"2026-10-05,0,0,0.00,0.00,ETB,,\n", w.Body.String())
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetSupplierSeries_InvalidDate() {
+	c, w := suite.newContext("/analytics/supplier/sales?from=last-week", "supplier1")
+	suite.controller.GetSupplierSeries(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "GetSupplierSeries", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AnalyticsControllerTestSuite) TestGetSupplierSeries_InvalidRange() {
+	suite.usecase.On("GetSupplierSeries", mock.Anything, "supplier1", mock.Anything).Return(nil, analytics.ErrInvalidRange)
+
+	c, w := suite.newContext("/analytics/supplier/sales?from=2026-10-11&to=2026-09-28", "supplier1")
+	suite.controller.GetSupplierSeries(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
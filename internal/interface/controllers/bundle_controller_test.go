@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
@@ -27,9 +29,12 @@ func (m *MockBundleUsecase) CreateBundle(ctx context.Context, supplierID string,
 	return args.Error(0)
 }
 
-func (m *MockBundleUsecase) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, supplierID)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleUsecase) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
 func (m *MockBundleUsecase) DeleteBundle(ctx context.Context, supplierID, bundleID string) error {
@@ -52,14 +57,12 @@ func (m *MockBundleUsecase) GetBundlePublicByID(ctx context.Context, bundleID st
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleUsecase) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
-}
-
-func (m *MockBundleUsecase) DecreaseRemainingItemCount(ctx context.Context, bundleID string) error {
-	args := m.Called(ctx, bundleID)
-	return args.Error(0)
+func (m *MockBundleUsecase) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
 func (m *MockBundleUsecase) GetBundleByTitle(ctx context.Context, title string) (*bundle.Bundle, error) {
@@ -70,11 +73,30 @@ func (m *MockBundleUsecase) GetBundleByTitle(ctx context.Context, title string)
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
+type MockConverter struct {
+	mock.Mock
+}
+
+func (m *MockConverter) Rate(ctx context.Context, from, to money.Currency) (*money.RateSnapshot, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*money.RateSnapshot), args.Error(1)
+}
+
+func (m *MockConverter) Convert(ctx context.Context, amount money.Money, to money.Currency) (money.Money, error) {
+	args := m.Called(ctx, amount, to)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
 type BundleControllerTestSuite struct {
 	suite.Suite
 	controller    *BundleController
 	mockBundleUC  *MockBundleUsecase
 	mockUserUC    *MockUserUsecase
+	mockWarehouse *MockWarehouseUsecase
+	mockConverter *MockConverter
 	router        *gin.Engine
 	supplierID    string
 	supplierToken string
@@ -83,7 +105,9 @@ type BundleControllerTestSuite struct {
 func (suite *BundleControllerTestSuite) SetupTest() {
 	suite.mockBundleUC = new(MockBundleUsecase)
 	suite.mockUserUC = new(MockUserUsecase)
-	suite.controller = NewBundleController(suite.mockBundleUC, suite.mockUserUC)
+	suite.mockWarehouse = new(MockWarehouseUsecase)
+	suite.mockConverter = new(MockConverter)
+	suite.controller = NewBundleController(suite.mockBundleUC, suite.mockUserUC, suite.mockWarehouse, suite.mockConverter)
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.Default()
 	suite.supplierID = "supplier123"
@@ -106,7 +130,7 @@ func (suite *BundleControllerTestSuite) TestCreateBundle_Success() {
 		Grade:              "A",
 		Type:               "basic",
 		EstimatedBreakdown: map[string]int{"shirts": 5, "pants": 5},
-		Price:              100.0,
+		Price:              money.New(10000, money.ETB),
 		DeclaredRating:     4,
 	}
 
@@ -146,7 +170,7 @@ func (suite *BundleControllerTestSuite) TestCreateBundle_BlacklistedUser() {
 		Grade:              "A",
 		Type:               "basic",
 		EstimatedBreakdown: map[string]int{"shirts": 5, "pants": 5},
-		Price:              100.0,
+		Price:              money.New(10000, money.ETB),
 		DeclaredRating:     4,
 	}
 
@@ -181,13 +205,14 @@ func (suite *BundleControllerTestSuite) TestListBundles_Success() {
 			ID:           "bundle1",
 			Title:        "Test Bundle 1",
 			Grade:        "A",
-			Price:        100.0,
+			Price:        money.New(10000, money.ETB),
 			SortingLevel: "basic",
 			Status:       "available",
 		},
 	}
 
-	suite.mockBundleUC.On("ListBundles", mock.Anything, suite.supplierID).Return(bundles, nil)
+	suite.mockBundleUC.On("ListBundles", mock.Anything, suite.supplierID, pagination.Request{Limit: pagination.DefaultLimit}).
+		Return(&pagination.Page[*bundle.Bundle]{Items: bundles, Total: 1}, nil)
 
 	// Execute
 	w := httptest.NewRecorder()
@@ -198,9 +223,8 @@ func (suite *BundleControllerTestSuite) TestListBundles_Success() {
 
 	// Assert
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
-	var response common.APIResponse
-	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.True(suite.T(), response.Success)
+	assert.Contains(suite.T(), w.Body.String(), `"items":[{"id":"bundle1"`)
+	assert.Contains(suite.T(), w.Body.String(), `"total":1`)
 	suite.mockBundleUC.AssertExpectations(suite.T())
 }
 
@@ -236,7 +260,7 @@ func (suite *BundleControllerTestSuite) TestUpdateBundle_Success() {
 		ID:           bundleID,
 		Title:        "Updated Title",
 		Grade:        "A",
-		Price:        150.0,
+		Price:        money.New(15000, money.ETB),
 		SortingLevel: "basic",
 		Status:       "available",
 	}
@@ -268,7 +292,7 @@ func (suite *BundleControllerTestSuite) TestGetBundle_Success() {
 		ID:           bundleID,
 		Title:        "Test Bundle",
 		Grade:        "A",
-		Price:        100.0,
+		Price:        money.New(10000, money.ETB),
 		SortingLevel: "basic",
 		Status:       "available",
 	}
@@ -290,6 +314,36 @@ func (suite *BundleControllerTestSuite) TestGetBundle_Success() {
 	suite.mockBundleUC.AssertExpectations(suite.T())
 }
 
+func (suite *BundleControllerTestSuite) TestGetBundleDetail_ShowsBreakdown() {
+	b := &bundle.Bundle{
+		ID:                 "bundle123",
+		SupplierID:         suite.supplierID,
+		Title:              "Winter Mix",
+		Price:              money.New(10000, money.ETB),
+		EstimatedBreakdown: map[string]int{"jackets": 20},
+	}
+	breakdown := bundle.CompareBreakdown(b.EstimatedBreakdown, map[string]int{"jackets": 15})
+	suite.mockBundleUC.On("GetBundlePublicByID", mock.Anything, "bundle123").Return(b, nil)
+	suite.mockUserUC.On("GetByID", mock.Anything, suite.supplierID).Return(&user.User{ID: suite.supplierID, TrustScore: 90}, nil)
+	suite.mockWarehouse.On("GetBundleBreakdown", mock.Anything, "bundle123").Return(breakdown, nil)
+
+	w := httptest.NewRecorder()
+	suite.router.GET("/bundles/detail/:id", suite.controller.GetBundleDetail)
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bundles/detail/bundle123", nil))
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var response struct {
+		Data models.BundleDetailResponse `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), map[string]int{"jackets": 20}, response.Data.Bundle.EstimatedBreakdown)
+	suite.Require().NotNil(response.Data.Bundle.ActualBreakdown)
+	suite.Require().Len(response.Data.Bundle.ActualBreakdown.Categories, 1)
+	assert.Equal(suite.T(), 15, response.Data.Bundle.ActualBreakdown.Categories[0].Actual)
+	assert.InDelta(suite.T(), -25.0, *response.Data.Bundle.ActualBreakdown.Categories[0].DeviationPercent, 0.001)
+	suite.mockWarehouse.AssertExpectations(suite.T())
+}
+
 func (suite *BundleControllerTestSuite) TestListAvailableBundles_Success() {
 	// Setup
 	bundles := []*bundle.Bundle{
@@ -297,13 +351,13 @@ func (suite *BundleControllerTestSuite) TestListAvailableBundles_Success() {
 			ID:           "bundle1",
 			Title:        "Test Bundle 1",
 			Grade:        "A",
-			Price:        100.0,
+			Price:        money.New(10000, money.ETB),
 			SortingLevel: "basic",
 			Status:       "available",
 		},
 	}
 
-	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything).Return(bundles, nil)
+	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything, mock.Anything).Return(&pagination.Page[*bundle.Bundle]{Items: bundles}, nil)
 
 	// Execute
 	w := httptest.NewRecorder()
@@ -316,6 +370,73 @@ func (suite *BundleControllerTestSuite) TestListAvailableBundles_Success() {
 	suite.mockBundleUC.AssertExpectations(suite.T())
 }
 
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_DisplayCurrency() {
+	bundles := []*bundle.Bundle{
+		{ID: "bundle1", Price: money.New(575000, money.ETB), Status: "available"},
+	}
+	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything, mock.Anything).Return(&pagination.Page[*bundle.Bundle]{Items: bundles}, nil)
+	suite.mockConverter.On("Convert", mock.Anything, money.New(575000, money.ETB), money.USD).Return(money.New(10000, money.USD), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?currency=usd", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"display_price":{"amount":10000,"currency":"USD"}`)
+	suite.mockConverter.AssertExpectations(suite.T())
+}
+
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_UnknownCurrency() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?currency=xyz", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockBundleUC.AssertNotCalled(suite.T(), "ListAvailableBundles", mock.Anything, mock.Anything)
+}
+
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_Filters() {
+	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything, mock.MatchedBy(func(q *bundle.Query) bool {
+		return assert.ObjectsAreEqual([]string{"A", "B"}, q.Grades) &&
+			assert.ObjectsAreEqual([]bundle.SortingLevel{bundle.Sorted}, q.SortingLevels) &&
+			q.MaxPrice.Amount == 500000 && q.MaxPrice.Currency == money.ETB && q.MinPrice == nil &&
+			q.MinRating == 4 && q.MinQuantity == 20 && q.MinTrustScore == 60 &&
+			q.Sort == bundle.SortPricePerItemAsc && q.Cursor == "abc" && q.Limit == 10
+	})).Return(&pagination.Page[*bundle.Bundle]{Items: []*bundle.Bundle{{ID: "bundle1"}}, NextCursor: "def"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?grade=A,B&sorting_level=sorted&max_price=5000&min_rating=4&min_quantity=20&min_trust=60&sort=price_per_item_asc&cursor=abc&limit=10", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"next_cursor":"def"`)
+	suite.mockBundleUC.AssertExpectations(suite.T())
+}
+
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_InvalidQuery() {
+	suite.mockBundleUC.On("ListAvailableBundles", mock.Anything, mock.Anything).Return(nil, bundle.ErrInvalidQuery)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?cursor=stale", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *BundleControllerTestSuite) TestListAvailableBundles_InvalidNumber() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/bundles/available?min_quantity=many", nil)
+	suite.router.GET("/bundles/available", suite.controller.ListAvailableBundles)
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockBundleUC.AssertNotCalled(suite.T(), "ListAvailableBundles", mock.Anything, mock.Anything)
+}
+
 func TestBundleControllerSuite(t *testing.T) {
 	suite.Run(t, new(BundleControllerTestSuite))
 }
@@ -1,11 +1,15 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
 	"github.com/gin-gonic/gin"
@@ -13,14 +17,18 @@ import (
 )
 
 type BundleController struct {
-	bundleUsecase bundle.Usecase
-	userUsecase   user.Usecase
+	bundleUsecase    bundle.Usecase
+	userUsecase      user.Usecase
+	warehouseUsecase warehouse.WarehouseUseCase
+	converter        money.Converter
 }
 
-func NewBundleController(bundleUsecase bundle.Usecase, userUsecase user.Usecase) *BundleController {
+func NewBundleController(bundleUsecase bundle.Usecase, userUsecase user.Usecase, warehouseUsecase warehouse.WarehouseUseCase, converter money.Converter) *BundleController {
 	return &BundleController{
-		bundleUsecase: bundleUsecase,
-		userUsecase:   userUsecase,
+		bundleUsecase:    bundleUsecase,
+		userUsecase:      userUsecase,
+		warehouseUsecase: warehouseUsecase,
+		converter:        converter,
 	}
 }
 
@@ -69,6 +77,7 @@ func (c *BundleController) CreateBundle(ctx *gin.Context) {
 		return
 	}
 
+	now := time.Now()
 	b := &bundle.Bundle{
 		ID:                 "bundle_" + primitive.NewObjectID().Hex(),
 		SupplierID:         supplierIDStr,
@@ -82,7 +91,8 @@ func (c *BundleController) CreateBundle(ctx *gin.Context) {
 		Type:               req.Type,
 		Price:              req.Price,
 		Status:             "available",
-		CreatedAt:          time.Now().Format(time.RFC3339),
+		CreatedAt:          now.Format(time.RFC3339),
+		DateListed:         now,
 		DeclaredRating:     req.DeclaredRating, // ✅ included here
 		RemainingItemCount: req.NumberOfItems,
 	}
@@ -147,18 +157,26 @@ func (c *BundleController) ListBundles(ctx *gin.Context) {
 		return
 	}
 
-	bundles, err := c.bundleUsecase.ListBundles(ctx, supplierIDStr)
+	req, err := pageRequest(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	page, err := c.bundleUsecase.ListBundles(ctx, supplierIDStr, req)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), common.APIResponse{
 			Success: false,
 			Message: err.Error(),
 		})
 		return
 	}
 
-	var resp []models.BundleResponse
-	for _, b := range bundles {
-		resp = append(resp, models.BundleResponse{
+	ctx.JSON(http.StatusOK, pagination.Map(page, func(b *bundle.Bundle) models.BundleResponse {
+		return models.BundleResponse{
 			ID:                 b.ID,
 			Title:              b.Title,
 			SampleImage:        b.SampleImage,
@@ -173,14 +191,8 @@ func (c *BundleController) ListBundles(ctx *gin.Context) {
 			DeclaredRating:     b.DeclaredRating,
 			SortingLevel:       string(b.SortingLevel),
 			CreatedAt:          b.CreatedAt,
-		})
-	}
-
-	ctx.JSON(http.StatusOK, common.APIResponse{
-		Success: true,
-		Message: "Bundles retrieved successfully",
-		Data:    resp,
-	})
+		}
+	}))
 }
 
 func (c *BundleController) DeleteBundle(ctx *gin.Context) {
@@ -363,6 +375,15 @@ func (c *BundleController) GetBundle(ctx *gin.Context) { // Added
 		return
 	}
 
+	currency, err := queryCurrency(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	// Fetch the bundle using the use case
 	b, err := c.bundleUsecase.GetBundleByID(ctx, supplierIDStr, id)
 	if err != nil {
@@ -375,12 +396,13 @@ func (c *BundleController) GetBundle(ctx *gin.Context) { // Added
 
 	// Map to response DTO
 	resp := models.BundleResponse{
-		ID:     b.ID,
-		Title:  b.Title,
-		Grade:  b.Grade,
-		Price:  b.Price,
-		Type:   string(b.SortingLevel),
-		Status: b.Status,
+		ID:           b.ID,
+		Title:        b.Title,
+		Grade:        b.Grade,
+		Price:        b.Price,
+		DisplayPrice: displayPrice(ctx, c.converter, b.Price, currency),
+		Type:         string(b.SortingLevel),
+		Status:       b.Status,
 	}
 
 	ctx.JSON(http.StatusOK, common.APIResponse{
@@ -390,13 +412,81 @@ func (c *BundleController) GetBundle(ctx *gin.Context) { // Added
 	})
 }
 
+// ListAvailableBundles handles GET /bundles/available. grade, sorting_level, type
+// and size_range take comma-separated values; min_price and max_price are in major
+// units of currency.
 func (c *BundleController) ListAvailableBundles(ctx *gin.Context) {
-	bundles, err := c.bundleUsecase.ListAvailableBundles(ctx)
+	q, err := bundleQueryFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// The usecase defaults the currency for price filters; display prices are only
+	// added when the reseller named one.
+	displayCurrency := q.Currency
+
+	page, err := c.bundleUsecase.ListAvailableBundles(ctx, q)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, bundle.ErrInvalidQuery) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(listErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(http.StatusOK, bundles)
+
+	ctx.JSON(http.StatusOK, pagination.Map(page, func(b *bundle.Bundle) models.AvailableBundleResponse {
+		return models.AvailableBundleResponse{
+			Bundle:       b,
+			DisplayPrice: displayPrice(ctx, c.converter, b.Price, displayCurrency),
+		}
+	}))
+}
+
+func bundleQueryFromRequest(ctx *gin.Context) (*bundle.Query, error) {
+	currency, err := queryCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	priceCurrency := currency
+	if priceCurrency == "" {
+		priceCurrency = money.DefaultCurrency
+	}
+
+	q := &bundle.Query{
+		Grades:     queryList(ctx, "grade"),
+		Types:      queryList(ctx, "type"),
+		SizeRanges: queryList(ctx, "size_range"),
+		Currency:   currency,
+		Sort:       bundle.Sort(ctx.DefaultQuery("sort", string(bundle.SortNewest))),
+	}
+	if q.Request, err = pageRequest(ctx); err != nil {
+		return nil, err
+	}
+	for _, level := range queryList(ctx, "sorting_level") {
+		q.SortingLevels = append(q.SortingLevels, bundle.SortingLevel(level))
+	}
+	if q.MinPrice, err = queryPrice(ctx, "min_price", priceCurrency); err != nil {
+		return nil, err
+	}
+	if q.MaxPrice, err = queryPrice(ctx, "max_price", priceCurrency); err != nil {
+		return nil, err
+	}
+	ints := []struct {
+		key string
+		dst *int
+	}{
+		{"min_rating", &q.MinRating},
+		{"min_quantity", &q.MinQuantity},
+		{"max_quantity", &q.MaxQuantity},
+		{"min_trust", &q.MinTrustScore},
+	}
+	for _, p := range ints {
+		if *p.dst, err = queryInt(ctx, p.key); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
 }
 
 func (c *BundleController) GetBundleDetail(ctx *gin.Context) {
@@ -438,6 +528,15 @@ func (c *BundleController) GetBundleDetail(ctx *gin.Context) {
 		return
 	}
 
+	currency, err := queryCurrency(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	// Get bundle details
 	bundle, err := c.bundleUsecase.GetBundlePublicByID(ctx, id)
 	if err != nil {
@@ -458,6 +557,16 @@ func (c *BundleController) GetBundleDetail(ctx *gin.Context) {
 		return
 	}
 
+	// Compare what was unpacked so far with the supplier's estimate
+	breakdown, err := c.warehouseUsecase.GetBundleBreakdown(ctx, bundle.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+			Success: false,
+			Message: "error fetching bundle breakdown",
+		})
+		return
+	}
+
 	// Calculate supplier rating from trust score
 	supplierRating := float64(supplier.TrustScore) / 100.0
 
@@ -473,8 +582,10 @@ func (c *BundleController) GetBundleDetail(ctx *gin.Context) {
 	response.Bundle.Grade = bundle.Grade
 	response.Bundle.SortingLevel = string(bundle.SortingLevel)
 	response.Bundle.EstimatedBreakdown = bundle.EstimatedBreakdown
+	response.Bundle.ActualBreakdown = breakdown
 	response.Bundle.Type = bundle.Type
 	response.Bundle.Price = bundle.Price
+	response.Bundle.DisplayPrice = displayPrice(ctx, c.converter, bundle.Price, currency)
 	response.Bundle.Status = bundle.Status
 	response.Bundle.DeclaredRating = bundle.DeclaredRating
 	response.Bundle.RemainingItemCount = bundle.RemainingItemCount
@@ -501,6 +612,15 @@ func (c *BundleController) GetBundleByTitle(ctx *gin.Context) {
 		return
 	}
 
+	currency, err := queryCurrency(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	bundle, err := c.bundleUsecase.GetBundleByTitle(ctx, title)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
@@ -520,6 +640,7 @@ func (c *BundleController) GetBundleByTitle(ctx *gin.Context) {
 		SizeRange:          bundle.SizeRange,
 		Type:               bundle.Type,
 		Price:              bundle.Price,
+		DisplayPrice:       displayPrice(ctx, c.converter, bundle.Price, currency),
 		Status:             bundle.Status,
 		EstimatedBreakdown: bundle.EstimatedBreakdown,
 		DeclaredRating:     bundle.DeclaredRating,
@@ -1,11 +1,14 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/cartitem"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/gin-gonic/gin"
 )
@@ -13,12 +16,14 @@ import (
 type CartItemController struct {
 	usecase        cartitem.Usecase
 	productUsecase product.Usecase
+	converter      money.Converter
 }
 
-func NewCartItemController(cartUC cartitem.Usecase, productUC product.Usecase) *CartItemController {
+func NewCartItemController(cartUC cartitem.Usecase, productUC product.Usecase, converter money.Converter) *CartItemController {
 	return &CartItemController{
 		usecase:        cartUC,
 		productUsecase: productUC, // ✅ Assign it
+		converter:      converter,
 	}
 }
 
@@ -54,30 +59,43 @@ func (ctr *CartItemController) GetCartItems(c *gin.Context) {
 		return
 	}
 
+	currency, err := queryCurrency(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	items, err := ctr.usecase.GetCartItems(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Convert domain items to response models.
-	var responses []models.CartItemResponse
+	// Convert domain items to response models. The cart is returned whole, and an
+	// empty one as an empty list.
+	responses := make([]models.CartItemResponse, 0, len(items))
+	now := time.Now()
 	for _, item := range items {
 		var rating float64 = 0
+		var heldByOther bool
 		product, err := ctr.productUsecase.GetProductByID(c.Request.Context(), item.ListingID)
 		if err == nil {
 			rating = product.Rating
+			heldByOther = product.IsHeldByOther(userID, now)
 		}
 
 		responses = append(responses, models.CartItemResponse{
-			ID:        item.ID,
-			ListingID: item.ListingID,
-			Title:     item.Title,
-			Price:     item.Price,
-			ImageURL:  item.ImageURL,
-			Grade:     item.Grade,
-			Rating:    rating, // ✅ Include the rating
-			CreatedAt: item.CreatedAt.Format(time.RFC3339),
+			ID:           item.ID,
+			ListingID:    item.ListingID,
+			Title:        item.Title,
+			Price:        item.Price,
+			DisplayPrice: displayPrice(c.Request.Context(), ctr.converter, item.Price, currency),
+			ImageURL:     item.ImageURL,
+			Grade:        item.Grade,
+			Rating:       rating, // ✅ Include the rating
+			CreatedAt:    item.CreatedAt.Format(time.RFC3339),
+
+			HeldByOther: heldByOther,
 		})
 	}
 
@@ -107,6 +125,24 @@ func (ctr *CartItemController) RemoveCartItem(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "item removed from cart"})
 }
 
+// respondCheckoutError reports a failed checkout. Unavailable items are listed so the
+// client can show the consumer which ones to remove from the cart.
+func respondCheckoutError(c *gin.Context, err error) {
+	var validationErr *cartitem.CheckoutValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":            validationErr.Message,
+			"unavailableItems": validationErr.UnavailableItems,
+		})
+		return
+	}
+	if errors.Is(err, shipping.ErrAddressNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
 // CheckoutCart handles POST /api/checkout
 func (ctr *CartItemController) CheckoutCart(c *gin.Context) {
 	userID := c.GetString("userID")
@@ -115,12 +151,25 @@ func (ctr *CartItemController) CheckoutCart(c *gin.Context) {
 		return
 	}
 
-	resp, err := ctr.usecase.CheckoutCart(c.Request.Context(), userID)
+	// The consumer may pay in another currency than the listings are priced in.
+	currency, err := queryCurrency(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	delivery, err := bindDelivery(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	resp, err := ctr.usecase.CheckoutCart(c.Request.Context(), userID, currency, delivery)
+	if err != nil {
+		respondCheckoutError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Payment successful. Order confirmed.",
@@ -142,12 +191,24 @@ func (ctr *CartItemController) CheckoutSingleItem(c *gin.Context) {
 		return
 	}
 
-	resp, err := ctr.usecase.CheckoutSingleItem(c.Request.Context(), userID, listingID)
+	currency, err := queryCurrency(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	delivery, err := bindDelivery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := ctr.usecase.CheckoutSingleItem(c.Request.Context(), userID, listingID, currency, delivery)
+	if err != nil {
+		respondCheckoutError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Payment successful. Order confirmed.",
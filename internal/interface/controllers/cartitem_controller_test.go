@@ -7,11 +7,15 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/cartitem"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -39,8 +43,8 @@ func (m *MockCartItemUsecase) RemoveCartItem(ctx context.Context, userID, listin
 }
 
 // Change signature to return *models.CheckoutResponse instead of interface{}
-func (m *MockCartItemUsecase) CheckoutCart(ctx context.Context, userID string) (*models.CheckoutResponse, error) {
-	args := m.Called(ctx, userID)
+func (m *MockCartItemUsecase) CheckoutCart(ctx context.Context, userID string, currency money.Currency, delivery shipping.Selection) (*models.CheckoutResponse, error) {
+	args := m.Called(ctx, userID, currency, delivery)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -48,8 +52,8 @@ func (m *MockCartItemUsecase) CheckoutCart(ctx context.Context, userID string) (
 }
 
 // Change signature to return *models.CheckoutResponse instead of interface{}
-func (m *MockCartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string) (*models.CheckoutResponse, error) {
-	args := m.Called(ctx, userID, listingID)
+func (m *MockCartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string, currency money.Currency, delivery shipping.Selection) (*models.CheckoutResponse, error) {
+	args := m.Called(ctx, userID, listingID, currency, delivery)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -85,13 +89,27 @@ func (m *MockProductUsecase) UpdateProduct(ctx context.Context, id string, updat
 	return args.Error(0)
 }
 
-func (m *MockProductUsecase) ListAvailableProducts(ctx context.Context, page int, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, page, limit)
-	return args.Get(0).([]*product.Product), args.Error(1)
+func (m *MockProductUsecase) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
+}
+
+func (m *MockProductUsecase) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
 }
-func (m *MockProductUsecase) ListProductsByReseller(ctx context.Context, resellerID string, page int, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, resellerID, page, limit)
-	return args.Get(0).([]*product.Product), args.Error(1)
+func (m *MockProductUsecase) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
 }
 
 type CartItemControllerTestSuite struct {
@@ -106,7 +124,7 @@ type CartItemControllerTestSuite struct {
 func (suite *CartItemControllerTestSuite) SetupTest() {
 	suite.mockUC = new(MockCartItemUsecase)
 	suite.mockProductUC = new(MockProductUsecase)
-	suite.controller = NewCartItemController(suite.mockUC, suite.mockProductUC)
+	suite.controller = NewCartItemController(suite.mockUC, suite.mockProductUC, nil)
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.Default()
 	suite.userID = "user123"
@@ -182,7 +200,7 @@ func (suite *CartItemControllerTestSuite) TestGetCartItems_Success() {
 			ID:        "item1",
 			ListingID: "listing1",
 			Title:     "Test Item 1",
-			Price:     100.0,
+			Price:     money.New(10000, money.ETB),
 			ImageURL:  "image1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -215,6 +233,51 @@ func (suite *CartItemControllerTestSuite) TestGetCartItems_Success() {
 	suite.mockProductUC.AssertExpectations(suite.T())
 }
 
+func (suite *CartItemControllerTestSuite) TestGetCartItems_HeldByOther() {
+	// Setup
+	items := []*cartitem.CartItem{
+		{ID: "item1", ListingID: "listing1", Title: "Test Item 1", CreatedAt: time.Now()},
+	}
+	suite.mockUC.On("GetCartItems", mock.Anything, suite.userID).Return(items, nil)
+
+	until := time.Now().Add(5 * time.Minute)
+	held := &product.Product{
+		ID:            "listing1",
+		Status:        product.StatusReserved,
+		ReservedBy:    "other-consumer",
+		ReservedUntil: &until,
+	}
+	suite.mockProductUC.On("GetProductByID", mock.Anything, "listing1").Return(held, nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/cart", nil)
+	suite.router.GET("/api/cart", suite.controller.GetCartItems)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var response []models.CartItemResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().Len(response, 1)
+	assert.True(suite.T(), response[0].HeldByOther)
+}
+
+func (suite *CartItemControllerTestSuite) TestGetCartItems_Empty() {
+	// Setup
+	suite.mockUC.On("GetCartItems", mock.Anything, suite.userID).Return([]*cartitem.CartItem(nil), nil)
+
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/cart", nil)
+	suite.router.GET("/api/cart", suite.controller.GetCartItems)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `[]`, w.Body.String())
+}
+
 func (suite *CartItemControllerTestSuite) TestGetCartItems_Unauthorized() {
 	// Execute
 	w := httptest.NewRecorder()
@@ -263,27 +326,31 @@ func (suite *CartItemControllerTestSuite) TestRemoveCartItem_Unauthorized() {
 	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
 }
 
+var testDelivery = shipping.Selection{AddressID: "addr1", Option: shipping.Standard}
+
+const testDeliveryBody = `{"address_id":"addr1","delivery_option":"standard"}`
+
 func (suite *CartItemControllerTestSuite) TestCheckoutCart_Success() {
 	// Setup
 	dummyResp := &models.CheckoutResponse{
-		TotalAmount: 100.0,
-		PlatformFee: 2.0,
-		NetPayable:  98.0,
+		TotalAmount: money.New(10000, money.ETB),
+		PlatformFee: []money.Money{money.New(200, money.ETB)},
+		NetPayable:  []money.Money{money.New(9800, money.ETB)},
 		Items: []models.CheckoutItemResponse{
 			{
 				ListingID: "item1",
 				Title:     "Test Item 1",
-				Price:     100.0,
+				Price:     money.New(10000, money.ETB),
 				SellerID:  "seller1",
 				Status:    "available",
 			},
 		},
 	}
-	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID).Return(dummyResp, nil)
+	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID, money.Currency(""), testDelivery).Return(dummyResp, nil)
 
 	// Execute
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/api/checkout", nil)
+	req, _ := http.NewRequest("POST", "/api/checkout", strings.NewReader(testDeliveryBody))
 	suite.router.POST("/api/checkout", suite.controller.CheckoutCart)
 	suite.router.ServeHTTP(w, req)
 
@@ -296,19 +363,19 @@ func (suite *CartItemControllerTestSuite) TestCheckoutCart_Success() {
 
 	// Verify that data matches dummyResp
 	data := response["data"].(map[string]interface{})
-	assert.Equal(suite.T(), dummyResp.TotalAmount, data["totalAmount"])
-	assert.Equal(suite.T(), dummyResp.PlatformFee, data["platformFee"])
-	assert.Equal(suite.T(), dummyResp.NetPayable, data["netPayable"])
+	assert.Equal(suite.T(), map[string]interface{}{"amount": 10000.0, "currency": "ETB"}, data["totalAmount"])
+	assert.Equal(suite.T(), []interface{}{map[string]interface{}{"amount": 200.0, "currency": "ETB"}}, data["platformFee"])
+	assert.Equal(suite.T(), []interface{}{map[string]interface{}{"amount": 9800.0, "currency": "ETB"}}, data["netPayable"])
 	suite.mockUC.AssertExpectations(suite.T())
 }
 
 func (suite *CartItemControllerTestSuite) TestCheckoutCart_ValidationError() {
 	// Setup
-	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID).Return(nil, errors.New("some items are unavailable"))
+	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID, money.Currency(""), testDelivery).Return(nil, errors.New("some items are unavailable"))
 
 	// Execute
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/api/checkout", nil)
+	req, _ := http.NewRequest("POST", "/api/checkout", strings.NewReader(testDeliveryBody))
 	suite.router.POST("/api/checkout", suite.controller.CheckoutCart)
 	suite.router.ServeHTTP(w, req)
 
@@ -320,6 +387,44 @@ func (suite *CartItemControllerTestSuite) TestCheckoutCart_ValidationError() {
 	suite.mockUC.AssertExpectations(suite.T())
 }
 
+func (suite *CartItemControllerTestSuite) TestCheckoutCart_UnavailableItems() {
+	// Setup
+	validationErr := &cartitem.CheckoutValidationError{
+		Message:          "1 item(s) in your cart are no longer available",
+		UnavailableItems: []cartitem.UnavailableItem{{ListingID: "prod2", Title: "Jacket"}},
+	}
+	suite.mockUC.On("CheckoutCart", mock.Anything, suite.userID, money.Currency(""), testDelivery).Return(nil, validationErr)
+
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/checkout", strings.NewReader(testDeliveryBody))
+	suite.router.POST("/api/checkout", suite.controller.CheckoutCart)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	var response struct {
+		Error            string                     `json:"error"`
+		UnavailableItems []cartitem.UnavailableItem `json:"unavailableItems"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(suite.T(), validationErr.Message, response.Error)
+	assert.Equal(suite.T(), validationErr.UnavailableItems, response.UnavailableItems)
+	suite.mockUC.AssertExpectations(suite.T())
+}
+
+func (suite *CartItemControllerTestSuite) TestCheckoutCart_MissingDelivery() {
+	// Execute
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/checkout", strings.NewReader(`{"address_id":"addr1"}`))
+	suite.router.POST("/api/checkout", suite.controller.CheckoutCart)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUC.AssertNotCalled(suite.T(), "CheckoutCart", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *CartItemControllerTestSuite) TestCheckoutCart_Unauthorized() {
 	// Execute
 	w := httptest.NewRecorder()
@@ -336,25 +441,25 @@ func (suite *CartItemControllerTestSuite) TestCheckoutCart_Unauthorized() {
 func (suite *CartItemControllerTestSuite) TestCheckoutSingleItem_Success() {
 	// Setup
 	dummyResp := &models.CheckoutResponse{
-		TotalAmount: 100.0,
-		PlatformFee: 2.0,
-		NetPayable:  98.0,
+		TotalAmount: money.New(10000, money.ETB),
+		PlatformFee: []money.Money{money.New(200, money.ETB)},
+		NetPayable:  []money.Money{money.New(9800, money.ETB)},
 		Items: []models.CheckoutItemResponse{
 			{
 				ListingID: "item1",
 				Title:     "Test Item 1",
-				Price:     100.0,
+				Price:     money.New(10000, money.ETB),
 				SellerID:  "seller1",
 				Status:    "available",
 			},
 		},
 	}
 	listingID := "listing123"
-	suite.mockUC.On("CheckoutSingleItem", mock.Anything, suite.userID, listingID).Return(dummyResp, nil)
+	suite.mockUC.On("CheckoutSingleItem", mock.Anything, suite.userID, listingID, money.Currency(""), testDelivery).Return(dummyResp, nil)
 
 	// Execute
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/api/checkout/"+listingID, nil)
+	req, _ := http.NewRequest("POST", "/api/checkout/"+listingID, strings.NewReader(testDeliveryBody))
 	suite.router.POST("/api/checkout/:listingId", suite.controller.CheckoutSingleItem)
 	suite.router.ServeHTTP(w, req)
 
@@ -367,9 +472,9 @@ func (suite *CartItemControllerTestSuite) TestCheckoutSingleItem_Success() {
 
 	// Verify that data matches dummyResp
 	data := response["data"].(map[string]interface{})
-	assert.Equal(suite.T(), dummyResp.TotalAmount, data["totalAmount"])
-	assert.Equal(suite.T(), dummyResp.PlatformFee, data["platformFee"])
-	assert.Equal(suite.T(), dummyResp.NetPayable, data["netPayable"])
+	assert.Equal(suite.T(), map[string]interface{}{"amount": 10000.0, "currency": "ETB"}, data["totalAmount"])
+	assert.Equal(suite.T(), []interface{}{map[string]interface{}{"amount": 200.0, "currency": "ETB"}}, data["platformFee"])
+	assert.Equal(suite.T(), []interface{}{map[string]interface{}{"amount": 9800.0, "currency": "ETB"}}, data["netPayable"])
 	suite.mockUC.AssertExpectations(suite.T())
 }
 
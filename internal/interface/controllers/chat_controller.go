@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/chat"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type ChatController struct {
+	chatUsecase chat.Usecase
+}
+
+func NewChatController(chatUsecase chat.Usecase) *ChatController {
+	return &ChatController{chatUsecase: chatUsecase}
+}
+
+func toMessageResponse(m *chat.ChatMessage) models.MessageResponse {
+	return models.MessageResponse{
+		ID:         m.ID,
+		SenderID:   m.SenderID,
+		ReceiverID: m.ReceiverID,
+		Content:    m.Text,
+		RelatedTo:  m.RelatedTo,
+		Timestamp:  m.Timestamp,
+		IsRead:     m.Seen,
+	}
+}
+
+func chatErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, chat.ErrNotRelated), errors.Is(err, chat.ErrNotReceiver):
+		return http.StatusForbidden
+	case errors.Is(err, chat.ErrMessageNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// SendMessage handles POST /chats
+func (c *ChatController) SendMessage(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.MessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	msg := &chat.ChatMessage{
+		ReceiverID: req.ReceiverID,
+		Text:       req.Content,
+		Type:       chat.Text,
+		RelatedTo:  req.RelatedTo,
+	}
+	if err := c.chatUsecase.SendMessage(ctx.Request.Context(), userID, msg); err != nil {
+		ctx.JSON(chatErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Message sent",
+		Data:    toMessageResponse(msg),
+	})
+}
+
+// ListConversations handles GET /chats
+func (c *ChatController) ListConversations(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	req, err := pageRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	page, err := c.chatUsecase.ListConversations(ctx.Request.Context(), userID, req)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pagination.Map(page, func(m *chat.ChatMessage) models.ConversationResponse {
+		counterpartyID := m.SenderID
+		if counterpartyID == userID {
+			counterpartyID = m.ReceiverID
+		}
+		return models.ConversationResponse{
+			CounterpartyID: counterpartyID,
+			LastMessage:    toMessageResponse(m),
+		}
+	}))
+}
+
+// GetConversation handles GET /chats/:userId?cursor=&limit=
+func (c *ChatController) GetConversation(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	counterpartyID := ctx.Param("userId")
+	req, err := pageRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	page, err := c.chatUsecase.GetConversation(ctx.Request.Context(), userID, counterpartyID, req)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pagination.Map(page, toMessageResponse))
+}
+
+// MarkMessageAsRead handles PUT /chats/messages/:id/read
+func (c *ChatController) MarkMessageAsRead(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	if err := c.chatUsecase.MarkAsSeen(ctx.Request.Context(), userID, ctx.Param("id")); err != nil {
+		ctx.JSON(chatErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Message marked as read",
+	})
+}
+
+// MarkConversationAsRead handles PUT /chats/:userId/read
+func (c *ChatController) MarkConversationAsRead(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	if err := c.chatUsecase.MarkConversationAsSeen(ctx.Request.Context(), userID, ctx.Param("userId")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Conversation marked as read",
+	})
+}
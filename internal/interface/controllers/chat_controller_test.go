@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/chat"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockChatUsecase struct {
+	mock.Mock
+}
+
+func (m *MockChatUsecase) SendMessage(ctx context.Context, senderID string, msg *chat.ChatMessage) error {
+	args := m.Called(ctx, senderID, msg)
+	return args.Error(0)
+}
+
+func (m *MockChatUsecase) ListConversations(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*chat.ChatMessage]), args.Error(1)
+}
+
+func (m *MockChatUsecase) GetConversation(ctx context.Context, userID, counterpartyID string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	args := m.Called(ctx, userID, counterpartyID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*chat.ChatMessage]), args.Error(1)
+}
+
+func (m *MockChatUsecase) MarkAsSeen(ctx context.Context, userID string, messageID string) error {
+	args := m.Called(ctx, userID, messageID)
+	return args.Error(0)
+}
+
+func (m *MockChatUsecase) MarkConversationAsSeen(ctx context.Context, userID string, counterpartyID string) error {
+	args := m.Called(ctx, userID, counterpartyID)
+	return args.Error(0)
+}
+
+type ChatControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockChatUsecase
+	controller *ChatController
+}
+
+func (suite *ChatControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockChatUsecase)
+	suite.controller = NewChatController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestChatControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ChatControllerTestSuite))
+}
+
+func (suite *ChatControllerTestSuite) TestSendMessage_Success() {
+	suite.usecase.On("SendMessage", mock.Anything, "reseller1", mock.AnythingOfType("*chat.ChatMessage")).
+		Return(nil)
+
+	body, _ := json.Marshal(map[string]string{"receiver_id": "supplier1", "content": "hello", "related_to": "bundle1"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Request = httptest.NewRequest("POST", "/chats", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.controller.SendMessage(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ChatControllerTestSuite) TestSendMessage_NotRelated() {
+	suite.usecase.On("SendMessage", mock.Anything, "reseller1", mock.AnythingOfType("*chat.ChatMessage")).
+		Return(chat.ErrNotRelated)
+
+	body, _ := json.Marshal(map[string]string{"receiver_id": "stranger", "content": "hello"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Request = httptest.NewRequest("POST", "/chats", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.controller.SendMessage(c)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *ChatControllerTestSuite) TestSendMessage_Unauthorized() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/chats", nil)
+
+	suite.controller.SendMessage(c)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "SendMessage")
+}
+
+func (suite *ChatControllerTestSuite) TestListConversations_Success() {
+	messages := []*chat.ChatMessage{
+		{ID: "m2", SenderID: "supplier1", ReceiverID: "reseller1", Text: "sure"},
+	}
+	suite.usecase.On("ListConversations", mock.Anything, "reseller1", mock.Anything).
+		Return(&pagination.Page[*chat.ChatMessage]{Items: messages, Total: 1}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Request = httptest.NewRequest("GET", "/chats", nil)
+
+	suite.controller.ListConversations(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"counterparty_id":"supplier1"`)
+}
+
+func (suite *ChatControllerTestSuite) TestGetConversation_InvalidLimit() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Params = gin.Params{{Key: "userId", Value: "supplier1"}}
+	c.Request = httptest.NewRequest("GET", "/chats/supplier1?limit=abc", nil)
+
+	suite.controller.GetConversation(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "GetConversation")
+}
+
+func (suite *ChatControllerTestSuite) TestMarkMessageAsRead_NotReceiver() {
+	suite.usecase.On("MarkAsSeen", mock.Anything, "reseller1", "m1").Return(chat.ErrNotReceiver)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Params = gin.Params{{Key: "id", Value: "m1"}}
+	c.Request = httptest.NewRequest("PUT", "/chats/messages/m1/read", nil)
+
+	suite.controller.MarkMessageAsRead(c)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type DisputeController struct {
+	disputeUsecase dispute.Usecase
+}
+
+func NewDisputeController(disputeUsecase dispute.Usecase) *DisputeController {
+	return &DisputeController{disputeUsecase: disputeUsecase}
+}
+
+func disputeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dispute.ErrDisputeNotFound), errors.Is(err, order.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, dispute.ErrNotDisputeParty):
+		return http.StatusForbidden
+	case errors.Is(err, dispute.ErrAlreadyDisputed), errors.Is(err, dispute.ErrAlreadyResolved),
+		errors.Is(err, dispute.ErrStatusChanged), errors.Is(err, dispute.ErrNotDisputable),
+		errors.Is(err, order.ErrInvalidTransition), errors.Is(err, payment.ErrNothingToRefund):
+		return http.StatusConflict
+	case errors.Is(err, dispute.ErrInvalidReason), errors.Is(err, dispute.ErrInvalidRemedy),
+		errors.Is(err, dispute.ErrInvalidOutcome), errors.Is(err, dispute.ErrInvalidAmount),
+		errors.Is(err, dispute.ErrEmptyResponse), errors.Is(err, payment.ErrInvalidRefundAmount):
+		return http.StatusBadRequest
+	default:
+		return listErrorStatus(err)
+	}
+}
+
+func respondDisputeError(ctx *gin.Context, err error) {
+	ctx.JSON(disputeErrorStatus(err), common.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+// OpenDispute handles POST /disputes
+func (c *DisputeController) OpenDispute(ctx *gin.Context) {
+	buyerID := ctx.GetString("userID")
+	if buyerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.OpenDisputeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	d, err := c.disputeUsecase.OpenDispute(ctx.Request.Context(), buyerID, &dispute.Dispute{
+		OrderID:         req.OrderID,
+		Reason:          dispute.Reason(req.Reason),
+		Description:     req.Description,
+		Photos:          req.Photos,
+		RequestedRemedy: dispute.Remedy(req.RequestedRemedy),
+		RequestedAmount: req.RequestedAmount,
+	})
+	if err != nil {
+		respondDisputeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Dispute opened",
+		Data:    d,
+	})
+}
+
+// ListMyDisputes handles GET /disputes
+func (c *DisputeController) ListMyDisputes(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	req, err := pageRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	page, err := c.disputeUsecase.ListUserDisputes(ctx.Request.Context(), userID, req)
+	if err != nil {
+		respondDisputeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// GetDispute handles GET /disputes/:id
+func (c *DisputeController) GetDispute(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	d, err := c.disputeUsecase.GetDispute(ctx.Request.Context(), userID, ctx.Param("id"))
+	if err != nil {
+		respondDisputeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Dispute retrieved successfully",
+		Data:    d,
+	})
+}
+
+// RespondToDispute handles POST /disputes/:id/response
+func (c *DisputeController) RespondToDispute(ctx *gin.Context) {
+	sellerID := ctx.GetString("userID")
+	if sellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.DisputeReplyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	d, err := c.disputeUsecase.RespondToDispute(ctx.Request.Context(), sellerID, ctx.Param("id"), req.Response)
+	if err != nil {
+		respondDisputeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Response recorded",
+		Data:    d,
+	})
+}
+
+// ListDisputes handles GET /admin/disputes?status=
+func (c *DisputeController) ListDisputes(ctx *gin.Context) {
+	req, err := pageRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	page, err := c.disputeUsecase.ListDisputes(ctx.Request.Context(), dispute.Status(ctx.Query("status")), req)
+	if err != nil {
+		respondDisputeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// ResolveDispute handles POST /admin/disputes/:id/resolve
+func (c *DisputeController) ResolveDispute(ctx *gin.Context) {
+	adminID := ctx.GetString("userID")
+	if adminID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.ResolveDisputeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	d, err := c.disputeUsecase.ResolveDispute(ctx.Request.Context(), adminID, ctx.Param("id"), dispute.Outcome(req.Outcome), req.RefundAmount, req.Note)
+	if err != nil {
+		respondDisputeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Dispute resolved",
+		Data:    d,
+	})
+}
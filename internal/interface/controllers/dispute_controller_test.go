@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockDisputeUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDisputeUsecase) OpenDispute(ctx context.Context, buyerID string, d *dispute.Dispute) (*dispute.Dispute, error) {
+	args := m.Called(ctx, buyerID, d)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) RespondToDispute(ctx context.Context, sellerID string, disputeID string, response string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, sellerID, disputeID, response)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) ResolveDispute(ctx context.Context, adminID string, disputeID string, outcome dispute.Outcome, refundAmount money.Money, note string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, adminID, disputeID, outcome, refundAmount, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) GetDispute(ctx context.Context, userID string, disputeID string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, userID, disputeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) ListUserDisputes(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*dispute.Dispute]), args.Error(1)
+}
+
+func (m *MockDisputeUsecase) ListDisputes(ctx context.Context, status dispute.Status, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	args := m.Called(ctx, status, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*dispute.Dispute]), args.Error(1)
+}
+
+type DisputeControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockDisputeUsecase
+	controller *DisputeController
+}
+
+func (suite *DisputeControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockDisputeUsecase)
+	suite.controller = NewDisputeController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestDisputeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(DisputeControllerTestSuite))
+}
+
+func (suite *DisputeControllerTestSuite) newRequest(userID, path, disputeID string, body interface{}) (*gin.Context, *httptest.ResponseRecorder) {
+	payload, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	if userID != "" {
+		c.Set("userID", userID)
+	}
+	if disputeID != "" {
+		c.Params = gin.Params{{Key: "id", Value: disputeID}}
+	}
+	c.Request = httptest.NewRequest("POST", path, bytes.NewBuffer(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func (suite *DisputeControllerTestSuite) TestOpenDispute_Success() {
+	opened := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "consumer1", SellerID: "reseller1", Status: dispute.StatusOpen}
+	suite.usecase.On("OpenDispute", mock.Anything, "consumer1", mock.MatchedBy(func(d *dispute.Dispute) bool {
+		return d.OrderID == "order1" && d.Reason == dispute.ReasonDamaged && len(d.Photos) == 1
+	})).Return(opened, nil)
+
+	c, w := suite.newRequest("consumer1", "/disputes", "", map[string]interface{}{
+		"order_id":         "order1",
+		"reason":           "damaged",
+		"photos":           []string{"https://img/1.jpg"},
+		"requested_remedy": "refund",
+	})
+	suite.controller.OpenDispute(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *DisputeControllerTestSuite) TestOpenDispute_MissingReason() {
+	c, w := suite.newRequest("consumer1", "/disputes", "", map[string]interface{}{
+		"order_id":         "order1",
+		"requested_remedy": "refund",
+	})
+	suite.controller.OpenDispute(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "OpenDispute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DisputeControllerTestSuite) TestOpenDispute_AlreadyDisputed() {
+	suite.usecase.On("OpenDispute", mock.Anything, "consumer1", mock.Anything).Return(nil, dispute.ErrAlreadyDisputed)
+
+	c, w := suite.newRequest("consumer1", "/disputes", "", map[string]interface{}{
+		"order_id":         "order1",
+		"reason":           "damaged",
+		"requested_remedy": "refund",
+	})
+	suite.controller.OpenDispute(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *DisputeControllerTestSuite) TestRespondToDispute_NotSeller() {
+	suite.usecase.On("RespondToDispute", mock.Anything, "reseller9", "d1", "not mine").Return(nil, dispute.ErrNotDisputeParty)
+
+	c, w := suite.newRequest("reseller9", "/disputes/d1/response", "d1", map[string]interface{}{"response": "not mine"})
+	suite.controller.RespondToDispute(c)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *DisputeControllerTestSuite) TestResolveDispute_Success() {
+	resolved := &dispute.Dispute{ID: "d1", Status: dispute.StatusResolved, Outcome: dispute.OutcomePartialRefund, RefundAmount: money.New(4000, money.ETB)}
+	suite.usecase.On("ResolveDispute", mock.Anything, "admin1", "d1", dispute.OutcomePartialRefund, money.New(4000, money.ETB), "half the pieces were grade C").Return(resolved, nil)
+
+	c, w := suite.newRequest("admin1", "/admin/disputes/d1/resolve", "d1", map[string]interface{}{
+		"outcome":       "partial_refund",
+		"refund_amount": map[string]interface{}{"amount": 4000, "currency": "ETB"},
+		"note":          "half the pieces were grade C",
+	})
+	suite.controller.ResolveDispute(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"outcome":"partial_refund"`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *DisputeControllerTestSuite) TestResolveDispute_InvalidOutcome() {
+	suite.usecase.On("ResolveDispute", mock.Anything, "admin1", "d1", dispute.Outcome("maybe"), money.Money{}, "").Return(nil, dispute.ErrInvalidOutcome)
+
+	c, w := suite.newRequest("admin1", "/admin/disputes/d1/resolve", "d1", map[string]interface{}{"outcome": "maybe"})
+	suite.controller.ResolveDispute(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
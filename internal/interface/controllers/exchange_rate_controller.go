@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateController struct {
+	moneyUsecase money.Usecase
+}
+
+func NewExchangeRateController(moneyUsecase money.Usecase) *ExchangeRateController {
+	return &ExchangeRateController{moneyUsecase: moneyUsecase}
+}
+
+func moneyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, money.ErrRateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, money.ErrUnknownCurrency), errors.Is(err, money.ErrInvalidRate):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func respondMoneyError(ctx *gin.Context, err error) {
+	ctx.JSON(moneyErrorStatus(err), common.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+// SetRate handles PUT /admin/exchange-rates
+func (c *ExchangeRateController) SetRate(ctx *gin.Context) {
+	var req models.ExchangeRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	rate := &money.Rate{
+		From: money.Currency(strings.ToUpper(req.From)),
+		To:   money.Currency(strings.ToUpper(req.To)),
+		Rate: req.Rate,
+	}
+	saved, err := c.moneyUsecase.SetRate(ctx.Request.Context(), ctx.GetString("userID"), rate)
+	if err != nil {
+		respondMoneyError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Exchange rate saved",
+		Data:    saved,
+	})
+}
+
+// ListRates handles GET /exchange-rates and GET /admin/exchange-rates
+func (c *ExchangeRateController) ListRates(ctx *gin.Context) {
+	rates, err := c.moneyUsecase.ListRates(ctx.Request.Context())
+	if err != nil {
+		respondMoneyError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Exchange rates retrieved successfully",
+		Data:    rates,
+	})
+}
+
+// DeleteRate handles DELETE /admin/exchange-rates/:from/:to
+func (c *ExchangeRateController) DeleteRate(ctx *gin.Context) {
+	from := money.Currency(strings.ToUpper(ctx.Param("from")))
+	to := money.Currency(strings.ToUpper(ctx.Param("to")))
+	if err := c.moneyUsecase.DeleteRate(ctx.Request.Context(), from, to); err != nil {
+		respondMoneyError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Exchange rate deleted",
+	})
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockMoneyUsecase struct {
+	MockConverter
+}
+
+func (m *MockMoneyUsecase) SetRate(ctx context.Context, adminID string, r *money.Rate) (*money.Rate, error) {
+	args := m.Called(ctx, adminID, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*money.Rate), args.Error(1)
+}
+
+func (m *MockMoneyUsecase) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*money.Rate), args.Error(1)
+}
+
+func (m *MockMoneyUsecase) DeleteRate(ctx context.Context, from, to money.Currency) error {
+	args := m.Called(ctx, from, to)
+	return args.Error(0)
+}
+
+type ExchangeRateControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockMoneyUsecase
+	controller *ExchangeRateController
+}
+
+func (suite *ExchangeRateControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockMoneyUsecase)
+	suite.controller = NewExchangeRateController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestExchangeRateControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeRateControllerTestSuite))
+}
+
+func (suite *ExchangeRateControllerTestSuite) newRequest(method string, params gin.Params, body interface{}) (*gin.Context, *httptest.ResponseRecorder) {
+	payload, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "admin1")
+	c.Params = params
+	c.Request = httptest.NewRequest(method, "/admin/exchange-rates", bytes.NewBuffer(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func (suite *ExchangeRateControllerTestSuite) TestSetRate_Success() {
+	suite.usecase.On("SetRate", mock.Anything, "admin1", mock.MatchedBy(func(r *money.Rate) bool {
+		return r.From == money.USD && r.To == money.ETB && r.Rate == 57.5
+	})).Return(&money.Rate{From: money.USD, To: money.ETB, Rate: 57.5}, nil)
+
+	c, w := suite.newRequest("PUT", nil, map[string]interface{}{"from": "usd", "to": "etb", "rate": 57.5})
+	suite.controller.SetRate(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ExchangeRateControllerTestSuite) TestSetRate_Invalid() {
+	suite.usecase.On("SetRate", mock.Anything, "admin1", mock.Anything).Return(nil, money.ErrInvalidRate)
+
+	c, w := suite.newRequest("PUT", nil, map[string]interface{}{"from": "USD", "to": "USD", "rate": 1})
+	suite.controller.SetRate(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ExchangeRateControllerTestSuite) TestSetRate_MissingRate() {
+	c, w := suite.newRequest("PUT", nil, map[string]interface{}{"from": "USD", "to": "ETB"})
+	suite.controller.SetRate(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "SetRate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExchangeRateControllerTestSuite) TestListRates_Success() {
+	suite.usecase.On("ListRates", mock.Anything).Return([]*money.Rate{{From: money.USD, To: money.ETB, Rate: 57.5}}, nil)
+
+	c, w := suite.newRequest("GET", nil, nil)
+	suite.controller.ListRates(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"rate":57.5`)
+}
+
+func (suite *ExchangeRateControllerTestSuite) TestDeleteRate_NotFound() {
+	suite.usecase.On("DeleteRate", mock.Anything, money.USD, money.ETB).Return(money.ErrRateNotFound)
+
+	c, w := suite.newRequest("DELETE", gin.Params{{Key: "from", Value: "usd"}, {Key: "to", Value: "etb"}}, nil)
+	suite.controller.DeleteRate(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
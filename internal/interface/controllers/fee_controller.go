@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type FeeController struct {
+	feeUsecase fee.Usecase
+}
+
+func NewFeeController(feeUsecase fee.Usecase) *FeeController {
+	return &FeeController{feeUsecase: feeUsecase}
+}
+
+func feeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, fee.ErrRuleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, fee.ErrVersionConflict):
+		return http.StatusConflict
+	case errors.Is(err, fee.ErrNameRequired), errors.Is(err, fee.ErrInvalidPaymentType),
+		errors.Is(err, fee.ErrInvalidTier), errors.Is(err, fee.ErrInvalidAmounts),
+		errors.Is(err, fee.ErrNoFeeComponent), errors.Is(err, fee.ErrInvalidCaps),
+		errors.Is(err, fee.ErrCurrencyRequired), errors.Is(err, money.ErrUnknownCurrency):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func respondFeeError(ctx *gin.Context, err error) {
+	ctx.JSON(feeErrorStatus(err), common.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+// bindFeeRule reads a fee rule from the request body, responding with 400 if it is malformed.
+func bindFeeRule(ctx *gin.Context) (*fee.Rule, bool) {
+	var req models.FeeRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return nil, false
+	}
+	return &fee.Rule{
+		Name:        req.Name,
+		PaymentType: payment.PaymentType(req.PaymentType),
+		SellerTier:  fee.SellerTier(req.SellerTier),
+		Category:    req.Category,
+		Currency:    money.Currency(strings.ToUpper(req.Currency)),
+		Percent:     req.Percent,
+		Flat:        req.Flat,
+		MinFee:      req.MinFee,
+		MaxFee:      req.MaxFee,
+		Priority:    req.Priority,
+	}, true
+}
+
+// CreateRule handles POST /admin/fee-rules
+func (c *FeeController) CreateRule(ctx *gin.Context) {
+	rule, ok := bindFeeRule(ctx)
+	if !ok {
+		return
+	}
+
+	created, err := c.feeUsecase.CreateRule(ctx.Request.Context(), ctx.GetString("userID"), rule)
+	if err != nil {
+		respondFeeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Fee rule created",
+		Data:    created,
+	})
+}
+
+// ListRules handles GET /admin/fee-rules
+func (c *FeeController) ListRules(ctx *gin.Context) {
+	rules, err := c.feeUsecase.ListRules(ctx.Request.Context())
+	if err != nil {
+		respondFeeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Fee rules retrieved successfully",
+		Data:    rules,
+	})
+}
+
+// GetRule handles GET /admin/fee-rules/:id
+func (c *FeeController) GetRule(ctx *gin.Context) {
+	rule, err := c.feeUsecase.GetRule(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		respondFeeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Fee rule retrieved successfully",
+		Data:    rule,
+	})
+}
+
+// UpdateRule handles PUT /admin/fee-rules/:id
+func (c *FeeController) UpdateRule(ctx *gin.Context) {
+	rule, ok := bindFeeRule(ctx)
+	if !ok {
+		return
+	}
+
+	updated, err := c.feeUsecase.UpdateRule(ctx.Request.Context(), ctx.GetString("userID"), ctx.Param("id"), rule)
+	if err != nil {
+		respondFeeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Fee rule updated",
+		Data:    updated,
+	})
+}
+
+// DeleteRule handles DELETE /admin/fee-rules/:id
+func (c *FeeController) DeleteRule(ctx *gin.Context) {
+	if err := c.feeUsecase.DeleteRule(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		respondFeeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Fee rule deleted",
+	})
+}
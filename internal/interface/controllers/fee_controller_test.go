@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockFeeUsecase struct {
+	mock.Mock
+}
+
+func (m *MockFeeUsecase) Quote(ctx context.Context, s fee.Sale) (*fee.Quote, error) {
+	args := m.Called(ctx, s)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Quote), args.Error(1)
+}
+
+func (m *MockFeeUsecase) CreateRule(ctx context.Context, adminID string, r *fee.Rule) (*fee.Rule, error) {
+	args := m.Called(ctx, adminID, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Rule), args.Error(1)
+}
+
+func (m *MockFeeUsecase) UpdateRule(ctx context.Context, adminID string, id string, r *fee.Rule) (*fee.Rule, error) {
+	args := m.Called(ctx, adminID, id, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Rule), args.Error(1)
+}
+
+func (m *MockFeeUsecase) DeleteRule(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockFeeUsecase) GetRule(ctx context.Context, id string) (*fee.Rule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Rule), args.Error(1)
+}
+
+func (m *MockFeeUsecase) ListRules(ctx context.Context) ([]*fee.Rule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fee.Rule), args.Error(1)
+}
+
+type FeeControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockFeeUsecase
+	controller *FeeController
+}
+
+func (suite *FeeControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockFeeUsecase)
+	suite.controller = NewFeeController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestFeeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(FeeControllerTestSuite))
+}
+
+func (suite *FeeControllerTestSuite) newRequest(method, ruleID string, body interface{}) (*gin.Context, *httptest.ResponseRecorder) {
+	payload, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "admin1")
+	if ruleID != "" {
+		c.Params = gin.Params{{Key: "id", Value: ruleID}}
+	}
+	c.Request = httptest.NewRequest(method, "/admin/fee-rules", bytes.NewBuffer(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func (suite *FeeControllerTestSuite) TestCreateRule_Success() {
+	suite.usecase.On("CreateRule", mock.Anything, "admin1", mock.MatchedBy(func(r *fee.Rule) bool {
+		return r.PaymentType == payment.B2B && r.SellerTier == fee.TierTop && r.Percent == 1.5 && r.MaxFee == 5000 && r.Currency == money.ETB
+	})).Return(&fee.Rule{ID: "rule1", Version: 1}, nil)
+
+	c, w := suite.newRequest("POST", "", map[string]interface{}{
+		"name":         "Top suppliers",
+		"payment_type": "b2b",
+		"seller_tier":  "top",
+		"currency":     "etb",
+		"percent":      1.5,
+		"max_fee":      5000,
+	})
+	suite.controller.CreateRule(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *FeeControllerTestSuite) TestCreateRule_Invalid() {
+	suite.usecase.On("CreateRule", mock.Anything, "admin1", mock.Anything).Return(nil, fee.ErrInvalidTier)
+
+	c, w := suite.newRequest("POST", "", map[string]interface{}{"name": "x", "seller_tier": "gold", "percent": 1})
+	suite.controller.CreateRule(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *FeeControllerTestSuite) TestUpdateRule_NotFound() {
+	suite.usecase.On("UpdateRule", mock.Anything, "admin1", "missing", mock.Anything).Return(nil, fee.ErrRuleNotFound)
+
+	c, w := suite.newRequest("PUT", "missing", map[string]interface{}{"name": "x", "percent": 1})
+	suite.controller.UpdateRule(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *FeeControllerTestSuite) TestDeleteRule_Success() {
+	suite.usecase.On("DeleteRule", mock.Anything, "rule1").Return(nil)
+
+	c, w := suite.newRequest("DELETE", "rule1", nil)
+	suite.controller.DeleteRule(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
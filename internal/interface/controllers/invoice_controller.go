@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceController struct {
+	invoiceUsecase invoice.Usecase
+}
+
+func NewInvoiceController(invoiceUsecase invoice.Usecase) *InvoiceController {
+	return &InvoiceController{invoiceUsecase: invoiceUsecase}
+}
+
+func invoiceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound), errors.Is(err, order.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, invoice.ErrNotInvoiceParty):
+		return http.StatusForbidden
+	case errors.Is(err, invoice.ErrNotInvoiceable), errors.Is(err, invoice.ErrInvoiceChanged):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func sendInvoice(ctx *gin.Context, inv *invoice.Invoice, doc []byte) {
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", inv.FileName()))
+	ctx.Data(http.StatusOK, "application/pdf", doc)
+}
+
+// GetInvoice handles GET /orders/:id/invoice
+func (c *InvoiceController) GetInvoice(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	inv, doc, err := c.invoiceUsecase.GetInvoice(ctx.Request.Context(), userID, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(invoiceErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	sendInvoice(ctx, inv, doc)
+}
+
+// RegenerateInvoice handles POST /admin/orders/:id/invoice
+func (c *InvoiceController) RegenerateInvoice(ctx *gin.Context) {
+	adminID := ctx.GetString("userID")
+	if adminID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	inv, doc, err := c.invoiceUsecase.RegenerateInvoice(ctx.Request.Context(), adminID, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(invoiceErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	sendInvoice(ctx, inv, doc)
+}
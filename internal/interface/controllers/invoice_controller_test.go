@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockInvoiceUsecase struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceUsecase) IssueInvoice(ctx context.Context, o *order.Order) (*invoice.Invoice, error) {
+	args := m.Called(ctx, o)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceUsecase) GetInvoice(ctx context.Context, userID, orderID string) (*invoice.Invoice, []byte, error) {
+	args := m.Called(ctx, userID, orderID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *MockInvoiceUsecase) RegenerateInvoice(ctx context.Context, adminID, orderID string) (*invoice.Invoice, []byte, error) {
+	args := m.Called(ctx, adminID, orderID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Get(1).([]byte), args.Error(2)
+}
+
+type InvoiceControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockInvoiceUsecase
+	controller *InvoiceController
+}
+
+func (suite *InvoiceControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockInvoiceUsecase)
+	suite.controller = NewInvoiceController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestInvoiceControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(InvoiceControllerTestSuite))
+}
+
+func (suite *InvoiceControllerTestSuite) newRequest(method, userID, orderID string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	if userID != "" {
+		c.Set("userID", userID)
+	}
+	c.Params = gin.Params{{Key: "id", Value: orderID}}
+	c.Request = httptest.NewRequest(method, "/orders/"+orderID+"/invoice", nil)
+	return c, w
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_Success() {
+	inv := &invoice.Invoice{ID: "order1", Number: "INV-PLIER1-000007"}
+	suite.usecase.On("GetInvoice", mock.Anything, "reseller1", "order1").Return(inv, []byte("%PDF-1.4"), nil)
+
+	c, w := suite.newRequest("GET", "reseller1", "order1")
+	suite.controller.GetInvoice(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), `attachment; filename="INV-PLIER1-000007.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(suite.T(), "%PDF-1.4", w.Body.String())
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_NotParty() {
+	suite.usecase.On("GetInvoice", mock.Anything, "consumer9", "order1").Return(nil, nil, invoice.ErrNotInvoiceParty)
+
+	c, w := suite.newRequest("GET", "consumer9", "order1")
+	suite.controller.GetInvoice(c)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_OrderNotFound() {
+	suite.usecase.On("GetInvoice", mock.Anything, "consumer1", "missing").Return(nil, nil, order.ErrOrderNotFound)
+
+	c, w := suite.newRequest("GET", "consumer1", "missing")
+	suite.controller.GetInvoice(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *InvoiceControllerTestSuite) TestGetInvoice_Unauthorized() {
+	c, w := suite.newRequest("GET", "", "order1")
+	suite.controller.GetInvoice(c)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "GetInvoice", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InvoiceControllerTestSuite) TestRegenerateInvoice_Success() {
+	inv := &invoice.Invoice{ID: "order1", Number: "INV-PLIER1-000007", Revision: 1}
+	suite.usecase.On("RegenerateInvoice", mock.Anything, "admin1", "order1").Return(inv, []byte("%PDF-1.4"), nil)
+
+	c, w := suite.newRequest("POST", "admin1", "order1")
+	suite.controller.RegenerateInvoice(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "application/pdf", w.Header().Get("Content-Type"))
+}
+
+func (suite *InvoiceControllerTestSuite) TestRegenerateInvoice_Concurrent() {
+	suite.usecase.On("RegenerateInvoice", mock.Anything, "admin1", "order1").Return(nil, nil, invoice.ErrInvoiceChanged)
+
+	c, w := suite.newRequest("POST", "admin1", "order1")
+	suite.controller.RegenerateInvoice(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type LedgerController struct {
+	ledgerUsecase ledger.Usecase
+}
+
+func NewLedgerController(ledgerUsecase ledger.Usecase) *LedgerController {
+	return &LedgerController{ledgerUsecase: ledgerUsecase}
+}
+
+// GetBalance handles GET /ledger/balance
+func (c *LedgerController) GetBalance(ctx *gin.Context) {
+	sellerID := ctx.GetString("userID")
+	if sellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	balance, err := c.ledgerUsecase.GetSellerBalance(ctx.Request.Context(), sellerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Balance retrieved successfully",
+		Data:    balance,
+	})
+}
+
+// RunPayouts handles POST /admin/payouts
+func (c *LedgerController) RunPayouts(ctx *gin.Context) {
+	batch, err := c.ledgerUsecase.RunPayouts(ctx.Request.Context(), ctx.GetString("userID"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	message := "Payout batch created"
+	if len(batch.Payouts) == 0 {
+		message = "No available funds to pay out"
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    batch,
+	})
+}
+
+// Reconcile handles GET /admin/ledger/reconciliation
+func (c *LedgerController) Reconcile(ctx *gin.Context) {
+	r, err := c.ledgerUsecase.Reconcile(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Reconciliation complete",
+		Data:    r,
+	})
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockLedgerUsecase struct {
+	mock.Mock
+}
+
+func (m *MockLedgerUsecase) GetSellerBalance(ctx context.Context, sellerID string) (*ledger.Balance, error) {
+	args := m.Called(ctx, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledger.Balance), args.Error(1)
+}
+
+func (m *MockLedgerUsecase) RunPayouts(ctx context.Context, adminID string) (*ledger.PayoutBatch, error) {
+	args := m.Called(ctx, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledger.PayoutBatch), args.Error(1)
+}
+
+func (m *MockLedgerUsecase) Reconcile(ctx context.Context) (*ledger.Reconciliation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledger.Reconciliation), args.Error(1)
+}
+
+type LedgerControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockLedgerUsecase
+	controller *LedgerController
+}
+
+func (suite *LedgerControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockLedgerUsecase)
+	suite.controller = NewLedgerController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestLedgerControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerControllerTestSuite))
+}
+
+func (suite *LedgerControllerTestSuite) newContext(method, path, userID string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	if userID != "" {
+		c.Set("userID", userID)
+	}
+	c.Request = httptest.NewRequest(method, path, nil)
+	return c, w
+}
+
+func (suite *LedgerControllerTestSuite) TestGetBalance_Success() {
+	suite.usecase.On("GetSellerBalance", mock.Anything, "supplier1").Return(&ledger.Balance{SellerID: "supplier1", Available: []money.Money{money.New(4900, money.ETB)}}, nil)
+
+	c, w := suite.newContext("GET", "/ledger/balance", "supplier1")
+	suite.controller.GetBalance(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"available":[{"amount":4900,"currency":"ETB"}]`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *LedgerControllerTestSuite) TestGetBalance_Unauthorized() {
+	c, w := suite.newContext("GET", "/ledger/balance", "")
+	suite.controller.GetBalance(c)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "GetSellerBalance", mock.Anything, mock.Anything)
+}
+
+func (suite *LedgerControllerTestSuite) TestRunPayouts_Success() {
+	batch := &ledger.PayoutBatch{ID: "batch1", Payouts: []ledger.Payout{{SellerID: "supplier1", Amount: money.New(9800, money.ETB)}}, Totals: []money.Money{money.New(9800, money.ETB)}}
+	suite.usecase.On("RunPayouts", mock.Anything, "admin1").Return(batch, nil)
+
+	c, w := suite.newContext("POST", "/admin/payouts", "admin1")
+	suite.controller.RunPayouts(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "Payout batch created")
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *LedgerControllerTestSuite) TestReconcile_Error() {
+	suite.usecase.On("Reconcile", mock.Anything).Return(nil, errors.New("database error"))
+
+	c, w := suite.newContext("GET", "/admin/ledger/reconciliation", "admin1")
+	suite.controller.Reconcile(c)
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/gin-gonic/gin"
+)
+
+// queryCurrency reads the optional ?currency= parameter. An empty result means the
+// caller expressed no preference.
+func queryCurrency(ctx *gin.Context) (money.Currency, error) {
+	code := ctx.Query("currency")
+	if code == "" {
+		return "", nil
+	}
+	return money.ParseCurrency(code)
+}
+
+// displayPrice converts a price for display. It returns nil when no conversion was
+// asked for or no rate is configured, so the listing price is shown on its own.
+func displayPrice(ctx context.Context, converter money.Converter, price money.Money, to money.Currency) *money.Money {
+	if converter == nil || to == "" || to == price.Currency {
+		return nil
+	}
+	converted, err := converter.Convert(ctx, price, to)
+	if err != nil {
+		return nil
+	}
+	return &converted
+}
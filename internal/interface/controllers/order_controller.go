@@ -1,10 +1,18 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	OrderUsecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
 	"github.com/gin-gonic/gin"
 )
@@ -20,6 +28,10 @@ func NewOrderController(orderUseCase OrderUsecase.OrderUseCase) *OrderController
 func (c *OrderController) PurchaseBundle(ctx *gin.Context) {
 	type Request struct {
 		BundleID string `json:"bundle_id"`
+		// Currency is what the reseller pays in; empty means the bundle's currency.
+		Currency       string `json:"currency"`
+		AddressID      string `json:"address_id"`
+		DeliveryOption string `json:"delivery_option"`
 	}
 
 	var req Request
@@ -27,6 +39,11 @@ func (c *OrderController) PurchaseBundle(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload; bundleId is required"})
 		return
 	}
+	if req.AddressID == "" || req.DeliveryOption == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "address_id and delivery_option are required"})
+		return
+	}
+	delivery := shipping.Selection{AddressID: req.AddressID, Option: shipping.Option(req.DeliveryOption)}
 
 	resellerID, _ := ctx.Get("userID")
 
@@ -39,7 +56,25 @@ func (c *OrderController) PurchaseBundle(ctx *gin.Context) {
 		return
 	}
 
-	order, payment, warehouseItem, err := c.orderUseCase.PurchaseBundle(ctx, req.BundleID, resellerIDStr)
+	var currency money.Currency
+	if req.Currency != "" {
+		parsed, err := money.ParseCurrency(req.Currency)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		currency = parsed
+	}
+
+	order, payment, warehouseItem, err := c.orderUseCase.PurchaseBundle(ctx, req.BundleID, resellerIDStr, currency, delivery)
+	if errors.Is(err, bundle.ErrBundleAlreadySold) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if status := shippingErrorStatus(err); err != nil && status != http.StatusInternalServerError {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -85,29 +120,30 @@ func (c *OrderController) GetSoldBundleHistory(ctx *gin.Context) {
 		return
 	}
 
-	orders, userNames, err := c.orderUseCase.GetSoldBundleHistory(ctx, supplierIDStr)
+	req, err := pageRequest(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
 			Success: false,
-			Message: fmt.Sprintf("failed to get sold bundle history: %v", err),
+			Message: err.Error(),
 		})
 		return
 	}
 
-	var formattedOrders []map[string]interface{}
-	for _, order := range orders {
-		formattedOrders = append(formattedOrders, map[string]interface{}{
-			"order": order,
-			"resellerUsername": userNames[order.ResellerID],
+	page, userNames, err := c.orderUseCase.GetSoldBundleHistory(ctx, supplierIDStr, req)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to get sold bundle history: %v", err),
 		})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, common.APIResponse{
-		Success: true,
-		Data: gin.H{
-			"orders": formattedOrders,
-		},
-	})
+	ctx.JSON(http.StatusOK, pagination.Map(page, func(o *order.Order) gin.H {
+		return gin.H{
+			"order":            o,
+			"resellerUsername": userNames[o.ResellerID],
+		}
+	}))
 }
 
 func (c *OrderController) GetOrdersByReseller(ctx *gin.Context) {
@@ -129,40 +165,39 @@ func (c *OrderController) GetOrdersByReseller(ctx *gin.Context) {
 		return
 	}
 
-	orders, userNames, err := c.orderUseCase.GetOrdersByReseller(ctx, resellerIDStr)
+	req, err := pageRequest(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
 			Success: false,
-			Message: fmt.Sprintf("failed to get reseller orders: %v", err),
+			Message: err.Error(),
 		})
 		return
 	}
 
-	// Separate orders into sold and bought
-	var soldOrders []map[string]interface{}
-	var boughtOrders []map[string]interface{}
-
-	for _, order := range orders {
-		if len(order.ProductIDs) > 0 { // Sold order
-			soldOrders = append(soldOrders, map[string]interface{}{
-				"order":        order,
-				"consumerName": userNames[order.ConsumerID],
-			})
-		} else if order.BundleID != "" { // Bought order
-			boughtOrders = append(boughtOrders, map[string]interface{}{
-				"order":      order,
-				"supplierName": userNames[order.SupplierID],
-			})
-		}
+	page, userNames, err := c.orderUseCase.GetOrdersByReseller(ctx, resellerIDStr, req)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to get reseller orders: %v", err),
+		})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, common.APIResponse{
-		Success: true,
-		Data: gin.H{
-			"sold":   soldOrders,
-			"bought": boughtOrders,
-		},
-	})
+	// Sold and bought orders share one timeline, so each is tagged with its kind.
+	ctx.JSON(http.StatusOK, pagination.Map(page, func(o *order.Order) gin.H {
+		if len(o.ProductIDs) > 0 {
+			return gin.H{
+				"kind":         "sold",
+				"order":        o,
+				"consumerName": userNames[o.ConsumerID],
+			}
+		}
+		return gin.H{
+			"kind":         "bought",
+			"order":        o,
+			"supplierName": userNames[o.SupplierID],
+		}
+	}))
 }
 
 func (c *OrderController) GetOrderHistory(ctx *gin.Context) {
@@ -184,20 +219,28 @@ func (c *OrderController) GetOrderHistory(ctx *gin.Context) {
 		return
 	}
 
-	orders, userNames, productNames, err := c.orderUseCase.GetOrdersByConsumer(ctx, consumerIDStr)
+	req, err := pageRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	page, userNames, productNames, err := c.orderUseCase.GetOrdersByConsumer(ctx, consumerIDStr, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.APIResponse{
+		ctx.JSON(listErrorStatus(err), common.APIResponse{
 			Success: false,
 			Message: fmt.Sprintf("failed to get order history: %v", err),
 		})
 		return
 	}
 
-	var formattedOrders []map[string]interface{}
-	for _, order := range orders {
+	ctx.JSON(http.StatusOK, pagination.Map(page, func(o *order.Order) gin.H {
 		// Get product details for this order
 		var products []map[string]interface{}
-		for _, productID := range order.ProductIDs {
+		for _, productID := range o.ProductIDs {
 			if name, exists := productNames[productID]; exists {
 				products = append(products, map[string]interface{}{
 					"id":    productID,
@@ -206,17 +249,110 @@ func (c *OrderController) GetOrderHistory(ctx *gin.Context) {
 			}
 		}
 
-		formattedOrders = append(formattedOrders, map[string]interface{}{
-			"order": order,
-			"resellerUsername": userNames[order.ResellerID],
-			"products": products,
-		})
+		return gin.H{
+			"order":            o,
+			"resellerUsername": userNames[o.ResellerID],
+			"products":         products,
+		}
+	}))
+}
+
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, order.ErrNotOrderParty):
+		return http.StatusForbidden
+	case errors.Is(err, order.ErrInvalidTransition), errors.Is(err, order.ErrStatusChanged),
+		errors.Is(err, shipment.ErrTrackingTaken):
+		return http.StatusConflict
+	case errors.Is(err, order.ErrMissingTracking):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
 	}
+}
 
+func (c *OrderController) respondOrderUpdate(ctx *gin.Context, o *order.Order, err error) {
+	if err != nil {
+		ctx.JSON(orderErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
 	ctx.JSON(http.StatusOK, common.APIResponse{
 		Success: true,
-		Data: gin.H{
-			"orders": formattedOrders,
-		},
+		Message: "Order is now " + string(o.Status),
+		Data:    o,
 	})
-}
\ No newline at end of file
+}
+
+// MarkOrderProcessing handles PATCH /orders/:id/processing
+func (c *OrderController) MarkOrderProcessing(ctx *gin.Context) {
+	sellerID := ctx.GetString("userID")
+	if sellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	o, err := c.orderUseCase.MarkOrderProcessing(ctx.Request.Context(), ctx.Param("id"), sellerID)
+	c.respondOrderUpdate(ctx, o, err)
+}
+
+// MarkOrderShipped handles PATCH /orders/:id/shipped
+func (c *OrderController) MarkOrderShipped(ctx *gin.Context) {
+	sellerID := ctx.GetString("userID")
+	if sellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.ShipOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	o, err := c.orderUseCase.MarkOrderShipped(ctx.Request.Context(), ctx.Param("id"), sellerID, req.TrackingNumber, req.Carrier)
+	c.respondOrderUpdate(ctx, o, err)
+}
+
+// ConfirmDelivery handles PATCH /orders/:id/delivered
+func (c *OrderController) ConfirmDelivery(ctx *gin.Context) {
+	buyerID := ctx.GetString("userID")
+	if buyerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	o, err := c.orderUseCase.ConfirmDelivery(ctx.Request.Context(), ctx.Param("id"), buyerID)
+	c.respondOrderUpdate(ctx, o, err)
+}
+
+// CancelOrder handles POST /orders/:id/cancel
+func (c *OrderController) CancelOrder(ctx *gin.Context) {
+	buyerID := ctx.GetString("userID")
+	if buyerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	o, err := c.orderUseCase.CancelOrder(ctx.Request.Context(), ctx.Param("id"), buyerID)
+	c.respondOrderUpdate(ctx, o, err)
+}
@@ -9,9 +9,14 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -30,8 +35,8 @@ type MockOrderUseCase struct {
 	mock.Mock
 }
 
-func (m *MockOrderUseCase) PurchaseBundle(ctx context.Context, bundleID, resellerID string) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
-	args := m.Called(ctx, bundleID, resellerID)
+func (m *MockOrderUseCase) PurchaseBundle(ctx context.Context, bundleID, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID, resellerID, currency, delivery)
 	if args.Get(0) == nil {
 		return nil, nil, nil, args.Error(3)
 	}
@@ -62,44 +67,110 @@ func (m *MockOrderUseCase) GetResellerMetrics(ctx context.Context, resellerID st
 	return args.Get(0).(*order.ResellerMetrics), args.Error(1)
 }
 
-func (m *MockOrderUseCase) GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, map[string]string, error) {
-	args := m.Called(ctx, supplierID)
+func (m *MockOrderUseCase) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Error(2)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
 }
 
-func (m *MockOrderUseCase) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, map[string]string, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockOrderUseCase) GetOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Error(2)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
+}
+
+func (m *MockOrderUseCase) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RefundOrder(ctx context.Context, orderID string, amount money.Money) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettlePayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
 }
 
-func (m *MockOrderUseCase) GetAdminDashboardMetrics(ctx context.Context) (*admin.Metrics, error) {
-	args := m.Called(ctx)
+func (m *MockOrderUseCase) SettleRefund(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*admin.Metrics), args.Error(1)
+	return args.Get(0).(*order.Order), args.Error(1)
 }
 
-func (m *MockOrderUseCase) PurchaseProduct(ctx context.Context, productID, consumerID string, totalPrice float64) (*order.Order, *payment.Payment, error) {
-	args := m.Called(ctx, productID, consumerID, totalPrice)
+func (m *MockOrderUseCase) SettleFailure(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCase) PurchaseProducts(ctx context.Context, consumerID string, products []*product.Product, currency money.Currency, delivery shipping.Selection) ([]*order.Order, []*payment.Payment, error) {
+	args := m.Called(ctx, consumerID, products, currency, delivery)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).(*order.Order), args.Get(1).(*payment.Payment), args.Error(2)
+	return args.Get(0).([]*order.Order), args.Get(1).([]*payment.Payment), args.Error(2)
+}
+
+func (m *MockOrderUseCase) MarkOrderProcessing(ctx context.Context, orderID, sellerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) MarkOrderShipped(ctx context.Context, orderID, sellerID, trackingNumber, carrier string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID, trackingNumber, carrier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ConfirmDelivery(ctx context.Context, orderID, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RecordDelivery(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) CancelOrder(ctx context.Context, orderID, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
 }
 
-func (m *MockOrderUseCase) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, map[string]string, map[string]string, error) {
-	args := m.Called(ctx, consumerID)
+func (m *MockOrderUseCase) GetOrdersByConsumer(ctx context.Context, consumerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, map[string]string, error) {
+	args := m.Called(ctx, consumerID, req)
 	if args.Get(0) == nil {
 		return nil, nil, nil, args.Error(3)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Get(2).(map[string]string), args.Error(3)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Get(2).(map[string]string), args.Error(3)
 }
 
 func (suite *OrderControllerTestSuite) SetupTest() {
@@ -113,13 +184,15 @@ func TestOrderControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(OrderControllerTestSuite))
 }
 
+var bundleDelivery = shipping.Selection{AddressID: "addr1", Option: shipping.Standard}
+
 func (suite *OrderControllerTestSuite) TestPurchaseBundle_Success() {
 	// Setup
 	expectedOrder := &order.Order{ID: "order123"}
 	expectedPayment := &payment.Payment{ID: "payment123"}
 	expectedWarehouseItem := &warehouse.WarehouseItem{ID: "warehouse123"}
 
-	suite.orderUseCase.On("PurchaseBundle", mock.Anything, "bundle123", "reseller123").
+	suite.orderUseCase.On("PurchaseBundle", mock.Anything, "bundle123", "reseller123", money.Currency(""), bundleDelivery).
 		Return(expectedOrder, expectedPayment, expectedWarehouseItem, nil)
 
 	// Create test request
@@ -127,7 +200,7 @@ func (suite *OrderControllerTestSuite) TestPurchaseBundle_Success() {
 	c, _ := gin.CreateTestContext(w)
 	c.Set("userID", "reseller123")
 
-	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123"})
+	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123", "address_id": "addr1", "delivery_option": "standard"})
 	c.Request = httptest.NewRequest("POST", "/purchase", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
@@ -139,6 +212,141 @@ func (suite *OrderControllerTestSuite) TestPurchaseBundle_Success() {
 	suite.orderUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *OrderControllerTestSuite) TestPurchaseBundle_AlreadySold() {
+	// Setup
+	suite.orderUseCase.On("PurchaseBundle", mock.Anything, "bundle123", "reseller123", money.Currency(""), bundleDelivery).
+		Return(nil, nil, nil, bundle.ErrBundleAlreadySold)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+
+	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123", "address_id": "addr1", "delivery_option": "standard"})
+	c.Request = httptest.NewRequest("POST", "/purchase", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.PurchaseBundle(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *OrderControllerTestSuite) TestMarkOrderShipped_Success() {
+	// Setup
+	shipped := &order.Order{ID: "order123", Status: order.Shipped, TrackingNumber: "TRK1", Carrier: "DHL"}
+	suite.orderUseCase.On("MarkOrderShipped", mock.Anything, "order123", "reseller123", "TRK1", "DHL").Return(shipped, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+	c.Params = gin.Params{{Key: "id", Value: "order123"}}
+
+	body, _ := json.Marshal(gin.H{"tracking_number": "TRK1", "carrier": "DHL"})
+	c.Request = httptest.NewRequest("PATCH", "/orders/order123/shipped", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.MarkOrderShipped(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"tracking_number":"TRK1"`)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *OrderControllerTestSuite) TestMarkOrderShipped_TrackingTaken() {
+	// Setup
+	suite.orderUseCase.On("MarkOrderShipped", mock.Anything, "order123", "reseller123", "TRK1", "DHL").Return(nil, shipment.ErrTrackingTaken)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+	c.Params = gin.Params{{Key: "id", Value: "order123"}}
+
+	body, _ := json.Marshal(gin.H{"tracking_number": "TRK1", "carrier": "DHL"})
+	c.Request = httptest.NewRequest("PATCH", "/orders/order123/shipped", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.MarkOrderShipped(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *OrderControllerTestSuite) TestMarkOrderShipped_MissingCarrier() {
+	// Setup
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+	c.Params = gin.Params{{Key: "id", Value: "order123"}}
+
+	body, _ := json.Marshal(gin.H{"tracking_number": "TRK123"})
+	c.Request = httptest.NewRequest("PATCH", "/orders/order123/shipped", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.MarkOrderShipped(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.orderUseCase.AssertNotCalled(suite.T(), "MarkOrderShipped", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OrderControllerTestSuite) TestMarkOrderProcessing_InvalidTransition() {
+	// Setup
+	suite.orderUseCase.On("MarkOrderProcessing", mock.Anything, "order123", "supplier123").Return(nil, order.ErrInvalidTransition)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "supplier123")
+	c.Params = gin.Params{{Key: "id", Value: "order123"}}
+	c.Request = httptest.NewRequest("PATCH", "/orders/order123/processing", nil)
+
+	// Execute
+	suite.controller.MarkOrderProcessing(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *OrderControllerTestSuite) TestConfirmDelivery_NotBuyer() {
+	// Setup
+	suite.orderUseCase.On("ConfirmDelivery", mock.Anything, "order123", "consumer999").Return(nil, order.ErrNotOrderParty)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "consumer999")
+	c.Params = gin.Params{{Key: "id", Value: "order123"}}
+	c.Request = httptest.NewRequest("PATCH", "/orders/order123/delivered", nil)
+
+	// Execute
+	suite.controller.ConfirmDelivery(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *OrderControllerTestSuite) TestCancelOrder_AfterShipment() {
+	// Setup
+	suite.orderUseCase.On("CancelOrder", mock.Anything, "order123", "consumer123").Return(nil, order.ErrInvalidTransition)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "consumer123")
+	c.Params = gin.Params{{Key: "id", Value: "order123"}}
+	c.Request = httptest.NewRequest("POST", "/orders/order123/cancel", nil)
+
+	// Execute
+	suite.controller.CancelOrder(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
 func (suite *OrderControllerTestSuite) TestPurchaseBundle_InvalidPayload() {
 	// Setup
 	w := httptest.NewRecorder()
@@ -155,12 +363,50 @@ func (suite *OrderControllerTestSuite) TestPurchaseBundle_InvalidPayload() {
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
 
+func (suite *OrderControllerTestSuite) TestPurchaseBundle_MissingDelivery() {
+	// Setup
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+
+	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123", "address_id": "addr1"})
+	c.Request = httptest.NewRequest("POST", "/purchase", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.PurchaseBundle(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.orderUseCase.AssertNotCalled(suite.T(), "PurchaseBundle", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OrderControllerTestSuite) TestPurchaseBundle_AddressNotFound() {
+	// Setup
+	suite.orderUseCase.On("PurchaseBundle", mock.Anything, "bundle123", "reseller123", money.Currency(""), bundleDelivery).
+		Return(nil, nil, nil, shipping.ErrAddressNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller123")
+
+	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123", "address_id": "addr1", "delivery_option": "standard"})
+	c.Request = httptest.NewRequest("POST", "/purchase", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	// Execute
+	suite.controller.PurchaseBundle(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
 func (suite *OrderControllerTestSuite) TestPurchaseBundle_InvalidUserID() {
 	// Setup
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
-	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123"})
+	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123", "address_id": "addr1", "delivery_option": "standard"})
 	c.Request = httptest.NewRequest("POST", "/purchase", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
@@ -173,7 +419,7 @@ func (suite *OrderControllerTestSuite) TestPurchaseBundle_InvalidUserID() {
 
 func (suite *OrderControllerTestSuite) TestPurchaseBundle_UseCaseError() {
 	// Setup
-	suite.orderUseCase.On("PurchaseBundle", mock.Anything, "bundle123", "reseller123").
+	suite.orderUseCase.On("PurchaseBundle", mock.Anything, "bundle123", "reseller123", money.Currency(""), bundleDelivery).
 		Return(nil, nil, nil, errors.New("use case error"))
 
 	// Create test request
@@ -181,7 +427,7 @@ func (suite *OrderControllerTestSuite) TestPurchaseBundle_UseCaseError() {
 	c, _ := gin.CreateTestContext(w)
 	c.Set("userID", "reseller123")
 
-	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123"})
+	body, _ := json.Marshal(gin.H{"bundle_id": "bundle123", "address_id": "addr1", "delivery_option": "standard"})
 	c.Request = httptest.NewRequest("POST", "/purchase", bytes.NewBuffer(body))
 	c.Request.Header.Set("Content-Type", "application/json")
 
@@ -241,3 +487,39 @@ func (suite *OrderControllerTestSuite) TestGetOrderByID_UseCaseError() {
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
 	suite.orderUseCase.AssertExpectations(suite.T())
 }
+
+func (suite *OrderControllerTestSuite) TestGetOrdersByReseller_TagsKind() {
+	orders := []*order.Order{
+		{ID: "sold1", ProductIDs: []string{"product1"}, ConsumerID: "consumer1"},
+		{ID: "bought1", BundleID: "bundle1", SupplierID: "supplier1"},
+	}
+	names := map[string]string{"consumer1": "amy", "supplier1": "sam"}
+	suite.orderUseCase.On("GetOrdersByReseller", mock.Anything, "reseller1", pagination.Request{Cursor: "abc", Limit: 2}).
+		Return(&pagination.Page[*order.Order]{Items: orders, NextCursor: "def", Total: 5}, names, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/reseller/history?cursor=abc&limit=2", nil)
+
+	suite.controller.GetOrdersByReseller(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"consumerName":"amy","kind":"sold"`)
+	assert.Contains(suite.T(), w.Body.String(), `"kind":"bought"`)
+	assert.Contains(suite.T(), w.Body.String(), `"supplierName":"sam"`)
+	assert.Contains(suite.T(), w.Body.String(), `"next_cursor":"def"`)
+	suite.orderUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *OrderControllerTestSuite) TestGetSoldBundleHistory_InvalidLimit() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "supplier1")
+	c.Request = httptest.NewRequest(http.MethodGet, "/orders/supplier/history?limit=1000", nil)
+
+	suite.controller.GetSoldBundleHistory(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.orderUseCase.AssertNotCalled(suite.T(), "GetSoldBundleHistory", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/trust"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
@@ -18,10 +18,11 @@ import (
 )
 
 type ProductController struct {
-	Usecase       product.Usecase
-	TrustUsecase  trust.Usecase
-	BundleUsecase bundle.Usecase
-	WarehouseRepo warehouse.Repository
+	Usecase          product.Usecase
+	TrustUsecase     trust.Usecase
+	BundleUsecase    bundle.Usecase
+	WarehouseRepo    warehouse.Repository
+	WarehouseUsecase warehouse.WarehouseUseCase
 }
 
 func NewProductController(
@@ -29,12 +30,14 @@ func NewProductController(
 	trustUC trust.Usecase,
 	bundleUC bundle.Usecase,
 	warehouseRepo warehouse.Repository,
+	warehouseUC warehouse.WarehouseUseCase,
 ) *ProductController {
 	return &ProductController{
-		Usecase:       prodUC,
-		TrustUsecase:  trustUC,
-		BundleUsecase: bundleUC,
-		WarehouseRepo: warehouseRepo,
+		Usecase:          prodUC,
+		TrustUsecase:     trustUC,
+		BundleUsecase:    bundleUC,
+		WarehouseRepo:    warehouseRepo,
+		WarehouseUsecase: warehouseUC,
 	}
 }
 
@@ -69,7 +72,7 @@ func (h *ProductController) Create(c *gin.Context) {
 		return
 	}
 	if !owns {
-		c.JSON(http.StatusForbidden, gin.H{"error": "you have not received this bundle in your warehouse yet"})
+		c.JSON(http.StatusForbidden, gin.H{"error": "receive this bundle in your warehouse before listing its pieces"})
 		return
 	}
 
@@ -79,23 +82,15 @@ func (h *ProductController) Create(c *gin.Context) {
 		return
 	}
 
-	if b.RemainingItemCount <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "bundle is fully unpacked"})
-		return
-	}
-
 	p.ResellerID = resellerID
 	p.SupplierID = b.SupplierID
 	p.ID = p.GenerateID()
 	p.Status = "available"
 
-	if err := h.Usecase.AddProduct(c.Request.Context(), &p); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	if err := h.BundleUsecase.DecreaseRemainingItemCount(c.Request.Context(), p.BundleID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decrease bundle quantity"})
+	// The product takes up one of the bundle's pieces, so a bundle yields no
+	// more products than it holds.
+	if _, err := h.WarehouseUsecase.ListProduct(c.Request.Context(), userIDStr, &p); err != nil {
+		c.JSON(warehouseErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -159,50 +154,95 @@ func (h *ProductController) GetByTitle(c *gin.Context) {
 	c.JSON(http.StatusOK, prod)
 }
 
+// ListAvailable handles GET /products?cursor=&limit=
 func (h *ProductController) ListAvailable(c *gin.Context) {
-	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page number"})
+	req, err := pageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	if err != nil || limit < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+	page, err := h.Usecase.ListAvailableProducts(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{"error": "failed to load products", "details": err.Error()})
 		return
 	}
 
-	products, err := h.Usecase.ListAvailableProducts(c.Request.Context(), page, limit)
+	c.JSON(http.StatusOK, page)
+}
+
+// Search handles GET /products/search. q is matched against titles, types and
+// descriptions; size, type and grade take comma-separated values; min_price and
+// max_price are in major units of currency.
+func (h *ProductController) Search(c *gin.Context) {
+	q, err := searchQueryFromRequest(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load products", "details": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if len(products) == 0 {
-		c.JSON(http.StatusOK, gin.H{"message": "no products available", "products": []product.Product{}})
+	result, err := h.Usecase.SearchProducts(c.Request.Context(), q)
+	if err != nil {
+		if errors.Is(err, product.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(listErrorStatus(err), gin.H{"error": "failed to search products", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	c.JSON(http.StatusOK, result)
 }
 
-func (h *ProductController) ListByReseller(c *gin.Context) {
-	resellerID := c.Param("id")
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+func searchQueryFromRequest(c *gin.Context) (*product.SearchQuery, error) {
+	currency, err := queryCurrency(c)
+	if err != nil {
+		return nil, err
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	q := &product.SearchQuery{
+		Text:       c.Query("q"),
+		Sizes:      queryList(c, "size"),
+		Types:      queryList(c, "type"),
+		Grades:     queryList(c, "grade"),
+		Currency:   currency,
+		SupplierID: c.Query("supplier_id"),
+		BundleID:   c.Query("bundle_id"),
+		Sort:       product.SearchSort(c.DefaultQuery("sort", string(product.SortRelevance))),
+	}
+	if q.Request, err = pageRequest(c); err != nil {
+		return nil, err
+	}
+	if q.MinTrustScore, err = queryInt(c, "min_trust"); err != nil {
+		return nil, err
+	}
+	if q.MinPrice, err = queryPrice(c, "min_price", currency); err != nil {
+		return nil, err
+	}
+	if q.MaxPrice, err = queryPrice(c, "max_price", currency); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
 
-	products, err := h.Usecase.ListProductsByReseller(c.Request.Context(), resellerID, page, limit)
+// ListByReseller handles GET /products/reseller/:id?cursor=&limit=
+func (h *ProductController) ListByReseller(c *gin.Context) {
+	req, err := pageRequest(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reseller products", "details": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if len(products) == 0 {
-		c.JSON(http.StatusOK, gin.H{"message": "no products found for this reseller", "products": []product.Product{}})
+	page, err := h.Usecase.ListProductsByReseller(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{"error": "failed to load reseller products", "details": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *ProductController) Update(c *gin.Context) {
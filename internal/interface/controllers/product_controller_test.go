@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/gin-gonic/gin"
@@ -27,12 +29,13 @@ var (
 
 type ProductControllerTestSuite struct {
 	suite.Suite
-	productUseCase *MockProductUseCase
-	trustUseCase   *MockTrustUseCase
-	bundleUseCase  *MockBundleUseCase
-	warehouseRepo  *MockWarehouseRepo
-	controller     *ProductController
-	router         *gin.Engine
+	productUseCase   *MockProductUseCase
+	trustUseCase     *MockTrustUseCase
+	bundleUseCase    *MockBundleUseCase
+	warehouseRepo    *MockWarehouseRepo
+	warehouseUseCase *MockWarehouseUsecase
+	controller       *ProductController
+	router           *gin.Engine
 }
 
 type MockProductUseCase struct {
@@ -61,20 +64,28 @@ func (m *MockProductUseCase) GetProductByID(ctx context.Context, id string) (*pr
 	return args.Get(0).(*product.Product), args.Error(1)
 }
 
-func (m *MockProductUseCase) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, page, limit)
+func (m *MockProductUseCase) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*product.Product), args.Error(1)
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
 }
 
-func (m *MockProductUseCase) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, resellerID, page, limit)
+func (m *MockProductUseCase) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*product.Product), args.Error(1)
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *MockProductUseCase) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
 }
 
 func (m *MockProductUseCase) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
@@ -96,6 +107,16 @@ func (m *MockTrustUseCase) UpdateSupplierTrustScoreOnNewRating(ctx context.Conte
 	return args.Error(0)
 }
 
+func (m *MockTrustUseCase) ApplyDisputePenalty(ctx context.Context, sellerID string, penalty float64) error {
+	args := m.Called(ctx, sellerID, penalty)
+	return args.Error(0)
+}
+
+func (m *MockTrustUseCase) ApplyBreakdownDeviation(ctx context.Context, supplierID string, deviationPercent float64) error {
+	args := m.Called(ctx, supplierID, deviationPercent)
+	return args.Error(0)
+}
+
 func (m *MockTrustUseCase) UpdateResellerTrustScoreOnNewRating(ctx context.Context, resellerID string, declaredRating, actualRating float64) error {
 	args := m.Called(ctx, resellerID, declaredRating, actualRating)
 	return args.Error(0)
@@ -131,20 +152,20 @@ func (m *MockBundleUseCase) GetBundlePublicByID(ctx context.Context, id string)
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleUseCase) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
+func (m *MockBundleUseCase) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockBundleUseCase) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, supplierID)
+func (m *MockBundleUseCase) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
 func (m *MockBundleUseCase) UpdateBundle(ctx context.Context, supplierID string, bundleID string, updates map[string]interface{}) error {
@@ -152,11 +173,6 @@ func (m *MockBundleUseCase) UpdateBundle(ctx context.Context, supplierID string,
 	return args.Error(0)
 }
 
-func (m *MockBundleUseCase) DecreaseRemainingItemCount(ctx context.Context, bundleID string) error {
-	args := m.Called(ctx, bundleID)
-	return args.Error(0)
-}
-
 func (m *MockBundleUseCase) GetBundleByTitle(ctx context.Context, title string) (*bundle.Bundle, error) {
 	args := m.Called(ctx, title)
 	if args.Get(0) == nil {
@@ -192,22 +208,33 @@ func (m *MockWarehouseRepo) GetItemsByBundle(ctx context.Context, bundleID strin
 	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
 }
 
-func (m *MockWarehouseRepo) GetItemsByReseller(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockWarehouseRepo) ListItemsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*warehouse.WarehouseItem], error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
+	return args.Get(0).(*pagination.Page[*warehouse.WarehouseItem]), args.Error(1)
 }
 
-func (m *MockWarehouseRepo) MarkItemAsListed(ctx context.Context, itemID string) error {
+func (m *MockWarehouseRepo) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
 	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseRepo) UpdateItemStatus(ctx context.Context, itemID, from string, updates map[string]interface{}) error {
+	args := m.Called(ctx, itemID, from, updates)
 	return args.Error(0)
 }
 
-func (m *MockWarehouseRepo) MarkItemAsSkipped(ctx context.Context, itemID string) error {
+func (m *MockWarehouseRepo) TakePiece(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
 	args := m.Called(ctx, itemID)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
 }
 func (m *MockWarehouseRepo) CountByStatus(ctx context.Context, status string) (int, error) {
 	args := m.Called(ctx, status)
@@ -219,11 +246,13 @@ func (suite *ProductControllerTestSuite) SetupTest() {
 	suite.trustUseCase = new(MockTrustUseCase)
 	suite.bundleUseCase = new(MockBundleUseCase)
 	suite.warehouseRepo = new(MockWarehouseRepo)
+	suite.warehouseUseCase = new(MockWarehouseUsecase)
 	suite.controller = NewProductController(
 		suite.productUseCase,
 		suite.trustUseCase,
 		suite.bundleUseCase,
 		suite.warehouseRepo,
+		suite.warehouseUseCase,
 	)
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.Default()
@@ -250,10 +279,8 @@ func (suite *ProductControllerTestSuite) TestCreate_Success() {
 		Return(true, nil)
 	suite.bundleUseCase.On("GetBundlePublicByID", mock.Anything, product.BundleID).
 		Return(bundle, nil)
-	suite.productUseCase.On("AddProduct", mock.Anything, mock.Anything).
-		Return(nil)
-	suite.bundleUseCase.On("DecreaseRemainingItemCount", mock.Anything, product.BundleID).
-		Return(nil)
+	suite.warehouseUseCase.On("ListProduct", mock.Anything, userID.Hex(), mock.AnythingOfType("*product.Product")).
+		Return(&warehouse.WarehouseItem{RemainingItemCount: 4}, nil)
 	suite.trustUseCase.On("UpdateSupplierTrustScoreOnNewRating", mock.Anything, bundle.SupplierID, float64(bundle.DeclaredRating), product.Rating).
 		Return(nil).Run(func(args mock.Arguments) {
 		// Add a small delay to allow the goroutine to complete
@@ -280,10 +307,35 @@ func (suite *ProductControllerTestSuite) TestCreate_Success() {
 	assert.Equal(suite.T(), http.StatusCreated, w.Code)
 	suite.warehouseRepo.AssertExpectations(suite.T())
 	suite.bundleUseCase.AssertExpectations(suite.T())
-	suite.productUseCase.AssertExpectations(suite.T())
+	suite.warehouseUseCase.AssertExpectations(suite.T())
 	suite.trustUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *ProductControllerTestSuite) TestCreate_BundleFullyUnpacked() {
+	userID := primitive.NewObjectID()
+	p := &product.Product{BundleID: "bundle123", Rating: 4.5}
+
+	suite.warehouseRepo.On("HasResellerReceivedBundle", mock.Anything, userID.Hex(), p.BundleID).
+		Return(true, nil)
+	suite.bundleUseCase.On("GetBundlePublicByID", mock.Anything, p.BundleID).
+		Return(&bundle.Bundle{SupplierID: "supplier123"}, nil)
+	suite.warehouseUseCase.On("ListProduct", mock.Anything, userID.Hex(), mock.AnythingOfType("*product.Product")).
+		Return(nil, warehouse.ErrNothingToUnpack)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", userID.Hex())
+	body, _ := json.Marshal(p)
+	c.Request = httptest.NewRequest("POST", "/products", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	suite.controller.Create(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "AddProduct", mock.Anything, mock.Anything)
+	suite.trustUseCase.AssertNotCalled(suite.T(), "UpdateSupplierTrustScoreOnNewRating", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *ProductControllerTestSuite) TestCreate_InvalidPayload() {
 	// Setup
 	w := httptest.NewRecorder()
@@ -365,36 +417,67 @@ func (suite *ProductControllerTestSuite) TestListAvailable_Success() {
 		{ID: "product1"},
 		{ID: "product2"},
 	}
-	suite.productUseCase.On("ListAvailableProducts", mock.Anything, 1, 10).
-		Return(expectedProducts, nil)
+	suite.productUseCase.On("ListAvailableProducts", mock.Anything, pagination.Request{Cursor: "abc", Limit: 10}).
+		Return(&pagination.Page[*product.Product]{Items: expectedProducts, NextCursor: "def", Total: 25}, nil)
 
 	// Create test request
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	c.Request = httptest.NewRequest("GET", "/products?page=1&limit=10", nil)
+	c.Request = httptest.NewRequest("GET", "/products?cursor=abc&limit=10", nil)
 
 	// Execute
 	suite.controller.ListAvailable(c)
 
 	// Assert
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"next_cursor":"def","total":25`)
 	suite.productUseCase.AssertExpectations(suite.T())
 }
 
+func (suite *ProductControllerTestSuite) TestListAvailable_InvalidLimit() {
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products?limit=500", nil)
+
+	// Execute
+	suite.controller.ListAvailable(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "ListAvailableProducts", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductControllerTestSuite) TestListAvailable_InvalidCursor() {
+	// Setup
+	suite.productUseCase.On("ListAvailableProducts", mock.Anything, mock.Anything).Return(nil, pagination.ErrInvalidCursor)
+
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products?cursor=garbage", nil)
+
+	// Execute
+	suite.controller.ListAvailable(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
 func (suite *ProductControllerTestSuite) TestListByReseller_Success() {
 	// Setup
 	expectedProducts := []*product.Product{
 		{ID: "product1"},
 		{ID: "product2"},
 	}
-	suite.productUseCase.On("ListProductsByReseller", mock.Anything, "reseller123", 1, 10).
-		Return(expectedProducts, nil)
+	suite.productUseCase.On("ListProductsByReseller", mock.Anything, "reseller123", pagination.Request{Limit: 10}).
+		Return(&pagination.Page[*product.Product]{Items: expectedProducts, Total: 2}, nil)
 
 	// Create test request
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Params = gin.Params{gin.Param{Key: "id", Value: "reseller123"}}
-	c.Request = httptest.NewRequest("GET", "/resellers/reseller123/products?page=1&limit=10", nil)
+	c.Request = httptest.NewRequest("GET", "/products/reseller/reseller123?limit=10", nil)
 
 	// Execute
 	suite.controller.ListByReseller(c)
@@ -490,3 +573,71 @@ func (suite *ProductControllerTestSuite) TestGetByTitle_EmptyTitle() {
 
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
+
+func (suite *ProductControllerTestSuite) TestSearch_Success() {
+	// Setup
+	suite.productUseCase.On("SearchProducts", mock.Anything, mock.MatchedBy(func(q *product.SearchQuery) bool {
+		return q.Text == "denim" &&
+			assert.ObjectsAreEqual([]string{"M", "L"}, q.Sizes) &&
+			assert.ObjectsAreEqual([]string{"jacket"}, q.Types) &&
+			q.Currency == money.USD && q.MinPrice.Amount == 1000 && q.MaxPrice == nil &&
+			q.Sort == product.SortPriceAsc && q.Cursor == "abc" && q.Limit == 5
+	})).Return(&product.SearchResult{
+		Page:   pagination.Page[*product.Product]{Items: []*product.Product{{ID: "product1"}}, NextCursor: "def", Total: 6},
+		Facets: product.Facets{Sizes: []product.FacetCount{{Value: "M", Count: 4}}},
+	}, nil)
+
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products/search?q=denim&size=M,L&type=jacket&currency=USD&min_price=10&sort=price_asc&cursor=abc&limit=5", nil)
+
+	// Execute
+	suite.controller.Search(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var body struct {
+		Items      []*product.Product `json:"items"`
+		NextCursor string             `json:"next_cursor"`
+		Total      int64              `json:"total"`
+		Facets     product.Facets     `json:"facets"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(suite.T(), body.Items, 1)
+	assert.Equal(suite.T(), "def", body.NextCursor)
+	assert.Equal(suite.T(), int64(6), body.Total)
+	assert.Equal(suite.T(), 4, body.Facets.Sizes[0].Count)
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestSearch_InvalidSearch() {
+	// Setup
+	suite.productUseCase.On("SearchProducts", mock.Anything, mock.Anything).Return(nil, product.ErrInvalidSearch)
+
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products/search?sort=cheapest", nil)
+
+	// Execute
+	suite.controller.Search(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertExpectations(suite.T())
+}
+
+func (suite *ProductControllerTestSuite) TestSearch_InvalidPrice() {
+	// Create test request
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/products/search?max_price=cheap", nil)
+
+	// Execute
+	suite.controller.Search(c)
+
+	// Assert
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.productUseCase.AssertNotCalled(suite.T(), "SearchProducts", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// queryList reads a filter given as comma-separated values, repeated parameters,
+// or both.
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func queryPrice(c *gin.Context, key string, currency money.Currency) (*money.Money, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	major, err := strconv.ParseFloat(raw, 64)
+	if err != nil || major < 0 {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+	price := money.FromMajor(major, currency)
+	return &price, nil
+}
+
+// queryInt reads an optional whole-number parameter, 0 when it is absent.
+func queryInt(c *gin.Context, key string) (int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+	return n, nil
+}
+
+// queryTime reads an optional RFC 3339 timestamp or date. A date stands for the
+// whole day, so as an end bound it means the start of the next one.
+func queryTime(c *gin.Context, key string, end bool) (time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	day, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s", key)
+	}
+	if end {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+// pageRequest reads the cursor and limit every list endpoint takes.
+func pageRequest(c *gin.Context) (pagination.Request, error) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		return pagination.Request{}, err
+	}
+	return pagination.Request{Cursor: c.Query("cursor"), Limit: limit}.Normalize()
+}
+
+// listErrorStatus maps a failed list to a response code: a cursor the client
+// tampered with or kept across a change of filters is its own mistake.
+func listErrorStatus(err error) int {
+	if errors.Is(err, pagination.ErrInvalidCursor) || errors.Is(err, pagination.ErrInvalidLimit) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
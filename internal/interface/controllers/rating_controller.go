@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/rating"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type RatingController struct {
+	ratingUsecase rating.Usecase
+}
+
+func NewRatingController(ratingUsecase rating.Usecase) *RatingController {
+	return &RatingController{ratingUsecase: ratingUsecase}
+}
+
+func toRatingResponse(r *rating.Rating) models.RatingResponse {
+	return models.RatingResponse{
+		ID:        r.ID,
+		RaterID:   r.ResellerID,
+		RateeID:   r.SupplierID,
+		BundleID:  r.BundleID,
+		Score:     r.Score,
+		Comment:   r.Comment,
+		SkipRate:  r.SkipRate,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// RateBundleSupplier handles POST /bundles/:id/rating
+func (c *RatingController) RateBundleSupplier(ctx *gin.Context) {
+	resellerID := ctx.GetString("userID")
+	if resellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.RatingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	r, err := c.ratingUsecase.RateSupplier(ctx.Request.Context(), resellerID, ctx.Param("id"), req.Score, req.Comment)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, rating.ErrInvalidScore):
+			status = http.StatusBadRequest
+		case errors.Is(err, rating.ErrBundleNotPurchased):
+			status = http.StatusForbidden
+		case errors.Is(err, rating.ErrAlreadyRated):
+			status = http.StatusConflict
+		}
+		ctx.JSON(status, common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Rating submitted",
+		Data:    toRatingResponse(r),
+	})
+}
+
+// GetSupplierRatings handles GET /suppliers/:id/ratings
+func (c *RatingController) GetSupplierRatings(ctx *gin.Context) {
+	supplierID := ctx.Param("id")
+
+	req, err := pageRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := c.ratingUsecase.GetSupplierRatings(ctx.Request.Context(), supplierID, req)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, pagination.Map(page, toRatingResponse))
+}
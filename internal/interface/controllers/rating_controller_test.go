@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/rating"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockRatingUsecase struct {
+	mock.Mock
+}
+
+func (m *MockRatingUsecase) RateSupplier(ctx context.Context, resellerID string, bundleID string, score int, comment string) (*rating.Rating, error) {
+	args := m.Called(ctx, resellerID, bundleID, score, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*rating.Rating), args.Error(1)
+}
+
+func (m *MockRatingUsecase) GetSupplierRatings(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*rating.Rating], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*rating.Rating]), args.Error(1)
+}
+
+type RatingControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockRatingUsecase
+	controller *RatingController
+}
+
+func (suite *RatingControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockRatingUsecase)
+	suite.controller = NewRatingController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRatingControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(RatingControllerTestSuite))
+}
+
+func (suite *RatingControllerTestSuite) newRateRequest(userID string, body interface{}) (*gin.Context, *httptest.ResponseRecorder) {
+	payload, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	if userID != "" {
+		c.Set("userID", userID)
+	}
+	c.Params = gin.Params{{Key: "id", Value: "bundle1"}}
+	c.Request = httptest.NewRequest("POST", "/bundles/bundle1/rating", bytes.NewBuffer(payload))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func (suite *RatingControllerTestSuite) TestRateBundleSupplier_Success() {
+	r := &rating.Rating{ID: "r1", ResellerID: "reseller1", SupplierID: "supplier1", BundleID: "bundle1", Score: 4}
+	suite.usecase.On("RateSupplier", mock.Anything, "reseller1", "bundle1", 4, "good").Return(r, nil)
+
+	c, w := suite.newRateRequest("reseller1", map[string]interface{}{"score": 4, "comment": "good"})
+	suite.controller.RateBundleSupplier(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"ratee_id":"supplier1"`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *RatingControllerTestSuite) TestRateBundleSupplier_AlreadyRated() {
+	suite.usecase.On("RateSupplier", mock.Anything, "reseller1", "bundle1", 4, "").Return(nil, rating.ErrAlreadyRated)
+
+	c, w := suite.newRateRequest("reseller1", map[string]interface{}{"score": 4})
+	suite.controller.RateBundleSupplier(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *RatingControllerTestSuite) TestRateBundleSupplier_NotPurchased() {
+	suite.usecase.On("RateSupplier", mock.Anything, "reseller1", "bundle1", 2, "").Return(nil, rating.ErrBundleNotPurchased)
+
+	c, w := suite.newRateRequest("reseller1", map[string]interface{}{"score": 2})
+	suite.controller.RateBundleSupplier(c)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *RatingControllerTestSuite) TestRateBundleSupplier_Unauthorized() {
+	c, w := suite.newRateRequest("", map[string]interface{}{"score": 4})
+	suite.controller.RateBundleSupplier(c)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "RateSupplier")
+}
+
+func (suite *RatingControllerTestSuite) TestGetSupplierRatings_Page() {
+	page := &pagination.Page[*rating.Rating]{
+		Items: []*rating.Rating{
+			{ID: "r1", ResellerID: "reseller1", SupplierID: "supplier1", Score: 4},
+			{ID: "r2", ResellerID: "reseller2", SupplierID: "supplier1", Score: 5},
+		},
+		NextCursor: "next",
+		Total:      7,
+	}
+	suite.usecase.On("GetSupplierRatings", mock.Anything, "supplier1", pagination.Request{Limit: 2}).Return(page, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Params = gin.Params{{Key: "id", Value: "supplier1"}}
+	c.Request = httptest.NewRequest("GET", "/suppliers/supplier1/ratings?limit=2", nil)
+
+	suite.controller.GetSupplierRatings(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var body pagination.Page[models.RatingResponse]
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(suite.T(), body.Items, 2)
+	assert.Equal(suite.T(), "reseller2", body.Items[1].RaterID)
+	assert.Equal(suite.T(), "next", body.NextCursor)
+	assert.Equal(suite.T(), int64(7), body.Total)
+}
+
+func (suite *RatingControllerTestSuite) TestGetSupplierRatings_InvalidCursor() {
+	suite.usecase.On("GetSupplierRatings", mock.Anything, "supplier1", mock.Anything).Return(nil, pagination.ErrInvalidCursor)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "supplier1"}}
+	c.Request = httptest.NewRequest("GET", "/suppliers/supplier1/ratings?cursor=bogus", nil)
+
+	suite.controller.GetSupplierRatings(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -96,18 +96,19 @@ func (ctrl *ReviewController) GetResellerReviews(c *gin.Context) {
 		return
 	}
 
-	reviews, err := ctrl.usecase.GetResellerReviews(c.Request.Context(), resellerID)
+	req, err := pageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := ctrl.usecase.GetResellerReviews(c.Request.Context(), resellerID, req)
 	if err != nil {
 		fmt.Printf("❌ Error fetching reviews: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(listErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	fmt.Printf("✅ Successfully fetched %d reviews\n", len(reviews))
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": gin.H{
-			"reviews": reviews,
-		},
-	})
+	fmt.Printf("✅ Successfully fetched %d reviews\n", len(page.Items))
+	c.JSON(http.StatusOK, page)
 }
@@ -11,6 +11,7 @@ import (
 
 	"errors"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/review"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
@@ -30,9 +31,12 @@ func (m *MockReviewUsecase) SubmitReview(ctx context.Context, r *review.Review)
 	return args.Error(0)
 }
 
-func (m *MockReviewUsecase) GetResellerReviews(ctx context.Context, resellerID string) ([]*review.Review, error) {
-	args := m.Called(ctx, resellerID)
-	return args.Get(0).([]*review.Review), args.Error(1)
+func (m *MockReviewUsecase) GetResellerReviews(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*review.Review], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*review.Review]), args.Error(1)
 }
 
 type MockTrustUsecase struct {
@@ -44,6 +48,16 @@ func (m *MockTrustUsecase) UpdateSupplierTrustScoreOnNewRating(ctx context.Conte
 	return args.Error(0)
 }
 
+func (m *MockTrustUsecase) ApplyDisputePenalty(ctx context.Context, sellerID string, penalty float64) error {
+	args := m.Called(ctx, sellerID, penalty)
+	return args.Error(0)
+}
+
+func (m *MockTrustUsecase) ApplyBreakdownDeviation(ctx context.Context, supplierID string, deviationPercent float64) error {
+	args := m.Called(ctx, supplierID, deviationPercent)
+	return args.Error(0)
+}
+
 func (m *MockTrustUsecase) UpdateResellerTrustScoreOnNewRating(ctx context.Context, resellerID string, declaredRating, productRating float64) error {
 	args := m.Called(ctx, resellerID, declaredRating, productRating)
 	return args.Error(0)
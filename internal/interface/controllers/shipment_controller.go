@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	carrierinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/carrier"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type ShipmentController struct {
+	verifier        shipment.WebhookVerifier
+	shipmentUsecase shipment.Usecase
+}
+
+func NewShipmentController(verifier shipment.WebhookVerifier, shipmentUsecase shipment.Usecase) *ShipmentController {
+	return &ShipmentController{
+		verifier:        verifier,
+		shipmentUsecase: shipmentUsecase,
+	}
+}
+
+func shipmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, shipment.ErrShipmentNotFound), errors.Is(err, shipment.ErrUnknownParcel):
+		return http.StatusNotFound
+	case errors.Is(err, shipment.ErrNotShipmentParty):
+		return http.StatusForbidden
+	case errors.Is(err, shipment.ErrShipmentChanged):
+		return http.StatusConflict
+	case errors.Is(err, shipment.ErrInvalidEvent):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetShipment handles GET /orders/:id/shipment
+func (c *ShipmentController) GetShipment(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	s, err := c.shipmentUsecase.GetShipment(ctx.Request.Context(), userID, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(shipmentErrorStatus(err), common.APIResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Shipment retrieved successfully",
+		Data:    s,
+	})
+}
+
+// HandleTrackingWebhook handles POST /webhooks/shipments. Any non-2xx response
+// makes the carrier retry the delivery later, so events about parcels not yet
+// recorded answer 404.
+func (c *ShipmentController) HandleTrackingWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "could not read request body"})
+		return
+	}
+
+	u, err := c.verifier.Verify(payload, ctx.GetHeader(carrierinfra.SignatureHeader))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, shipment.ErrInvalidSignature) {
+			status = http.StatusUnauthorized
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.shipmentUsecase.HandleTrackingUpdate(ctx.Request.Context(), u); err != nil {
+		log.Printf("Failed to process tracking event %s: %v", u.Event.ID, err)
+		ctx.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"received": true})
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	carrierinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/carrier"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const carrierSecret = "carrier_test"
+
+type MockShipmentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockShipmentUsecase) GetShipment(ctx context.Context, userID, orderID string) (*shipment.Shipment, error) {
+	args := m.Called(ctx, userID, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentUsecase) HandleTrackingUpdate(ctx context.Context, u *shipment.Update) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+type ShipmentControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockShipmentUsecase
+	controller *ShipmentController
+}
+
+func (suite *ShipmentControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.usecase = new(MockShipmentUsecase)
+	suite.controller = NewShipmentController(carrierinfra.NewWebhookVerifier(carrierSecret), suite.usecase)
+}
+
+func TestShipmentControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ShipmentControllerTestSuite))
+}
+
+func (suite *ShipmentControllerTestSuite) deliver(payload []byte, signature string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/webhooks/shipments", bytes.NewBuffer(payload))
+	c.Request.Header.Set(carrierinfra.SignatureHeader, signature)
+	suite.controller.HandleTrackingWebhook(c)
+	return w
+}
+
+var trackingPayload = []byte(`{"id":"e1","carrier":"simulator","tracking_number":"SIM0000000001","status":"delivered","occurred_at":"2025-01-04T12:00:00Z"}`)
+
+func (suite *ShipmentControllerTestSuite) TestHandleTrackingWebhook_Success() {
+	suite.usecase.On("HandleTrackingUpdate", mock.Anything, mock.MatchedBy(func(u *shipment.Update) bool {
+		return u.TrackingNumber == "SIM0000000001" && u.Event.Status == shipment.Delivered
+	})).Return(nil)
+
+	w := suite.deliver(trackingPayload, carrierinfra.SignPayload([]byte(carrierSecret), trackingPayload))
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ShipmentControllerTestSuite) TestHandleTrackingWebhook_BadSignature() {
+	w := suite.deliver(trackingPayload, "deadbeef")
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "HandleTrackingUpdate", mock.Anything, mock.Anything)
+}
+
+func (suite *ShipmentControllerTestSuite) TestHandleTrackingWebhook_UnknownParcel() {
+	suite.usecase.On("HandleTrackingUpdate", mock.Anything, mock.Anything).Return(shipment.ErrUnknownParcel)
+
+	w := suite.deliver(trackingPayload, carrierinfra.SignPayload([]byte(carrierSecret), trackingPayload))
+
+	// A 404 makes the carrier retry once the shipment is recorded
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *ShipmentControllerTestSuite) TestGetShipment_NotParty() {
+	suite.usecase.On("GetShipment", mock.Anything, "someone", "order1").Return(nil, shipment.ErrNotShipmentParty)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "someone")
+	c.Params = gin.Params{{Key: "id", Value: "order1"}}
+	c.Request = httptest.NewRequest("GET", "/orders/order1/shipment", nil)
+	suite.controller.GetShipment(c)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+type ShippingController struct {
+	shippingUsecase shipping.Usecase
+}
+
+func NewShippingController(shippingUsecase shipping.Usecase) *ShippingController {
+	return &ShippingController{shippingUsecase: shippingUsecase}
+}
+
+func shippingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, shipping.ErrAddressNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, shipping.ErrAddressRequired), errors.Is(err, shipping.ErrIncompleteAddress),
+		errors.Is(err, shipping.ErrInvalidCountry), errors.Is(err, shipping.ErrInvalidOption),
+		errors.Is(err, shipping.ErrOptionUnavailable):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func respondShippingError(ctx *gin.Context, err error) {
+	ctx.JSON(shippingErrorStatus(err), common.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+// bindDelivery reads the address and delivery option a checkout ships with from the
+// request body. Both are required.
+func bindDelivery(ctx *gin.Context) (shipping.Selection, error) {
+	var req models.DeliveryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return shipping.Selection{}, errors.New("address_id and delivery_option are required")
+	}
+	return shipping.Selection{AddressID: req.AddressID, Option: shipping.Option(req.DeliveryOption)}, nil
+}
+
+func addressFromRequest(req *models.AddressRequest) *shipping.Address {
+	return &shipping.Address{
+		Label:         req.Label,
+		RecipientName: req.RecipientName,
+		Phone:         req.Phone,
+		Line1:         req.Line1,
+		Line2:         req.Line2,
+		City:          req.City,
+		Region:        req.Region,
+		PostalCode:    req.PostalCode,
+		Country:       req.Country,
+		IsDefault:     req.IsDefault,
+	}
+}
+
+// AddAddress handles POST /addresses
+func (c *ShippingController) AddAddress(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.AddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	a, err := c.shippingUsecase.AddAddress(ctx.Request.Context(), userID, addressFromRequest(&req))
+	if err != nil {
+		respondShippingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.APIResponse{
+		Success: true,
+		Message: "Address added",
+		Data:    a,
+	})
+}
+
+// ListAddresses handles GET /addresses
+func (c *ShippingController) ListAddresses(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	addresses, err := c.shippingUsecase.ListAddresses(ctx.Request.Context(), userID)
+	if err != nil {
+		respondShippingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Addresses retrieved successfully",
+		Data:    addresses,
+	})
+}
+
+// UpdateAddress handles PUT /addresses/:id
+func (c *ShippingController) UpdateAddress(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.AddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	a, err := c.shippingUsecase.UpdateAddress(ctx.Request.Context(), userID, ctx.Param("id"), addressFromRequest(&req))
+	if err != nil {
+		respondShippingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Address updated",
+		Data:    a,
+	})
+}
+
+// DeleteAddress handles DELETE /addresses/:id
+func (c *ShippingController) DeleteAddress(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	if err := c.shippingUsecase.DeleteAddress(ctx.Request.Context(), userID, ctx.Param("id")); err != nil {
+		respondShippingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Address deleted",
+	})
+}
+
+// GetShippingOptions handles GET /shipping/options?address_id=&items=
+func (c *ShippingController) GetShippingOptions(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	addressID := ctx.Query("address_id")
+	if addressID == "" {
+		respondShippingError(ctx, shipping.ErrAddressRequired)
+		return
+	}
+	items := 1
+	if raw := ctx.Query("items"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			ctx.JSON(http.StatusBadRequest, common.APIResponse{
+				Success: false,
+				Message: "items must be a positive number",
+			})
+			return
+		}
+		items = n
+	}
+
+	quotes, err := c.shippingUsecase.Options(ctx.Request.Context(), userID, addressID, items)
+	if err != nil {
+		respondShippingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Shipping options retrieved successfully",
+		Data:    quotes,
+	})
+}
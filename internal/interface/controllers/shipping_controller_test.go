@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockShippingUsecase struct {
+	mock.Mock
+}
+
+func (m *MockShippingUsecase) Quote(ctx context.Context, buyerID string, sel shipping.Selection, items int) (*shipping.Quote, error) {
+	args := m.Called(ctx, buyerID, sel, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipping.Quote), args.Error(1)
+}
+
+func (m *MockShippingUsecase) AddAddress(ctx context.Context, userID string, a *shipping.Address) (*shipping.Address, error) {
+	args := m.Called(ctx, userID, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipping.Address), args.Error(1)
+}
+
+func (m *MockShippingUsecase) ListAddresses(ctx context.Context, userID string) ([]*shipping.Address, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shipping.Address), args.Error(1)
+}
+
+func (m *MockShippingUsecase) UpdateAddress(ctx context.Context, userID, id string, a *shipping.Address) (*shipping.Address, error) {
+	args := m.Called(ctx, userID, id, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipping.Address), args.Error(1)
+}
+
+func (m *MockShippingUsecase) DeleteAddress(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockShippingUsecase) Options(ctx context.Context, userID, addressID string, items int) ([]*shipping.Quote, error) {
+	args := m.Called(ctx, userID, addressID, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shipping.Quote), args.Error(1)
+}
+
+type ShippingControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockShippingUsecase
+	controller *ShippingController
+}
+
+func (suite *ShippingControllerTestSuite) SetupTest() {
+	suite.usecase = new(MockShippingUsecase)
+	suite.controller = NewShippingController(suite.usecase)
+	gin.SetMode(gin.TestMode)
+}
+
+func TestShippingControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ShippingControllerTestSuite))
+}
+
+func (suite *ShippingControllerTestSuite) newRequest(method, target, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "user1")
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func (suite *ShippingControllerTestSuite) TestAddAddress_Success() {
+	suite.usecase.On("AddAddress", mock.Anything, "user1", mock.MatchedBy(func(a *shipping.Address) bool {
+		return a.City == "Addis Ababa" && a.Country == "ET"
+	})).Return(&shipping.Address{ID: "addr1", IsDefault: true}, nil)
+
+	c, w := suite.newRequest("POST", "/addresses", `{"recipient_name":"Sara","phone":"0911","line1":"Bole Road","city":"Addis Ababa","country":"ET"}`)
+	suite.controller.AddAddress(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *ShippingControllerTestSuite) TestAddAddress_MissingFields() {
+	c, w := suite.newRequest("POST", "/addresses", `{"recipient_name":"Sara"}`)
+	suite.controller.AddAddress(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "AddAddress", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShippingControllerTestSuite) TestDeleteAddress_NotFound() {
+	suite.usecase.On("DeleteAddress", mock.Anything, "user1", "addr9").Return(shipping.ErrAddressNotFound)
+
+	c, w := suite.newRequest("DELETE", "/addresses/addr9", "")
+	c.Params = gin.Params{{Key: "id", Value: "addr9"}}
+	suite.controller.DeleteAddress(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *ShippingControllerTestSuite) TestGetShippingOptions_Success() {
+	quotes := []*shipping.Quote{{Option: shipping.Standard, Cost: money.New(20000, money.ETB)}}
+	suite.usecase.On("Options", mock.Anything, "user1", "addr1", 4).Return(quotes, nil)
+
+	c, w := suite.newRequest("GET", "/shipping/options?address_id=addr1&items=4", "")
+	suite.controller.GetShippingOptions(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"delivery_option":"standard"`)
+}
+
+func (suite *ShippingControllerTestSuite) TestGetShippingOptions_InvalidItems() {
+	c, w := suite.newRequest("GET", "/shipping/options?address_id=addr1&items=0", "")
+	suite.controller.GetShippingOptions(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "Options", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
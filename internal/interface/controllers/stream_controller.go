@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
+	"github.com/gin-gonic/gin"
+)
+
+const streamHeartbeatInterval = 25 * time.Second
+
+type StreamController struct {
+	subscriber event.Subscriber
+	heartbeat  time.Duration
+}
+
+func NewStreamController(subscriber event.Subscriber) *StreamController {
+	return &StreamController{subscriber: subscriber, heartbeat: streamHeartbeatInterval}
+}
+
+// Stream handles GET /stream. It keeps the connection open and writes every event
+// addressed to the caller as a server-sent event named after the event type.
+func (c *StreamController) Stream(ctx *gin.Context) {
+	userID := ctx.GetString("userID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	events, unsubscribe := c.subscriber.Subscribe(userID)
+	defer unsubscribe()
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	// Heartbeats keep idle connections from being closed by proxies.
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+
+	ctx.SSEvent("ready", gin.H{"user_id": userID})
+	ctx.Writer.Flush()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent(string(e.Type), e)
+			return true
+		case <-ticker.C:
+			ctx.SSEvent("ping", time.Now().Format(time.RFC3339))
+			return true
+		}
+	})
+}
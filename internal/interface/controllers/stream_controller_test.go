@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// closeNotifyRecorder adds the CloseNotifier support gin's Stream helper expects.
+type closeNotifyRecorder struct {
+	*httptest.ResponseRecorder
+	closed chan bool
+}
+
+func (r *closeNotifyRecorder) CloseNotify() <-chan bool {
+	return r.closed
+}
+
+// stubSubscriber hands out a pre-filled channel that is closed once drained.
+type stubSubscriber struct {
+	events       []*event.Event
+	unsubscribed bool
+}
+
+func (s *stubSubscriber) Subscribe(userID string) (<-chan *event.Event, func()) {
+	ch := make(chan *event.Event, len(s.events))
+	for _, e := range s.events {
+		ch <- e
+	}
+	close(ch)
+	return ch, func() { s.unsubscribed = true }
+}
+
+type StreamControllerTestSuite struct {
+	suite.Suite
+	subscriber *stubSubscriber
+	controller *StreamController
+}
+
+func (suite *StreamControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.subscriber = &stubSubscriber{}
+	suite.controller = NewStreamController(suite.subscriber)
+}
+
+func TestStreamControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(StreamControllerTestSuite))
+}
+
+func (suite *StreamControllerTestSuite) TestStream_DeliversEvents() {
+	suite.subscriber.events = []*event.Event{
+		{Type: event.OrderStatusChanged, UserID: "reseller1", Payload: event.OrderStatusPayload{OrderID: "order1", Status: "shipped"}},
+		{Type: event.WarehouseItemReady, UserID: "reseller1", Payload: event.WarehouseItemPayload{ItemID: "item1", BundleID: "bundle1", Status: "listed"}},
+	}
+
+	w := &closeNotifyRecorder{ResponseRecorder: httptest.NewRecorder(), closed: make(chan bool, 1)}
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "reseller1")
+	c.Request = httptest.NewRequest("GET", "/stream", nil)
+
+	suite.controller.Stream(c)
+
+	body := w.Body.String()
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Header().Get("Content-Type"), "text/event-stream")
+	assert.Contains(suite.T(), body, "event:ready")
+	assert.Contains(suite.T(), body, "event:order_status_changed")
+	assert.Contains(suite.T(), body, `"order_id":"order1"`)
+	assert.Contains(suite.T(), body, "event:warehouse_item_ready")
+	assert.True(suite.T(), suite.subscriber.unsubscribed)
+}
+
+func (suite *StreamControllerTestSuite) TestStream_Unauthorized() {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/stream", nil)
+
+	suite.controller.Stream(c)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	assert.False(suite.T(), suite.subscriber.unsubscribed)
+}
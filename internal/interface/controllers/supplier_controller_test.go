@@ -6,10 +6,12 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -21,8 +23,8 @@ type MockOrderUsecase struct {
 	mock.Mock
 }
 
-func (m *MockOrderUsecase) PurchaseBundle(ctx context.Context, bundleID, resellerID string) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
-	args := m.Called(ctx, bundleID, resellerID)
+func (m *MockOrderUsecase) PurchaseBundle(ctx context.Context, bundleID, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID, resellerID, currency, delivery)
 	if args.Get(0) == nil {
 		return nil, nil, nil, args.Error(3)
 	}
@@ -53,20 +55,20 @@ func (m *MockOrderUsecase) GetOrderByID(ctx context.Context, orderID string) (*o
 	return args.Get(0).(*order.Order), args.Error(1)
 }
 
-func (m *MockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, map[string]string, error) {
-	args := m.Called(ctx, supplierID)
+func (m *MockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Error(2)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
 }
 
-func (m *MockOrderUsecase) GetAdminDashboardMetrics(ctx context.Context) (*admin.Metrics, error) {
-	args := m.Called(ctx)
+func (m *MockOrderUsecase) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*admin.Metrics), args.Error(1)
+	return args.Get(0).(*order.Order), args.Error(1)
 }
 
 type SupplierControllerTestSuite struct {
@@ -90,7 +92,7 @@ func TestSupplierControllerTestSuite(t *testing.T) {
 func (suite *SupplierControllerTestSuite) TestGetDashboardMetrics_Success() {
 	// Setup
 	expectedMetrics := &order.DashboardMetrics{
-		TotalSales: 1000.0,
+		TotalSales: []money.Money{money.New(100000, money.ETB)},
 		ActiveBundles: []*bundle.Bundle{
 			{
 				ID: "bundle1",
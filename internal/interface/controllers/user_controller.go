@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 )
 
@@ -96,7 +97,9 @@ func (c *UserController) UpdateProfile(ctx *gin.Context) {
 	// Check if username is already taken if it's being updated
 	if req.Username != "" && req.Username != currentUser.Username {
 		// Get all users to check for duplicate username
-		users, err := c.userUsecase.ListByRole(ctx, user.Role(currentUser.Role))
+		users, err := pagination.Collect(func(req pagination.Request) (*pagination.Page[*user.User], error) {
+			return c.userUsecase.ListByRole(ctx, []user.Role{user.Role(currentUser.Role)}, req)
+		})
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check username availability"})
 			return
@@ -113,7 +116,9 @@ func (c *UserController) UpdateProfile(ctx *gin.Context) {
 	// Check if email is already taken if it's being updated
 	if req.Email != "" && req.Email != currentUser.Email {
 		// Get all users to check for duplicate email
-		users, err := c.userUsecase.ListByRole(ctx, user.Role(currentUser.Role))
+		users, err := pagination.Collect(func(req pagination.Request) (*pagination.Page[*user.User], error) {
+			return c.userUsecase.ListByRole(ctx, []user.Role{user.Role(currentUser.Role)}, req)
+		})
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check email availability"})
 			return
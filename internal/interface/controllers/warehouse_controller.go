@@ -1,11 +1,14 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models/common"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type WarehouseController struct {
@@ -35,10 +38,134 @@ func (c *WarehouseController) GetWarehouseItems(ctx *gin.Context) {
 		return
 	}
 
-	items, err := c.warehouseUsecase.GetWarehouseItems(ctx, resellerIDStr)
+	req, err := pageRequest(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(http.StatusOK, items)
+
+	page, err := c.warehouseUsecase.GetWarehouseItems(ctx, resellerIDStr, req)
+	if err != nil {
+		ctx.JSON(listErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, page)
+}
+
+func warehouseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, warehouse.ErrItemNotFound), errors.Is(err, mongo.ErrNoDocuments):
+		return http.StatusNotFound
+	case errors.Is(err, warehouse.ErrNotItemOwner):
+		return http.StatusForbidden
+	case errors.Is(err, warehouse.ErrNotReceivable), errors.Is(err, warehouse.ErrNotUnpacking),
+		errors.Is(err, warehouse.ErrNothingToUnpack), errors.Is(err, warehouse.ErrStatusChanged),
+		errors.Is(err, warehouse.ErrProductAlreadyUnpacked):
+		return http.StatusConflict
+	case errors.Is(err, warehouse.ErrInvalidSkipReason), errors.Is(err, warehouse.ErrProductNotFromBundle):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func respondWarehouseError(ctx *gin.Context, err error) {
+	ctx.JSON(warehouseErrorStatus(err), common.APIResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+// ReceiveBundle handles POST /warehouse/:id/receive
+func (c *WarehouseController) ReceiveBundle(ctx *gin.Context) {
+	resellerID := ctx.GetString("userID")
+	if resellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	item, err := c.warehouseUsecase.ReceiveBundle(ctx.Request.Context(), resellerID, ctx.Param("id"))
+	if err != nil {
+		respondWarehouseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Bundle received",
+		Data:    item,
+	})
+}
+
+// UnpackPiece handles POST /warehouse/:id/unpack
+func (c *WarehouseController) UnpackPiece(ctx *gin.Context) {
+	resellerID := ctx.GetString("userID")
+	if resellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.UnpackPieceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	item, err := c.warehouseUsecase.UnpackPiece(ctx.Request.Context(), resellerID, ctx.Param("id"), req.ProductID)
+	if err != nil {
+		respondWarehouseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Piece listed",
+		Data:    item,
+	})
+}
+
+// SkipPiece handles POST /warehouse/:id/skip
+func (c *WarehouseController) SkipPiece(ctx *gin.Context) {
+	resellerID := ctx.GetString("userID")
+	if resellerID == "" {
+		ctx.JSON(http.StatusUnauthorized, common.APIResponse{
+			Success: false,
+			Message: "user ID not found in context",
+		})
+		return
+	}
+
+	var req models.SkipPieceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	item, err := c.warehouseUsecase.SkipPiece(ctx.Request.Context(), resellerID, ctx.Param("id"), warehouse.Skip{
+		Reason: warehouse.SkipReason(req.Reason),
+		Type:   req.Type,
+		Note:   req.Note,
+	})
+	if err != nil {
+		respondWarehouseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.APIResponse{
+		Success: true,
+		Message: "Piece skipped",
+		Data:    item,
+	})
 }
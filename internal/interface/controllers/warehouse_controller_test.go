@@ -4,8 +4,13 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -17,9 +22,52 @@ type MockWarehouseUsecase struct {
 	mock.Mock
 }
 
-func (m *MockWarehouseUsecase) GetWarehouseItems(ctx context.Context, resellerID string) ([]*models.WarehouseItemResponse, error) {
-	args := m.Called(ctx, resellerID)
-	return args.Get(0).([]*models.WarehouseItemResponse), args.Error(1)
+func (m *MockWarehouseUsecase) GetWarehouseItems(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*models.WarehouseItemResponse], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*models.WarehouseItemResponse]), args.Error(1)
+}
+
+func (m *MockWarehouseUsecase) ReceiveBundle(ctx context.Context, resellerID string, itemID string) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, resellerID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseUsecase) UnpackPiece(ctx context.Context, resellerID string, itemID string, productID string) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, resellerID, itemID, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseUsecase) ListProduct(ctx context.Context, resellerID string, p *product.Product) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, resellerID, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseUsecase) SkipPiece(ctx context.Context, resellerID string, itemID string, skip warehouse.Skip) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, resellerID, itemID, skip)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseUsecase) GetBundleBreakdown(ctx context.Context, bundleID string) (*bundle.Breakdown, error) {
+	args := m.Called(ctx, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Breakdown), args.Error(1)
 }
 
 type WarehouseControllerTestSuite struct {
@@ -34,6 +82,13 @@ func (suite *WarehouseControllerTestSuite) SetupTest() {
 	suite.controller = NewWarehouseController(suite.usecase)
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.Default()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("userID", "reseller123")
+		c.Next()
+	})
+	suite.router.POST("/warehouse/:id/receive", suite.controller.ReceiveBundle)
+	suite.router.POST("/warehouse/:id/unpack", suite.controller.UnpackPiece)
+	suite.router.POST("/warehouse/:id/skip", suite.controller.SkipPiece)
 }
 
 func TestWarehouseControllerTestSuite(t *testing.T) {
@@ -67,8 +122,8 @@ func (suite *WarehouseControllerTestSuite) TestGetWarehouseItems_Success() {
 		},
 	}
 
-	suite.usecase.On("GetWarehouseItems", mock.Anything, "reseller123").
-		Return(expectedItems, nil)
+	suite.usecase.On("GetWarehouseItems", mock.Anything, "reseller123", pagination.Request{Limit: pagination.DefaultLimit}).
+		Return(&pagination.Page[*models.WarehouseItemResponse]{Items: expectedItems, Total: 2}, nil)
 
 	// Create test request
 	w := httptest.NewRecorder()
@@ -134,8 +189,8 @@ func (suite *WarehouseControllerTestSuite) TestGetWarehouseItems_EmptyUserID() {
 
 func (suite *WarehouseControllerTestSuite) TestGetWarehouseItems_UseCaseError() {
 	// Setup
-	suite.usecase.On("GetWarehouseItems", mock.Anything, "reseller123").
-		Return([]*models.WarehouseItemResponse{}, assert.AnError) // Return an empty slice instead of nil
+	suite.usecase.On("GetWarehouseItems", mock.Anything, "reseller123", mock.Anything).
+		Return(nil, assert.AnError)
 
 	// Create test request
 	w := httptest.NewRecorder()
@@ -150,3 +205,68 @@ func (suite *WarehouseControllerTestSuite) TestGetWarehouseItems_UseCaseError()
 	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
 	suite.usecase.AssertExpectations(suite.T())
 }
+
+func (suite *WarehouseControllerTestSuite) TestReceiveBundle_Success() {
+	suite.usecase.On("ReceiveBundle", mock.Anything, "reseller123", "item1").
+		Return(&warehouse.WarehouseItem{ID: "item1", Status: warehouse.StatusReceived}, nil)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/warehouse/item1/receive", nil))
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"received"`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *WarehouseControllerTestSuite) TestReceiveBundle_NotOwner() {
+	suite.usecase.On("ReceiveBundle", mock.Anything, "reseller123", "item1").Return(nil, warehouse.ErrNotItemOwner)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/warehouse/item1/receive", nil))
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *WarehouseControllerTestSuite) TestUnpackPiece_Success() {
+	suite.usecase.On("UnpackPiece", mock.Anything, "reseller123", "item1", "product1").
+		Return(&warehouse.WarehouseItem{ID: "item1", Status: warehouse.StatusReceived, RemainingItemCount: 4}, nil)
+
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"product_id":"product1"}`)
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/warehouse/item1/unpack", body))
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"remaining_item_count":4`)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *WarehouseControllerTestSuite) TestUnpackPiece_MissingProduct() {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/warehouse/item1/unpack", strings.NewReader(`{}`)))
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "UnpackPiece", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *WarehouseControllerTestSuite) TestSkipPiece_Success() {
+	suite.usecase.On("SkipPiece", mock.Anything, "reseller123", "item1", warehouse.Skip{Reason: warehouse.SkipDamaged, Type: "jackets", Note: "torn sleeve"}).
+		Return(&warehouse.WarehouseItem{ID: "item1", Status: warehouse.StatusClosed}, nil)
+
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"reason":"damaged","type":"jackets","note":"torn sleeve"}`)
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/warehouse/item1/skip", body))
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *WarehouseControllerTestSuite) TestSkipPiece_BundleClosed() {
+	suite.usecase.On("SkipPiece", mock.Anything, "reseller123", "item1", warehouse.Skip{Reason: warehouse.SkipWrongSize}).
+		Return(nil, warehouse.ErrNothingToUnpack)
+
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"reason":"wrong_size"}`)
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/warehouse/item1/skip", body))
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
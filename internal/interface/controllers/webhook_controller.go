@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/gin-gonic/gin"
+)
+
+const maxWebhookBodyBytes = 1 << 20
+
+type WebhookController struct {
+	verifier       payment.WebhookVerifier
+	paymentUsecase payment.Usecase
+}
+
+func NewWebhookController(verifier payment.WebhookVerifier, paymentUsecase payment.Usecase) *WebhookController {
+	return &WebhookController{
+		verifier:       verifier,
+		paymentUsecase: paymentUsecase,
+	}
+}
+
+// HandlePaymentWebhook handles POST /webhooks/payments. Any non-2xx response makes
+// the gateway retry the delivery later.
+func (c *WebhookController) HandlePaymentWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "could not read request body"})
+		return
+	}
+
+	e, err := c.verifier.Verify(payload, ctx.GetHeader("Stripe-Signature"))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			status = http.StatusUnauthorized
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.paymentUsecase.HandleWebhookEvent(ctx.Request.Context(), e); err != nil {
+		log.Printf("Failed to process payment webhook %s: %v", e.ID, err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, payment.ErrUnknownCharge) {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"received": true})
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	paymentinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/payment"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const webhookSecret = "whsec_test"
+
+type MockPaymentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPaymentUsecase) HandleWebhookEvent(ctx context.Context, e *payment.WebhookEvent) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+type WebhookControllerTestSuite struct {
+	suite.Suite
+	usecase    *MockPaymentUsecase
+	controller *WebhookController
+}
+
+func (suite *WebhookControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.usecase = new(MockPaymentUsecase)
+	verifier := paymentinfra.NewStripeWebhookVerifier(webhookSecret, paymentinfra.DefaultWebhookTolerance)
+	suite.controller = NewWebhookController(verifier, suite.usecase)
+}
+
+func TestWebhookControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookControllerTestSuite))
+}
+
+func (suite *WebhookControllerTestSuite) deliver(payload []byte, signature string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/webhooks/payments", bytes.NewBuffer(payload))
+	if signature != "" {
+		c.Request.Header.Set("Stripe-Signature", signature)
+	}
+	suite.controller.HandlePaymentWebhook(c)
+	return w
+}
+
+func sign(payload []byte) string {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	return "t=" + ts + ",v1=" + paymentinfra.SignWebhookPayload([]byte(webhookSecret), ts, payload)
+}
+
+func (suite *WebhookControllerTestSuite) TestHandlePaymentWebhook_Success() {
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","data":{"object":{"id":"pi_1"}}}`)
+	suite.usecase.On("HandleWebhookEvent", mock.Anything, mock.MatchedBy(func(e *payment.WebhookEvent) bool {
+		return e.ID == "evt_1" && e.ChargeID == "pi_1" && e.Status == payment.StatusCaptured
+	})).Return(nil)
+
+	w := suite.deliver(payload, sign(payload))
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookControllerTestSuite) TestHandlePaymentWebhook_BadSignature() {
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","data":{"object":{"id":"pi_1"}}}`)
+
+	w := suite.deliver(payload, "t=1,v1=deadbeef")
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "HandleWebhookEvent")
+}
+
+func (suite *WebhookControllerTestSuite) TestHandlePaymentWebhook_MissingSignature() {
+	w := suite.deliver([]byte(`{"id":"evt_1"}`), "")
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+func (suite *WebhookControllerTestSuite) TestHandlePaymentWebhook_UnknownCharge() {
+	payload := []byte(`{"id":"evt_2","type":"payment_intent.succeeded","data":{"object":{"id":"pi_new"}}}`)
+	suite.usecase.On("HandleWebhookEvent", mock.Anything, mock.Anything).Return(payment.ErrUnknownCharge)
+
+	w := suite.deliver(payload, sign(payload))
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
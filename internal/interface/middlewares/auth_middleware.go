@@ -50,6 +50,19 @@ func AuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
 	}
 }
 
+// QueryTokenMiddleware lets clients that cannot set headers, such as the browser
+// EventSource API, pass the JWT as ?token=. AuthMiddleware still does the validation.
+func QueryTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
+
 func AuthorizeRoles(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		roleVal, exists := c.Get("role")
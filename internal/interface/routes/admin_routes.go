@@ -17,6 +17,13 @@ func RegisterAdminRoutes(r *gin.Engine, ctrl *controllers.AdminController, jwtSv
 	adminGroup.GET("/users/trust-scores", ctrl.GetTrustScores)
 	adminGroup.GET("/blacklisted-users", ctrl.GetBlacklistedUsers)
 	adminGroup.GET("/dashboard", ctrl.GetDashboardMetrics)
+	adminGroup.POST("/orders/:id/cancel", ctrl.CancelOrder)
+
+	// Reports take ?from=&to=&interval=&currency= and ?format=csv
+	adminGroup.GET("/reports/revenue", ctrl.GetRevenueReport)
+	adminGroup.GET("/reports/users", ctrl.GetUserReport)
+	adminGroup.GET("/reports/skips", ctrl.GetSkipReport)
+	adminGroup.GET("/reports/top-sellers", ctrl.GetTopSellers)
 
 	// More admin routes can be added here (e.g. transactions, reviews, dashboards, etc.)
 	// adminGroup.GET("/dashboard", ctrl.GetDashboardMetrics)
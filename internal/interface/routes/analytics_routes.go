@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAnalyticsRoutes(r *gin.Engine, ctrl *controllers.AnalyticsController, jwtSvc auth.JWTService) {
+	analyticsGroup := r.Group("/analytics")
+	analyticsGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+	analyticsGroup.GET("/reseller/bundles", middlewares.AuthorizeRoles("reseller"), ctrl.GetResellerROI)
+	analyticsGroup.GET("/supplier/sales", middlewares.AuthorizeRoles("supplier"), ctrl.GetSupplierSeries)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterChatRoutes(r *gin.Engine, ctrl *controllers.ChatController, jwtSvc auth.JWTService) {
+	chatGroup := r.Group("/chats")
+	chatGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	chatGroup.POST("", ctrl.SendMessage)
+	chatGroup.GET("", ctrl.ListConversations)
+	chatGroup.GET("/:userId", ctrl.GetConversation)
+	chatGroup.PUT("/:userId/read", ctrl.MarkConversationAsRead)
+	chatGroup.PUT("/messages/:id/read", ctrl.MarkMessageAsRead)
+}
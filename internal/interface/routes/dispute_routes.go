@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDisputeRoutes(r *gin.Engine, ctrl *controllers.DisputeController, jwtSvc auth.JWTService) {
+	disputeGroup := r.Group("/disputes")
+	disputeGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+
+	// Buyers open disputes, sellers respond
+	disputeGroup.POST("", middlewares.AuthorizeRoles("reseller", "consumer"), ctrl.OpenDispute)
+	disputeGroup.GET("", ctrl.ListMyDisputes)
+	disputeGroup.GET("/:id", ctrl.GetDispute)
+	disputeGroup.POST("/:id/response", middlewares.AuthorizeRoles("supplier", "reseller"), ctrl.RespondToDispute)
+
+	adminGroup := r.Group("/admin/disputes")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	adminGroup.GET("", ctrl.ListDisputes)
+	adminGroup.POST("/:id/resolve", ctrl.ResolveDispute)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterExchangeRateRoutes(r *gin.Engine, ctrl *controllers.ExchangeRateController, jwtSvc auth.JWTService) {
+	r.GET("/exchange-rates", middlewares.AuthMiddleware(jwtSvc), ctrl.ListRates)
+
+	adminGroup := r.Group("/admin/exchange-rates")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	adminGroup.GET("", ctrl.ListRates)
+	adminGroup.PUT("", ctrl.SetRate)
+	adminGroup.DELETE("/:from/:to", ctrl.DeleteRate)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterFeeRoutes(r *gin.Engine, ctrl *controllers.FeeController, jwtSvc auth.JWTService) {
+	feeGroup := r.Group("/admin/fee-rules")
+	feeGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	feeGroup.POST("", ctrl.CreateRule)
+	feeGroup.GET("", ctrl.ListRules)
+	feeGroup.GET("/:id", ctrl.GetRule)
+	feeGroup.PUT("/:id", ctrl.UpdateRule)
+	feeGroup.DELETE("/:id", ctrl.DeleteRule)
+}
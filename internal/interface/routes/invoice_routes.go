@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterInvoiceRoutes(r *gin.Engine, ctrl *controllers.InvoiceController, jwtSvc auth.JWTService) {
+	orderGroup := r.Group("/orders")
+	orderGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+	orderGroup.GET("/:id/invoice", middlewares.AuthorizeRoles("supplier", "reseller", "consumer"), ctrl.GetInvoice)
+
+	adminGroup := r.Group("/admin/orders")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	adminGroup.POST("/:id/invoice", ctrl.RegenerateInvoice)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterLedgerRoutes(r *gin.Engine, ctrl *controllers.LedgerController, jwtSvc auth.JWTService) {
+	ledgerGroup := r.Group("/ledger")
+	ledgerGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+	ledgerGroup.GET("/balance", middlewares.AuthorizeRoles("supplier", "reseller"), ctrl.GetBalance)
+
+	adminGroup := r.Group("/admin")
+	adminGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("admin"))
+	adminGroup.POST("/payouts", ctrl.RunPayouts)
+	adminGroup.GET("/ledger/reconciliation", ctrl.Reconcile)
+}
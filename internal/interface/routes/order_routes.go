@@ -16,4 +16,10 @@ func RegisterOrderRoutes(r *gin.Engine, order_ctrl *controllers.OrderController,
 	consumerGroup.GET("/history", middlewares.AuthorizeRoles("reseller", "consumer"), order_ctrl.GetOrderHistory)
 	consumerGroup.GET("/supplier/history", middlewares.AuthorizeRoles("supplier"), order_ctrl.GetSoldBundleHistory)
 	consumerGroup.GET("/reseller/history", middlewares.AuthorizeRoles("reseller"), order_ctrl.GetOrdersByReseller)
+
+	// Fulfilment: the seller moves the order along, the buyer confirms it arrived
+	consumerGroup.PATCH("/:id/processing", middlewares.AuthorizeRoles("supplier", "reseller"), order_ctrl.MarkOrderProcessing)
+	consumerGroup.PATCH("/:id/shipped", middlewares.AuthorizeRoles("supplier", "reseller"), order_ctrl.MarkOrderShipped)
+	consumerGroup.PATCH("/:id/delivered", middlewares.AuthorizeRoles("reseller", "consumer"), order_ctrl.ConfirmDelivery)
+	consumerGroup.POST("/:id/cancel", middlewares.AuthorizeRoles("reseller", "consumer"), order_ctrl.CancelOrder)
 }
@@ -23,6 +23,7 @@ func RegisterProductRoutes(
 	{
 		products.POST("", middlewares.AuthorizeRoles("reseller"), productCtrl.Create)
 		products.GET("", productCtrl.ListAvailable)
+		products.GET("/search", productCtrl.Search)
 		products.GET("/title/:title", productCtrl.GetByTitle) 
 		products.GET("/:id", productCtrl.GetByID)
 		products.GET("/reseller/:id", productCtrl.ListByReseller)
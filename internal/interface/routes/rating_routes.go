@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRatingRoutes(r *gin.Engine, ctrl *controllers.RatingController, jwtSvc auth.JWTService) {
+	bundleGroup := r.Group("/bundles")
+	bundleGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+	bundleGroup.POST("/:id/rating", middlewares.AuthorizeRoles("reseller"), ctrl.RateBundleSupplier)
+
+	supplierGroup := r.Group("/suppliers")
+	supplierGroup.Use(middlewares.AuthMiddleware(jwtSvc))
+	supplierGroup.GET("/:id/ratings", ctrl.GetSupplierRatings)
+}
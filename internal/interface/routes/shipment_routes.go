@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterShipmentRoutes(r *gin.Engine, ctrl *controllers.ShipmentController, jwtSvc auth.JWTService) {
+	// Either party of an order can follow its parcel
+	orderGroup := r.Group("/orders")
+	orderGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("supplier", "reseller", "consumer"))
+	orderGroup.GET("/:id/shipment", ctrl.GetShipment)
+
+	// Carrier callbacks are authenticated by their signature rather than a JWT
+	webhookGroup := r.Group("/webhooks")
+	webhookGroup.POST("/shipments", ctrl.HandleTrackingWebhook)
+}
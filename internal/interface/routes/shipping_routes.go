@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterShippingRoutes(r *gin.Engine, ctrl *controllers.ShippingController, jwtSvc auth.JWTService) {
+	// Buyers keep an address book to pick delivery addresses from at checkout
+	addressGroup := r.Group("/addresses")
+	addressGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("reseller", "consumer"))
+	addressGroup.POST("", ctrl.AddAddress)
+	addressGroup.GET("", ctrl.ListAddresses)
+	addressGroup.PUT("/:id", ctrl.UpdateAddress)
+	addressGroup.DELETE("/:id", ctrl.DeleteAddress)
+
+	shippingGroup := r.Group("/shipping")
+	shippingGroup.Use(middlewares.AuthMiddleware(jwtSvc), middlewares.AuthorizeRoles("reseller", "consumer"))
+	shippingGroup.GET("/options", ctrl.GetShippingOptions)
+}
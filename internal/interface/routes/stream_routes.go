@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/auth"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStreamRoutes(r *gin.Engine, ctrl *controllers.StreamController, jwtSvc auth.JWTService) {
+	r.GET("/stream", middlewares.QueryTokenMiddleware(), middlewares.AuthMiddleware(jwtSvc), ctrl.Stream)
+}
@@ -12,4 +12,7 @@ func RegisterWarehouseRoutes(r *gin.Engine, warehouse_ctrl *controllers.Warehous
 	warehouseGroup.Use(middlewares.AuthMiddleware(jwtSvc))
 
 	warehouseGroup.GET("", middlewares.AuthorizeRoles("reseller"), warehouse_ctrl.GetWarehouseItems)
+	warehouseGroup.POST("/:id/receive", middlewares.AuthorizeRoles("reseller"), warehouse_ctrl.ReceiveBundle)
+	warehouseGroup.POST("/:id/unpack", middlewares.AuthorizeRoles("reseller"), warehouse_ctrl.UnpackPiece)
+	warehouseGroup.POST("/:id/skip", middlewares.AuthorizeRoles("reseller"), warehouse_ctrl.SkipPiece)
 }
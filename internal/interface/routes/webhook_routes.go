@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/interface/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterWebhookRoutes exposes the gateway callbacks. They are authenticated by
+// their signature rather than a JWT.
+func RegisterWebhookRoutes(r *gin.Engine, ctrl *controllers.WebhookController) {
+	webhookGroup := r.Group("/webhooks")
+	webhookGroup.POST("/payments", ctrl.HandlePaymentWebhook)
+}
@@ -0,0 +1,145 @@
+package adminusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type adminUsecase struct {
+	repo      admin.Repository
+	converter money.Converter
+}
+
+func NewAdminUsecase(repo admin.Repository, converter money.Converter) admin.Usecase {
+	return &adminUsecase{
+		repo:      repo,
+		converter: converter,
+	}
+}
+
+func (u *adminUsecase) GetDashboardMetrics(ctx context.Context) (*admin.Metrics, error) {
+	return u.repo.FetchPlatformMetrics(ctx)
+}
+
+// prepare defaults and validates a report query and fills in its exchange rates.
+func (u *adminUsecase) prepare(ctx context.Context, q *analytics.SeriesQuery) error {
+	q.SetDefaults(time.Now())
+	if err := q.Validate(); err != nil {
+		return err
+	}
+	factors, err := money.MinorFactors(ctx, u.converter, q.Currency)
+	if err != nil {
+		return err
+	}
+	q.Factors = factors
+	return nil
+}
+
+func (u *adminUsecase) GetRevenueReport(ctx context.Context, q *analytics.SeriesQuery) (*admin.RevenueReport, error) {
+	if err := u.prepare(ctx, q); err != nil {
+		return nil, err
+	}
+	rows, err := u.repo.GetRevenueReport(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &admin.RevenueReport{
+		ReportRange: admin.NewReportRange(q),
+		Rows:        rows,
+		GMV:         money.New(0, q.Currency),
+		FeeRevenue:  money.New(0, q.Currency),
+	}
+	if report.Rows == nil {
+		report.Rows = []*admin.RevenueRow{}
+	}
+	for _, row := range rows {
+		report.GMV = report.GMV.Add(row.GMV)
+		report.FeeRevenue = report.FeeRevenue.Add(row.FeeRevenue)
+	}
+	return report, nil
+}
+
+func (u *adminUsecase) GetUserReport(ctx context.Context, q *analytics.SeriesQuery) (*admin.UserReport, error) {
+	if err := u.prepare(ctx, q); err != nil {
+		return nil, err
+	}
+	rows, err := u.repo.GetNewUsers(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := u.repo.GetBlacklistRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &admin.UserReport{
+		ReportRange: admin.NewReportRange(q),
+		Rows:        rows,
+		Blacklist:   rates,
+	}
+	if report.Rows == nil {
+		report.Rows = []*admin.UserRow{}
+	}
+	if report.Blacklist == nil {
+		report.Blacklist = []*admin.BlacklistRate{}
+	}
+	return report, nil
+}
+
+func (u *adminUsecase) GetSkipReport(ctx context.Context, q *analytics.SeriesQuery) (*admin.SkipReport, error) {
+	if err := u.prepare(ctx, q); err != nil {
+		return nil, err
+	}
+	rows, err := u.repo.GetSkipReport(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &admin.SkipReport{
+		ReportRange: admin.NewReportRange(q),
+		Rows:        rows,
+	}
+	if report.Rows == nil {
+		report.Rows = []*admin.SkipRow{}
+	}
+	for _, row := range rows {
+		report.Unpacked += row.Unpacked
+		report.Skipped += row.Skipped
+	}
+	report.Ratio = admin.SkipRatio(report.Unpacked, report.Skipped)
+	return report, nil
+}
+
+func (u *adminUsecase) GetTopSellers(ctx context.Context, q *analytics.SeriesQuery, limit int) (*admin.TopSellersReport, error) {
+	if limit == 0 {
+		limit = admin.DefaultTopLimit
+	}
+	if limit < 0 || limit > admin.MaxTopLimit {
+		return nil, admin.ErrInvalidLimit
+	}
+	if err := u.prepare(ctx, q); err != nil {
+		return nil, err
+	}
+	suppliers, resellers, err := u.repo.GetTopSellers(ctx, q, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &admin.TopSellersReport{
+		ReportRange: admin.NewReportRange(q),
+		Suppliers:   suppliers,
+		Resellers:   resellers,
+	}
+	if report.Suppliers == nil {
+		report.Suppliers = []*admin.TopSeller{}
+	}
+	if report.Resellers == nil {
+		report.Resellers = []*admin.TopSeller{}
+	}
+	return report, nil
+}
@@ -0,0 +1,216 @@
+package adminusecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminRepository) FetchPlatformMetrics(ctx context.Context) (*admin.Metrics, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*admin.Metrics), args.Error(1)
+}
+
+func (m *MockAdminRepository) GetActiveUsersCount(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAdminRepository) GetRevenueReport(ctx context.Context, q *analytics.SeriesQuery) ([]*admin.RevenueRow, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.RevenueRow), args.Error(1)
+}
+
+func (m *MockAdminRepository) GetNewUsers(ctx context.Context, q *analytics.SeriesQuery) ([]*admin.UserRow, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.UserRow), args.Error(1)
+}
+
+func (m *MockAdminRepository) GetSkipReport(ctx context.Context, q *analytics.SeriesQuery) ([]*admin.SkipRow, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.SkipRow), args.Error(1)
+}
+
+func (m *MockAdminRepository) GetTopSellers(ctx context.Context, q *analytics.SeriesQuery, limit int) ([]*admin.TopSeller, []*admin.TopSeller, error) {
+	args := m.Called(ctx, q, limit)
+	var suppliers, resellers []*admin.TopSeller
+	if args.Get(0) != nil {
+		suppliers = args.Get(0).([]*admin.TopSeller)
+	}
+	if args.Get(1) != nil {
+		resellers = args.Get(1).([]*admin.TopSeller)
+	}
+	return suppliers, resellers, args.Error(2)
+}
+
+func (m *MockAdminRepository) GetBlacklistRates(ctx context.Context) ([]*admin.BlacklistRate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*admin.BlacklistRate), args.Error(1)
+}
+
+// staticRates converts with fixed exchange rates.
+type staticRates struct {
+	rates []*money.Rate
+}
+
+func (r *staticRates) Rate(ctx context.Context, from, to money.Currency) (*money.RateSnapshot, error) {
+	if from == to {
+		return nil, nil
+	}
+	for _, rate := range r.rates {
+		if rate.From == from && rate.To == to {
+			return rate.Snapshot(), nil
+		}
+	}
+	return nil, money.ErrRateNotFound
+}
+
+func (r *staticRates) Convert(ctx context.Context, m money.Money, to money.Currency) (money.Money, error) {
+	snapshot, err := r.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return snapshot.Convert(m), nil
+}
+
+type AdminUsecaseTestSuite struct {
+	suite.Suite
+	repo    *MockAdminRepository
+	rates   *staticRates
+	usecase admin.Usecase
+	ctx     context.Context
+	from    time.Time
+}
+
+func (s *AdminUsecaseTestSuite) SetupTest() {
+	s.repo = new(MockAdminRepository)
+	s.rates = &staticRates{}
+	s.usecase = NewAdminUsecase(s.repo, s.rates)
+	s.ctx = context.Background()
+	s.from = time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *AdminUsecaseTestSuite) query() *analytics.SeriesQuery {
+	return &analytics.SeriesQuery{From: s.from, To: s.from.AddDate(0, 2, 0), Interval: analytics.Month}
+}
+
+func (s *AdminUsecaseTestSuite) TestGetDashboardMetrics() {
+	metrics := &admin.Metrics{TotalBundles: 4, TotalUsers: 9}
+	s.repo.On("FetchPlatformMetrics", s.ctx).Return(metrics, nil)
+
+	got, err := s.usecase.GetDashboardMetrics(s.ctx)
+
+	s.NoError(err)
+	s.Same(metrics, got)
+}
+
+func (s *AdminUsecaseTestSuite) TestGetRevenueReport_Totals() {
+	s.rates.rates = []*money.Rate{{From: money.USD, To: money.ETB, Rate: 57.5}}
+	s.repo.On("GetRevenueReport", s.ctx, mock.MatchedBy(func(q *analytics.SeriesQuery) bool {
+		return q.Currency == money.ETB && q.Factors[money.USD] == 57.5
+	})).Return([]*admin.RevenueRow{
+		{Start: s.from, Type: payment.B2B, Payments: 1, GMV: money.New(100000, money.ETB), FeeRevenue: money.New(5000, money.ETB)},
+		{Start: s.from.AddDate(0, 1, 0), Type: payment.B2C, Payments: 2, GMV: money.New(30000, money.ETB), FeeRevenue: money.New(1500, money.ETB)},
+	}, nil)
+
+	report, err := s.usecase.GetRevenueReport(s.ctx, s.query())
+
+	s.Require().NoError(err)
+	s.Equal(analytics.Month, report.Interval)
+	s.Equal(money.New(130000, money.ETB), report.GMV)
+	s.Equal(money.New(6500, money.ETB), report.FeeRevenue)
+}
+
+func (s *AdminUsecaseTestSuite) TestGetRevenueReport_NoPayments() {
+	s.repo.On("GetRevenueReport", s.ctx, mock.Anything).Return(nil, nil)
+
+	report, err := s.usecase.GetRevenueReport(s.ctx, s.query())
+
+	s.Require().NoError(err)
+	s.NotNil(report.Rows)
+	s.Equal(money.New(0, money.ETB), report.GMV)
+}
+
+func (s *AdminUsecaseTestSuite) TestGetUserReport() {
+	rows := []*admin.UserRow{{Start: s.from, Role: "supplier", NewUsers: 3}}
+	rates := []*admin.BlacklistRate{{Role: "supplier", Active: 20, Blacklisted: 2, Rate: 10}}
+	s.repo.On("GetNewUsers", s.ctx, mock.Anything).Return(rows, nil)
+	s.repo.On("GetBlacklistRates", s.ctx).Return(rates, nil)
+
+	report, err := s.usecase.GetUserReport(s.ctx, s.query())
+
+	s.Require().NoError(err)
+	s.Equal(rows, report.Rows)
+	s.Equal(rates, report.Blacklist)
+}
+
+func (s *AdminUsecaseTestSuite) TestGetSkipReport_OverallRatio() {
+	s.repo.On("GetSkipReport", s.ctx, mock.Anything).Return([]*admin.SkipRow{
+		{Start: s.from, Unpacked: 40, Skipped: 4, Ratio: 10},
+		{Start: s.from.AddDate(0, 1, 0), Unpacked: 20, Skipped: 5, Ratio: 25},
+	}, nil)
+
+	report, err := s.usecase.GetSkipReport(s.ctx, s.query())
+
+	s.Require().NoError(err)
+	s.Equal(60, report.Unpacked)
+	s.Equal(9, report.Skipped)
+	s.Equal(15.0, report.Ratio)
+}
+
+func (s *AdminUsecaseTestSuite) TestGetTopSellers_DefaultLimit() {
+	s.repo.On("GetTopSellers", s.ctx, mock.Anything, admin.DefaultTopLimit).
+		Return([]*admin.TopSeller{{UserID: "s1"}}, nil, nil)
+
+	report, err := s.usecase.GetTopSellers(s.ctx, s.query(), 0)
+
+	s.Require().NoError(err)
+	s.Len(report.Suppliers, 1)
+	s.NotNil(report.Resellers)
+}
+
+func (s *AdminUsecaseTestSuite) TestGetTopSellers_InvalidLimit() {
+	_, err := s.usecase.GetTopSellers(s.ctx, s.query(), admin.MaxTopLimit+1)
+
+	s.ErrorIs(err, admin.ErrInvalidLimit)
+	s.repo.AssertNotCalled(s.T(), "GetTopSellers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AdminUsecaseTestSuite) TestReports_InvalidRange() {
+	q := &analytics.SeriesQuery{From: s.from, To: s.from, Interval: analytics.Day}
+
+	_, err := s.usecase.GetSkipReport(s.ctx, q)
+
+	s.ErrorIs(err, analytics.ErrInvalidRange)
+	s.repo.AssertNotCalled(s.T(), "GetSkipReport", mock.Anything, mock.Anything)
+}
+
+func TestAdminUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminUsecaseTestSuite))
+}
@@ -0,0 +1,82 @@
+package analyticsusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type analyticsUsecase struct {
+	repo      analytics.Repository
+	converter money.Converter
+}
+
+func NewAnalyticsUsecase(repo analytics.Repository, converter money.Converter) analytics.Usecase {
+	return &analyticsUsecase{
+		repo:      repo,
+		converter: converter,
+	}
+}
+
+func (u *analyticsUsecase) GetResellerROI(ctx context.Context, resellerID string, currency money.Currency) (*analytics.ResellerROI, error) {
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	factors, err := money.MinorFactors(ctx, u.converter, currency)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.ResellerROI(ctx, resellerID, currency, factors)
+}
+
+// GetSupplierSeries fills in every period of the range, so periods without
+// activity show as zeros, and carries the trust score forward across periods in
+// which it did not change.
+func (u *analyticsUsecase) GetSupplierSeries(ctx context.Context, supplierID string, q *analytics.SeriesQuery) (*analytics.SupplierSeries, error) {
+	q.SetDefaults(time.Now())
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	factors, err := money.MinorFactors(ctx, u.converter, q.Currency)
+	if err != nil {
+		return nil, err
+	}
+	q.Factors = factors
+	found, err := u.repo.SupplierSales(ctx, supplierID, q)
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[int64]*analytics.SupplierPeriod, len(found))
+	for _, p := range found {
+		byStart[p.Start.Unix()] = p
+	}
+
+	series := &analytics.SupplierSeries{
+		SupplierID: supplierID,
+		Interval:   q.Interval,
+		From:       q.From,
+		To:         q.To,
+		Currency:   q.Currency,
+	}
+	var trust *int
+	for _, start := range q.Periods() {
+		p, ok := byStart[start.Unix()]
+		if !ok {
+			p = &analytics.SupplierPeriod{
+				Start:        start,
+				Revenue:      money.New(0, q.Currency),
+				AvgSalePrice: money.New(0, q.Currency),
+			}
+		}
+		if p.TrustScore != nil {
+			trust = p.TrustScore
+		} else {
+			p.TrustScore = trust
+		}
+		series.Periods = append(series.Periods, p)
+	}
+	return series, nil
+}
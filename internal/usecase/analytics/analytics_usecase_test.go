@@ -0,0 +1,166 @@
+package analyticsusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/analytics"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsRepository) ResellerROI(ctx context.Context, resellerID string, currency money.Currency, factors map[money.Currency]float64) (*analytics.ResellerROI, error) {
+	args := m.Called(ctx, resellerID, currency, factors)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.ResellerROI), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) SupplierSales(ctx context.Context, supplierID string, q *analytics.SeriesQuery) ([]*analytics.SupplierPeriod, error) {
+	args := m.Called(ctx, supplierID, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*analytics.SupplierPeriod), args.Error(1)
+}
+
+// staticRates converts with fixed exchange rates.
+type staticRates struct {
+	rates []*money.Rate
+	err   error
+}
+
+func (r *staticRates) Rate(ctx context.Context, from, to money.Currency) (*money.RateSnapshot, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if from == to {
+		return nil, nil
+	}
+	for _, rate := range r.rates {
+		if rate.From == from && rate.To == to {
+			return rate.Snapshot(), nil
+		}
+	}
+	return nil, money.ErrRateNotFound
+}
+
+func (r *staticRates) Convert(ctx context.Context, m money.Money, to money.Currency) (money.Money, error) {
+	snapshot, err := r.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return snapshot.Convert(m), nil
+}
+
+type AnalyticsUsecaseTestSuite struct {
+	suite.Suite
+	repo    *MockAnalyticsRepository
+	rates   *staticRates
+	usecase analytics.Usecase
+	ctx     context.Context
+}
+
+func (s *AnalyticsUsecaseTestSuite) SetupTest() {
+	s.repo = new(MockAnalyticsRepository)
+	s.rates = &staticRates{}
+	s.usecase = NewAnalyticsUsecase(s.repo, s.rates)
+	s.ctx = context.Background()
+}
+
+func (s *AnalyticsUsecaseTestSuite) TestGetResellerROI_PassesRateFactors() {
+	s.rates.rates = []*money.Rate{{From: money.ETB, To: money.USD, Rate: 0.0175}}
+	report := &analytics.ResellerROI{Currency: money.USD}
+	s.repo.On("ResellerROI", s.ctx, "reseller-1", money.USD, map[money.Currency]float64{money.USD: 1, money.ETB: 0.0175}).
+		Return(report, nil)
+
+	got, err := s.usecase.GetResellerROI(s.ctx, "reseller-1", money.USD)
+
+	s.NoError(err)
+	s.Same(report, got)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *AnalyticsUsecaseTestSuite) TestGetResellerROI_DefaultsCurrency() {
+	s.repo.On("ResellerROI", s.ctx, "reseller-1", money.DefaultCurrency, mock.Anything).
+		Return(&analytics.ResellerROI{Currency: money.DefaultCurrency}, nil)
+
+	_, err := s.usecase.GetResellerROI(s.ctx, "reseller-1", "")
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *AnalyticsUsecaseTestSuite) TestGetResellerROI_RateError() {
+	s.rates.err = errors.New("rates unavailable")
+
+	_, err := s.usecase.GetResellerROI(s.ctx, "reseller-1", money.USD)
+
+	assert.EqualError(s.T(), err, "rates unavailable")
+	s.repo.AssertNotCalled(s.T(), "ResellerROI", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AnalyticsUsecaseTestSuite) TestGetSupplierSeries_FillsPeriods() {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	trust := 72
+	s.repo.On("SupplierSales", s.ctx, "supplier-1", mock.MatchedBy(func(q *analytics.SeriesQuery) bool {
+		return q.Currency == money.DefaultCurrency && q.Factors[money.DefaultCurrency] == 1
+	})).Return([]*analytics.SupplierPeriod{
+		{Start: day(3), BundlesSold: 1, Revenue: money.New(9000, money.ETB)},
+		{Start: day(2), TrustScore: &trust},
+	}, nil)
+
+	series, err := s.usecase.GetSupplierSeries(s.ctx, "supplier-1", &analytics.SeriesQuery{From: day(1), To: day(5)})
+
+	s.Require().NoError(err)
+	s.Equal(analytics.Day, series.Interval)
+	s.Require().Len(series.Periods, 4)
+	for i, p := range series.Periods {
+		s.Equal(day(i+1), p.Start)
+	}
+	s.Nil(series.Periods[0].TrustScore)
+	s.Equal(money.New(0, money.ETB), series.Periods[0].Revenue)
+	s.Equal(72, *series.Periods[1].TrustScore)
+	s.Equal(1, series.Periods[2].BundlesSold)
+	s.Equal(72, *series.Periods[2].TrustScore)
+	s.Equal(72, *series.Periods[3].TrustScore)
+}
+
+func (s *AnalyticsUsecaseTestSuite) TestGetSupplierSeries_DefaultRange() {
+	s.repo.On("SupplierSales", s.ctx, "supplier-1", mock.Anything).Return([]*analytics.SupplierPeriod{}, nil)
+
+	series, err := s.usecase.GetSupplierSeries(s.ctx, "supplier-1", &analytics.SeriesQuery{Interval: analytics.Month})
+
+	s.Require().NoError(err)
+	s.Len(series.Periods, 12)
+	s.Equal(1, series.From.Day())
+}
+
+func (s *AnalyticsUsecaseTestSuite) TestGetSupplierSeries_InvalidQuery() {
+	now := time.Now()
+	queries := map[string]*analytics.SeriesQuery{
+		"unknown interval": {Interval: "hour"},
+		"inverted range":   {From: now, To: now.Add(-time.Hour)},
+		"too many periods": {From: now.AddDate(-5, 0, 0), To: now},
+	}
+	for name, q := range queries {
+		s.Run(name, func() {
+			_, err := s.usecase.GetSupplierSeries(s.ctx, "supplier-1", q)
+			s.Error(err)
+		})
+	}
+	s.repo.AssertNotCalled(s.T(), "SupplierSales", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAnalyticsUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsUsecaseTestSuite))
+}
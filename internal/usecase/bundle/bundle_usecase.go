@@ -6,16 +6,20 @@ import (
 	"fmt"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type bundleUsecase struct {
 	bundleRepo bundle.Repository
+	converter  money.Converter
 }
 
-func NewBundleUsecase(bundleRepo bundle.Repository) bundle.Usecase {
+func NewBundleUsecase(bundleRepo bundle.Repository, converter money.Converter) bundle.Usecase {
 	return &bundleUsecase{
 		bundleRepo: bundleRepo,
+		converter:  converter,
 	}
 }
 
@@ -23,15 +27,16 @@ func (u *bundleUsecase) CreateBundle(ctx context.Context, supplierID string, b *
 	if b.SupplierID != supplierID {
 		return errors.New("unauthorized: supplier ID mismatch")
 	}
+	price, err := b.Price.Normalized()
+	if err != nil {
+		return err
+	}
+	b.Price = price
 	return u.bundleRepo.CreateBundle(ctx, b)
 }
 
-func (u *bundleUsecase) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	bundles, err := u.bundleRepo.ListBundles(ctx, supplierID)
-	if err != nil {
-		return nil, err
-	}
-	return bundles, nil
+func (u *bundleUsecase) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	return u.bundleRepo.ListBundles(ctx, supplierID, req)
 }
 
 func (u *bundleUsecase) DeleteBundle(ctx context.Context, supplierID string, bundleID string) error {
@@ -87,26 +92,43 @@ func (u *bundleUsecase) UpdateBundle(ctx context.Context, supplierID string, id
 		return errors.New("cannot update bundle: bundle must be in 'available' status")
 	}
 
+	if raw, ok := updatedData["price"]; ok {
+		price, err := money.FromValue(raw)
+		if err != nil {
+			return err
+		}
+		updatedData["price"] = price
+	}
+
 	// Update the bundle in the repository
 	return u.bundleRepo.UpdateBundle(ctx, id, updatedData)
 }
 
-func (uc *bundleUsecase) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	return uc.bundleRepo.ListAvailableBundles(ctx)
-}
-func (u *bundleUsecase) DecreaseRemainingItemCount(ctx context.Context, bundleID string) error {
-	b, err := u.bundleRepo.GetBundleByID(ctx, bundleID)
-	if err != nil {
-		return err
+// ListAvailableBundles returns a page of the bundles on sale, newest first unless
+// the query sorts by price per item. Prices in other currencies are compared at
+// the configured exchange rates.
+func (uc *bundleUsecase) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	if q.Sort == "" {
+		q.Sort = bundle.SortNewest
+	}
+	if q.Currency == "" {
+		q.Currency = money.DefaultCurrency
 	}
-	if b.RemainingItemCount <= 0 {
-		return errors.New("bundle is fully unpacked")
+	if err := q.Validate(); err != nil {
+		return nil, err
 	}
+	req, err := q.Request.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	q.Request = req
 
-	newCount := b.RemainingItemCount - 1
-	return u.bundleRepo.UpdateBundle(ctx, bundleID, map[string]interface{}{
-		"remaining_item_count": newCount,
-	})
+	factors, err := money.MinorFactors(ctx, uc.converter, q.Currency)
+	if err != nil {
+		return nil, err
+	}
+	q.PriceFactors = factors
+	return uc.bundleRepo.ListAvailableBundles(ctx, q)
 }
 func (u *bundleUsecase) GetBundlePublicByID(ctx context.Context, bundleID string) (*bundle.Bundle, error) {
 	return u.bundleRepo.GetBundleByID(ctx, bundleID)
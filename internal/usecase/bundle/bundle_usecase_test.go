@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -31,28 +33,28 @@ func (m *MockRepository) GetBundleByID(ctx context.Context, id string) (*bundle.
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockRepository) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, supplierID)
+func (m *MockRepository) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockRepository) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
+func (m *MockRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockRepository) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockRepository) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
 func (m *MockRepository) UpdateBundleStatus(ctx context.Context, id string, status string) error {
@@ -65,6 +67,11 @@ func (m *MockRepository) MarkAsPurchased(ctx context.Context, bundleID string, r
 	return args.Error(0)
 }
 
+func (m *MockRepository) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
 func (m *MockRepository) DeleteBundle(ctx context.Context, bundleID string) error {
 	args := m.Called(ctx, bundleID)
 	return args.Error(0)
@@ -105,7 +112,7 @@ func createTestBundle(supplierID string) *bundle.Bundle {
 		SortingLevel:       bundle.Sorted,
 		EstimatedBreakdown: map[string]int{"shirts": 5, "pants": 5},
 		Type:               "clothing",
-		Price:              100.00,
+		Price:              money.New(10000, money.ETB),
 		Status:             "available",
 		CreatedAt:          time.Now().Format(time.RFC3339),
 		DateListed:         time.Now(),
@@ -115,18 +122,45 @@ func createTestBundle(supplierID string) *bundle.Bundle {
 	}
 }
 
+// staticRates converts with fixed exchange rates.
+type staticRates struct {
+	rates []*money.Rate
+}
+
+func (r *staticRates) Rate(ctx context.Context, from, to money.Currency) (*money.RateSnapshot, error) {
+	if from == to {
+		return nil, nil
+	}
+	for _, rate := range r.rates {
+		if rate.From == from && rate.To == to {
+			return rate.Snapshot(), nil
+		}
+	}
+	return nil, money.ErrRateNotFound
+}
+
+func (r *staticRates) Convert(ctx context.Context, m money.Money, to money.Currency) (money.Money, error) {
+	snapshot, err := r.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return snapshot.Convert(m), nil
+}
+
 // ---------------- Test Suite ----------------
 
 type BundleUsecaseTestSuite struct {
 	suite.Suite
 	mockRepo *MockRepository
+	rates    *staticRates
 	usecase  bundle.Usecase
 	ctx      context.Context
 }
 
 func (suite *BundleUsecaseTestSuite) SetupTest() {
 	suite.mockRepo = new(MockRepository)
-	suite.usecase = NewBundleUsecase(suite.mockRepo)
+	suite.rates = &staticRates{}
+	suite.usecase = NewBundleUsecase(suite.mockRepo, suite.rates)
 	suite.ctx = context.Background()
 }
 
@@ -184,7 +218,8 @@ func (suite *BundleUsecaseTestSuite) TestListBundles() {
 			name:       "Successful bundle listing",
 			supplierID: "supplier-1",
 			setupMock: func() {
-				suite.mockRepo.On("ListBundles", suite.ctx, "supplier-1").Return([]*bundle.Bundle{createTestBundle("supplier-1")}, nil)
+				suite.mockRepo.On("ListBundles", suite.ctx, "supplier-1", mock.Anything).
+					Return(&pagination.Page[*bundle.Bundle]{Items: []*bundle.Bundle{createTestBundle("supplier-1")}}, nil)
 			},
 			expectError: false,
 		},
@@ -192,7 +227,7 @@ func (suite *BundleUsecaseTestSuite) TestListBundles() {
 			name:       "Repository error",
 			supplierID: "supplier-1",
 			setupMock: func() {
-				suite.mockRepo.On("ListBundles", suite.ctx, "supplier-1").Return(([]*bundle.Bundle)(nil), errors.New("database error"))
+				suite.mockRepo.On("ListBundles", suite.ctx, "supplier-1", mock.Anything).Return(nil, errors.New("database error"))
 			},
 			expectError: true,
 		},
@@ -202,7 +237,7 @@ func (suite *BundleUsecaseTestSuite) TestListBundles() {
 		suite.Run(tt.name, func() {
 			suite.mockRepo.ExpectedCalls = nil // Reset mock expectations
 			tt.setupMock()
-			bundles, err := suite.usecase.ListBundles(suite.ctx, tt.supplierID)
+			bundles, err := suite.usecase.ListBundles(suite.ctx, tt.supplierID, pagination.Request{})
 			if tt.expectError {
 				assert.Error(suite.T(), err)
 				assert.Nil(suite.T(), bundles)
@@ -420,14 +455,15 @@ func (suite *BundleUsecaseTestSuite) TestListAvailableBundles() {
 		{
 			name: "Successful available bundles listing",
 			setupMock: func() {
-				suite.mockRepo.On("ListAvailableBundles", suite.ctx).Return([]*bundle.Bundle{createTestBundle("supplier-1")}, nil)
+				suite.mockRepo.On("ListAvailableBundles", suite.ctx, mock.Anything).
+					Return(&pagination.Page[*bundle.Bundle]{Items: []*bundle.Bundle{createTestBundle("supplier-1")}}, nil)
 			},
 			expectError: false,
 		},
 		{
 			name: "Repository error",
 			setupMock: func() {
-				suite.mockRepo.On("ListAvailableBundles", suite.ctx).Return(([]*bundle.Bundle)(nil), errors.New("database error"))
+				suite.mockRepo.On("ListAvailableBundles", suite.ctx, mock.Anything).Return(nil, errors.New("database error"))
 			},
 			expectError: true,
 		},
@@ -437,70 +473,59 @@ func (suite *BundleUsecaseTestSuite) TestListAvailableBundles() {
 		suite.Run(tt.name, func() {
 			suite.mockRepo.ExpectedCalls = nil // Reset mock expectations
 			tt.setupMock()
-			bundles, err := suite.usecase.ListAvailableBundles(suite.ctx)
+			page, err := suite.usecase.ListAvailableBundles(suite.ctx, &bundle.Query{})
 			if tt.expectError {
 				assert.Error(suite.T(), err)
-				assert.Nil(suite.T(), bundles)
+				assert.Nil(suite.T(), page)
 			} else {
 				assert.NoError(suite.T(), err)
-				assert.NotNil(suite.T(), bundles)
+				assert.NotNil(suite.T(), page)
 			}
 			suite.mockRepo.AssertExpectations(suite.T())
 		})
 	}
 }
 
-func (suite *BundleUsecaseTestSuite) TestDecreaseRemainingItemCount() {
-	tests := []struct {
-		name        string
-		bundleID    string
-		setupMock   func()
-		expectError bool
-	}{
-		{
-			name:     "Successful decrease",
-			bundleID: "test-bundle-id",
-			setupMock: func() {
-				b := createTestBundle("supplier-1")
-				b.RemainingItemCount = 5
-				suite.mockRepo.On("GetBundleByID", suite.ctx, "test-bundle-id").Return(b, nil)
-				suite.mockRepo.On("UpdateBundle", suite.ctx, "test-bundle-id", mock.Anything).Return(nil)
-			},
-			expectError: false,
-		},
-		{
-			name:     "Bundle not found",
-			bundleID: "non-existent",
-			setupMock: func() {
-				suite.mockRepo.On("GetBundleByID", suite.ctx, "non-existent").Return(nil, mongo.ErrNoDocuments)
-			},
-			expectError: true,
-		},
-		{
-			name:     "Bundle fully unpacked",
-			bundleID: "test-bundle-id",
-			setupMock: func() {
-				b := createTestBundle("supplier-1")
-				b.RemainingItemCount = 0
-				suite.mockRepo.On("GetBundleByID", suite.ctx, "test-bundle-id").Return(b, nil)
-			},
-			expectError: true,
+func (suite *BundleUsecaseTestSuite) TestListAvailableBundles_Defaults() {
+	suite.rates.rates = []*money.Rate{{From: money.USD, To: money.ETB, Rate: 57.5}}
+	suite.mockRepo.On("ListAvailableBundles", suite.ctx, mock.MatchedBy(func(q *bundle.Query) bool {
+		return q.Sort == bundle.SortNewest && q.Limit == pagination.DefaultLimit && q.Currency == money.ETB &&
+			assert.ObjectsAreEqual(map[money.Currency]float64{money.ETB: 1, money.USD: 57.5}, q.PriceFactors)
+	})).Return(&pagination.Page[*bundle.Bundle]{}, nil)
+
+	_, err := suite.usecase.ListAvailableBundles(suite.ctx, &bundle.Query{})
+
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *BundleUsecaseTestSuite) TestListAvailableBundles_InvalidQuery() {
+	queries := map[string]*bundle.Query{
+		"unknown sort":      {Sort: "cheapest"},
+		"unknown level":     {SortingLevels: []bundle.SortingLevel{"tidy"}},
+		"inverted quantity": {MinQuantity: 50, MaxQuantity: 10},
+		"inverted price": {
+			MinPrice: &money.Money{Amount: 5000, Currency: money.ETB},
+			MaxPrice: &money.Money{Amount: 1000, Currency: money.ETB},
 		},
 	}
-
-	for _, tt := range tests {
-		suite.Run(tt.name, func() {
-			suite.mockRepo.ExpectedCalls = nil // Reset mock expectations
-			tt.setupMock()
-			err := suite.usecase.DecreaseRemainingItemCount(suite.ctx, tt.bundleID)
-			if tt.expectError {
-				assert.Error(suite.T(), err)
-			} else {
-				assert.NoError(suite.T(), err)
-			}
-			suite.mockRepo.AssertExpectations(suite.T())
+	for name, q := range queries {
+		suite.Run(name, func() {
+			_, err := suite.usecase.ListAvailableBundles(suite.ctx, q)
+			assert.ErrorIs(suite.T(), err, bundle.ErrInvalidQuery)
 		})
 	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "ListAvailableBundles", mock.Anything, mock.Anything)
+}
+
+func (suite *BundleUsecaseTestSuite) TestListAvailableBundles_InvalidLimit() {
+	q := &bundle.Query{}
+	q.Limit = pagination.MaxLimit + 1
+
+	_, err := suite.usecase.ListAvailableBundles(suite.ctx, q)
+
+	assert.ErrorIs(suite.T(), err, pagination.ErrInvalidLimit)
+	suite.mockRepo.AssertNotCalled(suite.T(), "ListAvailableBundles", mock.Anything, mock.Anything)
 }
 
 func (suite *BundleUsecaseTestSuite) TestGetBundlePublicByID() {
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/cartitem"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
-	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	orderusecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/order"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
 	"github.com/google/uuid"
@@ -20,18 +22,16 @@ import (
 type cartItemUsecase struct {
 	repo        cartitem.Repository
 	productRepo product.Repository // Used to fetch product details
-	paymentRepo payment.Repository
 	orderUC     orderusecase.OrderUseCase
 	orderRepo   order.Repository
 }
 
 // NewCartItemUsecase creates a new CartItem usecase instance.
 // Note: productRepo is used for product lookup and validation during checkout.
-func NewCartItemUsecase(repo cartitem.Repository, productRepo product.Repository, paymentRepo payment.Repository, orderUC orderusecase.OrderUseCase, orderRepo order.Repository) cartitem.Usecase {
+func NewCartItemUsecase(repo cartitem.Repository, productRepo product.Repository, orderUC orderusecase.OrderUseCase, orderRepo order.Repository) cartitem.Usecase {
 	return &cartItemUsecase{
 		repo:        repo,
 		productRepo: productRepo,
-		paymentRepo: paymentRepo,
 		orderUC:     orderUC,
 		orderRepo:   orderRepo,
 	}
@@ -48,8 +48,9 @@ func (u *cartItemUsecase) AddCartItem(ctx context.Context, userID string, listin
 	if prod == nil {
 		return errors.New("product not found")
 	}
-	// Check that the product is available.
-	if prod.Status != "available" {
+	// Check that the product is still for sale. A product another consumer is
+	// checking out may still be added; the hold may lapse.
+	if prod.Status != product.StatusAvailable && prod.Status != product.StatusReserved {
 		return fmt.Errorf("product %s is not available", listingID)
 	}
 
@@ -77,7 +78,7 @@ func (u *cartItemUsecase) GetCartItems(ctx context.Context, userID string) ([]*c
 func (u *cartItemUsecase) RemoveCartItem(ctx context.Context, userID string, listingID string) error {
 	return u.repo.DeleteCartItem(ctx, userID, listingID)
 }
-func (u *cartItemUsecase) CheckoutCart(ctx context.Context, userID string) (*models.CheckoutResponse, error) {
+func (u *cartItemUsecase) CheckoutCart(ctx context.Context, userID string, currency money.Currency, delivery shipping.Selection) (*models.CheckoutResponse, error) {
 	items, err := u.repo.GetCartItems(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -86,67 +87,18 @@ func (u *cartItemUsecase) CheckoutCart(ctx context.Context, userID string) (*mod
 		return nil, errors.New("cart is empty")
 	}
 
-	var total float64
-	var checkoutItems []models.CheckoutItemResponse
-
-	for _, item := range items {
-		prod, err := u.productRepo.GetProductByID(ctx, item.ListingID)
-		if err != nil || prod == nil {
-			return nil, fmt.Errorf("product with ListingID %s not found", item.ListingID)
-		}
-		if prod.Status != "available" {
-			return nil, fmt.Errorf("item %q is no longer available", prod.Title)
-		}
-
-		total += prod.Price
-		checkoutItems = append(checkoutItems, models.CheckoutItemResponse{
-			ListingID: prod.ID,
-			Title:     prod.Title,
-			Price:     prod.Price,
-			SellerID:  prod.ResellerID.Hex(),
-			Status:    prod.Status,
-		})
-
-		// Create order and payment before marking product as sold
-		_, payment, err := u.orderUC.PurchaseProduct(ctx, prod.ID, userID, prod.Price)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create order for product %s: %w", prod.ID, err)
-		}
-
-		// Update payment with reseller ID
-		payment.ToUserID = prod.ResellerID.Hex()
-		if err := u.paymentRepo.RecordPayment(ctx, payment); err != nil {
-			return nil, fmt.Errorf("failed to update payment for product %s: %w", prod.ID, err)
-		}
-
-		// Mark product as sold and save to database
-		if err := u.productRepo.UpdateProduct(ctx, prod.ID, map[string]interface{}{
-			"status": "sold",
-		}); err != nil {
-			return nil, fmt.Errorf("failed to mark product %s as sold: %w", prod.ID, err)
-		}
+	resp, err := u.checkout(ctx, userID, items, currency, delivery)
+	if err != nil {
+		return nil, err
 	}
 
-	platformFee := total * 0.02
-	netPayable := total - platformFee
-
 	if err := u.repo.ClearCart(ctx, userID); err != nil {
 		return nil, err
 	}
-
-	go func() {
-		time.Sleep(3 * time.Minute)
-		// Simulate delivery status update
-	}()
-
-	return &models.CheckoutResponse{
-		TotalAmount: total,
-		Items:       checkoutItems,
-		PlatformFee: platformFee,
-		NetPayable:  netPayable,
-	}, nil
+	return resp, nil
 }
-func (u *cartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string) (*models.CheckoutResponse, error) {
+
+func (u *cartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listingID string, currency money.Currency, delivery shipping.Selection) (*models.CheckoutResponse, error) {
 	// Get all cart items for the user
 	items, err := u.repo.GetCartItems(ctx, userID)
 	if err != nil {
@@ -165,62 +117,146 @@ func (u *cartItemUsecase) CheckoutSingleItem(ctx context.Context, userID, listin
 		return nil, errors.New("item not found in cart")
 	}
 
-	// Fetch product info
-	prod, err := u.productRepo.GetProductByID(ctx, listingID)
-	if err != nil || prod == nil {
-		return nil, fmt.Errorf("product with ListingID %s not found", listingID)
-	}
-	if prod.Status != "available" {
-		return nil, fmt.Errorf("item %q is no longer available", prod.Title)
+	resp, err := u.checkout(ctx, userID, []*cartitem.CartItem{targetItem}, currency, delivery)
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculate fees
-	total := prod.Price
-	platformFee := total * 0.02
-	netPayable := total - platformFee
-
-	// Create order and payment before marking product as sold
-	_, payment, err := u.orderUC.PurchaseProduct(ctx, prod.ID, userID, prod.Price)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create order for product %s: %w", prod.ID, err)
+	// Remove the item from cart
+	if err := u.repo.DeleteCartItem(ctx, userID, listingID); err != nil {
+		return nil, err
 	}
+	return resp, nil
+}
 
-	// Update payment with reseller ID
-	payment.ToUserID = prod.ResellerID.Hex()
-	if err := u.paymentRepo.RecordPayment(ctx, payment); err != nil {
-		return nil, fmt.Errorf("failed to update payment for product %s: %w", prod.ID, err)
+// checkout buys the given cart items as a single purchase. Every product is reserved
+// for the user first, so nobody else can buy it mid-checkout; nothing is bought unless
+// all of them could be reserved.
+func (u *cartItemUsecase) checkout(ctx context.Context, userID string, items []*cartitem.CartItem, currency money.Currency, delivery shipping.Selection) (*models.CheckoutResponse, error) {
+	products, err := u.validateItems(ctx, userID, items)
+	if err != nil {
+		return nil, err
 	}
 
-	// Mark product as sold and save to database
-	if err := u.productRepo.UpdateProduct(ctx, prod.ID, map[string]interface{}{
-		"status": "sold",
-	}); err != nil {
-		return nil, fmt.Errorf("failed to mark product %s as sold: %w", prod.ID, err)
+	if err := u.reserveProducts(ctx, userID, items, products); err != nil {
+		return nil, err
 	}
 
-	// Remove the item from cart
-	if err := u.repo.DeleteCartItem(ctx, userID, listingID); err != nil {
+	orders, _, err := u.orderUC.PurchaseProducts(ctx, userID, products, currency, delivery)
+	if err != nil {
+		u.releaseProducts(ctx, userID, products)
+		if errors.Is(err, product.ErrProductUnavailable) {
+			// The hold lapsed and another buyer got there first; report the
+			// items the same way as if validation had caught them.
+			if _, verr := u.validateItems(ctx, userID, items); verr != nil {
+				return nil, verr
+			}
+		}
 		return nil, err
 	}
 
-	// Simulate order delivery after 3 minutes
-	go func() {
-		time.Sleep(3 * time.Minute)
-		// TODO: Update order status to "Delivered"
-	}()
+	checkoutItems := make([]models.CheckoutItemResponse, 0, len(products))
+	for _, prod := range products {
+		checkoutItems = append(checkoutItems, models.CheckoutItemResponse{
+			ListingID: prod.ID,
+			Title:     prod.Title,
+			Price:     prod.Price,
+			SellerID:  prod.ResellerID.Hex(),
+			Status:    product.StatusSold,
+		})
+	}
+	// Orders are in their listing currencies; the total is what the user was
+	// charged for all of them.
+	var charged money.Money
+	var estimatedDelivery string
+	platformFee, netPayable, shippingCost := money.Totals{}, money.Totals{}, money.Totals{}
+	orderIDs := make([]string, 0, len(orders))
+	for _, o := range orders {
+		charged = charged.Add(o.Charged)
+		platformFee.Add(o.PlatformFee)
+		netPayable.Add(o.TotalPrice.Sub(o.PlatformFee))
+		shippingCost.Add(o.ShippingCost)
+		orderIDs = append(orderIDs, o.ID)
+		if o.EstimatedDelivery > estimatedDelivery {
+			estimatedDelivery = o.EstimatedDelivery
+		}
+	}
 
 	return &models.CheckoutResponse{
-		TotalAmount: total,
-		Items: []models.CheckoutItemResponse{
-			{
-				ListingID: prod.ID,
-				Title:     prod.Title,
-				Price:     prod.Price,
-				SellerID:  prod.ResellerID.Hex(),
-				Status:    "sold", // Update status in response
-			},
-		},
-		PlatformFee: platformFee,
-		NetPayable:  netPayable,
+		TotalAmount:       charged,
+		Items:             checkoutItems,
+		PlatformFee:       platformFee.List(),
+		NetPayable:        netPayable.List(),
+		OrderIDs:          orderIDs,
+		ShippingCost:      shippingCost.List(),
+		EstimatedDelivery: estimatedDelivery,
 	}, nil
 }
+
+// validateItems looks up the product behind every cart item. If any of them can no
+// longer be bought by the user, it returns a CheckoutValidationError listing all of them.
+func (u *cartItemUsecase) validateItems(ctx context.Context, userID string, items []*cartitem.CartItem) ([]*product.Product, error) {
+	now := time.Now()
+	products := make([]*product.Product, 0, len(items))
+	var unavailable []cartitem.UnavailableItem
+	for _, item := range items {
+		prod, err := u.productRepo.GetProductByID(ctx, item.ListingID)
+		if err != nil || prod == nil || !prod.AvailableTo(userID, now) {
+			unavailable = append(unavailable, cartitem.UnavailableItem{
+				ListingID: item.ListingID,
+				Title:     item.Title,
+			})
+			continue
+		}
+		products = append(products, prod)
+	}
+
+	if len(unavailable) > 0 {
+		return nil, unavailableError(unavailable)
+	}
+	return products, nil
+}
+
+// reserveProducts holds every product for the user. If any hold cannot be placed, the
+// ones already placed are released again.
+func (u *cartItemUsecase) reserveProducts(ctx context.Context, userID string, items []*cartitem.CartItem, products []*product.Product) error {
+	until := time.Now().Add(product.ReservationTTL)
+	reserved := make([]*product.Product, 0, len(products))
+	var unavailable []cartitem.UnavailableItem
+	for i, prod := range products {
+		err := u.productRepo.Reserve(ctx, prod.ID, userID, until)
+		if errors.Is(err, product.ErrProductUnavailable) {
+			unavailable = append(unavailable, cartitem.UnavailableItem{
+				ListingID: items[i].ListingID,
+				Title:     items[i].Title,
+			})
+			continue
+		}
+		if err != nil {
+			u.releaseProducts(ctx, userID, reserved)
+			return fmt.Errorf("failed to reserve product %s: %w", prod.ID, err)
+		}
+		reserved = append(reserved, prod)
+	}
+
+	if len(unavailable) > 0 {
+		u.releaseProducts(ctx, userID, reserved)
+		return unavailableError(unavailable)
+	}
+	return nil
+}
+
+func (u *cartItemUsecase) releaseProducts(ctx context.Context, userID string, products []*product.Product) {
+	for _, prod := range products {
+		if err := u.productRepo.ReleaseReservation(ctx, prod.ID, userID); err != nil {
+			log.Printf("Failed to release reservation on product %s: %v", prod.ID, err)
+		}
+	}
+}
+
+func unavailableError(items []cartitem.UnavailableItem) *cartitem.CheckoutValidationError {
+	return &cartitem.CheckoutValidationError{
+		Message:          fmt.Sprintf("%d item(s) in your cart are no longer available", len(items)),
+		UnavailableItems: items,
+	}
+}
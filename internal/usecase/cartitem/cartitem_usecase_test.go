@@ -2,14 +2,18 @@ package cartitem
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/cartitem"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -71,14 +75,28 @@ func (m *MockProductRepository) DeleteProduct(ctx context.Context, id string) er
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, resellerID, page, limit)
-	return args.Get(0).([]*product.Product), args.Error(1)
+func (m *MockProductRepository) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
 }
 
-func (m *MockProductRepository) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, page, limit)
-	return args.Get(0).([]*product.Product), args.Error(1)
+func (m *MockProductRepository) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
+}
+
+func (m *MockProductRepository) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
 }
 
 func (m *MockProductRepository) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
@@ -99,28 +117,29 @@ func (m *MockProductRepository) GetSoldProductsByReseller(ctx context.Context, r
 	return args.Get(0).([]*product.Product), args.Error(1)
 }
 
-type MockPaymentRepository struct {
-	mock.Mock
+func (m *MockProductRepository) Reserve(ctx context.Context, id, userID string, until time.Time) error {
+	args := m.Called(ctx, id, userID, until)
+	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) RecordPayment(ctx context.Context, p *payment.Payment) error {
-	args := m.Called(ctx, p)
+func (m *MockProductRepository) ReleaseReservation(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
 	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
-	args := m.Called(ctx)
-	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+func (m *MockProductRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockPaymentRepository) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
-	args := m.Called(ctx, userID, pType)
-	return args.Get(0).([]*payment.Payment), args.Error(1)
+func (m *MockProductRepository) Restock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
-	args := m.Called(ctx, userID)
-	return args.Get(0).([]*payment.Payment), args.Error(1)
+func (m *MockProductRepository) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
 }
 
 type MockOrderRepository struct {
@@ -132,9 +151,12 @@ func (m *MockOrderRepository) CreateOrder(ctx context.Context, o *order.Order) e
 	return args.Error(0)
 }
 
-func (m *MockOrderRepository) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, error) {
-	args := m.Called(ctx, consumerID)
-	return args.Get(0).([]*order.Order), args.Error(1)
+func (m *MockOrderRepository) ListOrdersByConsumer(ctx context.Context, consumerID string, status order.OrderStatus, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, consumerID, status, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
 }
 
 func (m *MockOrderRepository) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
@@ -150,35 +172,128 @@ func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderID str
 	return args.Error(0)
 }
 
+func (m *MockOrderRepository) TransitionOrderStatus(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+	args := m.Called(ctx, o, from)
+	return args.Error(0)
+}
+
 func (m *MockOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
 	args := m.Called(ctx, orderID)
 	return args.Error(0)
 }
 
-func (m *MockOrderRepository) GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*order.Order, error) {
-	args := m.Called(ctx, supplierID)
-	return args.Get(0).([]*order.Order), args.Error(1)
+func (m *MockOrderRepository) ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
 }
 
-func (m *MockOrderRepository) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, error) {
-	args := m.Called(ctx, resellerID)
-	return args.Get(0).([]*order.Order), args.Error(1)
+func (m *MockOrderRepository) ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*order.Order, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
 }
 
 type MockOrderUsecase struct {
 	mock.Mock
 }
 
-func (m *MockOrderUsecase) PurchaseProduct(ctx context.Context, productID, consumerID string, totalPrice float64) (*order.Order, *payment.Payment, error) {
-	args := m.Called(ctx, productID, consumerID, totalPrice)
+func (m *MockOrderUsecase) PurchaseProducts(ctx context.Context, consumerID string, products []*product.Product, currency money.Currency, delivery shipping.Selection) ([]*order.Order, []*payment.Payment, error) {
+	args := m.Called(ctx, consumerID, products, currency, delivery)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).(*order.Order), args.Get(1).(*payment.Payment), args.Error(2)
+	return args.Get(0).([]*order.Order), args.Get(1).([]*payment.Payment), args.Error(2)
+}
+
+func (m *MockOrderUsecase) MarkOrderProcessing(ctx context.Context, orderID, sellerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) MarkOrderShipped(ctx context.Context, orderID, sellerID, trackingNumber, carrier string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID, trackingNumber, carrier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) ConfirmDelivery(ctx context.Context, orderID, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) RecordDelivery(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) CancelOrder(ctx context.Context, orderID, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) RefundOrder(ctx context.Context, orderID string, amount money.Money) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUsecase) SettlePayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockOrderUsecase) SettleRefund(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUsecase) SettleFailure(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
 }
 
-func (m *MockOrderUsecase) PurchaseBundle(ctx context.Context, bundleID, resellerID string) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
-	args := m.Called(ctx, bundleID, resellerID)
+func (m *MockOrderUsecase) PurchaseBundle(ctx context.Context, bundleID, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID, resellerID, currency, delivery)
 	if args.Get(0) == nil {
 		return nil, nil, nil, args.Error(3)
 	}
@@ -195,20 +310,20 @@ func (m *MockOrderUsecase) GetOrderByID(ctx context.Context, orderID string) (*o
 	return args.Get(0).(*order.Order), args.Error(1)
 }
 
-func (m *MockOrderUsecase) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, map[string]string, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockOrderUsecase) GetOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Error(2)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
 }
 
-func (m *MockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, map[string]string, error) {
-	args := m.Called(ctx, supplierID)
+func (m *MockOrderUsecase) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Error(2)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
 }
 
 func (m *MockOrderUsecase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
@@ -219,12 +334,12 @@ func (m *MockOrderUsecase) GetResellerMetrics(ctx context.Context, resellerID st
 	return args.Get(0).(*order.ResellerMetrics), args.Error(1)
 }
 
-func (m *MockOrderUsecase) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, map[string]string, map[string]string, error) {
-	args := m.Called(ctx, consumerID)
+func (m *MockOrderUsecase) GetOrdersByConsumer(ctx context.Context, consumerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, map[string]string, error) {
+	args := m.Called(ctx, consumerID, req)
 	if args.Get(0) == nil {
 		return nil, nil, nil, args.Error(3)
 	}
-	return args.Get(0).([]*order.Order), args.Get(1).(map[string]string), args.Get(2).(map[string]string), args.Error(3)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Get(2).(map[string]string), args.Error(3)
 }
 
 // --- Test Suite ---
@@ -235,7 +350,6 @@ type CartItemUsecaseTestSuite struct {
 	usecase         cartitem.Usecase
 	mockCartRepo    *MockCartItemRepository
 	mockProductRepo *MockProductRepository
-	mockPaymentRepo *MockPaymentRepository
 	mockOrderRepo   *MockOrderRepository
 	mockOrderUC     *MockOrderUsecase
 	userID          string
@@ -245,10 +359,9 @@ func (suite *CartItemUsecaseTestSuite) SetupTest() {
 	suite.ctx = context.Background()
 	suite.mockCartRepo = new(MockCartItemRepository)
 	suite.mockProductRepo = new(MockProductRepository)
-	suite.mockPaymentRepo = new(MockPaymentRepository)
 	suite.mockOrderRepo = new(MockOrderRepository)
 	suite.mockOrderUC = new(MockOrderUsecase)
-	suite.usecase = NewCartItemUsecase(suite.mockCartRepo, suite.mockProductRepo, suite.mockPaymentRepo, suite.mockOrderUC, suite.mockOrderRepo)
+	suite.usecase = NewCartItemUsecase(suite.mockCartRepo, suite.mockProductRepo, suite.mockOrderUC, suite.mockOrderRepo)
 	suite.userID = "user123"
 }
 
@@ -258,7 +371,7 @@ func createTestProduct(id string, price float64, status string, title string) *p
 	return &product.Product{
 		ID:         id,
 		Title:      title,
-		Price:      price,
+		Price:      money.FromMajor(price, money.ETB),
 		ImageURL:   "image.jpg",
 		Grade:      "A",
 		Status:     status,
@@ -313,7 +426,7 @@ func (suite *CartItemUsecaseTestSuite) TestGetCartItems_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod123",
 			Title:     "Test Product",
-			Price:     100.0,
+			Price:     money.New(10000, money.ETB),
 			ImageURL:  "img.jpg",
 			Grade:     "A",
 			CreatedAt: time.Now(),
@@ -340,6 +453,8 @@ func (suite *CartItemUsecaseTestSuite) TestRemoveCartItem_Success() {
 
 // --- Tests for CheckoutCart ---
 
+var testDelivery = shipping.Selection{AddressID: "addr1", Option: shipping.Standard}
+
 func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_Success() {
 	now := time.Now()
 	cartItems := []*cartitem.CartItem{
@@ -348,7 +463,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod1",
 			Title:     "Test Product 1",
-			Price:     100.0,
+			Price:     money.New(10000, money.ETB),
 			ImageURL:  "img1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -358,7 +473,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod2",
 			Title:     "Test Product 2",
-			Price:     200.0,
+			Price:     money.New(20000, money.ETB),
 			ImageURL:  "img2.jpg",
 			Grade:     "B",
 			CreatedAt: now,
@@ -371,42 +486,140 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_Success() {
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
 
-	// Mock order and payment creation
-	order1 := &order.Order{ID: "order1"}
-	payment1 := &payment.Payment{ID: "payment1"}
-	order2 := &order.Order{ID: "order2"}
-	payment2 := &payment.Payment{ID: "payment2"}
-
-	suite.mockOrderUC.On("PurchaseProduct", suite.ctx, "prod1", suite.userID, 100.0).Return(order1, payment1, nil).Once()
-	suite.mockOrderUC.On("PurchaseProduct", suite.ctx, "prod2", suite.userID, 200.0).Return(order2, payment2, nil).Once()
+	// Both products are held for the user before anything is charged
+	suite.mockProductRepo.On("Reserve", suite.ctx, "prod1", suite.userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	suite.mockProductRepo.On("Reserve", suite.ctx, "prod2", suite.userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
 
-	// Mock payment updates
-	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.Anything).Return(nil).Twice()
-
-	// Mock product status updates
-	suite.mockProductRepo.On("UpdateProduct", suite.ctx, "prod1", mock.Anything).Return(nil).Once()
-	suite.mockProductRepo.On("UpdateProduct", suite.ctx, "prod2", mock.Anything).Return(nil).Once()
+	// Both products are bought together, one order per reseller
+	orders := []*order.Order{
+		{ID: "order1", TotalPrice: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), Charged: money.New(10000, money.ETB)},
+		{ID: "order2", TotalPrice: money.New(20000, money.ETB), PlatformFee: money.New(400, money.ETB), Charged: money.New(20000, money.ETB)},
+	}
+	payments := []*payment.Payment{{ID: "payment1"}, {ID: "payment2"}}
+	suite.mockOrderUC.On("PurchaseProducts", suite.ctx, suite.userID, []*product.Product{prod1, prod2}, money.Currency(""), testDelivery).Return(orders, payments, nil).Once()
 
 	// Mock cart clearing
 	suite.mockCartRepo.On("ClearCart", suite.ctx, suite.userID).Return(nil).Once()
 
-	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID)
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, "", testDelivery)
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), 300.0, resp.TotalAmount)
-	assert.Equal(suite.T(), 6.0, resp.PlatformFee)
-	assert.Equal(suite.T(), 294.0, resp.NetPayable)
+	assert.Equal(suite.T(), money.New(30000, money.ETB), resp.TotalAmount)
+	assert.Equal(suite.T(), []money.Money{money.New(600, money.ETB)}, resp.PlatformFee)
+	assert.Equal(suite.T(), []money.Money{money.New(29400, money.ETB)}, resp.NetPayable)
 	assert.Len(suite.T(), resp.Items, 2)
+	assert.Equal(suite.T(), []string{"order1", "order2"}, resp.OrderIDs)
 
 	suite.mockCartRepo.AssertExpectations(suite.T())
 	suite.mockProductRepo.AssertExpectations(suite.T())
-	suite.mockPaymentRepo.AssertExpectations(suite.T())
 	suite.mockOrderUC.AssertExpectations(suite.T())
 }
 
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_UnavailableItems() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1"},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2"},
+		{ID: "item3", UserID: suite.userID, ListingID: "prod3", Title: "Test Product 3"},
+	}
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(createTestProduct("prod1", 100.0, "available", "Test Product 1"), nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(createTestProduct("prod2", 200.0, "sold", "Test Product 2"), nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod3").Return(nil, errors.New("not found")).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, "", testDelivery)
+	assert.Nil(suite.T(), resp)
+
+	var validationErr *cartitem.CheckoutValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), []cartitem.UnavailableItem{
+		{ListingID: "prod2", Title: "Test Product 2"},
+		{ListingID: "prod3", Title: "Test Product 3"},
+	}, validationErr.UnavailableItems)
+
+	// Nothing is bought and the cart is left untouched
+	suite.mockOrderUC.AssertNotCalled(suite.T(), "PurchaseProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockCartRepo.AssertNotCalled(suite.T(), "ClearCart", mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_SoldDuringCheckout() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1"},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2"},
+	}
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	prod2 := createTestProduct("prod2", 200.0, "available", "Test Product 2")
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+	suite.mockProductRepo.On("Reserve", suite.ctx, "prod1", suite.userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	suite.mockProductRepo.On("Reserve", suite.ctx, "prod2", suite.userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	suite.mockOrderUC.On("PurchaseProducts", suite.ctx, suite.userID, []*product.Product{prod1, prod2}, money.Currency(""), testDelivery).
+		Return(nil, nil, fmt.Errorf("product prod2: %w", product.ErrProductUnavailable)).Once()
+	suite.mockProductRepo.On("ReleaseReservation", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+	suite.mockProductRepo.On("ReleaseReservation", suite.ctx, "prod2", suite.userID).Return(nil).Once()
+
+	// Re-validation sees that another buyer got prod2 first
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(createTestProduct("prod2", 200.0, "sold", "Test Product 2"), nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, "", testDelivery)
+	assert.Nil(suite.T(), resp)
+
+	var validationErr *cartitem.CheckoutValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), []cartitem.UnavailableItem{{ListingID: "prod2", Title: "Test Product 2"}}, validationErr.UnavailableItems)
+	suite.mockCartRepo.AssertNotCalled(suite.T(), "ClearCart", mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_HeldByOther() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1"},
+	}
+	held := createTestProduct("prod1", 100.0, product.StatusReserved, "Test Product 1")
+	held.ReservedBy = "someone-else"
+	until := time.Now().Add(time.Minute)
+	held.ReservedUntil = &until
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(held, nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, "", testDelivery)
+	assert.Nil(suite.T(), resp)
+
+	var validationErr *cartitem.CheckoutValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), "prod1", validationErr.UnavailableItems[0].ListingID)
+	suite.mockProductRepo.AssertNotCalled(suite.T(), "Reserve", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_ReservationLost() {
+	cartItems := []*cartitem.CartItem{
+		{ID: "item1", UserID: suite.userID, ListingID: "prod1", Title: "Test Product 1"},
+		{ID: "item2", UserID: suite.userID, ListingID: "prod2", Title: "Test Product 2"},
+	}
+	prod1 := createTestProduct("prod1", 100.0, "available", "Test Product 1")
+	prod2 := createTestProduct("prod2", 200.0, "available", "Test Product 2")
+	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return(cartItems, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
+	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod2").Return(prod2, nil).Once()
+
+	// Another consumer reserves prod2 between validation and reservation
+	suite.mockProductRepo.On("Reserve", suite.ctx, "prod1", suite.userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	suite.mockProductRepo.On("Reserve", suite.ctx, "prod2", suite.userID, mock.AnythingOfType("time.Time")).Return(product.ErrProductUnavailable).Once()
+	suite.mockProductRepo.On("ReleaseReservation", suite.ctx, "prod1", suite.userID).Return(nil).Once()
+
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, "", testDelivery)
+	assert.Nil(suite.T(), resp)
+
+	var validationErr *cartitem.CheckoutValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), []cartitem.UnavailableItem{{ListingID: "prod2", Title: "Test Product 2"}}, validationErr.UnavailableItems)
+	suite.mockProductRepo.AssertExpectations(suite.T())
+	suite.mockOrderUC.AssertNotCalled(suite.T(), "PurchaseProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *CartItemUsecaseTestSuite) TestCheckoutCart_EmptyCart() {
 	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return([]*cartitem.CartItem{}, nil).Once()
 
-	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID)
+	resp, err := suite.usecase.CheckoutCart(suite.ctx, suite.userID, "", testDelivery)
 	assert.Nil(suite.T(), resp)
 	assert.EqualError(suite.T(), err, "cart is empty")
 	suite.mockCartRepo.AssertExpectations(suite.T())
@@ -422,7 +635,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_Success() {
 			UserID:    suite.userID,
 			ListingID: "prod1",
 			Title:     "Test Product 1",
-			Price:     100.0,
+			Price:     money.New(10000, money.ETB),
 			ImageURL:  "img1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -434,29 +647,23 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_Success() {
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
 
 	// Mock order and payment creation
-	order := &order.Order{ID: "order1"}
-	payment := &payment.Payment{ID: "payment1"}
-	suite.mockOrderUC.On("PurchaseProduct", suite.ctx, "prod1", suite.userID, 100.0).Return(order, payment, nil).Once()
-
-	// Mock payment update
-	suite.mockPaymentRepo.On("RecordPayment", suite.ctx, mock.Anything).Return(nil).Once()
-
-	// Mock product status update
-	suite.mockProductRepo.On("UpdateProduct", suite.ctx, "prod1", mock.Anything).Return(nil).Once()
+	orders := []*order.Order{{ID: "order1", TotalPrice: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), Charged: money.New(10000, money.ETB)}}
+	payments := []*payment.Payment{{ID: "payment1"}}
+	suite.mockProductRepo.On("Reserve", suite.ctx, "prod1", suite.userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	suite.mockOrderUC.On("PurchaseProducts", suite.ctx, suite.userID, []*product.Product{prod1}, money.Currency(""), testDelivery).Return(orders, payments, nil).Once()
 
 	// Mock cart item deletion
 	suite.mockCartRepo.On("DeleteCartItem", suite.ctx, suite.userID, "prod1").Return(nil).Once()
 
-	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1")
+	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1", "", testDelivery)
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), 100.0, resp.TotalAmount)
-	assert.Equal(suite.T(), 2.0, resp.PlatformFee)
-	assert.Equal(suite.T(), 98.0, resp.NetPayable)
+	assert.Equal(suite.T(), money.New(10000, money.ETB), resp.TotalAmount)
+	assert.Equal(suite.T(), []money.Money{money.New(200, money.ETB)}, resp.PlatformFee)
+	assert.Equal(suite.T(), []money.Money{money.New(9800, money.ETB)}, resp.NetPayable)
 	assert.Len(suite.T(), resp.Items, 1)
 
 	suite.mockCartRepo.AssertExpectations(suite.T())
 	suite.mockProductRepo.AssertExpectations(suite.T())
-	suite.mockPaymentRepo.AssertExpectations(suite.T())
 	suite.mockOrderUC.AssertExpectations(suite.T())
 }
 
@@ -464,7 +671,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_ItemNotFoundInCart
 	// Empty cart scenario.
 	suite.mockCartRepo.On("GetCartItems", suite.ctx, suite.userID).Return([]*cartitem.CartItem{}, nil).Once()
 
-	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1")
+	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1", "", testDelivery)
 	assert.Nil(suite.T(), resp)
 	assert.EqualError(suite.T(), err, "item not found in cart")
 	suite.mockCartRepo.AssertExpectations(suite.T())
@@ -479,7 +686,7 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_ProductNotAvailabl
 			UserID:    suite.userID,
 			ListingID: "prod1",
 			Title:     "Test Product 1",
-			Price:     100.0,
+			Price:     money.New(10000, money.ETB),
 			ImageURL:  "img1.jpg",
 			Grade:     "A",
 			CreatedAt: now,
@@ -489,10 +696,11 @@ func (suite *CartItemUsecaseTestSuite) TestCheckoutSingleItem_ProductNotAvailabl
 	prod1 := createTestProduct("prod1", 100.0, "sold", "Test Product 1")
 	suite.mockProductRepo.On("GetProductByID", suite.ctx, "prod1").Return(prod1, nil).Once()
 
-	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1")
+	resp, err := suite.usecase.CheckoutSingleItem(suite.ctx, suite.userID, "prod1", "", testDelivery)
 	assert.Nil(suite.T(), resp)
-	expectedErr := fmt.Sprintf("item %q is no longer available", prod1.Title)
-	assert.EqualError(suite.T(), err, expectedErr)
+	var validationErr *cartitem.CheckoutValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), "prod1", validationErr.UnavailableItems[0].ListingID)
 	suite.mockCartRepo.AssertExpectations(suite.T())
 	suite.mockProductRepo.AssertExpectations(suite.T())
 }
@@ -0,0 +1,47 @@
+package cartitem
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+)
+
+// ReservationSweeper returns products whose checkout hold has lapsed to sale, so an
+// abandoned checkout does not keep a product off the market.
+type ReservationSweeper struct {
+	productRepo product.Repository
+	interval    time.Duration
+}
+
+func NewReservationSweeper(productRepo product.Repository, interval time.Duration) *ReservationSweeper {
+	return &ReservationSweeper{productRepo: productRepo, interval: interval}
+}
+
+// Run sweeps on every tick until the context is cancelled.
+func (s *ReservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Sweep(ctx, now)
+		}
+	}
+}
+
+// Sweep releases every hold that expired before now.
+func (s *ReservationSweeper) Sweep(ctx context.Context, now time.Time) {
+	released, err := s.productRepo.ReleaseExpiredReservations(ctx, now)
+	if err != nil {
+		log.Printf("Failed to release expired reservations: %v", err)
+		return
+	}
+	if released > 0 {
+		log.Printf("Released %d expired product reservations", released)
+	}
+}
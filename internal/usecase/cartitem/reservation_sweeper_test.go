@@ -0,0 +1,39 @@
+package cartitem
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReservationSweeper_Sweep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	repo := new(MockProductRepository)
+	repo.On("ReleaseExpiredReservations", ctx, now).Return(int64(2), nil).Once()
+	NewReservationSweeper(repo, time.Minute).Sweep(ctx, now)
+	repo.AssertExpectations(t)
+
+	failing := new(MockProductRepository)
+	failing.On("ReleaseExpiredReservations", ctx, now).Return(int64(0), errors.New("db down")).Once()
+	NewReservationSweeper(failing, time.Minute).Sweep(ctx, now)
+	failing.AssertExpectations(t)
+}
+
+func TestReservationSweeper_RunStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewReservationSweeper(new(MockProductRepository), time.Hour).Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop after the context was cancelled")
+	}
+}
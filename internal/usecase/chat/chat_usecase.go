@@ -0,0 +1,143 @@
+package chatusecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/chat"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type chatUsecase struct {
+	chatRepo   chat.Repository
+	orderRepo  order.Repository
+	bundleRepo bundle.Repository
+	userRepo   user.Repository
+	publisher  event.Publisher
+}
+
+func NewChatUsecase(chatRepo chat.Repository, orderRepo order.Repository, bundleRepo bundle.Repository, userRepo user.Repository, publisher event.Publisher) chat.Usecase {
+	return &chatUsecase{
+		chatRepo:   chatRepo,
+		orderRepo:  orderRepo,
+		bundleRepo: bundleRepo,
+		userRepo:   userRepo,
+		publisher:  publisher,
+	}
+}
+
+func (u *chatUsecase) SendMessage(ctx context.Context, senderID string, msg *chat.ChatMessage) error {
+	if strings.TrimSpace(msg.Text) == "" {
+		return errors.New("message content cannot be empty")
+	}
+	if msg.ReceiverID == "" {
+		return errors.New("receiver is required")
+	}
+	if msg.ReceiverID == senderID {
+		return errors.New("you cannot message yourself")
+	}
+
+	related, err := u.isRelated(ctx, senderID, msg.ReceiverID, msg.RelatedTo)
+	if err != nil {
+		return err
+	}
+	if !related {
+		return chat.ErrNotRelated
+	}
+
+	msg.ID = primitive.NewObjectID().Hex()
+	msg.SenderID = senderID
+	msg.Timestamp = time.Now().Format(time.RFC3339)
+	msg.Seen = false
+	if msg.Type == "" {
+		msg.Type = chat.Text
+	}
+
+	if err := u.chatRepo.SendMessage(ctx, msg); err != nil {
+		return err
+	}
+
+	u.publisher.Publish(ctx, &event.Event{
+		Type:    event.ChatMessage,
+		UserID:  msg.ReceiverID,
+		Payload: msg,
+	})
+	return nil
+}
+
+// isRelated reports whether the two users may talk. A message must either reference an
+// order both users are party to, reference a bundle between its supplier and a reseller,
+// or continue a conversation that was opened that way.
+func (u *chatUsecase) isRelated(ctx context.Context, senderID, receiverID, relatedTo string) (bool, error) {
+	if relatedTo == "" {
+		return u.chatRepo.HasConversation(ctx, senderID, receiverID)
+	}
+
+	o, err := u.orderRepo.GetOrderByID(ctx, relatedTo)
+	if err != nil {
+		return false, err
+	}
+	if o != nil {
+		parties := map[string]bool{}
+		for _, id := range []string{o.ResellerID, o.SupplierID, o.ConsumerID} {
+			if id != "" {
+				parties[id] = true
+			}
+		}
+		return parties[senderID] && parties[receiverID], nil
+	}
+
+	b, err := u.bundleRepo.GetBundleByID(ctx, relatedTo)
+	if err != nil || b == nil {
+		return false, errors.New("related_to must reference an existing order or bundle")
+	}
+
+	var otherID string
+	switch b.SupplierID {
+	case senderID:
+		otherID = receiverID
+	case receiverID:
+		otherID = senderID
+	default:
+		return false, nil
+	}
+
+	other, err := u.userRepo.GetByID(ctx, otherID)
+	if err != nil {
+		return false, err
+	}
+	return other.Role == string(user.RoleReseller), nil
+}
+
+func (u *chatUsecase) ListConversations(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	return u.chatRepo.ListConversationsForUser(ctx, userID, req)
+}
+
+func (u *chatUsecase) GetConversation(ctx context.Context, userID, counterpartyID string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	return u.chatRepo.ListMessagesBetweenUsers(ctx, userID, counterpartyID, req)
+}
+
+func (u *chatUsecase) MarkAsSeen(ctx context.Context, userID, messageID string) error {
+	msg, err := u.chatRepo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return err
+	}
+	if msg.ReceiverID != userID {
+		return chat.ErrNotReceiver
+	}
+	if msg.Seen {
+		return nil
+	}
+	return u.chatRepo.MarkAsSeen(ctx, messageID)
+}
+
+func (u *chatUsecase) MarkConversationAsSeen(ctx context.Context, userID, counterpartyID string) error {
+	return u.chatRepo.MarkConversationAsSeen(ctx, userID, counterpartyID)
+}
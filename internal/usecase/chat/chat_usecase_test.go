@@ -0,0 +1,438 @@
+package chatusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/chat"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	eventinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/event"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// Mock Repositories
+type MockChatRepository struct {
+	mock.Mock
+}
+
+func (m *MockChatRepository) SendMessage(ctx context.Context, msg *chat.ChatMessage) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) GetMessageByID(ctx context.Context, messageID string) (*chat.ChatMessage, error) {
+	args := m.Called(ctx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*chat.ChatMessage), args.Error(1)
+}
+
+func (m *MockChatRepository) ListMessagesBetweenUsers(ctx context.Context, user1, user2 string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	args := m.Called(ctx, user1, user2, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*chat.ChatMessage]), args.Error(1)
+}
+
+func (m *MockChatRepository) HasConversation(ctx context.Context, user1 string, user2 string) (bool, error) {
+	args := m.Called(ctx, user1, user2)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) MarkAsSeen(ctx context.Context, messageID string) error {
+	args := m.Called(ctx, messageID)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) MarkConversationAsSeen(ctx context.Context, receiverID string, senderID string) error {
+	args := m.Called(ctx, receiverID, senderID)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) ListConversationsForUser(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*chat.ChatMessage], error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*chat.ChatMessage]), args.Error(1)
+}
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersByConsumer(ctx context.Context, consumerID string, status order.OrderStatus, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, consumerID, status, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
+	args := m.Called(ctx, orderID, status)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) TransitionOrderStatus(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+	args := m.Called(ctx, o, from)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*order.Order, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+type MockBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepository) CreateBundle(ctx context.Context, b *bundle.Bundle) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) GetBundleByID(ctx context.Context, id string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) UpdateBundleStatus(ctx context.Context, id string, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) DeleteBundle(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) UpdateBundle(ctx context.Context, id string, updatedData map[string]interface{}) error {
+	args := m.Called(ctx, id, updatedData)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) DecreaseBundleQuantity(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) CountBundles(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBundleRepository) GetBundleByTitle(ctx context.Context, title string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, title)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUsersByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, roles, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTrustData(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) CountActiveUsers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// ChatUsecaseTestSuite is the test suite for chat usecase
+type ChatUsecaseTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	chatRepo   *MockChatRepository
+	orderRepo  *MockOrderRepository
+	bundleRepo *MockBundleRepository
+	userRepo   *MockUserRepository
+	hub        event.Hub
+	usecase    chat.Usecase
+}
+
+func (suite *ChatUsecaseTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.chatRepo = new(MockChatRepository)
+	suite.orderRepo = new(MockOrderRepository)
+	suite.bundleRepo = new(MockBundleRepository)
+	suite.userRepo = new(MockUserRepository)
+	suite.hub = eventinfra.NewHub()
+	suite.usecase = NewChatUsecase(suite.chatRepo, suite.orderRepo, suite.bundleRepo, suite.userRepo, suite.hub)
+}
+
+func (suite *ChatUsecaseTestSuite) TearDownTest() {
+	suite.chatRepo.AssertExpectations(suite.T())
+	suite.orderRepo.AssertExpectations(suite.T())
+	suite.bundleRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+}
+
+func TestChatUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ChatUsecaseTestSuite))
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_OrderParties() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", SupplierID: "supplier1", BundleID: "bundle1"}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.chatRepo.On("SendMessage", suite.ctx, mock.AnythingOfType("*chat.ChatMessage")).Return(nil)
+	events, unsubscribe := suite.hub.Subscribe("supplier1")
+	defer unsubscribe()
+
+	msg := &chat.ChatMessage{ReceiverID: "supplier1", Text: "When will it ship?", RelatedTo: "order1"}
+	err := suite.usecase.SendMessage(suite.ctx, "reseller1", msg)
+
+	suite.NoError(err)
+	suite.NotEmpty(msg.ID)
+	suite.Equal("reseller1", msg.SenderID)
+	suite.Equal(chat.Text, msg.Type)
+	suite.False(msg.Seen)
+	suite.Require().Len(events, 1)
+	e := <-events
+	suite.Equal(event.ChatMessage, e.Type)
+	suite.Equal(msg, e.Payload)
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_OrderOutsider() {
+	o := &order.Order{ID: "order1", ConsumerID: "consumer1", ResellerID: "reseller1"}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	msg := &chat.ChatMessage{ReceiverID: "reseller1", Text: "hello", RelatedTo: "order1"}
+	err := suite.usecase.SendMessage(suite.ctx, "stranger", msg)
+
+	suite.ErrorIs(err, chat.ErrNotRelated)
+	suite.chatRepo.AssertNotCalled(suite.T(), "SendMessage", mock.Anything, mock.Anything)
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_BundleNegotiation() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Status: "available"}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "bundle1").Return(nil, nil)
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.userRepo.On("GetByID", suite.ctx, "reseller1").Return(&user.User{ID: "reseller1", Role: string(user.RoleReseller)}, nil)
+	suite.chatRepo.On("SendMessage", suite.ctx, mock.AnythingOfType("*chat.ChatMessage")).Return(nil)
+
+	msg := &chat.ChatMessage{ReceiverID: "supplier1", Text: "Any jackets in this lot?", RelatedTo: "bundle1"}
+	err := suite.usecase.SendMessage(suite.ctx, "reseller1", msg)
+
+	suite.NoError(err)
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_BundleNonReseller() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1"}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "bundle1").Return(nil, nil)
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.userRepo.On("GetByID", suite.ctx, "consumer1").Return(&user.User{ID: "consumer1", Role: string(user.RoleConsumer)}, nil)
+
+	msg := &chat.ChatMessage{ReceiverID: "supplier1", Text: "hi", RelatedTo: "bundle1"}
+	err := suite.usecase.SendMessage(suite.ctx, "consumer1", msg)
+
+	suite.ErrorIs(err, chat.ErrNotRelated)
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_UnknownReference() {
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "missing").Return(nil, nil)
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "missing").Return(nil, errors.New("bundle not found"))
+
+	msg := &chat.ChatMessage{ReceiverID: "supplier1", Text: "hi", RelatedTo: "missing"}
+	err := suite.usecase.SendMessage(suite.ctx, "reseller1", msg)
+
+	suite.Error(err)
+	suite.Contains(err.Error(), "related_to")
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_ReplyInExistingConversation() {
+	suite.chatRepo.On("HasConversation", suite.ctx, "supplier1", "reseller1").Return(true, nil)
+	suite.chatRepo.On("SendMessage", suite.ctx, mock.AnythingOfType("*chat.ChatMessage")).Return(nil)
+
+	msg := &chat.ChatMessage{ReceiverID: "reseller1", Text: "Yes, about 20 jackets"}
+	err := suite.usecase.SendMessage(suite.ctx, "supplier1", msg)
+
+	suite.NoError(err)
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_NoRelationship() {
+	suite.chatRepo.On("HasConversation", suite.ctx, "reseller1", "supplier9").Return(false, nil)
+
+	msg := &chat.ChatMessage{ReceiverID: "supplier9", Text: "hello"}
+	err := suite.usecase.SendMessage(suite.ctx, "reseller1", msg)
+
+	suite.ErrorIs(err, chat.ErrNotRelated)
+}
+
+func (suite *ChatUsecaseTestSuite) TestSendMessage_Validation() {
+	err := suite.usecase.SendMessage(suite.ctx, "user1", &chat.ChatMessage{ReceiverID: "user2", Text: "   "})
+	suite.Error(err)
+
+	err = suite.usecase.SendMessage(suite.ctx, "user1", &chat.ChatMessage{ReceiverID: "user1", Text: "me"})
+	suite.Error(err)
+}
+
+func (suite *ChatUsecaseTestSuite) TestGetConversation_PassesPage() {
+	req := pagination.Request{Cursor: "abc", Limit: 5}
+	messages := []*chat.ChatMessage{{ID: "m1"}}
+	suite.chatRepo.On("ListMessagesBetweenUsers", suite.ctx, "user1", "user2", req).
+		Return(&pagination.Page[*chat.ChatMessage]{Items: messages, Total: 1}, nil)
+
+	result, err := suite.usecase.GetConversation(suite.ctx, "user1", "user2", req)
+
+	suite.NoError(err)
+	suite.Len(result.Items, 1)
+}
+
+func (suite *ChatUsecaseTestSuite) TestMarkAsSeen() {
+	suite.chatRepo.On("GetMessageByID", suite.ctx, "m1").Return(&chat.ChatMessage{ID: "m1", ReceiverID: "user2"}, nil)
+	suite.chatRepo.On("MarkAsSeen", suite.ctx, "m1").Return(nil)
+
+	suite.NoError(suite.usecase.MarkAsSeen(suite.ctx, "user2", "m1"))
+}
+
+func (suite *ChatUsecaseTestSuite) TestMarkAsSeen_NotReceiver() {
+	suite.chatRepo.On("GetMessageByID", suite.ctx, "m1").Return(&chat.ChatMessage{ID: "m1", SenderID: "user1", ReceiverID: "user2"}, nil)
+
+	err := suite.usecase.MarkAsSeen(suite.ctx, "user1", "m1")
+
+	suite.ErrorIs(err, chat.ErrNotReceiver)
+}
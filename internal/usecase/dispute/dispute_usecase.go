@@ -0,0 +1,259 @@
+package disputeusecase
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/trust"
+	OrderUsecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/order"
+)
+
+// Trust penalties for disputes upheld against the seller, in rating points. A full
+// refund counts like a bundle that missed its declared grade by the whole scale.
+const (
+	refundPenalty        = 5.0
+	partialRefundPenalty = 3.0
+)
+
+type disputeUsecase struct {
+	disputeRepo dispute.Repository
+	orderRepo   order.Repository
+	orderUC     OrderUsecase.OrderUseCase
+	trustUC     trust.Usecase
+	publisher   event.Publisher
+}
+
+func NewDisputeUsecase(disputeRepo dispute.Repository, orderRepo order.Repository, orderUC OrderUsecase.OrderUseCase, trustUC trust.Usecase, publisher event.Publisher) dispute.Usecase {
+	return &disputeUsecase{
+		disputeRepo: disputeRepo,
+		orderRepo:   orderRepo,
+		orderUC:     orderUC,
+		trustUC:     trustUC,
+		publisher:   publisher,
+	}
+}
+
+func (u *disputeUsecase) OpenDispute(ctx context.Context, buyerID string, d *dispute.Dispute) (*dispute.Dispute, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	o, err := u.orderRepo.GetOrderByID(ctx, d.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if o.BuyerID() != buyerID {
+		return nil, dispute.ErrNotDisputeParty
+	}
+	if o.Status != order.Delivered && o.Status != order.OrderStatusCompleted {
+		return nil, dispute.ErrNotDisputable
+	}
+	if d.RequestedRemedy == dispute.RemedyPartialRefund {
+		if d.RequestedAmount.Currency == "" {
+			d.RequestedAmount.Currency = o.TotalPrice.Currency
+		}
+		if !d.RequestedAmount.SameCurrency(o.TotalPrice) || d.RequestedAmount.Cmp(o.TotalPrice) > 0 {
+			return nil, dispute.ErrInvalidAmount
+		}
+	}
+
+	existing, err := u.disputeRepo.GetDisputeByOrder(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, dispute.ErrAlreadyDisputed
+	}
+
+	// Keyed by the order, so a second dispute that raced past the check above is
+	// refused when it is stored.
+	d.ID = o.ID
+	d.BuyerID = buyerID
+	d.SellerID = o.SellerID()
+	d.Status = dispute.StatusOpen
+	d.CreatedAt = time.Now().Format(time.RFC3339)
+	if d.RequestedRemedy == dispute.RemedyRefund {
+		d.RequestedAmount = money.Money{}
+	}
+	if err := u.disputeRepo.CreateDispute(ctx, d); err != nil {
+		return nil, err
+	}
+
+	u.notify(ctx, d, d.SellerID)
+	return d, nil
+}
+
+func (u *disputeUsecase) RespondToDispute(ctx context.Context, sellerID, disputeID, response string) (*dispute.Dispute, error) {
+	response = strings.TrimSpace(response)
+	if response == "" {
+		return nil, dispute.ErrEmptyResponse
+	}
+
+	d, err := u.getDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.SellerID != sellerID {
+		return nil, dispute.ErrNotDisputeParty
+	}
+	if d.Status == dispute.StatusResolved {
+		return nil, dispute.ErrAlreadyResolved
+	}
+
+	from := d.Status
+	d.SellerResponse = response
+	d.RespondedAt = time.Now().Format(time.RFC3339)
+	d.Status = dispute.StatusResponded
+	if err := u.disputeRepo.UpdateDispute(ctx, d, from); err != nil {
+		return nil, err
+	}
+
+	u.notify(ctx, d, d.BuyerID)
+	return d, nil
+}
+
+// ResolveDispute records the ruling before paying anything out, so two admins
+// resolving the same dispute cannot both trigger a refund. If the refund then
+// fails, the ruling is rolled back and the dispute can be resolved again.
+func (u *disputeUsecase) ResolveDispute(ctx context.Context, adminID, disputeID string, outcome dispute.Outcome, refundAmount money.Money, note string) (*dispute.Dispute, error) {
+	if !outcome.Valid() {
+		return nil, dispute.ErrInvalidOutcome
+	}
+	if outcome == dispute.OutcomePartialRefund && !refundAmount.IsPositive() {
+		return nil, dispute.ErrInvalidAmount
+	}
+
+	d, err := u.getDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status == dispute.StatusResolved {
+		return nil, dispute.ErrAlreadyResolved
+	}
+
+	if outcome == dispute.OutcomePartialRefund && refundAmount.Currency == "" {
+		// Amounts given without a currency are in the order's.
+		o, err := u.orderRepo.GetOrderByID(ctx, d.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if o == nil {
+			return nil, order.ErrOrderNotFound
+		}
+		refundAmount.Currency = o.TotalPrice.Currency
+	}
+
+	previous := *d
+	d.Status = dispute.StatusResolved
+	d.Outcome = outcome
+	d.AdminNote = strings.TrimSpace(note)
+	d.ResolvedBy = adminID
+	d.ResolvedAt = time.Now().Format(time.RFC3339)
+	d.RefundAmount = money.Money{}
+	if outcome == dispute.OutcomePartialRefund {
+		d.RefundAmount = refundAmount
+	}
+	if err := u.disputeRepo.UpdateDispute(ctx, d, previous.Status); err != nil {
+		return nil, err
+	}
+
+	if err := u.payOut(ctx, d); err != nil {
+		if rollbackErr := u.disputeRepo.UpdateDispute(ctx, &previous, dispute.StatusResolved); rollbackErr != nil {
+			log.Printf("Failed to reopen dispute %s after refund error: %v", d.ID, rollbackErr)
+		}
+		return nil, err
+	}
+
+	if penalty := penaltyFor(outcome); penalty > 0 {
+		if err := u.trustUC.ApplyDisputePenalty(ctx, d.SellerID, penalty); err != nil {
+			log.Printf("Failed to apply dispute penalty to seller %s: %v", d.SellerID, err)
+		}
+	}
+
+	u.notify(ctx, d, d.BuyerID, d.SellerID)
+	return d, nil
+}
+
+// payOut refunds the buyer as ruled. A full refund cancels the order; goods the
+// buyer never received go back to the seller's stock, while a delivered order or a
+// received bundle is only refunded.
+func (u *disputeUsecase) payOut(ctx context.Context, d *dispute.Dispute) error {
+	switch d.Outcome {
+	case dispute.OutcomeRefund:
+		o, err := u.orderUC.ForceCancelOrder(ctx, d.OrderID)
+		if err != nil {
+			return err
+		}
+		d.RefundAmount = o.TotalPrice
+		return u.disputeRepo.UpdateDispute(ctx, d, dispute.StatusResolved)
+	case dispute.OutcomePartialRefund:
+		_, err := u.orderUC.RefundOrder(ctx, d.OrderID, d.RefundAmount)
+		return err
+	}
+	return nil
+}
+
+func penaltyFor(outcome dispute.Outcome) float64 {
+	switch outcome {
+	case dispute.OutcomeRefund:
+		return refundPenalty
+	case dispute.OutcomePartialRefund:
+		return partialRefundPenalty
+	}
+	return 0
+}
+
+func (u *disputeUsecase) GetDispute(ctx context.Context, userID, disputeID string) (*dispute.Dispute, error) {
+	d, err := u.getDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.BuyerID != userID && d.SellerID != userID {
+		return nil, dispute.ErrNotDisputeParty
+	}
+	return d, nil
+}
+
+func (u *disputeUsecase) ListUserDisputes(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	return u.disputeRepo.ListDisputesByUser(ctx, userID, req)
+}
+
+func (u *disputeUsecase) ListDisputes(ctx context.Context, status dispute.Status, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	return u.disputeRepo.ListDisputesByStatus(ctx, status, req)
+}
+
+func (u *disputeUsecase) getDispute(ctx context.Context, disputeID string) (*dispute.Dispute, error) {
+	d, err := u.disputeRepo.GetDisputeByID(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, dispute.ErrDisputeNotFound
+	}
+	return d, nil
+}
+
+func (u *disputeUsecase) notify(ctx context.Context, d *dispute.Dispute, userIDs ...string) {
+	for _, userID := range userIDs {
+		u.publisher.Publish(ctx, &event.Event{
+			Type:   event.DisputeUpdated,
+			UserID: userID,
+			Payload: event.DisputePayload{
+				DisputeID: d.ID,
+				OrderID:   d.OrderID,
+				Status:    string(d.Status),
+				Outcome:   string(d.Outcome),
+			},
+		})
+	}
+}
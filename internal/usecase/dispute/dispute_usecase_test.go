@@ -0,0 +1,547 @@
+package disputeusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/dispute"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	eventinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/event"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockDisputeRepository struct {
+	mock.Mock
+}
+
+func (m *MockDisputeRepository) CreateDispute(ctx context.Context, d *dispute.Dispute) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockDisputeRepository) GetDisputeByID(ctx context.Context, id string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeRepository) GetDisputeByOrder(ctx context.Context, orderID string) (*dispute.Dispute, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dispute.Dispute), args.Error(1)
+}
+
+func (m *MockDisputeRepository) ListDisputesByUser(ctx context.Context, userID string, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*dispute.Dispute]), args.Error(1)
+}
+
+func (m *MockDisputeRepository) ListDisputesByStatus(ctx context.Context, status dispute.Status, req pagination.Request) (*pagination.Page[*dispute.Dispute], error) {
+	args := m.Called(ctx, status, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*dispute.Dispute]), args.Error(1)
+}
+
+func (m *MockDisputeRepository) UpdateDispute(ctx context.Context, d *dispute.Dispute, from dispute.Status) error {
+	args := m.Called(ctx, d, from)
+	return args.Error(0)
+}
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersByConsumer(ctx context.Context, consumerID string, status order.OrderStatus, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, consumerID, status, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
+	args := m.Called(ctx, orderID, status)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) TransitionOrderStatus(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+	args := m.Called(ctx, o, from)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*order.Order, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+type MockOrderUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOrderUseCase) PurchaseBundle(ctx context.Context, bundleID string, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID, resellerID, currency, delivery)
+	if args.Get(0) == nil {
+		return nil, nil, nil, args.Error(3)
+	}
+	return args.Get(0).(*order.Order), args.Get(1).(*payment.Payment), args.Get(2).(*warehouse.WarehouseItem), args.Error(3)
+}
+
+func (m *MockOrderUseCase) GetDashboardMetrics(ctx context.Context, supplierID string) (*order.DashboardMetrics, error) {
+	args := m.Called(ctx, supplierID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.DashboardMetrics), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.ResellerMetrics), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
+}
+
+func (m *MockOrderUseCase) GetOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
+}
+
+func (m *MockOrderUseCase) GetOrdersByConsumer(ctx context.Context, consumerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, map[string]string, error) {
+	args := m.Called(ctx, consumerID, req)
+	if args.Get(0) == nil {
+		return nil, nil, nil, args.Error(3)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Get(2).(map[string]string), args.Error(3)
+}
+
+func (m *MockOrderUseCase) PurchaseProducts(ctx context.Context, consumerID string, products []*product.Product, currency money.Currency, delivery shipping.Selection) ([]*order.Order, []*payment.Payment, error) {
+	args := m.Called(ctx, consumerID, products, currency, delivery)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*order.Order), args.Get(1).([]*payment.Payment), args.Error(2)
+}
+
+func (m *MockOrderUseCase) MarkOrderProcessing(ctx context.Context, orderID string, sellerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) MarkOrderShipped(ctx context.Context, orderID string, sellerID string, trackingNumber string, carrier string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID, trackingNumber, carrier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ConfirmDelivery(ctx context.Context, orderID string, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RecordDelivery(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) CancelOrder(ctx context.Context, orderID string, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RefundOrder(ctx context.Context, orderID string, amount money.Money) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettlePayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCase) SettleRefund(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettleFailure(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+type MockTrustUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTrustUsecase) UpdateSupplierTrustScoreOnNewRating(ctx context.Context, supplierID string, declaredRating float64, productRating float64) error {
+	args := m.Called(ctx, supplierID, declaredRating, productRating)
+	return args.Error(0)
+}
+
+func (m *MockTrustUsecase) UpdateResellerTrustScoreOnNewRating(ctx context.Context, resellerID string, declaredRating float64, productRating float64) error {
+	args := m.Called(ctx, resellerID, declaredRating, productRating)
+	return args.Error(0)
+}
+
+func (m *MockTrustUsecase) ApplyDisputePenalty(ctx context.Context, sellerID string, penalty float64) error {
+	args := m.Called(ctx, sellerID, penalty)
+	return args.Error(0)
+}
+
+func (m *MockTrustUsecase) ApplyBreakdownDeviation(ctx context.Context, supplierID string, deviationPercent float64) error {
+	args := m.Called(ctx, supplierID, deviationPercent)
+	return args.Error(0)
+}
+
+// DisputeUsecaseTestSuite is the test suite for dispute usecase
+type DisputeUsecaseTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	disputeRepo *MockDisputeRepository
+	orderRepo   *MockOrderRepository
+	orderUC     *MockOrderUseCase
+	trustUC     *MockTrustUsecase
+	usecase     dispute.Usecase
+}
+
+func (suite *DisputeUsecaseTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.disputeRepo = new(MockDisputeRepository)
+	suite.orderRepo = new(MockOrderRepository)
+	suite.orderUC = new(MockOrderUseCase)
+	suite.trustUC = new(MockTrustUsecase)
+	suite.usecase = NewDisputeUsecase(suite.disputeRepo, suite.orderRepo, suite.orderUC, suite.trustUC, eventinfra.NewHub())
+}
+
+func (suite *DisputeUsecaseTestSuite) TearDownTest() {
+	suite.disputeRepo.AssertExpectations(suite.T())
+	suite.orderRepo.AssertExpectations(suite.T())
+	suite.orderUC.AssertExpectations(suite.T())
+	suite.trustUC.AssertExpectations(suite.T())
+}
+
+func TestDisputeUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(DisputeUsecaseTestSuite))
+}
+
+func deliveredBundleOrder() *order.Order {
+	return &order.Order{ID: "order1", BundleID: "bundle1", SupplierID: "supplier1", ResellerID: "reseller1", TotalPrice: money.New(20000, money.ETB), Status: order.Delivered}
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_Success() {
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(deliveredBundleOrder(), nil)
+	suite.disputeRepo.On("GetDisputeByOrder", suite.ctx, "order1").Return(nil, nil)
+	suite.disputeRepo.On("CreateDispute", suite.ctx, mock.AnythingOfType("*dispute.Dispute")).Return(nil)
+
+	d, err := suite.usecase.OpenDispute(suite.ctx, "reseller1", &dispute.Dispute{
+		OrderID:         "order1",
+		Reason:          dispute.ReasonMisgraded,
+		Photos:          []string{"https://img/1.jpg"},
+		RequestedRemedy: dispute.RemedyPartialRefund,
+		RequestedAmount: money.Money{Amount: 5000},
+	})
+
+	suite.NoError(err)
+	suite.Equal(money.New(5000, money.ETB), d.RequestedAmount)
+	suite.Equal("supplier1", d.SellerID)
+	suite.Equal("reseller1", d.BuyerID)
+	suite.Equal(dispute.StatusOpen, d.Status)
+	suite.Equal("order1", d.ID)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_NotDelivered() {
+	o := deliveredBundleOrder()
+	o.Status = order.Shipped
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	_, err := suite.usecase.OpenDispute(suite.ctx, "reseller1", &dispute.Dispute{
+		OrderID:         "order1",
+		Reason:          dispute.ReasonDamaged,
+		RequestedRemedy: dispute.RemedyRefund,
+	})
+
+	suite.ErrorIs(err, dispute.ErrNotDisputable)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_AmountInOtherCurrency() {
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(deliveredBundleOrder(), nil)
+
+	_, err := suite.usecase.OpenDispute(suite.ctx, "reseller1", &dispute.Dispute{
+		OrderID:         "order1",
+		Reason:          dispute.ReasonMisgraded,
+		RequestedRemedy: dispute.RemedyPartialRefund,
+		RequestedAmount: money.New(500, money.USD),
+	})
+
+	suite.ErrorIs(err, dispute.ErrInvalidAmount)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_NotBuyer() {
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(deliveredBundleOrder(), nil)
+
+	_, err := suite.usecase.OpenDispute(suite.ctx, "supplier1", &dispute.Dispute{
+		OrderID:         "order1",
+		Reason:          dispute.ReasonDamaged,
+		RequestedRemedy: dispute.RemedyRefund,
+	})
+
+	suite.ErrorIs(err, dispute.ErrNotDisputeParty)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_AlreadyDisputed() {
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(deliveredBundleOrder(), nil)
+	suite.disputeRepo.On("GetDisputeByOrder", suite.ctx, "order1").Return(&dispute.Dispute{ID: "d0"}, nil)
+
+	_, err := suite.usecase.OpenDispute(suite.ctx, "reseller1", &dispute.Dispute{
+		OrderID:         "order1",
+		Reason:          dispute.ReasonDamaged,
+		RequestedRemedy: dispute.RemedyRefund,
+	})
+
+	suite.ErrorIs(err, dispute.ErrAlreadyDisputed)
+}
+
+// TestOpenDispute_Raced tests that a dispute opened while another was being stored
+// for the same order is refused
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_Raced() {
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(deliveredBundleOrder(), nil)
+	suite.disputeRepo.On("GetDisputeByOrder", suite.ctx, "order1").Return(nil, nil)
+	suite.disputeRepo.On("CreateDispute", suite.ctx, mock.AnythingOfType("*dispute.Dispute")).Return(dispute.ErrAlreadyDisputed)
+
+	_, err := suite.usecase.OpenDispute(suite.ctx, "reseller1", &dispute.Dispute{
+		OrderID:         "order1",
+		Reason:          dispute.ReasonDamaged,
+		RequestedRemedy: dispute.RemedyRefund,
+	})
+
+	suite.ErrorIs(err, dispute.ErrAlreadyDisputed)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_InvalidReason() {
+	_, err := suite.usecase.OpenDispute(suite.ctx, "reseller1", &dispute.Dispute{
+		OrderID:         "order1",
+		Reason:          "changed my mind",
+		RequestedRemedy: dispute.RemedyRefund,
+	})
+
+	suite.ErrorIs(err, dispute.ErrInvalidReason)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestRespondToDispute() {
+	d := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusOpen}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, d, dispute.StatusOpen).Return(nil)
+
+	updated, err := suite.usecase.RespondToDispute(suite.ctx, "supplier1", "d1", "The grade was checked before shipping")
+
+	suite.NoError(err)
+	suite.Equal(dispute.StatusResponded, updated.Status)
+	suite.NotEmpty(updated.RespondedAt)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestRespondToDispute_NotSeller() {
+	d := &dispute.Dispute{ID: "d1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusOpen}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+
+	_, err := suite.usecase.RespondToDispute(suite.ctx, "reseller1", "d1", "Not me")
+
+	suite.ErrorIs(err, dispute.ErrNotDisputeParty)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_RefundPenalizesSeller() {
+	d := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusResponded}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, d, dispute.StatusResponded).Return(nil).Once()
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, d, dispute.StatusResolved).Return(nil).Once()
+	suite.orderUC.On("ForceCancelOrder", suite.ctx, "order1").Return(&order.Order{ID: "order1", TotalPrice: money.New(20000, money.ETB), Status: order.OrderStatusCanceled}, nil)
+	suite.trustUC.On("ApplyDisputePenalty", suite.ctx, "supplier1", refundPenalty).Return(nil)
+
+	resolved, err := suite.usecase.ResolveDispute(suite.ctx, "admin1", "d1", dispute.OutcomeRefund, money.Money{}, "Photos show a lower grade")
+
+	suite.NoError(err)
+	suite.Equal(dispute.StatusResolved, resolved.Status)
+	suite.Equal(money.New(20000, money.ETB), resolved.RefundAmount)
+	suite.Equal("admin1", resolved.ResolvedBy)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_PartialRefund() {
+	d := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusOpen}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, d, dispute.StatusOpen).Return(nil)
+	suite.orderUC.On("RefundOrder", suite.ctx, "order1", money.New(4000, money.ETB)).Return(&payment.Payment{ID: "refund1", Amount: money.New(-4000, money.ETB)}, nil)
+	suite.trustUC.On("ApplyDisputePenalty", suite.ctx, "supplier1", partialRefundPenalty).Return(nil)
+
+	resolved, err := suite.usecase.ResolveDispute(suite.ctx, "admin1", "d1", dispute.OutcomePartialRefund, money.New(4000, money.ETB), "")
+
+	suite.NoError(err)
+	suite.Equal(money.New(4000, money.ETB), resolved.RefundAmount)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_PartialRefundInOrderCurrency() {
+	d := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusOpen}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, d, dispute.StatusOpen).Return(nil)
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(deliveredBundleOrder(), nil)
+	suite.orderUC.On("RefundOrder", suite.ctx, "order1", money.New(4000, money.ETB)).Return(&payment.Payment{ID: "refund1"}, nil)
+	suite.trustUC.On("ApplyDisputePenalty", suite.ctx, "supplier1", partialRefundPenalty).Return(nil)
+
+	resolved, err := suite.usecase.ResolveDispute(suite.ctx, "admin1", "d1", dispute.OutcomePartialRefund, money.Money{Amount: 4000}, "")
+
+	suite.NoError(err)
+	suite.Equal(money.New(4000, money.ETB), resolved.RefundAmount)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_PartialRefundOrderMissing() {
+	d := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusOpen}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(nil, nil)
+
+	_, err := suite.usecase.ResolveDispute(suite.ctx, "admin1", "d1", dispute.OutcomePartialRefund, money.Money{Amount: 4000}, "")
+
+	suite.ErrorIs(err, order.ErrOrderNotFound)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_RejectedLeavesTrustAlone() {
+	d := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusResponded}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, d, dispute.StatusResponded).Return(nil)
+
+	resolved, err := suite.usecase.ResolveDispute(suite.ctx, "admin1", "d1", dispute.OutcomeRejected, money.Money{}, "Item matches listing")
+
+	suite.NoError(err)
+	suite.Equal(dispute.OutcomeRejected, resolved.Outcome)
+	suite.trustUC.AssertNotCalled(suite.T(), "ApplyDisputePenalty", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_RefundFailureReopens() {
+	d := &dispute.Dispute{ID: "d1", OrderID: "order1", BuyerID: "reseller1", SellerID: "supplier1", Status: dispute.StatusOpen}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, d, dispute.StatusOpen).Return(nil)
+	suite.disputeRepo.On("UpdateDispute", suite.ctx, mock.MatchedBy(func(prev *dispute.Dispute) bool {
+		return prev.Status == dispute.StatusOpen && prev.Outcome == ""
+	}), dispute.StatusResolved).Return(nil)
+	suite.orderUC.On("RefundOrder", suite.ctx, "order1", money.New(4000, money.ETB)).Return(nil, errors.New("gateway down"))
+
+	_, err := suite.usecase.ResolveDispute(suite.ctx, "admin1", "d1", dispute.OutcomePartialRefund, money.New(4000, money.ETB), "")
+
+	suite.EqualError(err, "gateway down")
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_AlreadyResolved() {
+	d := &dispute.Dispute{ID: "d1", Status: dispute.StatusResolved}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+
+	_, err := suite.usecase.ResolveDispute(suite.ctx, "admin1", "d1", dispute.OutcomeRejected, money.Money{}, "")
+
+	suite.ErrorIs(err, dispute.ErrAlreadyResolved)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestGetDispute_NotParty() {
+	d := &dispute.Dispute{ID: "d1", BuyerID: "reseller1", SellerID: "supplier1"}
+	suite.disputeRepo.On("GetDisputeByID", suite.ctx, "d1").Return(d, nil)
+
+	_, err := suite.usecase.GetDispute(suite.ctx, "someone", "d1")
+
+	suite.ErrorIs(err, dispute.ErrNotDisputeParty)
+}
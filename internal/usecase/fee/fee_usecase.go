@@ -0,0 +1,100 @@
+package feeusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/transaction"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type feeUsecase struct {
+	feeRepo   fee.Repository
+	userRepo  user.Repository
+	txManager transaction.Manager
+}
+
+func NewFeeUsecase(feeRepo fee.Repository, userRepo user.Repository, txManager transaction.Manager) fee.Usecase {
+	return &feeUsecase{
+		feeRepo:   feeRepo,
+		userRepo:  userRepo,
+		txManager: txManager,
+	}
+}
+
+func (u *feeUsecase) Quote(ctx context.Context, s fee.Sale) (*fee.Quote, error) {
+	rules, err := u.feeRepo.ListCurrentRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.SellerTier = fee.TierStandard
+	if s.SellerID != "" {
+		seller, err := u.userRepo.GetByID(ctx, s.SellerID)
+		if err != nil {
+			return nil, err
+		}
+		s.SellerTier = fee.TierForTrustScore(seller.TrustScore)
+	}
+	return fee.QuoteSale(rules, s), nil
+}
+
+func (u *feeUsecase) CreateRule(ctx context.Context, adminID string, r *fee.Rule) (*fee.Rule, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	r.ID = primitive.NewObjectID().Hex()
+	r.Version = 1
+	r.Current = true
+	r.CreatedBy = adminID
+	r.CreatedAt = time.Now().Format(time.RFC3339)
+	if err := u.feeRepo.CreateRule(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UpdateRule retires the current version and stores the new terms as the next one
+// in a single transaction, so there is never more than one current version.
+func (u *feeUsecase) UpdateRule(ctx context.Context, adminID, id string, r *fee.Rule) (*fee.Rule, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		current, err := u.feeRepo.GetRule(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if err := u.feeRepo.SupersedeRule(txCtx, id, current.Version); err != nil {
+			return err
+		}
+		r.ID = id
+		r.Version = current.Version + 1
+		r.Current = true
+		r.CreatedBy = adminID
+		r.CreatedAt = time.Now().Format(time.RFC3339)
+		return u.feeRepo.CreateRule(txCtx, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (u *feeUsecase) DeleteRule(ctx context.Context, id string) error {
+	current, err := u.feeRepo.GetRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	return u.feeRepo.SupersedeRule(ctx, id, current.Version)
+}
+
+func (u *feeUsecase) GetRule(ctx context.Context, id string) (*fee.Rule, error) {
+	return u.feeRepo.GetRule(ctx, id)
+}
+
+func (u *feeUsecase) ListRules(ctx context.Context) ([]*fee.Rule, error) {
+	return u.feeRepo.ListCurrentRules(ctx)
+}
@@ -0,0 +1,229 @@
+package feeusecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockFeeRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeeRepository) CreateRule(ctx context.Context, r *fee.Rule) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockFeeRepository) GetRule(ctx context.Context, id string) (*fee.Rule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Rule), args.Error(1)
+}
+
+func (m *MockFeeRepository) GetRuleVersion(ctx context.Context, id string, version int) (*fee.Rule, error) {
+	args := m.Called(ctx, id, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fee.Rule), args.Error(1)
+}
+
+func (m *MockFeeRepository) ListCurrentRules(ctx context.Context) ([]*fee.Rule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fee.Rule), args.Error(1)
+}
+
+func (m *MockFeeRepository) SupersedeRule(ctx context.Context, id string, fromVersion int) error {
+	args := m.Called(ctx, id, fromVersion)
+	return args.Error(0)
+}
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUsersByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, roles, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTrustData(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) CountActiveUsers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// passthroughTxManager runs the callback directly; the repositories are mocked.
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type FeeUsecaseTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	feeRepo  *MockFeeRepository
+	userRepo *MockUserRepository
+	usecase  fee.Usecase
+}
+
+func (suite *FeeUsecaseTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.feeRepo = new(MockFeeRepository)
+	suite.userRepo = new(MockUserRepository)
+	suite.usecase = NewFeeUsecase(suite.feeRepo, suite.userRepo, passthroughTxManager{})
+}
+
+func (suite *FeeUsecaseTestSuite) TearDownTest() {
+	suite.feeRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+}
+
+func TestFeeUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(FeeUsecaseTestSuite))
+}
+
+func (suite *FeeUsecaseTestSuite) TestQuote_UsesSellerTier() {
+	suite.feeRepo.On("ListCurrentRules", suite.ctx).Return([]*fee.Rule{
+		{ID: "b2c", Version: 1, PaymentType: payment.B2C, Percent: 5},
+		{ID: "b2c-top", Version: 4, PaymentType: payment.B2C, SellerTier: fee.TierTop, Percent: 1},
+	}, nil)
+	suite.userRepo.On("GetByID", suite.ctx, "reseller1").Return(&user.User{ID: "reseller1", TrustScore: 95}, nil)
+
+	q, err := suite.usecase.Quote(suite.ctx, fee.Sale{Type: payment.B2C, SellerID: "reseller1", Amount: money.New(20000, money.ETB)})
+
+	suite.Require().NoError(err)
+	suite.Equal(money.New(200, money.ETB), q.Fee)
+	suite.Equal("b2c-top", q.RuleID)
+	suite.Equal(4, q.RuleVersion)
+}
+
+func (suite *FeeUsecaseTestSuite) TestQuote_FallsBackToDefault() {
+	suite.feeRepo.On("ListCurrentRules", suite.ctx).Return([]*fee.Rule{}, nil)
+	suite.userRepo.On("GetByID", suite.ctx, "supplier1").Return(&user.User{ID: "supplier1", TrustScore: 50}, nil)
+
+	q, err := suite.usecase.Quote(suite.ctx, fee.Sale{Type: payment.B2B, SellerID: "supplier1", Amount: money.New(10000, money.ETB)})
+
+	suite.Require().NoError(err)
+	suite.Equal(money.New(200, money.ETB), q.Fee)
+	suite.Equal(money.New(9800, money.ETB), q.Net)
+	suite.Equal(fee.DefaultRule.ID, q.RuleID)
+}
+
+func (suite *FeeUsecaseTestSuite) TestCreateRule() {
+	suite.feeRepo.On("CreateRule", suite.ctx, mock.AnythingOfType("*fee.Rule")).Return(nil)
+
+	r, err := suite.usecase.CreateRule(suite.ctx, "admin1", &fee.Rule{Name: "B2B", PaymentType: payment.B2B, Percent: 3})
+
+	suite.Require().NoError(err)
+	suite.NotEmpty(r.ID)
+	suite.Equal(1, r.Version)
+	suite.True(r.Current)
+	suite.Equal("admin1", r.CreatedBy)
+}
+
+func (suite *FeeUsecaseTestSuite) TestCreateRule_Invalid() {
+	_, err := suite.usecase.CreateRule(suite.ctx, "admin1", &fee.Rule{Name: "B2B", Currency: money.ETB, Percent: 2, MinFee: 1000, MaxFee: 500})
+
+	suite.ErrorIs(err, fee.ErrInvalidCaps)
+	suite.feeRepo.AssertNotCalled(suite.T(), "CreateRule", mock.Anything, mock.Anything)
+}
+
+func (suite *FeeUsecaseTestSuite) TestUpdateRule_StoresNextVersion() {
+	suite.feeRepo.On("GetRule", suite.ctx, "rule1").Return(&fee.Rule{ID: "rule1", Version: 2, Name: "B2B", Percent: 3, Current: true}, nil)
+	suite.feeRepo.On("SupersedeRule", suite.ctx, "rule1", 2).Return(nil)
+	suite.feeRepo.On("CreateRule", suite.ctx, mock.MatchedBy(func(r *fee.Rule) bool {
+		return r.ID == "rule1" && r.Version == 3 && r.Percent == 2.5
+	})).Return(nil)
+
+	r, err := suite.usecase.UpdateRule(suite.ctx, "admin1", "rule1", &fee.Rule{Name: "B2B", Percent: 2.5})
+
+	suite.Require().NoError(err)
+	suite.Equal(3, r.Version)
+}
+
+func (suite *FeeUsecaseTestSuite) TestUpdateRule_Conflict() {
+	suite.feeRepo.On("GetRule", suite.ctx, "rule1").Return(&fee.Rule{ID: "rule1", Version: 2, Name: "B2B", Percent: 3, Current: true}, nil)
+	suite.feeRepo.On("SupersedeRule", suite.ctx, "rule1", 2).Return(fee.ErrVersionConflict)
+
+	_, err := suite.usecase.UpdateRule(suite.ctx, "admin1", "rule1", &fee.Rule{Name: "B2B", Percent: 2.5})
+
+	suite.ErrorIs(err, fee.ErrVersionConflict)
+	suite.feeRepo.AssertNotCalled(suite.T(), "CreateRule", mock.Anything, mock.Anything)
+}
+
+func (suite *FeeUsecaseTestSuite) TestDeleteRule_NotFound() {
+	suite.feeRepo.On("GetRule", suite.ctx, "missing").Return(nil, fee.ErrRuleNotFound)
+
+	err := suite.usecase.DeleteRule(suite.ctx, "missing")
+
+	suite.ErrorIs(err, fee.ErrRuleNotFound)
+}
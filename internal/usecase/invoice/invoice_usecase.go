@@ -0,0 +1,242 @@
+package invoiceusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+)
+
+type invoiceUsecase struct {
+	invoiceRepo invoice.Repository
+	orderRepo   order.Repository
+	userRepo    user.Repository
+	productRepo product.Repository
+	bundleRepo  bundle.Repository
+	renderer    invoice.Renderer
+}
+
+func NewInvoiceUsecase(invoiceRepo invoice.Repository, orderRepo order.Repository, userRepo user.Repository, productRepo product.Repository, bundleRepo bundle.Repository, renderer invoice.Renderer) invoice.Usecase {
+	return &invoiceUsecase{
+		invoiceRepo: invoiceRepo,
+		orderRepo:   orderRepo,
+		userRepo:    userRepo,
+		productRepo: productRepo,
+		bundleRepo:  bundleRepo,
+		renderer:    renderer,
+	}
+}
+
+func (u *invoiceUsecase) GetInvoice(ctx context.Context, userID, orderID string) (*invoice.Invoice, []byte, error) {
+	o, err := u.invoiceableOrder(ctx, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if userID != o.BuyerID() && userID != o.SellerID() {
+		return nil, nil, invoice.ErrNotInvoiceParty
+	}
+
+	inv, err := u.invoiceRepo.GetInvoiceByOrderID(ctx, o.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inv == nil {
+		return nil, nil, invoice.ErrInvoiceNotFound
+	}
+	return u.render(inv)
+}
+
+func (u *invoiceUsecase) IssueInvoice(ctx context.Context, o *order.Order) (*invoice.Invoice, error) {
+	return u.issue(ctx, o)
+}
+
+func (u *invoiceUsecase) RegenerateInvoice(ctx context.Context, adminID, orderID string) (*invoice.Invoice, []byte, error) {
+	o, err := u.invoiceableOrder(ctx, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := u.invoiceRepo.GetInvoiceByOrderID(ctx, o.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing == nil {
+		inv, err := u.issue(ctx, o)
+		if err != nil {
+			return nil, nil, err
+		}
+		return u.render(inv)
+	}
+
+	inv, err := u.build(ctx, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	inv.ID = existing.ID
+	inv.Number = existing.Number
+	inv.Sequence = existing.Sequence
+	inv.IssuedAt = existing.IssuedAt
+	inv.Revision = existing.Revision + 1
+	inv.RegeneratedBy = adminID
+	inv.RegeneratedAt = time.Now().Format(time.RFC3339)
+	if err := u.invoiceRepo.ReplaceInvoice(ctx, inv, existing.Revision); err != nil {
+		return nil, nil, err
+	}
+	return u.render(inv)
+}
+
+func (u *invoiceUsecase) invoiceableOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	o, err := u.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	if o.Status == order.Failed || o.Status == order.OrderStatusCanceled {
+		return nil, invoice.ErrNotInvoiceable
+	}
+	return o, nil
+}
+
+// issue numbers and stores a new invoice for the order. When another request
+// issued it first, theirs is returned and the number reserved here goes unused.
+func (u *invoiceUsecase) issue(ctx context.Context, o *order.Order) (*invoice.Invoice, error) {
+	inv, err := u.build(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	seq, err := u.invoiceRepo.NextSequence(ctx, o.SellerID())
+	if err != nil {
+		return nil, fmt.Errorf("reserving invoice number: %w", err)
+	}
+	inv.ID = o.ID
+	inv.Sequence = seq
+	inv.Number = invoice.FormatNumber(o.SellerID(), seq)
+	inv.IssuedAt = time.Now().Format(time.RFC3339)
+
+	err = u.invoiceRepo.CreateInvoice(ctx, inv)
+	if errors.Is(err, invoice.ErrInvoiceExists) {
+		existing, err := u.invoiceRepo.GetInvoiceByOrderID(ctx, o.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, invoice.ErrInvoiceNotFound
+		}
+		return existing, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// build snapshots the order, its parties and its items. The totals come from the
+// order, which is what was charged, rather than from the current listings.
+func (u *invoiceUsecase) build(ctx context.Context, o *order.Order) (*invoice.Invoice, error) {
+	seller, err := u.party(ctx, o.SellerID())
+	if err != nil {
+		return nil, err
+	}
+	buyer, err := u.party(ctx, o.BuyerID())
+	if err != nil {
+		return nil, err
+	}
+	lines, err := u.lines(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shipping is billed as its own line and, like the goods, paid to the seller.
+	total := o.TotalPrice
+	if o.ShippingCost.IsPositive() {
+		lines = append(lines, invoice.Line{
+			Description: fmt.Sprintf("Shipping (%s)", o.DeliveryOption),
+			Quantity:    1,
+			UnitPrice:   o.ShippingCost,
+			Amount:      o.ShippingCost,
+		})
+		total = total.Add(o.ShippingCost)
+	}
+
+	charged := o.Charged
+	if charged.Currency == "" {
+		charged = total
+	}
+	return &invoice.Invoice{
+		OrderID:      o.ID,
+		Seller:       seller,
+		Buyer:        buyer,
+		Lines:        lines,
+		Total:        total,
+		PlatformFee:  o.PlatformFee,
+		NetPayable:   total.Sub(o.PlatformFee),
+		Charged:      charged,
+		ExchangeRate: o.ExchangeRate,
+		OrderDate:    o.CreatedAt,
+	}, nil
+}
+
+func (u *invoiceUsecase) party(ctx context.Context, userID string) (invoice.Party, error) {
+	usr, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return invoice.Party{}, fmt.Errorf("loading invoice party %s: %w", userID, err)
+	}
+	name := usr.Name
+	if name == "" {
+		name = usr.Username
+	}
+	return invoice.Party{ID: usr.ID, Name: name, Email: usr.Email}, nil
+}
+
+// lines lists a bundle order as the bundle itself and a product order as one
+// line per product.
+func (u *invoiceUsecase) lines(ctx context.Context, o *order.Order) ([]invoice.Line, error) {
+	if o.BundleID != "" {
+		b, err := u.bundleRepo.GetBundleByID(ctx, o.BundleID)
+		if err != nil {
+			return nil, fmt.Errorf("loading bundle %s: %w", o.BundleID, err)
+		}
+		desc := b.Title
+		if b.Quantity > 0 {
+			desc = fmt.Sprintf("%s (bundle of %d items)", b.Title, b.Quantity)
+		}
+		return []invoice.Line{{Description: desc, Quantity: 1, UnitPrice: o.TotalPrice, Amount: o.TotalPrice}}, nil
+	}
+
+	lines := make([]invoice.Line, 0, len(o.ProductIDs))
+	for _, id := range o.ProductIDs {
+		p, err := u.productRepo.GetProductByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("loading product %s: %w", id, err)
+		}
+		details := []string{}
+		for _, d := range []string{p.Size, p.Grade} {
+			if d != "" {
+				details = append(details, d)
+			}
+		}
+		desc := p.Title
+		if len(details) > 0 {
+			desc += " (" + strings.Join(details, ", ") + ")"
+		}
+		lines = append(lines, invoice.Line{Description: desc, Quantity: 1, UnitPrice: p.Price, Amount: p.Price})
+	}
+	return lines, nil
+}
+
+func (u *invoiceUsecase) render(inv *invoice.Invoice) (*invoice.Invoice, []byte, error) {
+	doc, err := u.renderer.Render(inv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rendering invoice %s: %w", inv.Number, err)
+	}
+	return inv, doc, nil
+}
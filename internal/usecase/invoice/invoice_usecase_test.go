@@ -0,0 +1,630 @@
+package invoiceusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockInvoiceRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceRepository) NextSequence(ctx context.Context, sellerID string) (int64, error) {
+	args := m.Called(ctx, sellerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) CreateInvoice(ctx context.Context, inv *invoice.Invoice) error {
+	args := m.Called(ctx, inv)
+	return args.Error(0)
+}
+
+func (m *MockInvoiceRepository) GetInvoiceByOrderID(ctx context.Context, orderID string) (*invoice.Invoice, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invoice.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) ReplaceInvoice(ctx context.Context, inv *invoice.Invoice, from int) error {
+	args := m.Called(ctx, inv, from)
+	return args.Error(0)
+}
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, u *user.User) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUsersByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, roles, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTrustData(ctx context.Context, user *user.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) CountActiveUsers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) AddProduct(ctx context.Context, p *product.Product) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetProductByID(ctx context.Context, id string) (*product.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetProductByTitle(ctx context.Context, title string) (*product.Product, error) {
+	args := m.Called(ctx, title)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
+}
+
+func (m *MockProductRepository) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
+}
+
+func (m *MockProductRepository) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *MockProductRepository) DeleteProduct(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
+	args := m.Called(ctx, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetSoldProductsByReseller(ctx context.Context, resellerID string) ([]*product.Product, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Reserve(ctx context.Context, id string, userID string, until time.Time) error {
+	args := m.Called(ctx, id, userID, until)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) ReleaseReservation(ctx context.Context, id string, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductRepository) MarkAsSold(ctx context.Context, id string, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Restock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepository) CreateBundle(ctx context.Context, b *bundle.Bundle) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) GetBundleByID(ctx context.Context, id string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) UpdateBundleStatus(ctx context.Context, id string, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) DeleteBundle(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) UpdateBundle(ctx context.Context, id string, updatedData map[string]interface{}) error {
+	args := m.Called(ctx, id, updatedData)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) DecreaseBundleQuantity(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) CountBundles(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBundleRepository) GetBundleByTitle(ctx context.Context, title string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, title)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersByConsumer(ctx context.Context, consumerID string, status order.OrderStatus, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, consumerID, status, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
+	args := m.Called(ctx, orderID, status)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) TransitionOrderStatus(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+	args := m.Called(ctx, o, from)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*order.Order, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+type MockRenderer struct {
+	mock.Mock
+}
+
+func (m *MockRenderer) Render(inv *invoice.Invoice) ([]byte, error) {
+	args := m.Called(inv)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+type InvoiceUsecaseTestSuite struct {
+	suite.Suite
+	invoiceRepo *MockInvoiceRepository
+	orderRepo   *MockOrderRepository
+	userRepo    *MockUserRepository
+	productRepo *MockProductRepository
+	bundleRepo  *MockBundleRepository
+	renderer    *MockRenderer
+	usecase     invoice.Usecase
+	ctx         context.Context
+}
+
+func (s *InvoiceUsecaseTestSuite) SetupTest() {
+	s.invoiceRepo = new(MockInvoiceRepository)
+	s.orderRepo = new(MockOrderRepository)
+	s.userRepo = new(MockUserRepository)
+	s.productRepo = new(MockProductRepository)
+	s.bundleRepo = new(MockBundleRepository)
+	s.renderer = new(MockRenderer)
+	s.usecase = NewInvoiceUsecase(s.invoiceRepo, s.orderRepo, s.userRepo, s.productRepo, s.bundleRepo, s.renderer)
+	s.ctx = context.Background()
+
+	s.userRepo.On("GetByID", s.ctx, "supplier1").Return(&user.User{ID: "supplier1", Name: "Addis Vintage", Email: "supplier@example.com"}, nil).Maybe()
+	s.userRepo.On("GetByID", s.ctx, "reseller1").Return(&user.User{ID: "reseller1", Username: "sara", Email: "sara@example.com"}, nil).Maybe()
+	s.userRepo.On("GetByID", s.ctx, "consumer1").Return(&user.User{ID: "consumer1", Name: "Abel", Email: "abel@example.com"}, nil).Maybe()
+	s.renderer.On("Render", mock.AnythingOfType("*invoice.Invoice")).Return([]byte("%PDF-"), nil).Maybe()
+}
+
+func (s *InvoiceUsecaseTestSuite) TearDownTest() {
+	s.invoiceRepo.AssertExpectations(s.T())
+	s.orderRepo.AssertExpectations(s.T())
+}
+
+func bundleOrder() *order.Order {
+	return &order.Order{
+		ID:          "order1",
+		BundleID:    "bundle1",
+		SupplierID:  "supplier1",
+		ResellerID:  "reseller1",
+		TotalPrice:  money.New(500000, money.ETB),
+		PlatformFee: money.New(10000, money.ETB),
+		Status:      order.Delivered,
+		CreatedAt:   "2025-02-28T09:00:00Z",
+	}
+}
+
+func productOrder() *order.Order {
+	return &order.Order{
+		ID:          "order2",
+		ResellerID:  "reseller1",
+		ConsumerID:  "consumer1",
+		ProductIDs:  []string{"p1", "p2"},
+		TotalPrice:  money.New(4000, money.USD),
+		PlatformFee: money.New(80, money.USD),
+		Charged:     money.New(4000, money.USD),
+		Status:      order.Pending,
+	}
+}
+
+func (s *InvoiceUsecaseTestSuite) TestIssueInvoice() {
+	o := bundleOrder()
+	s.bundleRepo.On("GetBundleByID", s.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", Title: "Denim mix", Quantity: 40}, nil)
+	s.invoiceRepo.On("NextSequence", s.ctx, "supplier1").Return(int64(7), nil)
+	s.invoiceRepo.On("CreateInvoice", s.ctx, mock.AnythingOfType("*invoice.Invoice")).Return(nil)
+
+	inv, err := s.usecase.IssueInvoice(s.ctx, o)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "order1", inv.ID)
+	assert.Equal(s.T(), "INV-PLIER1-000007", inv.Number)
+	assert.Equal(s.T(), "Addis Vintage", inv.Seller.Name)
+	assert.Equal(s.T(), "sara", inv.Buyer.Name)
+	s.Require().Len(inv.Lines, 1)
+	assert.Equal(s.T(), "Denim mix (bundle of 40 items)", inv.Lines[0].Description)
+	assert.Equal(s.T(), money.New(500000, money.ETB), inv.Total)
+	assert.Equal(s.T(), money.New(490000, money.ETB), inv.NetPayable)
+	assert.Equal(s.T(), money.New(500000, money.ETB), inv.Charged)
+	assert.NotEmpty(s.T(), inv.IssuedAt)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestGetInvoice_ReturnsIssuedInvoice() {
+	o := productOrder()
+	issued := &invoice.Invoice{ID: "order2", Number: "INV-LLER1-000003", Sequence: 3}
+	s.orderRepo.On("GetOrderByID", s.ctx, "order2").Return(o, nil)
+	s.invoiceRepo.On("GetInvoiceByOrderID", s.ctx, "order2").Return(issued, nil)
+
+	inv, _, err := s.usecase.GetInvoice(s.ctx, "consumer1", "order2")
+
+	s.Require().NoError(err)
+	assert.Same(s.T(), issued, inv)
+	s.invoiceRepo.AssertNotCalled(s.T(), "NextSequence", mock.Anything, mock.Anything)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestIssueInvoice_ProductLines() {
+	o := productOrder()
+	s.productRepo.On("GetProductByID", s.ctx, "p1").Return(&product.Product{ID: "p1", Title: "Leather jacket", Size: "M", Grade: "A", Price: money.New(2500, money.USD)}, nil)
+	s.productRepo.On("GetProductByID", s.ctx, "p2").Return(&product.Product{ID: "p2", Title: "Silk scarf", Price: money.New(1500, money.USD)}, nil)
+	s.invoiceRepo.On("NextSequence", s.ctx, "reseller1").Return(int64(1), nil)
+	s.invoiceRepo.On("CreateInvoice", s.ctx, mock.AnythingOfType("*invoice.Invoice")).Return(nil)
+
+	inv, err := s.usecase.IssueInvoice(s.ctx, o)
+
+	s.Require().NoError(err)
+	s.Require().Len(inv.Lines, 2)
+	assert.Equal(s.T(), "Leather jacket (M, A)", inv.Lines[0].Description)
+	assert.Equal(s.T(), "Silk scarf", inv.Lines[1].Description)
+	assert.Equal(s.T(), money.New(1500, money.USD), inv.Lines[1].Amount)
+	assert.Equal(s.T(), "Abel", inv.Buyer.Name)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestIssueInvoice_ShippingLine() {
+	o := productOrder()
+	o.ProductIDs = []string{"p2"}
+	o.TotalPrice = money.New(1500, money.USD)
+	o.PlatformFee = money.New(30, money.USD)
+	o.ShippingCost = money.New(700, money.USD)
+	o.DeliveryOption = shipping.Express
+	o.Charged = money.New(2200, money.USD)
+	s.productRepo.On("GetProductByID", s.ctx, "p2").Return(&product.Product{ID: "p2", Title: "Silk scarf", Price: money.New(1500, money.USD)}, nil)
+	s.invoiceRepo.On("NextSequence", s.ctx, "reseller1").Return(int64(2), nil)
+	s.invoiceRepo.On("CreateInvoice", s.ctx, mock.AnythingOfType("*invoice.Invoice")).Return(nil)
+
+	inv, err := s.usecase.IssueInvoice(s.ctx, o)
+
+	s.Require().NoError(err)
+	s.Require().Len(inv.Lines, 2)
+	assert.Equal(s.T(), "Shipping (express)", inv.Lines[1].Description)
+	assert.Equal(s.T(), money.New(700, money.USD), inv.Lines[1].Amount)
+	assert.Equal(s.T(), money.New(2200, money.USD), inv.Total)
+	assert.Equal(s.T(), money.New(2170, money.USD), inv.NetPayable)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestIssueInvoice_AlreadyIssued() {
+	o := bundleOrder()
+	theirs := &invoice.Invoice{ID: "order1", Number: "INV-PLIER1-000004", Sequence: 4}
+	s.bundleRepo.On("GetBundleByID", s.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", Title: "Denim mix"}, nil)
+	s.invoiceRepo.On("NextSequence", s.ctx, "supplier1").Return(int64(5), nil)
+	s.invoiceRepo.On("CreateInvoice", s.ctx, mock.AnythingOfType("*invoice.Invoice")).Return(invoice.ErrInvoiceExists)
+	s.invoiceRepo.On("GetInvoiceByOrderID", s.ctx, "order1").Return(theirs, nil)
+
+	inv, err := s.usecase.IssueInvoice(s.ctx, o)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "INV-PLIER1-000004", inv.Number)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestGetInvoice_NotIssued() {
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(bundleOrder(), nil)
+	s.invoiceRepo.On("GetInvoiceByOrderID", s.ctx, "order1").Return(nil, nil)
+
+	_, _, err := s.usecase.GetInvoice(s.ctx, "reseller1", "order1")
+
+	assert.ErrorIs(s.T(), err, invoice.ErrInvoiceNotFound)
+	s.invoiceRepo.AssertNotCalled(s.T(), "NextSequence", mock.Anything, mock.Anything)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestGetInvoice_NotParty() {
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(bundleOrder(), nil)
+
+	_, _, err := s.usecase.GetInvoice(s.ctx, "someone-else", "order1")
+
+	assert.ErrorIs(s.T(), err, invoice.ErrNotInvoiceParty)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestGetInvoice_FailedOrder() {
+	o := bundleOrder()
+	o.Status = order.Failed
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(o, nil)
+
+	_, _, err := s.usecase.GetInvoice(s.ctx, "reseller1", "order1")
+
+	assert.ErrorIs(s.T(), err, invoice.ErrNotInvoiceable)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestGetInvoice_CanceledOrder() {
+	o := bundleOrder()
+	o.Status = order.OrderStatusCanceled
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(o, nil)
+
+	_, _, err := s.usecase.GetInvoice(s.ctx, "reseller1", "order1")
+
+	assert.ErrorIs(s.T(), err, invoice.ErrNotInvoiceable)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestGetInvoice_OrderNotFound() {
+	s.orderRepo.On("GetOrderByID", s.ctx, "missing").Return(nil, nil)
+
+	_, _, err := s.usecase.GetInvoice(s.ctx, "reseller1", "missing")
+
+	assert.ErrorIs(s.T(), err, order.ErrOrderNotFound)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestGetInvoice_RenderFails() {
+	s.renderer = new(MockRenderer)
+	s.renderer.On("Render", mock.Anything).Return(nil, errors.New("boom"))
+	s.usecase = NewInvoiceUsecase(s.invoiceRepo, s.orderRepo, s.userRepo, s.productRepo, s.bundleRepo, s.renderer)
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(bundleOrder(), nil)
+	s.invoiceRepo.On("GetInvoiceByOrderID", s.ctx, "order1").Return(&invoice.Invoice{ID: "order1", Number: "INV-1"}, nil)
+
+	_, _, err := s.usecase.GetInvoice(s.ctx, "reseller1", "order1")
+
+	assert.Error(s.T(), err)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestRegenerateInvoice_KeepsNumber() {
+	o := bundleOrder()
+	existing := &invoice.Invoice{ID: "order1", Number: "INV-PLIER1-000007", Sequence: 7, IssuedAt: "2025-03-01T10:00:00Z", Revision: 1}
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(o, nil)
+	s.invoiceRepo.On("GetInvoiceByOrderID", s.ctx, "order1").Return(existing, nil)
+	s.bundleRepo.On("GetBundleByID", s.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", Title: "Denim mix (corrected)"}, nil)
+	s.invoiceRepo.On("ReplaceInvoice", s.ctx, mock.AnythingOfType("*invoice.Invoice"), 1).Return(nil)
+
+	inv, _, err := s.usecase.RegenerateInvoice(s.ctx, "admin1", "order1")
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "INV-PLIER1-000007", inv.Number)
+	assert.Equal(s.T(), int64(7), inv.Sequence)
+	assert.Equal(s.T(), "2025-03-01T10:00:00Z", inv.IssuedAt)
+	assert.Equal(s.T(), 2, inv.Revision)
+	assert.Equal(s.T(), "admin1", inv.RegeneratedBy)
+	assert.Equal(s.T(), "Denim mix (corrected)", inv.Lines[0].Description)
+	_, err = time.Parse(time.RFC3339, inv.RegeneratedAt)
+	assert.NoError(s.T(), err)
+	s.invoiceRepo.AssertNotCalled(s.T(), "NextSequence", mock.Anything, mock.Anything)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestRegenerateInvoice_IssuesWhenMissing() {
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(bundleOrder(), nil)
+	s.invoiceRepo.On("GetInvoiceByOrderID", s.ctx, "order1").Return(nil, nil)
+	s.bundleRepo.On("GetBundleByID", s.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", Title: "Denim mix"}, nil)
+	s.invoiceRepo.On("NextSequence", s.ctx, "supplier1").Return(int64(1), nil)
+	s.invoiceRepo.On("CreateInvoice", s.ctx, mock.AnythingOfType("*invoice.Invoice")).Return(nil)
+
+	inv, _, err := s.usecase.RegenerateInvoice(s.ctx, "admin1", "order1")
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), 0, inv.Revision)
+}
+
+func (s *InvoiceUsecaseTestSuite) TestRegenerateInvoice_Concurrent() {
+	s.orderRepo.On("GetOrderByID", s.ctx, "order1").Return(bundleOrder(), nil)
+	s.invoiceRepo.On("GetInvoiceByOrderID", s.ctx, "order1").Return(&invoice.Invoice{ID: "order1", Revision: 2}, nil)
+	s.bundleRepo.On("GetBundleByID", s.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", Title: "Denim mix"}, nil)
+	s.invoiceRepo.On("ReplaceInvoice", s.ctx, mock.AnythingOfType("*invoice.Invoice"), 2).Return(invoice.ErrInvoiceChanged)
+
+	_, _, err := s.usecase.RegenerateInvoice(s.ctx, "admin1", "order1")
+
+	assert.ErrorIs(s.T(), err, invoice.ErrInvoiceChanged)
+}
+
+func TestInvoiceUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(InvoiceUsecaseTestSuite))
+}
@@ -0,0 +1,147 @@
+package ledgerusecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/transaction"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// minPayout skips balances too small to be worth a transfer, in minor units; they
+// roll over into the next batch.
+const minPayout = 100
+
+type ledgerUsecase struct {
+	ledgerRepo  ledger.Repository
+	paymentRepo payment.Repository
+	txManager   transaction.Manager
+}
+
+func NewLedgerUsecase(ledgerRepo ledger.Repository, paymentRepo payment.Repository, txManager transaction.Manager) ledger.Usecase {
+	return &ledgerUsecase{
+		ledgerRepo:  ledgerRepo,
+		paymentRepo: paymentRepo,
+		txManager:   txManager,
+	}
+}
+
+func (u *ledgerUsecase) GetSellerBalance(ctx context.Context, sellerID string) (*ledger.Balance, error) {
+	pending, err := u.ledgerRepo.AccountBalance(ctx, ledger.AccountSellerPending, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	available, err := u.ledgerRepo.AccountBalance(ctx, ledger.AccountSellerAvailable, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	paidOut, err := u.ledgerRepo.AccountBalance(ctx, ledger.AccountPayouts, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	return &ledger.Balance{
+		SellerID:  sellerID,
+		Pending:   pending.List(),
+		Available: available.List(),
+		PaidOut:   paidOut.List(),
+	}, nil
+}
+
+// RunPayouts books one payout per seller and currency with an available balance. The batch is
+// written in a single transaction that first takes the payouts lock, so overlapping
+// runs conflict and the one retried sees the balances already paid out; a seller is
+// never paid twice for the same funds. Sending the money is left to the payment
+// provider's payout tooling, which works from the stored batch.
+func (u *ledgerUsecase) RunPayouts(ctx context.Context, adminID string) (*ledger.PayoutBatch, error) {
+	batch := &ledger.PayoutBatch{
+		ID:        primitive.NewObjectID().Hex(),
+		CreatedBy: adminID,
+		Payouts:   []ledger.Payout{},
+		Totals:    []money.Money{},
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		batch.Payouts = []ledger.Payout{}
+		if err := u.ledgerRepo.LockPayouts(txCtx); err != nil {
+			return err
+		}
+		balances, err := u.ledgerRepo.BalancesByOwner(txCtx, ledger.AccountSellerAvailable)
+		if err != nil {
+			return err
+		}
+
+		sellerIDs := make([]string, 0, len(balances))
+		for sellerID := range balances {
+			sellerIDs = append(sellerIDs, sellerID)
+		}
+		sort.Strings(sellerIDs)
+
+		totals := money.Totals{}
+		for _, sellerID := range sellerIDs {
+			for _, amount := range balances[sellerID].List() {
+				if amount.Amount < minPayout {
+					continue
+				}
+				t := ledger.ForPayout(sellerID, batch.ID, amount)
+				if err := u.ledgerRepo.RecordTransaction(txCtx, t); err != nil {
+					return err
+				}
+				batch.Payouts = append(batch.Payouts, ledger.Payout{SellerID: sellerID, Amount: amount, TransactionID: t.ID})
+				totals.Add(amount)
+			}
+		}
+		if len(batch.Payouts) == 0 {
+			return nil
+		}
+		batch.Totals = totals.List()
+		return u.ledgerRepo.CreatePayoutBatch(txCtx, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (u *ledgerUsecase) Reconcile(ctx context.Context) (*ledger.Reconciliation, error) {
+	paymentSales, paymentFees, err := u.paymentRepo.GetAllPlatformFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buyers, err := u.ledgerRepo.AccountTotal(ctx, ledger.AccountBuyer)
+	if err != nil {
+		return nil, err
+	}
+	ledgerFees, err := u.ledgerRepo.AccountTotal(ctx, ledger.AccountPlatformFees)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := money.Totals{}
+	for _, totals := range []money.Totals{paymentSales, paymentFees, buyers, ledgerFees} {
+		for c := range totals {
+			currencies[c] = 0
+		}
+	}
+
+	r := &ledger.Reconciliation{Currencies: []ledger.CurrencyReconciliation{}, Balanced: true}
+	for _, c := range currencies.List() {
+		cr := ledger.CurrencyReconciliation{
+			Currency:     c.Currency,
+			PaymentSales: paymentSales.Get(c.Currency),
+			LedgerSales:  buyers.Get(c.Currency).Neg(),
+			PaymentFees:  paymentFees.Get(c.Currency),
+			LedgerFees:   ledgerFees.Get(c.Currency),
+		}
+		cr.SalesDifference = cr.PaymentSales.Sub(cr.LedgerSales)
+		cr.FeesDifference = cr.PaymentFees.Sub(cr.LedgerFees)
+		cr.Balanced = cr.SalesDifference.IsZero() && cr.FeesDifference.IsZero()
+		r.Balanced = r.Balanced && cr.Balanced
+		r.Currencies = append(r.Currencies, cr)
+	}
+	return r, nil
+}
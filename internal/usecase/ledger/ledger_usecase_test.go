@@ -0,0 +1,236 @@
+package ledgerusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) RecordTransaction(ctx context.Context, t *ledger.Transaction) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) AccountBalance(ctx context.Context, account ledger.AccountType, ownerID string) (money.Totals, error) {
+	args := m.Called(ctx, account, ownerID)
+	return args.Get(0).(money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepository) OrderAccountBalance(ctx context.Context, account ledger.AccountType, ownerID string, orderID string) (money.Totals, error) {
+	args := m.Called(ctx, account, ownerID, orderID)
+	return args.Get(0).(money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepository) BalancesByOwner(ctx context.Context, account ledger.AccountType) (map[string]money.Totals, error) {
+	args := m.Called(ctx, account)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepository) AccountTotal(ctx context.Context, account ledger.AccountType) (money.Totals, error) {
+	args := m.Called(ctx, account)
+	return args.Get(0).(money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepository) CreatePayoutBatch(ctx context.Context, b *ledger.PayoutBatch) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepository) LockPayouts(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID, pType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetAllPlatformFees(ctx context.Context) (money.Totals, money.Totals, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(money.Totals), args.Get(1).(money.Totals), args.Error(2)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByChargeID(ctx context.Context, chargeID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, chargeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) UpdatePaymentStatus(ctx context.Context, paymentID string, status payment.Status) error {
+	args := m.Called(ctx, paymentID, status)
+	return args.Error(0)
+}
+
+// passthroughTxManager runs the callback directly; the repositories are mocked.
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type LedgerUsecaseTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	ledgerRepo  *MockLedgerRepository
+	paymentRepo *MockPaymentRepository
+	usecase     ledger.Usecase
+}
+
+func (suite *LedgerUsecaseTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.ledgerRepo = new(MockLedgerRepository)
+	suite.paymentRepo = new(MockPaymentRepository)
+	suite.usecase = NewLedgerUsecase(suite.ledgerRepo, suite.paymentRepo, passthroughTxManager{})
+}
+
+func (suite *LedgerUsecaseTestSuite) TearDownTest() {
+	suite.ledgerRepo.AssertExpectations(suite.T())
+	suite.paymentRepo.AssertExpectations(suite.T())
+}
+
+func TestLedgerUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerUsecaseTestSuite))
+}
+
+func etb(amount int64) money.Money {
+	return money.New(amount, money.ETB)
+}
+
+func (suite *LedgerUsecaseTestSuite) TestGetSellerBalance() {
+	suite.ledgerRepo.On("AccountBalance", suite.ctx, ledger.AccountSellerPending, "supplier1").Return(money.Totals{money.ETB: 9800}, nil)
+	suite.ledgerRepo.On("AccountBalance", suite.ctx, ledger.AccountSellerAvailable, "supplier1").Return(money.Totals{money.ETB: 4900, money.USD: 1000}, nil)
+	suite.ledgerRepo.On("AccountBalance", suite.ctx, ledger.AccountPayouts, "supplier1").Return(money.Totals{}, nil)
+
+	balance, err := suite.usecase.GetSellerBalance(suite.ctx, "supplier1")
+
+	suite.Require().NoError(err)
+	suite.Equal([]money.Money{etb(9800)}, balance.Pending)
+	suite.Equal([]money.Money{etb(4900), money.New(1000, money.USD)}, balance.Available)
+	suite.Empty(balance.PaidOut)
+}
+
+func (suite *LedgerUsecaseTestSuite) TestRunPayouts_PaysAvailableBalances() {
+	suite.ledgerRepo.On("LockPayouts", suite.ctx).Return(nil)
+	suite.ledgerRepo.On("BalancesByOwner", suite.ctx, ledger.AccountSellerAvailable).Return(map[string]money.Totals{
+		"supplier1": {money.ETB: 9800, money.USD: 500},
+		"reseller1": {money.ETB: 2450},
+		"reseller2": {money.ETB: 0},
+	}, nil)
+	suite.ledgerRepo.On("RecordTransaction", suite.ctx, mock.MatchedBy(func(t *ledger.Transaction) bool {
+		return t.Kind == ledger.KindPayout && t.Validate() == nil
+	})).Return(nil).Times(3)
+	suite.ledgerRepo.On("CreatePayoutBatch", suite.ctx, mock.AnythingOfType("*ledger.PayoutBatch")).Return(nil)
+
+	batch, err := suite.usecase.RunPayouts(suite.ctx, "admin1")
+
+	suite.Require().NoError(err)
+	suite.Require().Len(batch.Payouts, 3)
+	suite.Equal("reseller1", batch.Payouts[0].SellerID)
+	suite.Equal("supplier1", batch.Payouts[1].SellerID)
+	suite.Equal(money.New(500, money.USD), batch.Payouts[2].Amount)
+	suite.Equal([]money.Money{etb(12250), money.New(500, money.USD)}, batch.Totals)
+	suite.Equal("admin1", batch.CreatedBy)
+}
+
+func (suite *LedgerUsecaseTestSuite) TestRunPayouts_NothingAvailable() {
+	suite.ledgerRepo.On("LockPayouts", suite.ctx).Return(nil)
+	suite.ledgerRepo.On("BalancesByOwner", suite.ctx, ledger.AccountSellerAvailable).Return(map[string]money.Totals{}, nil)
+
+	batch, err := suite.usecase.RunPayouts(suite.ctx, "admin1")
+
+	suite.Require().NoError(err)
+	suite.Empty(batch.Payouts)
+	suite.ledgerRepo.AssertNotCalled(suite.T(), "CreatePayoutBatch", mock.Anything, mock.Anything)
+}
+
+func (suite *LedgerUsecaseTestSuite) TestRunPayouts_RecordFails() {
+	suite.ledgerRepo.On("LockPayouts", suite.ctx).Return(nil)
+	suite.ledgerRepo.On("BalancesByOwner", suite.ctx, ledger.AccountSellerAvailable).Return(map[string]money.Totals{"supplier1": {money.ETB: 9800}}, nil)
+	suite.ledgerRepo.On("RecordTransaction", suite.ctx, mock.AnythingOfType("*ledger.Transaction")).Return(errors.New("write failed"))
+
+	_, err := suite.usecase.RunPayouts(suite.ctx, "admin1")
+
+	suite.Error(err)
+	suite.ledgerRepo.AssertNotCalled(suite.T(), "CreatePayoutBatch", mock.Anything, mock.Anything)
+}
+
+func (suite *LedgerUsecaseTestSuite) TestRunPayouts_LockFails() {
+	suite.ledgerRepo.On("LockPayouts", suite.ctx).Return(errors.New("write conflict"))
+
+	_, err := suite.usecase.RunPayouts(suite.ctx, "admin1")
+
+	suite.Error(err)
+	suite.ledgerRepo.AssertNotCalled(suite.T(), "BalancesByOwner", mock.Anything, mock.Anything)
+}
+
+func (suite *LedgerUsecaseTestSuite) TestReconcile_Balanced() {
+	suite.paymentRepo.On("GetAllPlatformFees", suite.ctx).Return(money.Totals{money.ETB: 30000}, money.Totals{money.ETB: 600}, nil)
+	suite.ledgerRepo.On("AccountTotal", suite.ctx, ledger.AccountBuyer).Return(money.Totals{money.ETB: -30000}, nil)
+	suite.ledgerRepo.On("AccountTotal", suite.ctx, ledger.AccountPlatformFees).Return(money.Totals{money.ETB: 600}, nil)
+
+	r, err := suite.usecase.Reconcile(suite.ctx)
+
+	suite.Require().NoError(err)
+	suite.True(r.Balanced)
+	suite.Require().Len(r.Currencies, 1)
+	suite.Equal(etb(30000), r.Currencies[0].LedgerSales)
+}
+
+func (suite *LedgerUsecaseTestSuite) TestReconcile_Mismatch() {
+	suite.paymentRepo.On("GetAllPlatformFees", suite.ctx).Return(money.Totals{money.ETB: 30000, money.USD: 1000}, money.Totals{money.ETB: 600, money.USD: 20}, nil)
+	suite.ledgerRepo.On("AccountTotal", suite.ctx, ledger.AccountBuyer).Return(money.Totals{money.ETB: -20000, money.USD: -1000}, nil)
+	suite.ledgerRepo.On("AccountTotal", suite.ctx, ledger.AccountPlatformFees).Return(money.Totals{money.ETB: 400, money.USD: 20}, nil)
+
+	r, err := suite.usecase.Reconcile(suite.ctx)
+
+	suite.Require().NoError(err)
+	suite.False(r.Balanced)
+	suite.Require().Len(r.Currencies, 2)
+	suite.Equal(etb(10000), r.Currencies[0].SalesDifference)
+	suite.Equal(etb(200), r.Currencies[0].FeesDifference)
+	suite.True(r.Currencies[1].Balanced)
+}
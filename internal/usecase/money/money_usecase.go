@@ -0,0 +1,65 @@
+package moneyusecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
+type moneyUsecase struct {
+	rateRepo money.RateRepository
+}
+
+func NewMoneyUsecase(rateRepo money.RateRepository) money.Usecase {
+	return &moneyUsecase{rateRepo: rateRepo}
+}
+
+// Rate looks up the pair in either direction, so admins only need to enter one
+// rate per pair.
+func (u *moneyUsecase) Rate(ctx context.Context, from, to money.Currency) (*money.RateSnapshot, error) {
+	if from == to {
+		return nil, nil
+	}
+	r, err := u.rateRepo.GetRate(ctx, from, to)
+	if err == nil {
+		return r.Snapshot(), nil
+	}
+	if !errors.Is(err, money.ErrRateNotFound) {
+		return nil, err
+	}
+	r, err = u.rateRepo.GetRate(ctx, to, from)
+	if err != nil {
+		return nil, err
+	}
+	return r.Inverse(), nil
+}
+
+func (u *moneyUsecase) Convert(ctx context.Context, m money.Money, to money.Currency) (money.Money, error) {
+	snapshot, err := u.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return snapshot.Convert(m), nil
+}
+
+func (u *moneyUsecase) SetRate(ctx context.Context, adminID string, r *money.Rate) (*money.Rate, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	r.UpdatedBy = adminID
+	r.UpdatedAt = time.Now().Format(time.RFC3339)
+	if err := u.rateRepo.SaveRate(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (u *moneyUsecase) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	return u.rateRepo.ListRates(ctx)
+}
+
+func (u *moneyUsecase) DeleteRate(ctx context.Context, from, to money.Currency) error {
+	return u.rateRepo.DeleteRate(ctx, from, to)
+}
@@ -0,0 +1,161 @@
+package moneyusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeRateRepository keeps rates in memory, keyed by pair.
+type fakeRateRepository struct {
+	rates map[[2]money.Currency]*money.Rate
+	err   error
+}
+
+func (r *fakeRateRepository) SaveRate(ctx context.Context, rate *money.Rate) error {
+	r.rates[[2]money.Currency{rate.From, rate.To}] = rate
+	return nil
+}
+
+func (r *fakeRateRepository) GetRate(ctx context.Context, from, to money.Currency) (*money.Rate, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	rate, ok := r.rates[[2]money.Currency{from, to}]
+	if !ok {
+		return nil, money.ErrRateNotFound
+	}
+	return rate, nil
+}
+
+func (r *fakeRateRepository) ListRates(ctx context.Context) ([]*money.Rate, error) {
+	rates := make([]*money.Rate, 0, len(r.rates))
+	for _, rate := range r.rates {
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func (r *fakeRateRepository) DeleteRate(ctx context.Context, from, to money.Currency) error {
+	delete(r.rates, [2]money.Currency{from, to})
+	return nil
+}
+
+type MoneyUsecaseTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	repo    *fakeRateRepository
+	usecase money.Usecase
+}
+
+func (suite *MoneyUsecaseTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.repo = &fakeRateRepository{rates: map[[2]money.Currency]*money.Rate{
+		{money.USD, money.ETB}: {From: money.USD, To: money.ETB, Rate: 50, UpdatedAt: "2025-01-01T00:00:00Z"},
+	}}
+	suite.usecase = NewMoneyUsecase(suite.repo)
+}
+
+func TestMoneyUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MoneyUsecaseTestSuite))
+}
+
+func (suite *MoneyUsecaseTestSuite) TestRate() {
+	tests := []struct {
+		name     string
+		from, to money.Currency
+		want     *money.RateSnapshot
+		wantErr  error
+	}{
+		{
+			name: "stored direction",
+			from: money.USD, to: money.ETB,
+			want: &money.RateSnapshot{From: money.USD, To: money.ETB, Rate: 50, AsOf: "2025-01-01T00:00:00Z"},
+		},
+		{
+			name: "inverse of the stored rate",
+			from: money.ETB, to: money.USD,
+			want: &money.RateSnapshot{From: money.ETB, To: money.USD, Rate: 0.02, AsOf: "2025-01-01T00:00:00Z"},
+		},
+		{
+			name: "same currency needs no rate",
+			from: money.ETB, to: money.ETB,
+		},
+		{
+			name: "missing rate",
+			from: money.EUR, to: money.ETB,
+			wantErr: money.ErrRateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := suite.usecase.Rate(suite.ctx, tt.from, tt.to)
+			if tt.wantErr != nil {
+				suite.ErrorIs(err, tt.wantErr)
+				return
+			}
+			suite.NoError(err)
+			suite.Equal(tt.want, got)
+		})
+	}
+}
+
+// TestRate_RepositoryError tests that a failed lookup is not mistaken for a missing rate
+func (suite *MoneyUsecaseTestSuite) TestRate_RepositoryError() {
+	suite.repo.err = errors.New("db down")
+
+	_, err := suite.usecase.Rate(suite.ctx, money.ETB, money.USD)
+
+	suite.EqualError(err, "db down")
+}
+
+func (suite *MoneyUsecaseTestSuite) TestConvert() {
+	tests := []struct {
+		name    string
+		amount  money.Money
+		to      money.Currency
+		want    money.Money
+		wantErr error
+	}{
+		{
+			name:   "stored direction",
+			amount: money.New(1250, money.USD),
+			to:     money.ETB,
+			want:   money.New(62500, money.ETB),
+		},
+		{
+			name:   "inverse of the stored rate",
+			amount: money.New(62500, money.ETB),
+			to:     money.USD,
+			want:   money.New(1250, money.USD),
+		},
+		{
+			name:   "same currency",
+			amount: money.New(999, money.EUR),
+			to:     money.EUR,
+			want:   money.New(999, money.EUR),
+		},
+		{
+			name:    "missing rate",
+			amount:  money.New(1000, money.EUR),
+			to:      money.USD,
+			wantErr: money.ErrRateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := suite.usecase.Convert(suite.ctx, tt.amount, tt.to)
+			if tt.wantErr != nil {
+				suite.ErrorIs(err, tt.wantErr)
+				return
+			}
+			suite.NoError(err)
+			suite.Equal(tt.want, got)
+		})
+	}
+}
@@ -4,22 +4,41 @@ import (
 	"context"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/transaction"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
-	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 )
 
 type OrderUseCase interface {
-	PurchaseBundle(ctx context.Context, bundleID, resellerID string) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error)
+	PurchaseBundle(ctx context.Context, bundleID, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error)
 	GetDashboardMetrics(ctx context.Context, supplierID string) (*order.DashboardMetrics, error)
 	GetOrderByID(ctx context.Context, orderID string) (*order.Order, error)
 	GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error)
-	GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, map[string]string, error)
-	GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, map[string]string, error)
-	GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, map[string]string, map[string]string, error)
-	PurchaseProduct(ctx context.Context, productID, consumerID string, totalPrice float64) (*order.Order, *payment.Payment, error)
+	GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error)
+	GetOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error)
+	GetOrdersByConsumer(ctx context.Context, consumerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, map[string]string, error)
+	PurchaseProducts(ctx context.Context, consumerID string, products []*product.Product, currency money.Currency, delivery shipping.Selection) ([]*order.Order, []*payment.Payment, error)
+	MarkOrderProcessing(ctx context.Context, orderID, sellerID string) (*order.Order, error)
+	MarkOrderShipped(ctx context.Context, orderID, sellerID, trackingNumber, carrier string) (*order.Order, error)
+	ConfirmDelivery(ctx context.Context, orderID, buyerID string) (*order.Order, error)
+	RecordDelivery(ctx context.Context, orderID string) (*order.Order, error)
+	CancelOrder(ctx context.Context, orderID, buyerID string) (*order.Order, error)
+	ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error)
+	RefundOrder(ctx context.Context, orderID string, amount money.Money) (*payment.Payment, error)
+	SettlePayment(ctx context.Context, p *payment.Payment) error
+	SettleRefund(ctx context.Context, orderID string) (*order.Order, error)
+	SettleFailure(ctx context.Context, p *payment.Payment) error
 }
 
 type orderUseCaseImpl struct {
@@ -29,5 +48,13 @@ type orderUseCaseImpl struct {
 	paymentRepo   payment.Repository
 	userRepo      user.Repository
 	prodRepo      product.Repository
+	publisher     event.Publisher
+	gateway       payment.Gateway
+	txManager     transaction.Manager
+	ledgerRepo    ledger.Repository
+	fees          fee.Quoter
+	converter     money.Converter
+	shipping      shipping.Quoter
+	dispatcher    shipment.Dispatcher
+	invoices      invoice.Issuer
 }
-
@@ -0,0 +1,392 @@
+package OrderUsecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CancelOrder lets the buyer cancel an order that has not shipped yet.
+func (uc *orderUseCaseImpl) CancelOrder(ctx context.Context, orderID, buyerID string) (*order.Order, error) {
+	o, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.BuyerID() != buyerID {
+		return nil, order.ErrNotOrderParty
+	}
+	if !order.CanTransition(o.Status, order.OrderStatusCanceled) {
+		return nil, fmt.Errorf("%w: %s to %s", order.ErrInvalidTransition, o.Status, order.OrderStatusCanceled)
+	}
+	return uc.cancelOrder(ctx, o)
+}
+
+// ForceCancelOrder cancels an order on an admin's behalf, whatever stage it reached.
+func (uc *orderUseCaseImpl) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	o, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.CanForceCancel(o.Status) {
+		return nil, fmt.Errorf("%w: %s to %s", order.ErrInvalidTransition, o.Status, order.OrderStatusCanceled)
+	}
+	return uc.cancelOrder(ctx, o)
+}
+
+// cancelOrder marks the order canceled, puts what it bought back on sale and refunds
+// the buyer. The database writes share a transaction, which only records the
+// reversals owed; the gateway is asked for the money back once it has committed, so
+// a retried transaction never moves money twice.
+func (uc *orderUseCaseImpl) cancelOrder(ctx context.Context, o *order.Order) (*order.Order, error) {
+	owed, err := uc.recordCancellation(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.reverse(ctx, owed)
+	uc.notifyOrderStatus(ctx, o, o.BuyerID(), o.SellerID())
+	return o, nil
+}
+
+// recordCancellation writes a cancellation in one transaction and returns the
+// reversals owed at the gateway. The order keeps its status if the writes fail.
+func (uc *orderUseCaseImpl) recordCancellation(ctx context.Context, o *order.Order) ([]reversal, error) {
+	from := o.Status
+	o.Status = order.OrderStatusCanceled
+	var owed []reversal
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.orderRepo.TransitionOrderStatus(txCtx, o, from); err != nil {
+			return err
+		}
+		if err := uc.restock(txCtx, o, from); err != nil {
+			return err
+		}
+		var err error
+		owed, err = uc.refundOrder(txCtx, o)
+		return err
+	})
+	if err != nil {
+		o.Status = from
+		return nil, err
+	}
+	return owed, nil
+}
+
+// SettleRefund records a refund the gateway made in full on its own, for example
+// from the provider's dashboard. What is left of the order's payments is refunded
+// and booked in the ledger, and an order that has not shipped is canceled and
+// restocked. Nothing is sent to the gateway, which already returned the money.
+func (uc *orderUseCaseImpl) SettleRefund(ctx context.Context, orderID string) (*order.Order, error) {
+	o, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.CanTransition(o.Status, order.OrderStatusCanceled) {
+		err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			_, err := uc.refundOrder(txCtx, o)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return o, nil
+	}
+
+	if _, err := uc.recordCancellation(ctx, o); err != nil {
+		return nil, err
+	}
+	uc.notifyOrderStatus(ctx, o, o.BuyerID(), o.SellerID())
+	return o, nil
+}
+
+// SettleFailure records a payment the gateway reports as failed before it was
+// captured. Its order fails, unless it went past processing, and what it bought
+// goes back on sale. Nothing is sent to the gateway, which holds no money for it.
+func (uc *orderUseCaseImpl) SettleFailure(ctx context.Context, p *payment.Payment) error {
+	o, err := uc.getOrder(ctx, p.OrderID)
+	if err != nil {
+		return err
+	}
+	from := o.Status
+	fails := order.CanTransition(from, order.Failed)
+	if fails {
+		o.Status = order.Failed
+	}
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.paymentRepo.UpdatePaymentStatus(txCtx, p.ID, payment.StatusFailed); err != nil {
+			return err
+		}
+		if !fails {
+			return nil
+		}
+		if err := uc.orderRepo.TransitionOrderStatus(txCtx, o, from); err != nil {
+			return err
+		}
+		return uc.restock(txCtx, o, from)
+	})
+	if err != nil {
+		o.Status = from
+		return err
+	}
+
+	if fails {
+		uc.notifyOrderStatus(ctx, o, o.BuyerID(), o.SellerID())
+	}
+	return nil
+}
+
+// restock returns the bundle or products of a canceled order, which was in status
+// from, to sale. A bundle also leaves the reseller's warehouse. Goods that already
+// reached the buyer stay with them and the buyer is only refunded: those of an
+// order that was delivered, and a bundle the reseller received, whose pieces may
+// have been listed by then.
+func (uc *orderUseCaseImpl) restock(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+	if from == order.Delivered || from == order.OrderStatusCompleted {
+		return nil
+	}
+	if o.BundleID != "" {
+		items, err := uc.warehouseRepo.GetItemsByBundle(ctx, o.BundleID)
+		if err != nil {
+			return err
+		}
+		var owned []*warehouse.WarehouseItem
+		for _, item := range items {
+			if item.ResellerID != o.ResellerID {
+				continue
+			}
+			if item.Status != warehouse.StatusPending {
+				return nil
+			}
+			owned = append(owned, item)
+		}
+		if err := uc.bundleRepo.ReleasePurchase(ctx, o.BundleID, o.ResellerID); err != nil {
+			return err
+		}
+		for _, item := range owned {
+			if err := uc.warehouseRepo.DeleteItem(ctx, item.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, productID := range o.ProductIDs {
+		if err := uc.prodRepo.Restock(ctx, productID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reversal is money owed back to a buyer at the gateway. Held funds are released
+// by voiding the charge and captured funds are refunded.
+type reversal struct {
+	chargeID string
+	void     bool
+	amount   money.Money
+	// key is the idempotency key, derived from the charge voided or the refund
+	// payment so that replaying the call cannot return the money twice.
+	key string
+}
+
+// refundOrder records a refund reversing what is left of each payment of the order,
+// platform fee included, and returns what is owed at the gateway. It only writes to
+// the database; the caller sends the reversals once the writes have committed.
+func (uc *orderUseCaseImpl) refundOrder(ctx context.Context, o *order.Order) ([]reversal, error) {
+	payments, err := uc.paymentRepo.GetPaymentsByOrderID(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	refundedSoFar := refundedAmounts(payments)
+
+	var owed []reversal
+	voided := make(map[string]bool)
+	for _, p := range payments {
+		if p.RefundOf != "" || !payment.CanTransition(p.Status, payment.StatusRefunded) {
+			continue
+		}
+		held := p.Status == payment.StatusPending || p.Status == payment.StatusAuthorized
+		var refund *payment.Payment
+		remaining := p.Amount.Sub(refundedSoFar[p.ID].amount)
+		if remaining.IsPositive() {
+			refund = refundFor(p, remaining, refundedSoFar[p.ID])
+			if err := uc.paymentRepo.RecordPayment(ctx, refund); err != nil {
+				return nil, err
+			}
+			if !held {
+				if err := uc.bookRefund(ctx, refund); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := uc.paymentRepo.UpdatePaymentStatus(ctx, p.ID, payment.StatusRefunded); err != nil {
+			return nil, err
+		}
+
+		if p.GatewayChargeID == "" {
+			continue
+		}
+		if held {
+			// A hold is voided once for the whole charge, however many payments it covers.
+			if !voided[p.GatewayChargeID] {
+				voided[p.GatewayChargeID] = true
+				owed = append(owed, reversal{chargeID: p.GatewayChargeID, void: true, key: "void-" + p.GatewayChargeID})
+			}
+		} else if refund != nil {
+			owed = append(owed, refundReversal(refund))
+		}
+	}
+	return owed, nil
+}
+
+// refundReversal is the gateway refund for a recorded refund payment.
+func refundReversal(refund *payment.Payment) reversal {
+	return reversal{
+		chargeID: refund.GatewayChargeID,
+		amount:   refund.Charged.Neg(),
+		key:      "refund-" + refund.ID,
+	}
+}
+
+// reverse returns the money owed at the gateway. The reversals are already recorded
+// when it runs, so a failure cannot be undone here; it is logged with the
+// idempotency key, under which the call can be replayed safely.
+func (uc *orderUseCaseImpl) reverse(ctx context.Context, owed []reversal) {
+	for _, r := range owed {
+		var err error
+		if r.void {
+			_, err = uc.gateway.Void(ctx, r.chargeID, r.key)
+		} else if r.amount.IsPositive() {
+			_, err = uc.gateway.Refund(ctx, r.chargeID, r.amount, r.key)
+		}
+		if err != nil {
+			log.Printf("Failed to return payment on charge %s (idempotency key %s): %v", r.chargeID, r.key, err)
+		}
+	}
+}
+
+// RefundOrder returns part of what the buyer paid without canceling the order.
+// The amount is in the order's listing currency; the buyer gets it back in the
+// currency they paid in, at the rate of their checkout. Refunding the whole
+// remaining amount marks the payment as refunded. The refund is recorded first and
+// sent to the gateway once recorded, keyed by the refund payment's ID.
+func (uc *orderUseCaseImpl) RefundOrder(ctx context.Context, orderID string, amount money.Money) (*payment.Payment, error) {
+	o, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := uc.paymentRepo.GetPaymentsByOrderID(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var paid *payment.Payment
+	for _, p := range payments {
+		if p.RefundOf == "" && p.Status != payment.StatusPending && p.Status != payment.StatusAuthorized &&
+			payment.CanTransition(p.Status, payment.StatusRefunded) {
+			paid = p
+			break
+		}
+	}
+	if paid == nil {
+		return nil, payment.ErrNothingToRefund
+	}
+	soFar := refundedAmounts(payments)[paid.ID]
+	remaining := paid.Amount.Sub(soFar.amount)
+	if amount.Currency != remaining.Currency || !amount.IsPositive() || amount.Cmp(remaining) > 0 {
+		return nil, fmt.Errorf("%w: %s of %s", payment.ErrInvalidRefundAmount, amount, remaining)
+	}
+
+	refund := refundFor(paid, amount, soFar)
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.paymentRepo.RecordPayment(txCtx, refund); err != nil {
+			return err
+		}
+		if err := uc.bookRefund(txCtx, refund); err != nil {
+			return err
+		}
+		if amount == remaining {
+			return uc.paymentRepo.UpdatePaymentStatus(txCtx, paid.ID, payment.StatusRefunded)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if paid.GatewayChargeID != "" {
+		uc.reverse(ctx, []reversal{refundReversal(refund)})
+	}
+	return refund, nil
+}
+
+// refundTotals is what has been refunded against a payment so far.
+type refundTotals struct {
+	amount  money.Money
+	fee     money.Money
+	charged money.Money
+}
+
+// refundedAmounts sums the refunds already recorded against each payment.
+func refundedAmounts(payments []*payment.Payment) map[string]refundTotals {
+	refunded := make(map[string]refundTotals)
+	for _, p := range payments {
+		if p.RefundOf != "" {
+			t := refunded[p.RefundOf]
+			t.amount = t.amount.Sub(p.Amount)
+			t.fee = t.fee.Sub(p.PlatformFee)
+			t.charged = t.charged.Sub(p.Charged)
+			refunded[p.RefundOf] = t
+		}
+	}
+	return refunded
+}
+
+// chargedFor is what the buyer paid for p. Payments recorded before checkout
+// conversion were charged in the listing currency.
+func chargedFor(p *payment.Payment) money.Money {
+	if p.Charged.Currency == "" {
+		return p.Amount
+	}
+	return p.Charged
+}
+
+// refundFor builds the payment that reverses amount of p, given what was already
+// refunded. The platform fee and the amount charged are reversed in proportion and
+// the seller's earning makes up the rest, so the refund stays balanced in the
+// ledger; the final refund takes exactly what is left. All amounts are negated so
+// that totals over a user's payments net out to what was actually kept.
+func refundFor(p *payment.Payment, amount money.Money, soFar refundTotals) *payment.Payment {
+	charged := chargedFor(p)
+	var fee, chargedBack money.Money
+	if amount == p.Amount.Sub(soFar.amount) {
+		fee = p.PlatformFee.Sub(soFar.fee)
+		chargedBack = charged.Sub(soFar.charged)
+	} else {
+		share := float64(amount.Amount) / float64(p.Amount.Amount)
+		fee = p.PlatformFee.Mul(share)
+		chargedBack = charged.Mul(share)
+	}
+	return &payment.Payment{
+		ID:              primitive.NewObjectID().Hex(),
+		FromUserID:      p.FromUserID,
+		ToUserID:        p.ToUserID,
+		Amount:          amount.Neg(),
+		PlatformFee:     fee.Neg(),
+		SellerEarning:   amount.Sub(fee).Neg(),
+		Charged:         chargedBack.Neg(),
+		Status:          payment.StatusRefunded,
+		ReferenceID:     p.ReferenceID,
+		OrderID:         p.OrderID,
+		GatewayChargeID: p.GatewayChargeID,
+		Type:            p.Type,
+		RefundOf:        p.ID,
+		CreatedAt:       time.Now().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,178 @@
+package OrderUsecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+)
+
+// MarkOrderProcessing lets the seller acknowledge an order and start preparing it.
+func (uc *orderUseCaseImpl) MarkOrderProcessing(ctx context.Context, orderID, sellerID string) (*order.Order, error) {
+	o, err := uc.orderForSeller(ctx, orderID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.transitionOrder(ctx, o, order.OrderStatusProcessing)
+}
+
+// MarkOrderShipped hands the order to a carrier. Carriers with an adapter book the
+// parcel and may issue the tracking number; for any other carrier the seller
+// must enter it.
+//
+// The order is marked shipped before the carrier is booked, so of two requests
+// to ship it only one sends a carrier to collect it. A failed booking puts the
+// order back.
+func (uc *orderUseCaseImpl) MarkOrderShipped(ctx context.Context, orderID, sellerID, trackingNumber, carrier string) (*order.Order, error) {
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	carrier = strings.TrimSpace(carrier)
+	if carrier == "" {
+		return nil, order.ErrMissingTracking
+	}
+
+	o, err := uc.orderForSeller(ctx, orderID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	unshipped := *o
+	o.TrackingNumber = trackingNumber
+	o.Carrier = carrier
+	o.ShippedAt = time.Now().Format(time.RFC3339)
+	if err := uc.applyTransition(ctx, o, order.Shipped); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.dispatcher.Dispatch(ctx, o); err != nil {
+		if undoErr := uc.orderRepo.TransitionOrderStatus(ctx, &unshipped, order.Shipped); undoErr != nil {
+			log.Printf("Failed to return order %s to %s after its booking failed: %v", o.ID, unshipped.Status, undoErr)
+		}
+		return nil, err
+	}
+	if o.TrackingNumber != trackingNumber || o.Carrier != carrier {
+		// The carrier issued the tracking number. The parcel is booked either way,
+		// so failing to record it is only logged.
+		if err := uc.orderRepo.TransitionOrderStatus(ctx, o, order.Shipped); err != nil {
+			log.Printf("Failed to record tracking number %s on order %s: %v", o.TrackingNumber, o.ID, err)
+		}
+	}
+	uc.notifyOrderStatus(ctx, o, o.BuyerID(), o.SellerID())
+	return o, nil
+}
+
+// ConfirmDelivery lets the buyer confirm that a shipped order arrived.
+func (uc *orderUseCaseImpl) ConfirmDelivery(ctx context.Context, orderID, buyerID string) (*order.Order, error) {
+	o, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.BuyerID() != buyerID {
+		return nil, order.ErrNotOrderParty
+	}
+	return uc.deliver(ctx, o)
+}
+
+// RecordDelivery marks the order delivered on the carrier's word. An order the
+// buyer already confirmed is returned unchanged.
+func (uc *orderUseCaseImpl) RecordDelivery(ctx context.Context, orderID string) (*order.Order, error) {
+	o, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status == order.Delivered || o.Status == order.OrderStatusCompleted {
+		return o, nil
+	}
+	return uc.deliver(ctx, o)
+}
+
+func (uc *orderUseCaseImpl) deliver(ctx context.Context, o *order.Order) (*order.Order, error) {
+	o.DeliveredAt = time.Now().Format(time.RFC3339)
+
+	// The seller's earning stops being held once the buyer has the goods, so the
+	// release is booked together with the status change.
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.applyTransition(txCtx, o, order.Delivered); err != nil {
+			return err
+		}
+		return uc.releaseEarnings(txCtx, o)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.notifyOrderStatus(ctx, o, o.BuyerID(), o.SellerID())
+	if o.BundleID != "" {
+		uc.announceDeliveredBundle(ctx, o)
+	}
+	return o, nil
+}
+
+// announceDeliveredBundle tells the reseller a delivered bundle is waiting in
+// their warehouse. It stays pending until they confirm they received it.
+func (uc *orderUseCaseImpl) announceDeliveredBundle(ctx context.Context, o *order.Order) {
+	items, err := uc.warehouseRepo.GetItemsByBundle(ctx, o.BundleID)
+	if err != nil {
+		log.Printf("Failed to load warehouse items for bundle %s: %v", o.BundleID, err)
+		return
+	}
+	for _, item := range items {
+		if item.ResellerID != o.ResellerID || item.Status != warehouse.StatusPending {
+			continue
+		}
+		uc.publisher.Publish(ctx, &event.Event{
+			Type:    event.WarehouseItemReady,
+			UserID:  item.ResellerID,
+			Payload: event.WarehouseItemPayload{ItemID: item.ID, BundleID: item.BundleID, Status: item.Status},
+		})
+	}
+}
+
+func (uc *orderUseCaseImpl) getOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	o, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	return o, nil
+}
+
+func (uc *orderUseCaseImpl) orderForSeller(ctx context.Context, orderID, sellerID string) (*order.Order, error) {
+	o, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.SellerID() != sellerID {
+		return nil, order.ErrNotOrderParty
+	}
+	return o, nil
+}
+
+// transitionOrder moves the order to the given status, persisting any fulfilment
+// details already set on it, and tells both parties.
+func (uc *orderUseCaseImpl) transitionOrder(ctx context.Context, o *order.Order, to order.OrderStatus) (*order.Order, error) {
+	if err := uc.applyTransition(ctx, o, to); err != nil {
+		return nil, err
+	}
+	uc.notifyOrderStatus(ctx, o, o.BuyerID(), o.SellerID())
+	return o, nil
+}
+
+func (uc *orderUseCaseImpl) applyTransition(ctx context.Context, o *order.Order, to order.OrderStatus) error {
+	from := o.Status
+	if !order.CanTransition(from, to) {
+		return fmt.Errorf("%w: %s to %s", order.ErrInvalidTransition, from, to)
+	}
+
+	o.Status = to
+	if err := uc.orderRepo.TransitionOrderStatus(ctx, o, from); err != nil {
+		o.Status = from
+		return err
+	}
+	return nil
+}
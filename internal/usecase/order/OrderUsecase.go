@@ -5,23 +5,29 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math/rand"
 	"sort"
+	"strings"
 	"time"
 
-	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/admin"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/transaction"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
-	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-
-
-func NewOrderUsecase(bRepo bundle.Repository, oRepo order.Repository, wRepo warehouse.Repository, pRepo payment.Repository, uRepo user.Repository, prodRepo product.Repository) *orderUseCaseImpl {
+func NewOrderUsecase(bRepo bundle.Repository, oRepo order.Repository, wRepo warehouse.Repository, pRepo payment.Repository, uRepo user.Repository, prodRepo product.Repository, publisher event.Publisher, gateway payment.Gateway, txManager transaction.Manager, ledgerRepo ledger.Repository, fees fee.Quoter, converter money.Converter, shipping shipping.Quoter, dispatcher shipment.Dispatcher, invoices invoice.Issuer) *orderUseCaseImpl {
 	return &orderUseCaseImpl{
 		bundleRepo:    bRepo,
 		orderRepo:     oRepo,
@@ -29,40 +35,191 @@ func NewOrderUsecase(bRepo bundle.Repository, oRepo order.Repository, wRepo ware
 		paymentRepo:   pRepo,
 		userRepo:      uRepo,
 		prodRepo:      prodRepo,
+		publisher:     publisher,
+		gateway:       gateway,
+		txManager:     txManager,
+		ledgerRepo:    ledgerRepo,
+		fees:          fees,
+		converter:     converter,
+		shipping:      shipping,
+		dispatcher:    dispatcher,
+		invoices:      invoices,
 	}
 }
 
-func simulateStripePayment(_ float64) (string, error) {
-	time.Sleep(500 * time.Millisecond)
-	return fmt.Sprintf("ch_%d", rand.Intn(1000000)), nil
+// notifyOrderStatus pushes the order's current status to each of the given users.
+func (uc *orderUseCaseImpl) notifyOrderStatus(ctx context.Context, o *order.Order, userIDs ...string) {
+	for _, userID := range userIDs {
+		uc.publisher.Publish(ctx, &event.Event{
+			Type:    event.OrderStatusChanged,
+			UserID:  userID,
+			Payload: event.OrderStatusPayload{OrderID: o.ID, Status: string(o.Status)},
+		})
+	}
 }
 
-func processPayment(total float64) (fee float64, net float64, err error) {
-	fee = total * 0.02
-	net = total - fee
-	_, err = simulateStripePayment(total)
-	return
+// feeSplit adds up the fee quotes for the items settled by one payment.
+type feeSplit struct {
+	fee   money.Money
+	net   money.Money
+	rules []payment.FeeRuleRef
 }
 
-func (uc *orderUseCaseImpl) PurchaseBundle(ctx context.Context, bundleID, resellerID string) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
-	b, err := uc.bundleRepo.GetBundleByID(ctx, bundleID)
+func (s *feeSplit) add(q *fee.Quote) {
+	s.fee = s.fee.Add(q.Fee)
+	s.net = s.net.Add(q.Net)
+	ref := payment.FeeRuleRef{RuleID: q.RuleID, Version: q.RuleVersion}
+	for _, r := range s.rules {
+		if r == ref {
+			return
+		}
+	}
+	s.rules = append(s.rules, ref)
+}
+
+// quoteFee prices the platform fee for a single sale.
+func (uc *orderUseCaseImpl) quoteFee(ctx context.Context, pType payment.PaymentType, sellerID, category string, amount money.Money) (*feeSplit, error) {
+	q, err := uc.fees.Quote(ctx, fee.Sale{Type: pType, SellerID: sellerID, Category: category, Amount: amount})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, fmt.Errorf("pricing platform fee: %w", err)
 	}
+	split := &feeSplit{}
+	split.add(q)
+	return split, nil
+}
 
-	availables, err := uc.bundleRepo.ListAvailableBundles(ctx)
+// checkoutRate looks up the rate for charging a price listed in one currency in
+// another. The snapshot is stored on the order so refunds convert the same way.
+func (uc *orderUseCaseImpl) checkoutRate(ctx context.Context, listing, charge money.Currency) (*money.RateSnapshot, error) {
+	rate, err := uc.converter.Rate(ctx, listing, charge)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, fmt.Errorf("converting %s to %s: %w", listing, charge, err)
+	}
+	return rate, nil
+}
+
+// quoteShipping prices the delivery the buyer picked and converts the courier's
+// price into the listing currency, so an order's amounts share one currency.
+func (uc *orderUseCaseImpl) quoteShipping(ctx context.Context, buyerID string, delivery shipping.Selection, items int, listing money.Currency) (*shipping.Quote, money.Money, error) {
+	q, err := uc.shipping.Quote(ctx, buyerID, delivery, items)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	rate, err := uc.checkoutRate(ctx, q.Cost.Currency, listing)
+	if err != nil {
+		return nil, money.Money{}, err
 	}
+	return q, rate.Convert(q.Cost), nil
+}
 
-	found := false
-	for _, available := range availables {
-		if available.ID == b.ID {
-			found = true
-			break
+// authorizePayment places a hold for the purchase. The hold must be captured once
+// the purchase is recorded, or voided if recording it fails.
+func (uc *orderUseCaseImpl) authorizePayment(ctx context.Context, buyerID string, amount money.Money, description string, metadata map[string]string) (*payment.Charge, error) {
+	charge, err := uc.gateway.Authorize(ctx, payment.AuthorizeRequest{
+		Amount:      amount,
+		CustomerID:  buyerID,
+		Description: description,
+		Metadata:    metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("payment authorization failed: %w", err)
+	}
+	return charge, nil
+}
+
+// capturePayment settles an authorized hold and then the payments recorded for it.
+// If the gateway will not capture, the purchase is undone: canceling its orders
+// puts what they bought back on sale and voids the hold.
+func (uc *orderUseCaseImpl) capturePayment(ctx context.Context, charge *payment.Charge, orders []*order.Order, payments ...*payment.Payment) error {
+	if _, err := uc.gateway.Capture(ctx, charge.ID); err != nil {
+		// The hold is released once for the whole charge, then each order it paid
+		// for is canceled without voiding it again.
+		uc.releasePayment(ctx, charge)
+		for _, o := range orders {
+			if _, cancelErr := uc.recordCancellation(ctx, o); cancelErr != nil {
+				log.Printf("Failed to cancel order %s after capture failed: %v", o.ID, cancelErr)
+				continue
+			}
+			uc.notifyOrderStatus(ctx, o, o.BuyerID(), o.SellerID())
 		}
+		return fmt.Errorf("payment capture failed: %w", err)
+	}
+	for _, p := range payments {
+		if err := uc.SettlePayment(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SettlePayment marks a payment whose charge the gateway captured as captured and
+// books it in the ledger. A purchase settles its payments right after capturing;
+// the gateway's webhook settles them again, and the ledger skips the repeat.
+func (uc *orderUseCaseImpl) SettlePayment(ctx context.Context, p *payment.Payment) error {
+	if err := uc.paymentRepo.UpdatePaymentStatus(ctx, p.ID, payment.StatusCaptured); err != nil {
+		return err
+	}
+	p.Status = payment.StatusCaptured
+	return uc.bookLedger(ctx, ledger.ForPurchase(p))
+}
+
+// bookLedger records a ledger transaction. Transactions that were already booked,
+// for example by a retried request, are skipped.
+func (uc *orderUseCaseImpl) bookLedger(ctx context.Context, t *ledger.Transaction) error {
+	if err := uc.ledgerRepo.RecordTransaction(ctx, t); err != nil && !errors.Is(err, ledger.ErrDuplicateTransaction) {
+		return fmt.Errorf("recording ledger transaction %s: %w", t.ID, err)
+	}
+	return nil
+}
+
+// bookRefund records a refund in the ledger, taking the seller's share from the
+// earning still held for the order before their available balance.
+func (uc *orderUseCaseImpl) bookRefund(ctx context.Context, refund *payment.Payment) error {
+	held, err := uc.ledgerRepo.OrderAccountBalance(ctx, ledger.AccountSellerPending, refund.ToUserID, refund.OrderID)
+	if err != nil {
+		return err
+	}
+	return uc.bookLedger(ctx, ledger.ForRefund(refund, held.Get(refund.Amount.Currency)))
+}
+
+// releaseEarnings makes the seller's held earning for a delivered order available
+// for payout.
+func (uc *orderUseCaseImpl) releaseEarnings(ctx context.Context, o *order.Order) error {
+	sellerID := o.SellerID()
+	held, err := uc.ledgerRepo.OrderAccountBalance(ctx, ledger.AccountSellerPending, sellerID, o.ID)
+	if err != nil {
+		return err
+	}
+	amount := held.Get(o.TotalPrice.Currency)
+	if !amount.IsPositive() {
+		return nil
+	}
+	return uc.bookLedger(ctx, ledger.ForRelease(sellerID, o.ID, amount))
+}
+
+// releasePayment voids a hold after the purchase could not be completed.
+func (uc *orderUseCaseImpl) releasePayment(ctx context.Context, charge *payment.Charge) {
+	if _, err := uc.gateway.Void(ctx, charge.ID, "void-"+charge.ID); err != nil {
+		log.Printf("Failed to void charge %s: %v", charge.ID, err)
 	}
-	if !found {
+}
+
+// PurchaseBundle buys a bundle for a reseller and ships it as delivery selects. The
+// reseller is charged for the bundle and its shipping in currency, converted from
+// the bundle's listing currency, or in the listing currency itself when currency
+// is empty.
+func (uc *orderUseCaseImpl) PurchaseBundle(ctx context.Context, bundleID, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	b, err := uc.bundleRepo.GetBundleByID(ctx, bundleID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Cheap early exit before a payment is authorized. The authoritative check is
+	// the conditional claim inside the transaction below.
+	if b.Status != "available" {
+		if b.Status == "purchased" {
+			return nil, nil, nil, bundle.ErrBundleAlreadySold
+		}
 		return nil, nil, nil, errors.New("bundle not available")
 	}
 
@@ -70,49 +227,114 @@ func (uc *orderUseCaseImpl) PurchaseBundle(ctx context.Context, bundleID, resell
 		return nil, nil, nil, errors.New("reseller cannot purchase their own bundle")
 	}
 
-	fee, net, err := processPayment(b.Price)
+	fees, err := uc.quoteFee(ctx, payment.B2B, b.SupplierID, b.Type, b.Price)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	quote, shippingCost, err := uc.quoteShipping(ctx, resellerID, delivery, b.Quantity, b.Price.Currency)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if currency == "" {
+		currency = b.Price.Currency
+	}
+	rate, err := uc.checkoutRate(ctx, b.Price.Currency, currency)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	charge, err := uc.authorizePayment(ctx, resellerID, rate.Convert(b.Price.Add(shippingCost)), "Bundle "+b.Title, map[string]string{"bundle_id": b.ID})
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	order := &order.Order{
-		ID:          primitive.NewObjectID().Hex(),
-		BundleID:    b.ID,
-		ResellerID:  resellerID,
-		SupplierID:  b.SupplierID,
-		TotalPrice:  b.Price,
-		PlatformFee: fee,
-		Status:      order.OrderStatusCompleted,
-		CreatedAt:   time.Now().Format(time.RFC3339),
+	var (
+		o    *order.Order
+		p    *payment.Payment
+		item *warehouse.WarehouseItem
+	)
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var txErr error
+		o, p, item, txErr = uc.recordBundlePurchase(txCtx, b, resellerID, charge, rate, fees, quote, shippingCost)
+		return txErr
+	})
+	if err != nil {
+		uc.releasePayment(ctx, charge)
+		return nil, nil, nil, err
 	}
+
+	if err := uc.capturePayment(ctx, charge, []*order.Order{o}, p); err != nil {
+		return nil, nil, nil, err
+	}
+
+	uc.publisher.Publish(ctx, &event.Event{
+		Type:   event.BundleSold,
+		UserID: b.SupplierID,
+		Payload: event.BundleSoldPayload{
+			BundleID:   b.ID,
+			OrderID:    o.ID,
+			ResellerID: resellerID,
+			Price:      b.Price,
+		},
+	})
+	uc.notifyOrderStatus(ctx, o, resellerID)
+
+	return o, p, item, nil
+}
+
+// recordBundlePurchase claims the bundle and writes the order, payment, invoice and
+// warehouse entry for an authorized purchase. It runs inside a transaction, so a
+// failure at any step leaves none of these writes behind.
+func (uc *orderUseCaseImpl) recordBundlePurchase(ctx context.Context, b *bundle.Bundle, resellerID string, charge *payment.Charge, rate *money.RateSnapshot, fees *feeSplit, quote *shipping.Quote, shippingCost money.Money) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	if err := uc.bundleRepo.MarkAsPurchased(ctx, b.ID, resellerID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	order := &order.Order{
+		ID:           primitive.NewObjectID().Hex(),
+		BundleID:     b.ID,
+		ResellerID:   resellerID,
+		SupplierID:   b.SupplierID,
+		TotalPrice:   b.Price,
+		PlatformFee:  fees.fee,
+		Status:       order.Pending,
+		CreatedAt:    time.Now().Format(time.RFC3339),
+		Charged:      charge.Amount,
+		ExchangeRate: rate,
+	}
+	order.SetShipping(quote, shippingCost)
 	if err := uc.orderRepo.CreateOrder(ctx, order); err != nil {
 		return nil, nil, nil, err
 	}
 
 	payment := &payment.Payment{
-		FromUserID:    resellerID,
-		ToUserID:      b.SupplierID,
-		Amount:        b.Price,
-		PlatformFee:   fee,
-		SellerEarning: net,
-		Status:        "Paid",
-		ReferenceID:   b.ID,
-		Type:          payment.B2B,
-		CreatedAt:     time.Now().Format(time.RFC3339),
+		ID:              primitive.NewObjectID().Hex(),
+		FromUserID:      resellerID,
+		ToUserID:        b.SupplierID,
+		Amount:          b.Price.Add(shippingCost),
+		PlatformFee:     fees.fee,
+		SellerEarning:   fees.net.Add(shippingCost),
+		Shipping:        shippingCost,
+		Charged:         charge.Amount,
+		Status:          payment.StatusAuthorized,
+		ReferenceID:     b.ID,
+		OrderID:         order.ID,
+		GatewayChargeID: charge.ID,
+		Type:            payment.B2B,
+		FeeRules:        fees.rules,
+		CreatedAt:       time.Now().Format(time.RFC3339),
 	}
 	if err := uc.paymentRepo.RecordPayment(ctx, payment); err != nil {
 		return nil, nil, nil, err
 	}
-
-	if err := uc.bundleRepo.MarkAsPurchased(ctx, b.ID, resellerID); err != nil {
-		return nil, nil, nil, err
+	if _, err := uc.invoices.IssueInvoice(ctx, order); err != nil {
+		return nil, nil, nil, fmt.Errorf("issuing invoice: %w", err)
 	}
 
 	warehouseItem := &warehouse.WarehouseItem{
 		ID:                 primitive.NewObjectID().Hex(),
 		BundleID:           b.ID,
 		ResellerID:         resellerID,
-		Status:             "pending",
+		Status:             warehouse.StatusPending,
 		DeclaredRating:     b.DeclaredRating,
 		RemainingItemCount: b.RemainingItemCount,
 		Grade:              b.Grade,
@@ -126,26 +348,21 @@ func (uc *orderUseCaseImpl) PurchaseBundle(ctx context.Context, bundleID, resell
 		return nil, nil, nil, err
 	}
 
-	go func(itemID string) {
-		time.Sleep(3 * time.Minute)
-		if err := uc.warehouseRepo.MarkItemAsListed(context.Background(), itemID); err != nil {
-			fmt.Println("Failed to mark warehouse item as listed:", err)
-		}
-	}(warehouseItem.ID)
-
 	return order, payment, warehouseItem, nil
 }
 
 func (uc *orderUseCaseImpl) GetDashboardMetrics(ctx context.Context, supplierID string) (*order.DashboardMetrics, error) {
-	bundles, err := uc.bundleRepo.ListBundles(ctx, supplierID)
+	bundles, err := pagination.Collect(func(req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+		return uc.bundleRepo.ListBundles(ctx, supplierID, req)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	totalSales := 0.0
+	totalSales := money.Totals{}
+	bestSelling := money.Totals{}
 	activeCount := 0
 	soldCount := 0
-	bestSelling := 0.0
 	var activeBundles []*bundle.Bundle
 
 	userData, err := uc.userRepo.GetByID(ctx, supplierID)
@@ -155,10 +372,10 @@ func (uc *orderUseCaseImpl) GetDashboardMetrics(ctx context.Context, supplierID
 
 	for _, b := range bundles {
 		if b.Status == "purchased" {
-			totalSales += b.Price
+			totalSales.Add(b.Price)
 			soldCount++
-			if b.Price > bestSelling {
-				bestSelling = b.Price
+			if b.Price.Amount > bestSelling[b.Price.Currency] {
+				bestSelling[b.Price.Currency] = b.Price.Amount
 			}
 		} else if b.Status == "available" {
 			activeCount++
@@ -171,84 +388,63 @@ func (uc *orderUseCaseImpl) GetDashboardMetrics(ctx context.Context, supplierID
 	})
 
 	return &order.DashboardMetrics{
-		TotalSales:         totalSales,
+		TotalSales:         totalSales.List(),
 		ActiveBundles:      activeBundles,
 		PerformanceMetrics: order.PerformanceMetrics{TotalBundlesListed: len(bundles), ActiveCount: activeCount, SoldCount: soldCount},
 		Rating:             userData.TrustScore,
-		BestSelling:        bestSelling,
+		BestSelling:        bestSelling.List(),
 	}, nil
 }
 
 func (uc *orderUseCaseImpl) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
-	fmt.Printf("\n🔍 Starting GetResellerMetrics for reseller: %s\n", resellerID)
-
 	// Get purchased bundles
-	bundles, err := uc.bundleRepo.ListPurchasedByReseller(ctx, resellerID)
+	bundles, err := pagination.Collect(func(req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+		return uc.bundleRepo.ListPurchasedByReseller(ctx, resellerID, req)
+	})
 	if err != nil {
-		fmt.Printf("❌ Error getting bundles: %v\n", err)
 		return nil, err
 	}
-	fmt.Printf("📦 Found %d purchased bundles\n", len(bundles))
 
 	// Get reseller info
 	reseller, err := uc.userRepo.GetByID(ctx, resellerID)
 	if err != nil {
-		fmt.Printf("❌ Error getting reseller info: %v\n", err)
 		return nil, err
 	}
-	fmt.Printf("👤 Reseller found: %s\n", reseller.Username)
-	fmt.Printf("📊 Reseller Trust Data:\n")
-	fmt.Printf("  - Trust Score: %d\n", reseller.TrustScore)
-	fmt.Printf("  - Trust Rated Count: %d\n", reseller.TrustRatedCount)
-	fmt.Printf("  - Trust Total Error: %f\n", reseller.TrustTotalError)
-	fmt.Printf("  - Is Blacklisted: %v\n", reseller.IsBlacklisted)
 
 	// Get sold products directly from product collection
 	soldProducts, err := uc.prodRepo.GetSoldProductsByReseller(ctx, resellerID)
 	if err != nil {
-		fmt.Printf("❌ Error getting sold products: %v\n", err)
 		return nil, err
 	}
-	fmt.Printf("🛍️ Found %d sold products\n", len(soldProducts))
 
-	// Find best selling item
-	bestSelling := 0.0
+	// Find best selling item in each currency
+	bestSelling := money.Totals{}
 	for _, product := range soldProducts {
-		if product.Price > bestSelling {
-			bestSelling = product.Price
-			fmt.Printf("💰 New best selling item found: %s with price %.2f\n", product.Title, product.Price)
+		if product.Price.Amount > bestSelling[product.Price.Currency] {
+			bestSelling[product.Price.Currency] = product.Price.Amount
 		}
 	}
-	fmt.Printf("💰 Best selling item price: %.2f\n", bestSelling)
 
 	metrics := &order.ResellerMetrics{
 		TotalBoughtBundles: len(bundles),
 		TotalItemsSold:     len(soldProducts),
 		Rating:             reseller.TrustScore,
-		BestSelling:        bestSelling,
+		BestSelling:        bestSelling.List(),
 		BoughtBundles:      bundles,
 	}
-
-	fmt.Printf("📊 Final Metrics:\n")
-	fmt.Printf("  - Total Bought Bundles: %d\n", metrics.TotalBoughtBundles)
-	fmt.Printf("  - Total Items Sold: %d\n", metrics.TotalItemsSold)
-	fmt.Printf("  - Rating (Trust Score): %d\n", metrics.Rating)
-	fmt.Printf("  - Best Selling: %.2f\n", metrics.BestSelling)
-	fmt.Printf("✅ GetResellerMetrics completed\n\n")
-
 	return metrics, nil
 }
 
-func (uc *orderUseCaseImpl) GetSoldBundleHistory(ctx context.Context, supplierID string) ([]*order.Order, map[string]string, error) {
+func (uc *orderUseCaseImpl) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
 	log.Printf("Getting sold bundle history for supplier: %s", supplierID)
-	orders, err := uc.orderRepo.GetOrdersBySupplier(ctx, supplierID)
+	page, err := uc.orderRepo.ListOrdersBySupplier(ctx, supplierID, req)
 	if err != nil {
 		log.Printf("Error getting orders: %v", err)
 		return nil, nil, err
 	}
 
 	userNames := make(map[string]string)
-	for _, order := range orders {
+	for _, order := range page.Items {
 		if order.ResellerID != "" {
 			user, err := uc.userRepo.GetByID(ctx, order.ResellerID)
 			if err != nil {
@@ -259,100 +455,199 @@ func (uc *orderUseCaseImpl) GetSoldBundleHistory(ctx context.Context, supplierID
 		}
 	}
 
-	log.Printf("Found %d orders for supplier %s", len(orders), supplierID)
-	return orders, userNames, nil
+	log.Printf("Found %d orders for supplier %s", len(page.Items), supplierID)
+	return page, userNames, nil
 }
 
-func (uc *orderUseCaseImpl) GetAdminDashboardMetrics(ctx context.Context) (*admin.Metrics, error) {
-	totalBundles, err := uc.bundleRepo.CountBundles(ctx)
-	if err != nil {
-		return nil, err
+func (uc *orderUseCaseImpl) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	return uc.orderRepo.GetOrderByID(ctx, orderID)
+}
+
+// PurchaseProducts buys several products the consumer has reserved. The consumer is
+// charged once for the whole basket, and one order with its own payment record is
+// created per reseller and listing currency. Claiming the products and writing the
+// orders, payments and invoices happen in a single transaction, so either every
+// product is bought or none is.
+//
+// The basket is charged in currency, or when that is empty in the currency the
+// products are listed in, falling back to money.DefaultCurrency for a basket that
+// mixes currencies. Every order ships separately to the address in delivery and
+// carries its own shipping cost.
+func (uc *orderUseCaseImpl) PurchaseProducts(ctx context.Context, consumerID string, products []*product.Product, currency money.Currency, delivery shipping.Selection) ([]*order.Order, []*payment.Payment, error) {
+	if len(products) == 0 {
+		return nil, nil, errors.New("no products to purchase")
+	}
+
+	groups := groupProducts(products)
+	if currency == "" {
+		currency = groups[0].currency
+		for _, g := range groups {
+			if g.currency != currency {
+				currency = money.DefaultCurrency
+				break
+			}
+		}
 	}
 
-	totalUsers, err := uc.userRepo.CountActiveUsers(ctx)
-	if err != nil {
-		return nil, err
+	total := money.New(0, currency)
+	productIDs := make([]string, 0, len(products))
+	for _, g := range groups {
+		quote, shippingCost, err := uc.quoteShipping(ctx, consumerID, delivery, len(g.products), g.currency)
+		if err != nil {
+			return nil, nil, err
+		}
+		g.shipping = quote
+		g.shippingCost = shippingCost
+		rate, err := uc.checkoutRate(ctx, g.currency, currency)
+		if err != nil {
+			return nil, nil, err
+		}
+		g.rate = rate
+		g.charged = rate.Convert(g.subtotal.Add(shippingCost))
+		total = total.Add(g.charged)
+		for _, p := range g.products {
+			productIDs = append(productIDs, p.ID)
+		}
 	}
 
-	totalSales, platformFees, err := uc.paymentRepo.GetAllPlatformFees(ctx)
+	charge, err := uc.authorizePayment(ctx, consumerID, total, fmt.Sprintf("Checkout of %d items", len(products)), map[string]string{"product_ids": strings.Join(productIDs, ",")})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	skippedClothes, err := uc.warehouseRepo.CountByStatus(ctx, "skipped")
+	var (
+		orders   []*order.Order
+		payments []*payment.Payment
+	)
+	err = uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var txErr error
+		orders, payments, txErr = uc.recordProductPurchase(txCtx, consumerID, groups, charge)
+		return txErr
+	})
 	if err != nil {
-		return nil, err
+		uc.releasePayment(ctx, charge)
+		return nil, nil, err
 	}
 
-	return &admin.Metrics{
-		TotalBundles:    totalBundles,
-		TotalUsers:      totalUsers,
-		TotalSales:      totalSales,
-		RevenueFromFees: platformFees,
-		SkippedClothes:  skippedClothes,
-	}, nil
+	if err := uc.capturePayment(ctx, charge, orders, payments...); err != nil {
+		return nil, nil, err
+	}
+
+	for _, o := range orders {
+		uc.notifyOrderStatus(ctx, o, consumerID, o.ResellerID)
+	}
+	return orders, payments, nil
 }
 
-func (uc *orderUseCaseImpl) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
-	return uc.orderRepo.GetOrderByID(ctx, orderID)
+// productGroup is the part of a basket that becomes one order: the products of one
+// reseller listed in one currency.
+type productGroup struct {
+	resellerID string
+	currency   money.Currency
+	products   []*product.Product
+	subtotal   money.Money
+	charged    money.Money
+	rate       *money.RateSnapshot
+
+	shipping     *shipping.Quote
+	shippingCost money.Money
 }
 
-func (uc *orderUseCaseImpl) PurchaseProduct(ctx context.Context, productID, consumerID string, totalPrice float64) (*order.Order, *payment.Payment, error) {
-	// Get product details to get reseller ID
-	prod, err := uc.prodRepo.GetProductByID(ctx, productID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get product details: %w", err)
+// groupProducts splits a basket into orders, keeping the order products first
+// appear in.
+func groupProducts(products []*product.Product) []*productGroup {
+	var groups []*productGroup
+	index := make(map[string]*productGroup)
+	for _, p := range products {
+		key := p.ResellerID.Hex() + "/" + string(p.Price.Currency)
+		g, ok := index[key]
+		if !ok {
+			g = &productGroup{resellerID: p.ResellerID.Hex(), currency: p.Price.Currency}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.products = append(g.products, p)
+		g.subtotal = g.subtotal.Add(p.Price)
 	}
+	return groups
+}
 
-	// Calculate platform fee and net amount
-	platformFee := totalPrice * 0.02
-	netAmount := totalPrice - platformFee
-
-	// Create order
-	order := &order.Order{
-		ID:          primitive.NewObjectID().Hex(),
-		ResellerID:  prod.ResellerID.Hex(),
-		SupplierID:  "", // Not needed for product orders
-		BundleID:    "", // Not needed for product orders
-		ConsumerID:  consumerID,
-		ProductIDs:  []string{productID},
-		TotalPrice:  totalPrice,
-		PlatformFee: platformFee,
-		Status:      order.OrderStatusCompleted,
-		CreatedAt:   time.Now().Format(time.RFC3339),
-	}
+// recordProductPurchase marks the products as sold and writes one order and payment
+// per group. It is meant to run inside a transaction.
+func (uc *orderUseCaseImpl) recordProductPurchase(ctx context.Context, consumerID string, groups []*productGroup, charge *payment.Charge) ([]*order.Order, []*payment.Payment, error) {
+	now := time.Now().Format(time.RFC3339)
+	orders := make([]*order.Order, 0, len(groups))
+	payments := make([]*payment.Payment, 0, len(groups))
+	for _, g := range groups {
+		var ids []string
+		fees := &feeSplit{}
+		for _, p := range g.products {
+			if err := uc.prodRepo.MarkAsSold(ctx, p.ID, consumerID); err != nil {
+				return nil, nil, fmt.Errorf("product %s: %w", p.ID, err)
+			}
+			ids = append(ids, p.ID)
+			q, err := uc.fees.Quote(ctx, fee.Sale{Type: payment.B2C, SellerID: g.resellerID, Category: p.Type, Amount: p.Price})
+			if err != nil {
+				return nil, nil, fmt.Errorf("pricing platform fee: %w", err)
+			}
+			fees.add(q)
+		}
 
-	if err := uc.orderRepo.CreateOrder(ctx, order); err != nil {
-		return nil, nil, err
-	}
+		o := &order.Order{
+			ID:           primitive.NewObjectID().Hex(),
+			ResellerID:   g.resellerID,
+			ConsumerID:   consumerID,
+			ProductIDs:   ids,
+			TotalPrice:   g.subtotal,
+			PlatformFee:  fees.fee,
+			Status:       order.Pending,
+			CreatedAt:    now,
+			Charged:      g.charged,
+			ExchangeRate: g.rate,
+		}
+		o.SetShipping(g.shipping, g.shippingCost)
+		if err := uc.orderRepo.CreateOrder(ctx, o); err != nil {
+			return nil, nil, err
+		}
 
-	// Create payment record
-	payment := &payment.Payment{
-		FromUserID:    consumerID,
-		ToUserID:      prod.ResellerID.Hex(),
-		Amount:        totalPrice,
-		PlatformFee:   platformFee,
-		SellerEarning: netAmount,
-		Status:        "Paid",
-		ReferenceID:   productID,
-		Type:          payment.B2C,
-		CreatedAt:     time.Now().Format(time.RFC3339),
-	}
+		p := &payment.Payment{
+			ID:              primitive.NewObjectID().Hex(),
+			FromUserID:      consumerID,
+			ToUserID:        g.resellerID,
+			Amount:          g.subtotal.Add(g.shippingCost),
+			PlatformFee:     fees.fee,
+			SellerEarning:   fees.net.Add(g.shippingCost),
+			Shipping:        g.shippingCost,
+			Charged:         g.charged,
+			Status:          payment.StatusAuthorized,
+			ReferenceID:     strings.Join(ids, ","),
+			OrderID:         o.ID,
+			GatewayChargeID: charge.ID,
+			Type:            payment.B2C,
+			FeeRules:        fees.rules,
+			CreatedAt:       now,
+		}
+		if err := uc.paymentRepo.RecordPayment(ctx, p); err != nil {
+			return nil, nil, err
+		}
+		if _, err := uc.invoices.IssueInvoice(ctx, o); err != nil {
+			return nil, nil, fmt.Errorf("issuing invoice: %w", err)
+		}
 
-	if err := uc.paymentRepo.RecordPayment(ctx, payment); err != nil {
-		return nil, nil, err
+		orders = append(orders, o)
+		payments = append(payments, p)
 	}
-
-	return order, payment, nil
+	return orders, payments, nil
 }
-func (uc *orderUseCaseImpl) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, map[string]string, error) {
-	orders, err := uc.orderRepo.GetOrdersByReseller(ctx, resellerID)
+
+func (uc *orderUseCaseImpl) GetOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	page, err := uc.orderRepo.ListOrdersByReseller(ctx, resellerID, req)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	userNames := make(map[string]string)
-	for _, order := range orders {
+	for _, order := range page.Items {
 		if len(order.ProductIDs) > 0 { // Sold order
 			if order.ConsumerID != "" {
 				user, err := uc.userRepo.GetByID(ctx, order.ConsumerID)
@@ -370,15 +665,12 @@ func (uc *orderUseCaseImpl) GetOrdersByReseller(ctx context.Context, resellerID
 		}
 	}
 
-	return orders, userNames, nil
+	return page, userNames, nil
 }
 
-func (uc *orderUseCaseImpl) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, map[string]string, map[string]string, error) {
-	fmt.Printf("🔍 Getting orders for consumer: %s\n", consumerID)
-	
-	orders, err := uc.orderRepo.GetOrdersByConsumer(ctx, consumerID)
+func (uc *orderUseCaseImpl) GetOrdersByConsumer(ctx context.Context, consumerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, map[string]string, error) {
+	page, err := uc.orderRepo.ListOrdersByConsumer(ctx, consumerID, "", req)
 	if err != nil {
-		fmt.Printf("❌ Error getting consumer orders: %v\n", err)
 		return nil, nil, nil, fmt.Errorf("failed to get consumer orders: %w", err)
 	}
 
@@ -387,7 +679,7 @@ func (uc *orderUseCaseImpl) GetOrdersByConsumer(ctx context.Context, consumerID
 
 	// Get unique product IDs
 	productIDs := make(map[string]bool)
-	for _, order := range orders {
+	for _, order := range page.Items {
 		if order.ResellerID != "" {
 			user, err := uc.userRepo.GetByID(ctx, order.ResellerID)
 			if err == nil && user != nil {
@@ -407,7 +699,5 @@ func (uc *orderUseCaseImpl) GetOrdersByConsumer(ctx context.Context, consumerID
 		}
 	}
 
-	fmt.Printf("✅ Found %d orders for consumer\n", len(orders))
-	return orders, userNames, productNames, nil
+	return page, userNames, productNames, nil
 }
-
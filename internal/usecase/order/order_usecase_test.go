@@ -7,11 +7,21 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/fee"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/invoice"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/ledger"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	eventinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/event"
+	paymentinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/payment"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -36,28 +46,28 @@ func (m *MockBundleRepo) GetBundleByID(ctx context.Context, id string) (*bundle.
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleRepo) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, supplierID)
+func (m *MockBundleRepo) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockBundleRepo) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
+func (m *MockBundleRepo) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockBundleRepo) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockBundleRepo) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
 func (m *MockBundleRepo) UpdateBundleStatus(ctx context.Context, id string, status string) error {
@@ -70,6 +80,11 @@ func (m *MockBundleRepo) MarkAsPurchased(ctx context.Context, bundleID string, r
 	return args.Error(0)
 }
 
+func (m *MockBundleRepo) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
 func (m *MockBundleRepo) DeleteBundle(ctx context.Context, bundleID string) error {
 	args := m.Called(ctx, bundleID)
 	return args.Error(0)
@@ -94,12 +109,12 @@ func (m *MockOrderRepo) CreateOrder(ctx context.Context, o *order.Order) error {
 	return args.Error(0)
 }
 
-func (m *MockOrderRepo) GetOrdersByConsumer(ctx context.Context, consumerID string) ([]*order.Order, error) {
-	args := m.Called(ctx, consumerID)
+func (m *MockOrderRepo) ListOrdersByConsumer(ctx context.Context, consumerID string, status order.OrderStatus, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, consumerID, status, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*order.Order), args.Error(1)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
 }
 
 func (m *MockOrderRepo) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
@@ -115,25 +130,38 @@ func (m *MockOrderRepo) UpdateOrderStatus(ctx context.Context, orderID string, s
 	return args.Error(0)
 }
 
+func (m *MockOrderRepo) TransitionOrderStatus(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+	args := m.Called(ctx, o, from)
+	return args.Error(0)
+}
+
 func (m *MockOrderRepo) DeleteOrder(ctx context.Context, orderID string) error {
 	args := m.Called(ctx, orderID)
 	return args.Error(0)
 }
 
-func (m *MockOrderRepo) GetOrdersBySupplier(ctx context.Context, supplierID string) ([]*order.Order, error) {
-	args := m.Called(ctx, supplierID)
+func (m *MockOrderRepo) ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, supplierID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*order.Order), args.Error(1)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
 }
 
-func (m *MockOrderRepo) GetOrdersByReseller(ctx context.Context, resellerID string) ([]*order.Order, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockOrderRepo) ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*order.Order), args.Error(1)
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepo) GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*order.Order, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
 }
 
 type MockWarehouseRepo struct {
@@ -145,12 +173,12 @@ func (m *MockWarehouseRepo) AddItem(ctx context.Context, item *warehouse.Warehou
 	return args.Error(0)
 }
 
-func (m *MockWarehouseRepo) GetItemsByReseller(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockWarehouseRepo) ListItemsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*warehouse.WarehouseItem], error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
+	return args.Get(0).(*pagination.Page[*warehouse.WarehouseItem]), args.Error(1)
 }
 
 func (m *MockWarehouseRepo) GetItemsByBundle(ctx context.Context, bundleID string) ([]*warehouse.WarehouseItem, error) {
@@ -161,14 +189,25 @@ func (m *MockWarehouseRepo) GetItemsByBundle(ctx context.Context, bundleID strin
 	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
 }
 
-func (m *MockWarehouseRepo) MarkItemAsListed(ctx context.Context, itemID string) error {
+func (m *MockWarehouseRepo) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
 	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseRepo) UpdateItemStatus(ctx context.Context, itemID, from string, updates map[string]interface{}) error {
+	args := m.Called(ctx, itemID, from, updates)
 	return args.Error(0)
 }
 
-func (m *MockWarehouseRepo) MarkItemAsSkipped(ctx context.Context, itemID string) error {
+func (m *MockWarehouseRepo) TakePiece(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
 	args := m.Called(ctx, itemID)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
 }
 func (m *MockWarehouseRepo) CountByStatus(ctx context.Context, status string) (int, error) {
 	args := m.Called(ctx, status)
@@ -201,9 +240,30 @@ func (m *MockPaymentRepo) GetPaymentsByUser(ctx context.Context, userID string)
 	}
 	return args.Get(0).([]*payment.Payment), args.Error(1)
 }
-func (m *MockPaymentRepo) GetAllPlatformFees(ctx context.Context) (float64, float64, error) {
+func (m *MockPaymentRepo) GetAllPlatformFees(ctx context.Context) (money.Totals, money.Totals, error) {
 	args := m.Called(ctx)
-	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+	return args.Get(0).(money.Totals), args.Get(1).(money.Totals), args.Error(2)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByChargeID(ctx context.Context, chargeID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, chargeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepo) UpdatePaymentStatus(ctx context.Context, paymentID string, status payment.Status) error {
+	args := m.Called(ctx, paymentID, status)
+	return args.Error(0)
 }
 
 func (m *MockPaymentRepo) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
@@ -248,12 +308,12 @@ func (m *MockUserRepo) GetUserByEmail(ctx context.Context, email string) (*user.
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
-func (m *MockUserRepo) ListUsersByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
-	args := m.Called(ctx, role)
+func (m *MockUserRepo) ListUsersByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, roles, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*user.User), args.Error(1)
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
 }
 
 func (m *MockUserRepo) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
@@ -279,12 +339,12 @@ func (m *MockUserRepo) UpdateTrustData(ctx context.Context, user *user.User) err
 	return args.Error(0)
 }
 
-func (m *MockUserRepo) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
-	args := m.Called(ctx)
+func (m *MockUserRepo) ListBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*user.User), args.Error(1)
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
 }
 func (m *MockBundleRepo) CountBundles(ctx context.Context) (int, error) {
 	args := m.Called(ctx)
@@ -349,20 +409,53 @@ func (m *MockProductRepo) GetSoldProductsByReseller(ctx context.Context, reselle
 	return args.Get(0).([]*product.Product), args.Error(1)
 }
 
-func (m *MockProductRepo) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, page, limit)
+func (m *MockProductRepo) Reserve(ctx context.Context, id, userID string, until time.Time) error {
+	args := m.Called(ctx, id, userID, until)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) ReleaseReservation(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductRepo) Restock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*product.Product), args.Error(1)
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
 }
 
-func (m *MockProductRepo) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, resellerID, page, limit)
+func (m *MockProductRepo) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*product.Product), args.Error(1)
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *MockProductRepo) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
 }
 func (m *MockBundleRepo) GetBundleByTitle(ctx context.Context, title string) (*bundle.Bundle, error) {
 	args := m.Called(ctx, title)
@@ -372,6 +465,169 @@ func (m *MockBundleRepo) GetBundleByTitle(ctx context.Context, title string) (*b
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
+type MockLedgerRepo struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepo) RecordTransaction(ctx context.Context, t *ledger.Transaction) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepo) AccountBalance(ctx context.Context, account ledger.AccountType, ownerID string) (money.Totals, error) {
+	args := m.Called(ctx, account, ownerID)
+	return args.Get(0).(money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepo) OrderAccountBalance(ctx context.Context, account ledger.AccountType, ownerID string, orderID string) (money.Totals, error) {
+	args := m.Called(ctx, account, ownerID, orderID)
+	return args.Get(0).(money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepo) BalancesByOwner(ctx context.Context, account ledger.AccountType) (map[string]money.Totals, error) {
+	args := m.Called(ctx, account)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepo) AccountTotal(ctx context.Context, account ledger.AccountType) (money.Totals, error) {
+	args := m.Called(ctx, account)
+	return args.Get(0).(money.Totals), args.Error(1)
+}
+
+func (m *MockLedgerRepo) CreatePayoutBatch(ctx context.Context, b *ledger.PayoutBatch) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepo) LockPayouts(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// passthroughTxManager runs the callback directly; the repositories are mocked, so
+// there is nothing to roll back.
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// retryingTxManager runs the callback twice, as a driver retrying after a transient
+// conflict would.
+type retryingTxManager struct{}
+
+func (retryingTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+// staticFees quotes fees from a fixed set of rules, ignoring seller tiers.
+type staticFees struct {
+	rules []*fee.Rule
+}
+
+func (f *staticFees) Quote(ctx context.Context, s fee.Sale) (*fee.Quote, error) {
+	return fee.QuoteSale(f.rules, s), nil
+}
+
+// staticRates converts with a fixed set of rates, each usable in one direction only.
+type staticRates struct {
+	rates []*money.Rate
+}
+
+func (r *staticRates) Rate(ctx context.Context, from, to money.Currency) (*money.RateSnapshot, error) {
+	if from == to {
+		return nil, nil
+	}
+	for _, rate := range r.rates {
+		if rate.From == from && rate.To == to {
+			return rate.Snapshot(), nil
+		}
+	}
+	return nil, money.ErrRateNotFound
+}
+
+func (r *staticRates) Convert(ctx context.Context, m money.Money, to money.Currency) (money.Money, error) {
+	snapshot, err := r.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return snapshot.Convert(m), nil
+}
+
+// staticShipping quotes every delivery at a fixed cost and remembers the last quote.
+type staticShipping struct {
+	cost  money.Money
+	err   error
+	items int
+}
+
+var testDelivery = shipping.Selection{AddressID: "addr1", Option: shipping.Standard}
+
+func (s *staticShipping) Quote(ctx context.Context, buyerID string, sel shipping.Selection, items int) (*shipping.Quote, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.items = items
+	return &shipping.Quote{
+		Address:           shipping.Address{ID: sel.AddressID, UserID: buyerID, City: "Addis Ababa", Country: "ET"},
+		Option:            sel.Option,
+		WeightGrams:       shipping.WeightForItems(items),
+		Cost:              s.cost,
+		EstimatedDelivery: "2025-01-03T00:00:00Z",
+	}, nil
+}
+
+// stubDispatcher stands in for the carrier booking. A non-empty tracking number
+// is issued as the carrier's own.
+type stubDispatcher struct {
+	tracking string
+	err      error
+	calls    int
+}
+
+func (d *stubDispatcher) Dispatch(ctx context.Context, o *order.Order) (*shipment.Shipment, error) {
+	d.calls++
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.tracking != "" {
+		o.TrackingNumber = d.tracking
+	}
+	return &shipment.Shipment{ID: o.ID, OrderID: o.ID, Carrier: o.Carrier, TrackingNumber: o.TrackingNumber}, nil
+}
+
+// stubInvoices stands in for invoice issuance and remembers the orders invoiced.
+type stubInvoices struct {
+	err    error
+	issued []string
+}
+
+func (i *stubInvoices) IssueInvoice(ctx context.Context, o *order.Order) (*invoice.Invoice, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	i.issued = append(i.issued, o.ID)
+	return &invoice.Invoice{ID: o.ID, OrderID: o.ID}, nil
+}
+
+// voidRecordingGateway is the fake gateway, remembering the idempotency key of
+// every void it is asked for.
+type voidRecordingGateway struct {
+	*paymentinfra.FakeGateway
+	voids []string
+}
+
+func (g *voidRecordingGateway) Void(ctx context.Context, chargeID string, idempotencyKey string) (*payment.Charge, error) {
+	g.voids = append(g.voids, idempotencyKey)
+	return g.FakeGateway.Void(ctx, chargeID, idempotencyKey)
+}
+
 // OrderUsecaseTestSuite is the test suite for order usecase
 type OrderUsecaseTestSuite struct {
 	suite.Suite
@@ -382,6 +638,14 @@ type OrderUsecaseTestSuite struct {
 	paymentRepo   *MockPaymentRepo
 	userRepo      *MockUserRepo
 	productRepo   *MockProductRepo
+	ledgerRepo    *MockLedgerRepo
+	hub           event.Hub
+	gateway       *voidRecordingGateway
+	fees          *staticFees
+	rates         *staticRates
+	shipping      *staticShipping
+	dispatcher    *stubDispatcher
+	invoices      *stubInvoices
 	useCase       *orderUseCaseImpl
 }
 
@@ -394,6 +658,14 @@ func (suite *OrderUsecaseTestSuite) SetupTest() {
 	suite.paymentRepo = new(MockPaymentRepo)
 	suite.userRepo = new(MockUserRepo)
 	suite.productRepo = new(MockProductRepo)
+	suite.ledgerRepo = new(MockLedgerRepo)
+	suite.hub = eventinfra.NewHub()
+	suite.gateway = &voidRecordingGateway{FakeGateway: paymentinfra.NewFakeGateway()}
+	suite.fees = &staticFees{}
+	suite.rates = &staticRates{}
+	suite.shipping = &staticShipping{cost: money.New(0, money.ETB)}
+	suite.dispatcher = &stubDispatcher{}
+	suite.invoices = &stubInvoices{}
 	suite.useCase = NewOrderUsecase(
 		suite.bundleRepo,
 		suite.orderRepo,
@@ -401,9 +673,25 @@ func (suite *OrderUsecaseTestSuite) SetupTest() {
 		suite.paymentRepo,
 		suite.userRepo,
 		suite.productRepo,
+		suite.hub,
+		suite.gateway,
+		passthroughTxManager{},
+		suite.ledgerRepo,
+		suite.fees,
+		suite.rates,
+		suite.shipping,
+		suite.dispatcher,
+		suite.invoices,
 	)
 }
 
+// expectPosting expects n ledger transactions of the given kind.
+func (suite *OrderUsecaseTestSuite) expectPosting(kind ledger.Kind, n int) {
+	suite.ledgerRepo.On("RecordTransaction", suite.ctx, mock.MatchedBy(func(t *ledger.Transaction) bool {
+		return t.Kind == kind && t.Validate() == nil
+	})).Return(nil).Times(n)
+}
+
 // TearDownTest runs after each test
 func (suite *OrderUsecaseTestSuite) TearDownTest() {
 	suite.bundleRepo.AssertExpectations(suite.T())
@@ -412,6 +700,7 @@ func (suite *OrderUsecaseTestSuite) TearDownTest() {
 	suite.paymentRepo.AssertExpectations(suite.T())
 	suite.userRepo.AssertExpectations(suite.T())
 	suite.productRepo.AssertExpectations(suite.T())
+	suite.ledgerRepo.AssertExpectations(suite.T())
 }
 
 // TestOrderUsecaseTestSuite runs all the tests in the suite
@@ -429,6 +718,15 @@ func (suite *OrderUsecaseTestSuite) TestNewOrderUsecase() {
 		suite.paymentRepo,
 		suite.userRepo,
 		suite.productRepo,
+		suite.hub,
+		suite.gateway,
+		passthroughTxManager{},
+		suite.ledgerRepo,
+		suite.fees,
+		suite.rates,
+		suite.shipping,
+		suite.dispatcher,
+		suite.invoices,
 	)
 
 	// Assert
@@ -439,6 +737,8 @@ func (suite *OrderUsecaseTestSuite) TestNewOrderUsecase() {
 	assert.Equal(suite.T(), suite.paymentRepo, uc.paymentRepo)
 	assert.Equal(suite.T(), suite.userRepo, uc.userRepo)
 	assert.Equal(suite.T(), suite.productRepo, uc.prodRepo)
+	assert.Equal(suite.T(), suite.gateway, uc.gateway)
+	assert.Equal(suite.T(), suite.ledgerRepo, uc.ledgerRepo)
 }
 
 // TestPurchaseBundle tests the PurchaseBundle method
@@ -459,7 +759,7 @@ func (suite *OrderUsecaseTestSuite) TestPurchaseBundle() {
 			mockBundle: &bundle.Bundle{
 				ID:         "bundle1",
 				SupplierID: "supplier1",
-				Price:      100.0,
+				Price:      money.New(10000, money.ETB),
 				Status:     "available",
 			},
 			mockError:   nil,
@@ -481,7 +781,7 @@ func (suite *OrderUsecaseTestSuite) TestPurchaseBundle() {
 			mockBundle: &bundle.Bundle{
 				ID:         "bundle1",
 				SupplierID: "supplier1",
-				Price:      100.0,
+				Price:      money.New(10000, money.ETB),
 				Status:     "available",
 			},
 			mockError:    nil,
@@ -493,19 +793,17 @@ func (suite *OrderUsecaseTestSuite) TestPurchaseBundle() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			suite.bundleRepo.On("GetBundleByID", suite.ctx, tt.bundleID).Return(tt.mockBundle, tt.mockError)
-			if tt.mockBundle != nil {
-				suite.bundleRepo.On("ListAvailableBundles", suite.ctx).Return([]*bundle.Bundle{tt.mockBundle}, nil)
-			}
-
 			if !tt.expectError {
 				suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
 				suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+				suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil)
+				suite.expectPosting(ledger.KindPurchase, 1)
 				suite.bundleRepo.On("MarkAsPurchased", suite.ctx, tt.bundleID, tt.resellerID).Return(nil)
 				suite.warehouseRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
 			}
 
 			// Act
-			order, payment, warehouseItem, err := suite.useCase.PurchaseBundle(suite.ctx, tt.bundleID, tt.resellerID)
+			order, payment, warehouseItem, err := suite.useCase.PurchaseBundle(suite.ctx, tt.bundleID, tt.resellerID, "", testDelivery)
 
 			// Assert
 			if tt.expectError {
@@ -533,10 +831,10 @@ func (suite *OrderUsecaseTestSuite) TestGetDashboardMetrics() {
 		mockUser       *user.User
 		mockError      error
 		expectError    bool
-		expectedSales  float64
+		expectedSales  []money.Money
 		expectedCounts order.PerformanceMetrics
 		expectedRating int
-		expectedBest   float64
+		expectedBest   []money.Money
 	}{
 		{
 			name:       "Success - With bundles",
@@ -545,13 +843,13 @@ func (suite *OrderUsecaseTestSuite) TestGetDashboardMetrics() {
 				{
 					ID:         "bundle1",
 					Status:     "purchased",
-					Price:      100.0,
+					Price:      money.New(10000, money.ETB),
 					DateListed: time.Now(),
 				},
 				{
 					ID:         "bundle2",
 					Status:     "available",
-					Price:      200.0,
+					Price:      money.New(20000, money.ETB),
 					DateListed: time.Now(),
 				},
 			},
@@ -561,14 +859,14 @@ func (suite *OrderUsecaseTestSuite) TestGetDashboardMetrics() {
 			},
 			mockError:     nil,
 			expectError:   false,
-			expectedSales: 100.0,
+			expectedSales: []money.Money{money.New(10000, money.ETB)},
 			expectedCounts: order.PerformanceMetrics{
 				TotalBundlesListed: 2,
 				ActiveCount:        1,
 				SoldCount:          1,
 			},
 			expectedRating: 85,
-			expectedBest:   100.0,
+			expectedBest:   []money.Money{money.New(10000, money.ETB)},
 		},
 		{
 			name:        "Success - No bundles",
@@ -580,14 +878,14 @@ func (suite *OrderUsecaseTestSuite) TestGetDashboardMetrics() {
 			},
 			mockError:     nil,
 			expectError:   false,
-			expectedSales: 0.0,
+			expectedSales: []money.Money{},
 			expectedCounts: order.PerformanceMetrics{
 				TotalBundlesListed: 0,
 				ActiveCount:        0,
 				SoldCount:          0,
 			},
 			expectedRating: 90,
-			expectedBest:   0.0,
+			expectedBest:   []money.Money{},
 		},
 		{
 			name:           "Error - Repository error",
@@ -596,16 +894,20 @@ func (suite *OrderUsecaseTestSuite) TestGetDashboardMetrics() {
 			mockUser:       nil,
 			mockError:      errors.New("database error"),
 			expectError:    true,
-			expectedSales:  0.0,
+			expectedSales:  nil,
 			expectedCounts: order.PerformanceMetrics{},
 			expectedRating: 0,
-			expectedBest:   0.0,
+			expectedBest:   nil,
 		},
 	}
 
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
-			suite.bundleRepo.On("ListBundles", suite.ctx, tt.supplierID).Return(tt.mockBundles, tt.mockError)
+			var page *pagination.Page[*bundle.Bundle]
+			if tt.mockError == nil {
+				page = &pagination.Page[*bundle.Bundle]{Items: tt.mockBundles}
+			}
+			suite.bundleRepo.On("ListBundles", suite.ctx, tt.supplierID, pagination.Request{Limit: pagination.MaxLimit}).Return(page, tt.mockError)
 			if tt.mockUser != nil {
 				suite.userRepo.On("GetByID", suite.ctx, tt.supplierID).Return(tt.mockUser, nil)
 			}
@@ -727,78 +1029,915 @@ func (suite *OrderUsecaseTestSuite) TestGetSoldBundleHistory() {
 
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
-			suite.orderRepo.On("GetOrdersBySupplier", suite.ctx, tt.supplierID).Return(tt.mockOrders, tt.mockError)
+			var page *pagination.Page[*order.Order]
+			if tt.mockError == nil {
+				page = &pagination.Page[*order.Order]{Items: tt.mockOrders}
+			}
+			suite.orderRepo.On("ListOrdersBySupplier", suite.ctx, tt.supplierID, pagination.Request{}).Return(page, tt.mockError)
 
-			orders, userNames, err := suite.useCase.GetSoldBundleHistory(suite.ctx, tt.supplierID)
+			result, userNames, err := suite.useCase.GetSoldBundleHistory(suite.ctx, tt.supplierID, pagination.Request{})
 
 			// Assert
 			if tt.expectError {
 				assert.Error(suite.T(), err)
-				assert.Nil(suite.T(), orders)
+				assert.Nil(suite.T(), result)
 				assert.Nil(suite.T(), userNames)
 			} else {
 				assert.NoError(suite.T(), err)
 				if tt.expectedCount == 0 {
-					assert.Empty(suite.T(), orders)
+					assert.Empty(suite.T(), result.Items)
 					assert.Empty(suite.T(), userNames)
 				} else {
-					assert.NotNil(suite.T(), orders)
+					assert.NotNil(suite.T(), result)
 					assert.NotNil(suite.T(), userNames)
-					assert.Len(suite.T(), orders, tt.expectedCount)
+					assert.Len(suite.T(), result.Items, tt.expectedCount)
 				}
 			}
 		})
 	}
 }
 
-// TestPurchaseProduct tests the PurchaseProduct method
-func (suite *OrderUsecaseTestSuite) TestPurchaseProduct() {
-	productID := "test-product-id"
-	userID := "test-user-id"
-	price := 100.0
+// TestPurchaseBundle_InvoiceFails tests that a purchase whose invoice cannot be issued is
+// not recorded and its hold is released
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_InvoiceFails() {
+	suite.invoices.err = errors.New("counter unavailable")
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
 
-	resellerObjID := primitive.NewObjectID()
+	_, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
 
-	// Mock product
-	mockProduct := &product.Product{
-		ID:         productID,
-		ResellerID: resellerObjID,
-		Price:      price,
-	}
-	suite.productRepo.On("GetProductByID", suite.ctx, productID).Return(mockProduct, nil)
+	assert.ErrorContains(suite.T(), err, "issuing invoice")
+	charge, _ := suite.gateway.Charge("ch_fake_000001")
+	assert.Equal(suite.T(), payment.ChargeVoided, charge.Status)
+	suite.warehouseRepo.AssertNotCalled(suite.T(), "AddItem", mock.Anything, mock.Anything)
+}
 
-	// Mock order creation
+// TestPurchaseBundle_CaptureFails tests that a purchase whose charge cannot be captured is
+// canceled, returning the bundle to sale and releasing the hold
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_CaptureFails() {
+	suite.gateway.FailCaptures(true)
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
+
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, mock.AnythingOfType("*order.Order"), order.Pending).Return(nil)
+	suite.bundleRepo.On("ReleasePurchase", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{{ID: "item1", BundleID: "bundle1", ResellerID: "reseller1", Status: warehouse.StatusPending}}, nil)
+	suite.warehouseRepo.On("DeleteItem", suite.ctx, "item1").Return(nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, mock.AnythingOfType("string")).Return([]*payment.Payment{
+		{ID: "pay1", Amount: money.New(8000, money.ETB), Status: payment.StatusAuthorized, GatewayChargeID: "ch_fake_000001"},
+	}, nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusRefunded).Return(nil)
+
+	_, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
+
+	assert.ErrorContains(suite.T(), err, "payment capture failed")
+	charge, _ := suite.gateway.Charge("ch_fake_000001")
+	assert.Equal(suite.T(), payment.ChargeVoided, charge.Status)
+	suite.ledgerRepo.AssertNotCalled(suite.T(), "RecordTransaction", mock.Anything, mock.Anything)
+}
+
+// TestPurchaseBundle_PublishesEvents tests that the supplier and reseller are notified of a sale
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_PublishesEvents() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(10000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
 	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil)
+	suite.expectPosting(ledger.KindPurchase, 1)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
+
+	supplierEvents, unsubSupplier := suite.hub.Subscribe("supplier1")
+	defer unsubSupplier()
+	resellerEvents, unsubReseller := suite.hub.Subscribe("reseller1")
+	defer unsubReseller()
 
-	// Mock payment recording
+	// Act
+	order, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.Require().Len(supplierEvents, 1)
+	sold := <-supplierEvents
+	assert.Equal(suite.T(), event.BundleSold, sold.Type)
+	assert.Equal(suite.T(), order.ID, sold.Payload.(event.BundleSoldPayload).OrderID)
+	suite.Require().Len(resellerEvents, 1)
+	assert.Equal(suite.T(), event.OrderStatusChanged, (<-resellerEvents).Type)
+}
+
+// TestPurchaseBundle_CapturesCharge tests that a successful purchase captures the gateway charge
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_CapturesCharge() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
 	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil)
+	suite.expectPosting(ledger.KindPurchase, 1)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
 
 	// Act
-	order, payment, err := suite.useCase.PurchaseProduct(suite.ctx, productID, userID, price)
+	_, p, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
 
 	// Assert
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), order)
-	assert.NotNil(suite.T(), payment)
+	assert.NotEmpty(suite.T(), p.GatewayChargeID)
+	charge, ok := suite.gateway.Charge(p.GatewayChargeID)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), payment.ChargeCaptured, charge.Status)
+	assert.Equal(suite.T(), money.New(8000, money.ETB), charge.Amount)
+}
+
+// TestPurchaseBundle_AppliesFeeRule tests that the matching fee rule prices the sale
+// and is recorded on the payment
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_AppliesFeeRule() {
+	suite.fees.rules = []*fee.Rule{
+		{ID: "b2b", Version: 2, PaymentType: payment.B2B, Currency: money.ETB, Percent: 3, Flat: 100},
+		{ID: "b2c", Version: 1, PaymentType: payment.B2C, Percent: 10},
+	}
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(10000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil)
+	suite.expectPosting(ledger.KindPurchase, 1)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
+
+	// Act
+	o, p, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), money.New(400, money.ETB), o.PlatformFee)
+	assert.Equal(suite.T(), money.New(400, money.ETB), p.PlatformFee)
+	assert.Equal(suite.T(), money.New(9600, money.ETB), p.SellerEarning)
+	assert.Equal(suite.T(), []payment.FeeRuleRef{{RuleID: "b2b", Version: 2}}, p.FeeRules)
 }
 
-// TestPurchaseProduct_ProductNotFound tests the PurchaseProduct method when product is not found
-func (suite *OrderUsecaseTestSuite) TestPurchaseProduct_ProductNotFound() {
-	productID := "test-product-id"
-	userID := "test-user-id"
-	price := 100.0
+// TestPurchaseBundle_Declined tests that nothing is recorded when the gateway declines
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_Declined() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.gateway.DeclineAll(true)
+
+	// Act
+	o, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
 
-	// Mock product not found
-	suite.productRepo.On("GetProductByID", suite.ctx, productID).Return(nil, errors.New("product not found"))
+	// Assert
+	assert.ErrorIs(suite.T(), err, payment.ErrPaymentDeclined)
+	assert.Nil(suite.T(), o)
+	suite.orderRepo.AssertNotCalled(suite.T(), "CreateOrder", mock.Anything, mock.Anything)
+}
 
-	// No need to mock other calls since the test should fail before reaching them
+// TestPurchaseBundle_VoidsOnFailure tests that the hold is released when recording the purchase fails
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_VoidsOnFailure() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(errors.New("write failed"))
 
 	// Act
-	order, payment, err := suite.useCase.PurchaseProduct(suite.ctx, productID, userID, price)
+	_, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
 
 	// Assert
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), order)
-	assert.Nil(suite.T(), payment)
-	assert.Contains(suite.T(), err.Error(), "product not found")
+	charge, ok := suite.gateway.Charge("ch_fake_000001")
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), payment.ChargeVoided, charge.Status)
+}
+
+// TestPurchaseBundle_AlreadySold tests that losing the race for a bundle voids the hold
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_AlreadySold() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(bundle.ErrBundleAlreadySold)
+
+	// Act
+	o, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, bundle.ErrBundleAlreadySold)
+	assert.Nil(suite.T(), o)
+	suite.orderRepo.AssertNotCalled(suite.T(), "CreateOrder", mock.Anything, mock.Anything)
+	charge, ok := suite.gateway.Charge("ch_fake_000001")
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), payment.ChargeVoided, charge.Status)
+}
+
+// TestPurchaseBundle_SoldBeforeCheckout tests that a purchased bundle is rejected before charging
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_SoldBeforeCheckout() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "purchased"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+
+	// Act
+	_, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, bundle.ErrBundleAlreadySold)
+	_, charged := suite.gateway.Charge("ch_fake_000001")
+	assert.False(suite.T(), charged)
+}
+
+// TestPurchaseBundle_ConvertsCurrency tests that a reseller paying in another currency is
+// charged at the current rate, which is kept on the order
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_ConvertsCurrency() {
+	suite.rates.rates = []*money.Rate{{From: money.ETB, To: money.USD, Rate: 0.02, UpdatedAt: "2025-01-01T00:00:00Z"}}
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(500000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil)
+	suite.expectPosting(ledger.KindPurchase, 1)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
+
+	// Act
+	o, p, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", money.USD, testDelivery)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), money.New(500000, money.ETB), o.TotalPrice)
+	assert.Equal(suite.T(), money.New(10000, money.USD), o.Charged)
+	suite.Require().NotNil(o.ExchangeRate)
+	assert.Equal(suite.T(), 0.02, o.ExchangeRate.Rate)
+	assert.Equal(suite.T(), money.New(10000, money.USD), p.Charged)
+	charge, ok := suite.gateway.Charge(p.GatewayChargeID)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), money.New(10000, money.USD), charge.Amount)
+}
+
+// TestPurchaseBundle_NoRate tests that nothing is charged when the currency cannot be converted
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_NoRate() {
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+
+	// Act
+	_, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", money.EUR, testDelivery)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, money.ErrRateNotFound)
+	_, charged := suite.gateway.Charge("ch_fake_000001")
+	assert.False(suite.T(), charged)
+}
+
+// TestPurchaseBundle_ChargesShipping tests that the shipping quote for the bundle's
+// weight is charged on top of the price and passed on to the supplier
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_ChargesShipping() {
+	suite.rates.rates = []*money.Rate{{From: money.ETB, To: money.USD, Rate: 0.02, UpdatedAt: "2025-01-01T00:00:00Z"}}
+	suite.shipping.cost = money.New(45000, money.ETB)
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(500000, money.ETB), Quantity: 30, Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil)
+	suite.expectPosting(ledger.KindPurchase, 1)
+	suite.bundleRepo.On("MarkAsPurchased", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(nil)
+
+	// Act
+	o, p, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", money.USD, testDelivery)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 30, suite.shipping.items)
+	assert.Equal(suite.T(), money.New(45000, money.ETB), o.ShippingCost)
+	assert.Equal(suite.T(), shipping.Standard, o.DeliveryOption)
+	suite.Require().NotNil(o.ShippingAddress)
+	assert.Equal(suite.T(), "addr1", o.ShippingAddress.ID)
+	assert.Equal(suite.T(), "2025-01-03T00:00:00Z", o.EstimatedDelivery)
+	assert.Equal(suite.T(), money.New(500000, money.ETB), o.TotalPrice)
+	assert.Equal(suite.T(), money.New(10900, money.USD), o.Charged)
+	assert.Equal(suite.T(), money.New(545000, money.ETB), p.Amount)
+	assert.Equal(suite.T(), money.New(45000, money.ETB), p.Shipping)
+	assert.Equal(suite.T(), p.Amount, p.SellerEarning.Add(p.PlatformFee))
+}
+
+// TestPurchaseBundle_UnknownAddress tests that nothing is charged when the address
+// is not in the reseller's address book
+func (suite *OrderUsecaseTestSuite) TestPurchaseBundle_UnknownAddress() {
+	suite.shipping.err = shipping.ErrAddressNotFound
+	b := &bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", Price: money.New(8000, money.ETB), Status: "available"}
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+
+	// Act
+	_, _, _, err := suite.useCase.PurchaseBundle(suite.ctx, "bundle1", "reseller1", "", testDelivery)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, shipping.ErrAddressNotFound)
+	_, charged := suite.gateway.Charge("ch_fake_000001")
+	assert.False(suite.T(), charged)
+}
+
+// TestPurchaseProducts_OneOrderPerReseller tests that a basket is charged once and split into one order per reseller
+func (suite *OrderUsecaseTestSuite) TestPurchaseProducts_OneOrderPerReseller() {
+	resellerA := primitive.NewObjectID()
+	resellerB := primitive.NewObjectID()
+	products := []*product.Product{
+		{ID: "p1", ResellerID: resellerA, Price: money.New(10000, money.ETB), Status: "available"},
+		{ID: "p2", ResellerID: resellerB, Price: money.New(5000, money.ETB), Status: "available"},
+		{ID: "p3", ResellerID: resellerA, Price: money.New(2500, money.ETB), Status: "available"},
+	}
+	for _, p := range products {
+		suite.productRepo.On("MarkAsSold", suite.ctx, p.ID, "consumer1").Return(nil).Once()
+	}
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil).Twice()
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil).Twice()
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil).Twice()
+	suite.expectPosting(ledger.KindPurchase, 2)
+
+	// Act
+	orders, payments, err := suite.useCase.PurchaseProducts(suite.ctx, "consumer1", products, "", testDelivery)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(orders, 2)
+	suite.Require().Len(payments, 2)
+	assert.Equal(suite.T(), resellerA.Hex(), orders[0].ResellerID)
+	assert.Equal(suite.T(), []string{"p1", "p3"}, orders[0].ProductIDs)
+	assert.Equal(suite.T(), money.New(12500, money.ETB), orders[0].TotalPrice)
+	assert.Equal(suite.T(), resellerB.Hex(), orders[1].ResellerID)
+	assert.Equal(suite.T(), money.New(5000, money.ETB), orders[1].TotalPrice)
+	assert.Equal(suite.T(), orders[1].ID, payments[1].OrderID)
+	assert.Equal(suite.T(), payments[0].GatewayChargeID, payments[1].GatewayChargeID)
+	assert.Equal(suite.T(), []string{orders[0].ID, orders[1].ID}, suite.invoices.issued)
+
+	charge, ok := suite.gateway.Charge(payments[0].GatewayChargeID)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), payment.ChargeCaptured, charge.Status)
+	assert.Equal(suite.T(), money.New(17500, money.ETB), charge.Amount)
+}
+
+// TestPurchaseProducts_ShipsEachOrder tests that every order in a basket carries its
+// own shipping cost and the basket is charged for all of them
+func (suite *OrderUsecaseTestSuite) TestPurchaseProducts_ShipsEachOrder() {
+	suite.shipping.cost = money.New(10000, money.ETB)
+	resellerA := primitive.NewObjectID()
+	resellerB := primitive.NewObjectID()
+	products := []*product.Product{
+		{ID: "p1", ResellerID: resellerA, Price: money.New(10000, money.ETB), Status: "available"},
+		{ID: "p2", ResellerID: resellerB, Price: money.New(5000, money.ETB), Status: "available"},
+	}
+	for _, p := range products {
+		suite.productRepo.On("MarkAsSold", suite.ctx, p.ID, "consumer1").Return(nil).Once()
+	}
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil).Twice()
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil).Twice()
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusCaptured).Return(nil).Twice()
+	suite.expectPosting(ledger.KindPurchase, 2)
+
+	// Act
+	orders, payments, err := suite.useCase.PurchaseProducts(suite.ctx, "consumer1", products, "", testDelivery)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(orders, 2)
+	for i, o := range orders {
+		assert.Equal(suite.T(), money.New(10000, money.ETB), o.ShippingCost)
+		assert.Equal(suite.T(), o.TotalPrice.Add(o.ShippingCost), o.Charged)
+		assert.Equal(suite.T(), o.Charged, payments[i].Amount)
+	}
+	charge, ok := suite.gateway.Charge(payments[0].GatewayChargeID)
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), money.New(35000, money.ETB), charge.Amount)
+}
+
+// TestPurchaseProducts_CaptureFails tests that a basket whose charge cannot be captured
+// has the charge voided once before each of its orders is canceled
+func (suite *OrderUsecaseTestSuite) TestPurchaseProducts_CaptureFails() {
+	suite.gateway.FailCaptures(true)
+	resellerA := primitive.NewObjectID()
+	resellerB := primitive.NewObjectID()
+	products := []*product.Product{
+		{ID: "p1", ResellerID: resellerA, Price: money.New(10000, money.ETB), Status: "available"},
+		{ID: "p2", ResellerID: resellerB, Price: money.New(5000, money.ETB), Status: "available"},
+	}
+	for _, p := range products {
+		suite.productRepo.On("MarkAsSold", suite.ctx, p.ID, "consumer1").Return(nil).Once()
+		suite.productRepo.On("Restock", suite.ctx, p.ID).Return(nil).Once()
+	}
+	suite.orderRepo.On("CreateOrder", suite.ctx, mock.AnythingOfType("*order.Order")).Return(nil).Twice()
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, mock.AnythingOfType("*order.Order"), order.Pending).Return(nil).Twice()
+	for _, id := range []string{"pay1", "pay2"} {
+		suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, mock.AnythingOfType("string")).Return([]*payment.Payment{
+			{ID: id, Amount: money.New(5000, money.ETB), Status: payment.StatusAuthorized, GatewayChargeID: "ch_fake_000001"},
+		}, nil).Once()
+	}
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, mock.AnythingOfType("string"), payment.StatusRefunded).Return(nil).Twice()
+
+	// Act
+	_, _, err := suite.useCase.PurchaseProducts(suite.ctx, "consumer1", products, "", testDelivery)
+
+	// Assert
+	assert.ErrorContains(suite.T(), err, "payment capture failed")
+	assert.Equal(suite.T(), []string{"void-ch_fake_000001"}, suite.gateway.voids)
+	charge, _ := suite.gateway.Charge("ch_fake_000001")
+	assert.Equal(suite.T(), payment.ChargeVoided, charge.Status)
+	suite.ledgerRepo.AssertNotCalled(suite.T(), "RecordTransaction", mock.Anything, mock.Anything)
+}
+
+// TestPurchaseProducts_Unavailable tests that nothing is bought when one product was already sold
+func (suite *OrderUsecaseTestSuite) TestPurchaseProducts_Unavailable() {
+	reseller := primitive.NewObjectID()
+	products := []*product.Product{
+		{ID: "p1", ResellerID: reseller, Price: money.New(10000, money.ETB), Status: "available"},
+		{ID: "p2", ResellerID: reseller, Price: money.New(5000, money.ETB), Status: "available"},
+	}
+	suite.productRepo.On("MarkAsSold", suite.ctx, "p1", "consumer1").Return(nil).Once()
+	suite.productRepo.On("MarkAsSold", suite.ctx, "p2", "consumer1").Return(product.ErrProductUnavailable).Once()
+
+	// Act
+	orders, _, err := suite.useCase.PurchaseProducts(suite.ctx, "consumer1", products, "", testDelivery)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, product.ErrProductUnavailable)
+	assert.Nil(suite.T(), orders)
+	suite.orderRepo.AssertNotCalled(suite.T(), "CreateOrder", mock.Anything, mock.Anything)
+	charge, ok := suite.gateway.Charge("ch_fake_000001")
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), payment.ChargeVoided, charge.Status)
+}
+
+// TestMarkOrderShipped tests that the seller can ship a paid order with tracking details
+func (suite *OrderUsecaseTestSuite) TestMarkOrderShipped() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.OrderStatusProcessing}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.OrderStatusProcessing).Return(nil)
+	events, unsubscribe := suite.hub.Subscribe("consumer1")
+	defer unsubscribe()
+
+	// Act
+	shipped, err := suite.useCase.MarkOrderShipped(suite.ctx, "order1", "reseller1", "TRK123", "DHL")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.Shipped, shipped.Status)
+	assert.Equal(suite.T(), "TRK123", shipped.TrackingNumber)
+	assert.Equal(suite.T(), "DHL", shipped.Carrier)
+	assert.NotEmpty(suite.T(), shipped.ShippedAt)
+	suite.Require().Len(events, 1)
+	assert.Equal(suite.T(), event.OrderStatusPayload{OrderID: "order1", Status: "shipped"}, (<-events).Payload)
+}
+
+// TestMarkOrderShipped_MissingCarrier tests that shipping requires a carrier
+func (suite *OrderUsecaseTestSuite) TestMarkOrderShipped_MissingCarrier() {
+	_, err := suite.useCase.MarkOrderShipped(suite.ctx, "order1", "reseller1", "TRK123", " ")
+
+	assert.ErrorIs(suite.T(), err, order.ErrMissingTracking)
+	assert.Zero(suite.T(), suite.dispatcher.calls)
+}
+
+// TestMarkOrderShipped_CarrierIssuesTracking tests that a carrier adapter may issue the tracking number
+func (suite *OrderUsecaseTestSuite) TestMarkOrderShipped_CarrierIssuesTracking() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.OrderStatusProcessing}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.OrderStatusProcessing).Return(nil)
+	// The issued tracking number is recorded once the booking is made
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Shipped).Return(nil)
+	suite.dispatcher.tracking = "SIM0000000001"
+
+	shipped, err := suite.useCase.MarkOrderShipped(suite.ctx, "order1", "reseller1", "", "simulator")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.Shipped, shipped.Status)
+	assert.Equal(suite.T(), "SIM0000000001", shipped.TrackingNumber)
+	suite.orderRepo.AssertExpectations(suite.T())
+}
+
+// TestMarkOrderShipped_DispatchFails tests that the order is put back when the carrier cannot take it
+func (suite *OrderUsecaseTestSuite) TestMarkOrderShipped_DispatchFails() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.OrderStatusProcessing}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.OrderStatusProcessing).Return(nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, mock.MatchedBy(func(undo *order.Order) bool {
+		return undo.ID == "order1" && undo.Status == order.OrderStatusProcessing && undo.Carrier == "" && undo.ShippedAt == ""
+	}), order.Shipped).Return(nil)
+	suite.dispatcher.err = order.ErrMissingTracking
+	events, unsubscribe := suite.hub.Subscribe("consumer1")
+	defer unsubscribe()
+
+	_, err := suite.useCase.MarkOrderShipped(suite.ctx, "order1", "reseller1", "", "DHL")
+
+	assert.ErrorIs(suite.T(), err, order.ErrMissingTracking)
+	suite.orderRepo.AssertExpectations(suite.T())
+	assert.Empty(suite.T(), events)
+}
+
+// TestMarkOrderShipped_AlreadyClaimed tests that no carrier is booked when another request
+// shipped the order first
+func (suite *OrderUsecaseTestSuite) TestMarkOrderShipped_AlreadyClaimed() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.OrderStatusProcessing}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.OrderStatusProcessing).Return(order.ErrStatusChanged)
+
+	_, err := suite.useCase.MarkOrderShipped(suite.ctx, "order1", "reseller1", "TRK123", "DHL")
+
+	assert.ErrorIs(suite.T(), err, order.ErrStatusChanged)
+	assert.Zero(suite.T(), suite.dispatcher.calls)
+}
+
+// TestMarkOrderShipped_NotShippable tests that no carrier is booked for an order that cannot ship
+func (suite *OrderUsecaseTestSuite) TestMarkOrderShipped_NotShippable() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.OrderStatusCanceled}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	_, err := suite.useCase.MarkOrderShipped(suite.ctx, "order1", "reseller1", "TRK123", "DHL")
+
+	assert.ErrorIs(suite.T(), err, order.ErrInvalidTransition)
+	assert.Zero(suite.T(), suite.dispatcher.calls)
+}
+
+// TestMarkOrderProcessing_NotSeller tests that only the seller can move an order along
+func (suite *OrderUsecaseTestSuite) TestMarkOrderProcessing_NotSeller() {
+	o := &order.Order{ID: "order1", BundleID: "bundle1", SupplierID: "supplier1", ResellerID: "reseller1", Status: order.Pending}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	// The reseller is the buyer of a bundle order, not its seller
+	_, err := suite.useCase.MarkOrderProcessing(suite.ctx, "order1", "reseller1")
+
+	assert.ErrorIs(suite.T(), err, order.ErrNotOrderParty)
+	suite.orderRepo.AssertNotCalled(suite.T(), "TransitionOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestConfirmDelivery tests that the buyer can confirm a shipped order arrived
+func (suite *OrderUsecaseTestSuite) TestConfirmDelivery() {
+	o := &order.Order{ID: "order1", BundleID: "bundle1", SupplierID: "supplier1", ResellerID: "reseller1", TotalPrice: money.New(10000, money.ETB), Status: order.Shipped}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Shipped).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "supplier1", "order1").Return(money.Totals{money.ETB: 9800}, nil)
+	suite.ledgerRepo.On("RecordTransaction", suite.ctx, mock.MatchedBy(func(t *ledger.Transaction) bool {
+		return t.Kind == ledger.KindRelease && t.Entries[1].Account == ledger.AccountSellerAvailable && t.Entries[1].Amount == money.New(9800, money.ETB)
+	})).Return(nil)
+
+	suite.warehouseRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{
+		{ID: "item1", BundleID: "bundle1", ResellerID: "reseller1", Status: "pending"},
+		{ID: "item2", BundleID: "bundle1", ResellerID: "reseller2", Status: "pending"},
+	}, nil)
+	events, unsubscribe := suite.hub.Subscribe("reseller1")
+	defer unsubscribe()
+
+	// Act
+	delivered, err := suite.useCase.ConfirmDelivery(suite.ctx, "order1", "reseller1")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.Delivered, delivered.Status)
+	assert.NotEmpty(suite.T(), delivered.DeliveredAt)
+	// The delivered bundle waits in the reseller's warehouse until they receive it
+	suite.Require().Len(events, 2)
+	<-events
+	assert.Equal(suite.T(), event.WarehouseItemPayload{ItemID: "item1", BundleID: "bundle1", Status: "pending"}, (<-events).Payload)
+	suite.warehouseRepo.AssertNotCalled(suite.T(), "UpdateItemStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRecordDelivery tests that the carrier's delivery scan marks a shipped order delivered
+func (suite *OrderUsecaseTestSuite) TestRecordDelivery() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, TotalPrice: money.New(5000, money.ETB), Status: order.Shipped}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Shipped).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "reseller1", "order1").Return(money.Totals{money.ETB: 4900}, nil)
+	suite.expectPosting(ledger.KindRelease, 1)
+
+	delivered, err := suite.useCase.RecordDelivery(suite.ctx, "order1")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.Delivered, delivered.Status)
+	assert.NotEmpty(suite.T(), delivered.DeliveredAt)
+}
+
+// TestRecordDelivery_AlreadyConfirmed tests that a buyer's earlier confirmation is left alone
+func (suite *OrderUsecaseTestSuite) TestRecordDelivery_AlreadyConfirmed() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", Status: order.Delivered, DeliveredAt: "2025-01-02T00:00:00Z"}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	delivered, err := suite.useCase.RecordDelivery(suite.ctx, "order1")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "2025-01-02T00:00:00Z", delivered.DeliveredAt)
+	suite.orderRepo.AssertNotCalled(suite.T(), "TransitionOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestConfirmDelivery_NotShipped tests that an order cannot be delivered before it ships
+func (suite *OrderUsecaseTestSuite) TestConfirmDelivery_NotShipped() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Pending}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	_, err := suite.useCase.ConfirmDelivery(suite.ctx, "order1", "consumer1")
+
+	assert.ErrorIs(suite.T(), err, order.ErrInvalidTransition)
+}
+
+// TestConfirmDelivery_NotFound tests that a missing order is reported as such
+func (suite *OrderUsecaseTestSuite) TestConfirmDelivery_NotFound() {
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "missing").Return(nil, nil)
+
+	_, err := suite.useCase.ConfirmDelivery(suite.ctx, "missing", "consumer1")
+
+	assert.ErrorIs(suite.T(), err, order.ErrOrderNotFound)
+}
+
+// TestCancelOrder_RefundsAndRestocksBundle tests that canceling a bundle order refunds the
+// reseller, reverses the platform fee and returns the bundle to sale
+func (suite *OrderUsecaseTestSuite) TestCancelOrder_RefundsAndRestocksBundle() {
+	charge, _ := suite.gateway.Authorize(suite.ctx, payment.AuthorizeRequest{Amount: money.New(10000, money.ETB)})
+	suite.gateway.Capture(suite.ctx, charge.ID)
+
+	o := &order.Order{ID: "order1", BundleID: "bundle1", SupplierID: "supplier1", ResellerID: "reseller1", Status: order.Pending}
+	paid := &payment.Payment{ID: "pay1", OrderID: "order1", Amount: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), SellerEarning: money.New(9800, money.ETB), Status: payment.StatusCaptured, GatewayChargeID: charge.ID}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Pending).Return(nil)
+	suite.bundleRepo.On("ReleasePurchase", suite.ctx, "bundle1", "reseller1").Return(nil)
+	suite.warehouseRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{
+		{ID: "item1", BundleID: "bundle1", ResellerID: "reseller1", Status: warehouse.StatusPending},
+		{ID: "item2", BundleID: "bundle1", ResellerID: "someone-else", Status: warehouse.StatusReceived},
+	}, nil)
+	suite.warehouseRepo.On("DeleteItem", suite.ctx, "item1").Return(nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.MatchedBy(func(p *payment.Payment) bool {
+		return p.RefundOf == "pay1" && p.Amount == money.New(-10000, money.ETB) && p.PlatformFee == money.New(-200, money.ETB) && p.SellerEarning == money.New(-9800, money.ETB)
+	})).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusRefunded).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "", "order1").Return(money.Totals{money.ETB: 9800}, nil)
+	suite.expectPosting(ledger.KindRefund, 1)
+
+	// Act
+	canceled, err := suite.useCase.CancelOrder(suite.ctx, "order1", "reseller1")
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.OrderStatusCanceled, canceled.Status)
+	c, _ := suite.gateway.Charge(charge.ID)
+	assert.Equal(suite.T(), payment.ChargeRefunded, c.Status)
+}
+
+// TestCancelOrder_RetriedTransactionRefundsOnce tests that the buyer is refunded once
+// even when the transaction recording the cancellation is retried
+func (suite *OrderUsecaseTestSuite) TestCancelOrder_RetriedTransactionRefundsOnce() {
+	charge, _ := suite.gateway.Authorize(suite.ctx, payment.AuthorizeRequest{Amount: money.New(10000, money.ETB)})
+	suite.gateway.Capture(suite.ctx, charge.ID)
+	suite.useCase.txManager = retryingTxManager{}
+
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Pending}
+	paid := &payment.Payment{ID: "pay1", OrderID: "order1", Amount: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), SellerEarning: money.New(9800, money.ETB), Status: payment.StatusCaptured, GatewayChargeID: charge.ID}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Pending).Return(nil)
+	suite.productRepo.On("Restock", suite.ctx, "p1").Return(nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusRefunded).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "", "order1").Return(money.Totals{money.ETB: 9800}, nil)
+	suite.expectPosting(ledger.KindRefund, 2)
+
+	_, err := suite.useCase.CancelOrder(suite.ctx, "order1", "consumer1")
+
+	suite.Require().NoError(err)
+	c, _ := suite.gateway.Charge(charge.ID)
+	assert.Equal(suite.T(), payment.ChargeRefunded, c.Status)
+	assert.Equal(suite.T(), money.New(10000, money.ETB), c.AmountRefunded)
+}
+
+// TestSettleRefund_CancelsUnshippedOrder tests that a refund made at the gateway cancels
+// and restocks an order that has not shipped, without asking the gateway again
+func (suite *OrderUsecaseTestSuite) TestSettleRefund_CancelsUnshippedOrder() {
+	charge, _ := suite.gateway.Authorize(suite.ctx, payment.AuthorizeRequest{Amount: money.New(10000, money.ETB)})
+	suite.gateway.Capture(suite.ctx, charge.ID)
+
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.OrderStatusProcessing}
+	paid := &payment.Payment{ID: "pay1", OrderID: "order1", ToUserID: "reseller1", Amount: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), SellerEarning: money.New(9800, money.ETB), Status: payment.StatusCaptured, GatewayChargeID: charge.ID}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.OrderStatusProcessing).Return(nil)
+	suite.productRepo.On("Restock", suite.ctx, "p1").Return(nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusRefunded).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "reseller1", "order1").Return(money.Totals{money.ETB: 9800}, nil)
+	suite.expectPosting(ledger.KindRefund, 1)
+
+	settled, err := suite.useCase.SettleRefund(suite.ctx, "order1")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.OrderStatusCanceled, settled.Status)
+	c, _ := suite.gateway.Charge(charge.ID)
+	assert.True(suite.T(), c.AmountRefunded.IsZero())
+}
+
+// TestSettleRefund_KeepsDeliveredOrder tests that a refund made at the gateway after delivery
+// is booked without changing the order
+func (suite *OrderUsecaseTestSuite) TestSettleRefund_KeepsDeliveredOrder() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Delivered}
+	paid := &payment.Payment{ID: "pay1", OrderID: "order1", ToUserID: "reseller1", Amount: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), SellerEarning: money.New(9800, money.ETB), Status: payment.StatusCaptured}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusRefunded).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "reseller1", "order1").Return(money.Totals{}, nil)
+	suite.expectPosting(ledger.KindRefund, 1)
+
+	settled, err := suite.useCase.SettleRefund(suite.ctx, "order1")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.Delivered, settled.Status)
+	suite.orderRepo.AssertNotCalled(suite.T(), "TransitionOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSettleFailure_FailsAndRestocksOrder tests that a payment the gateway failed fails its
+// order and puts what it bought back on sale, without asking the gateway for anything
+func (suite *OrderUsecaseTestSuite) TestSettleFailure_FailsAndRestocksOrder() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Pending}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", Status: payment.StatusAuthorized, GatewayChargeID: "ch_fake_000001"}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusFailed).Return(nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Pending).Return(nil)
+	suite.productRepo.On("Restock", suite.ctx, "p1").Return(nil)
+	events, unsubscribe := suite.hub.Subscribe("consumer1")
+	defer unsubscribe()
+
+	err := suite.useCase.SettleFailure(suite.ctx, p)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.Failed, o.Status)
+	assert.Len(suite.T(), events, 1)
+	assert.Empty(suite.T(), suite.gateway.voids)
+}
+
+// TestSettleFailure_KeepsShippedOrder tests that a failure reported for an order that
+// already shipped only fails the payment
+func (suite *OrderUsecaseTestSuite) TestSettleFailure_KeepsShippedOrder() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Shipped}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", Status: payment.StatusAuthorized}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusFailed).Return(nil)
+
+	err := suite.useCase.SettleFailure(suite.ctx, p)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.Shipped, o.Status)
+	suite.orderRepo.AssertNotCalled(suite.T(), "TransitionOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+	suite.productRepo.AssertNotCalled(suite.T(), "Restock", mock.Anything, mock.Anything)
+}
+
+// TestCancelOrder_RestocksProducts tests that canceling a product order puts its products back on sale
+func (suite *OrderUsecaseTestSuite) TestCancelOrder_RestocksProducts() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1", "p2"}, Status: order.OrderStatusProcessing}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.OrderStatusProcessing).Return(nil)
+	suite.productRepo.On("Restock", suite.ctx, "p1").Return(nil)
+	suite.productRepo.On("Restock", suite.ctx, "p2").Return(nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{}, nil)
+
+	_, err := suite.useCase.CancelOrder(suite.ctx, "order1", "consumer1")
+
+	suite.Require().NoError(err)
+}
+
+// TestCancelOrder_AfterShipment tests that the buyer cannot cancel an order once it shipped
+func (suite *OrderUsecaseTestSuite) TestCancelOrder_AfterShipment() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Shipped}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	_, err := suite.useCase.CancelOrder(suite.ctx, "order1", "consumer1")
+
+	assert.ErrorIs(suite.T(), err, order.ErrInvalidTransition)
+}
+
+// TestCancelOrder_NotBuyer tests that only the buyer can cancel an order
+func (suite *OrderUsecaseTestSuite) TestCancelOrder_NotBuyer() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Pending}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+
+	_, err := suite.useCase.CancelOrder(suite.ctx, "order1", "reseller1")
+
+	assert.ErrorIs(suite.T(), err, order.ErrNotOrderParty)
+}
+
+// TestForceCancelOrder_AfterShipment tests that an admin can cancel a shipped order
+func (suite *OrderUsecaseTestSuite) TestForceCancelOrder_AfterShipment() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Shipped}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Shipped).Return(nil)
+	suite.productRepo.On("Restock", suite.ctx, "p1").Return(nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{
+		{ID: "pay1", OrderID: "order1", Amount: money.New(5000, money.ETB), Status: payment.StatusRefunded},
+	}, nil)
+
+	canceled, err := suite.useCase.ForceCancelOrder(suite.ctx, "order1")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.OrderStatusCanceled, canceled.Status)
+}
+
+// TestForceCancelOrder_BundleReceived tests that a bundle the reseller already received
+// is refunded without returning it to sale or clearing the reseller's warehouse
+func (suite *OrderUsecaseTestSuite) TestForceCancelOrder_BundleReceived() {
+	o := &order.Order{ID: "order1", BundleID: "bundle1", SupplierID: "supplier1", ResellerID: "reseller1", Status: order.Shipped}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Shipped).Return(nil)
+	suite.warehouseRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{
+		{ID: "item1", BundleID: "bundle1", ResellerID: "reseller1", Status: warehouse.StatusReceived, RemainingItemCount: 3},
+		{ID: "piece1", BundleID: "bundle1", ResellerID: "reseller1", EntryID: "item1", ProductID: "p1", Status: warehouse.StatusListed},
+	}, nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{
+		{ID: "pay1", OrderID: "order1", Amount: money.New(10000, money.ETB), Status: payment.StatusRefunded},
+	}, nil)
+
+	canceled, err := suite.useCase.ForceCancelOrder(suite.ctx, "order1")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.OrderStatusCanceled, canceled.Status)
+	suite.bundleRepo.AssertNotCalled(suite.T(), "ReleasePurchase", mock.Anything, mock.Anything, mock.Anything)
+	suite.warehouseRepo.AssertNotCalled(suite.T(), "DeleteItem", mock.Anything, mock.Anything)
+}
+
+// TestForceCancelOrder_Delivered tests that canceling a delivered order, as a full refund
+// ruled in a dispute does, refunds the buyer without putting the products back on sale
+func (suite *OrderUsecaseTestSuite) TestForceCancelOrder_Delivered() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Delivered}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.orderRepo.On("TransitionOrderStatus", suite.ctx, o, order.Delivered).Return(nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{
+		{ID: "pay1", OrderID: "order1", Amount: money.New(5000, money.ETB), Status: payment.StatusRefunded},
+	}, nil)
+
+	canceled, err := suite.useCase.ForceCancelOrder(suite.ctx, "order1")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), order.OrderStatusCanceled, canceled.Status)
+	suite.productRepo.AssertNotCalled(suite.T(), "Restock", mock.Anything, mock.Anything)
+}
+
+// TestRefundOrder_Partial tests that a partial refund reverses a proportional share of the fee
+// and keeps the payment captured
+func (suite *OrderUsecaseTestSuite) TestRefundOrder_Partial() {
+	charge, _ := suite.gateway.Authorize(suite.ctx, payment.AuthorizeRequest{Amount: money.New(10000, money.ETB)})
+	suite.gateway.Capture(suite.ctx, charge.ID)
+
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Delivered}
+	paid := &payment.Payment{ID: "pay1", OrderID: "order1", Amount: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), SellerEarning: money.New(9800, money.ETB), Status: payment.StatusCaptured, GatewayChargeID: charge.ID}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "", "order1").Return(money.Totals{}, nil)
+	suite.ledgerRepo.On("RecordTransaction", suite.ctx, mock.MatchedBy(func(t *ledger.Transaction) bool {
+		// Nothing is held for a delivered order, so the refund comes out of the available balance.
+		for _, e := range t.Entries {
+			if e.Account == ledger.AccountSellerPending {
+				return false
+			}
+		}
+		return t.Kind == ledger.KindRefund && t.Validate() == nil
+	})).Return(nil)
+
+	refund, err := suite.useCase.RefundOrder(suite.ctx, "order1", money.New(2500, money.ETB))
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), money.New(-2500, money.ETB), refund.Amount)
+	assert.Equal(suite.T(), money.New(-50, money.ETB), refund.PlatformFee)
+	assert.Equal(suite.T(), money.New(-2450, money.ETB), refund.SellerEarning)
+	suite.paymentRepo.AssertNotCalled(suite.T(), "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+	c, _ := suite.gateway.Charge(charge.ID)
+	assert.Equal(suite.T(), money.New(2500, money.ETB), c.AmountRefunded)
+}
+
+// TestRefundOrder_RetriedTransaction tests that retrying the transaction recording a refund
+// does not return the money twice
+func (suite *OrderUsecaseTestSuite) TestRefundOrder_RetriedTransaction() {
+	charge, _ := suite.gateway.Authorize(suite.ctx, payment.AuthorizeRequest{Amount: money.New(10000, money.ETB)})
+	suite.gateway.Capture(suite.ctx, charge.ID)
+	suite.useCase.txManager = retryingTxManager{}
+
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Delivered}
+	paid := &payment.Payment{ID: "pay1", OrderID: "order1", Amount: money.New(10000, money.ETB), PlatformFee: money.New(200, money.ETB), SellerEarning: money.New(9800, money.ETB), Status: payment.StatusCaptured, GatewayChargeID: charge.ID}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{paid}, nil)
+	suite.paymentRepo.On("RecordPayment", suite.ctx, mock.AnythingOfType("*payment.Payment")).Return(nil)
+	suite.ledgerRepo.On("OrderAccountBalance", suite.ctx, ledger.AccountSellerPending, "", "order1").Return(money.Totals{}, nil)
+	suite.expectPosting(ledger.KindRefund, 2)
+
+	_, err := suite.useCase.RefundOrder(suite.ctx, "order1", money.New(2500, money.ETB))
+
+	suite.Require().NoError(err)
+	c, _ := suite.gateway.Charge(charge.ID)
+	assert.Equal(suite.T(), money.New(2500, money.ETB), c.AmountRefunded)
+}
+
+// TestRefundOrder_ExceedsBalance tests that earlier refunds count against what is left to refund
+func (suite *OrderUsecaseTestSuite) TestRefundOrder_ExceedsBalance() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", ProductIDs: []string{"p1"}, Status: order.Delivered}
+	suite.orderRepo.On("GetOrderByID", suite.ctx, "order1").Return(o, nil)
+	suite.paymentRepo.On("GetPaymentsByOrderID", suite.ctx, "order1").Return([]*payment.Payment{
+		{ID: "pay1", OrderID: "order1", Amount: money.New(10000, money.ETB), Status: payment.StatusCaptured},
+		{ID: "refund1", OrderID: "order1", Amount: money.New(-8000, money.ETB), Status: payment.StatusRefunded, RefundOf: "pay1"},
+	}, nil)
+
+	_, err := suite.useCase.RefundOrder(suite.ctx, "order1", money.New(3000, money.ETB))
+
+	assert.ErrorIs(suite.T(), err, payment.ErrInvalidRefundAmount)
 }
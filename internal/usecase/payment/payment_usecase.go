@@ -0,0 +1,99 @@
+package paymentusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	OrderUsecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/order"
+)
+
+type paymentUsecase struct {
+	paymentRepo payment.Repository
+	eventRepo   payment.EventRepository
+	orderUC     OrderUsecase.OrderUseCase
+}
+
+func NewPaymentUsecase(paymentRepo payment.Repository, eventRepo payment.EventRepository, orderUC OrderUsecase.OrderUseCase) payment.Usecase {
+	return &paymentUsecase{
+		paymentRepo: paymentRepo,
+		eventRepo:   eventRepo,
+		orderUC:     orderUC,
+	}
+}
+
+func (u *paymentUsecase) HandleWebhookEvent(ctx context.Context, e *payment.WebhookEvent) error {
+	processed, err := u.eventRepo.HasProcessedEvent(ctx, e.ID)
+	if err != nil {
+		return err
+	}
+	if processed {
+		return nil
+	}
+
+	if e.Status != "" && e.ChargeID != "" {
+		if err := u.applyStatus(ctx, e.ChargeID, e.Status); err != nil {
+			return err
+		}
+	}
+
+	// Recorded last so a delivery that failed half way is applied again on retry.
+	return u.eventRepo.RecordEvent(ctx, e)
+}
+
+// applyStatus moves the charge's payments to status. Captures, refunds and failures
+// of an order's payments are settled by the order usecase, which books them in the
+// ledger and moves the order along.
+func (u *paymentUsecase) applyStatus(ctx context.Context, chargeID string, status payment.Status) error {
+	payments, err := u.paymentRepo.GetPaymentsByChargeID(ctx, chargeID)
+	if err != nil {
+		return err
+	}
+	if len(payments) == 0 {
+		return fmt.Errorf("%w %s", payment.ErrUnknownCharge, chargeID)
+	}
+
+	settled := make(map[string]bool)
+	for _, p := range payments {
+		if !payment.CanTransition(p.Status, status) {
+			continue
+		}
+		if p.OrderID != "" {
+			switch status {
+			case payment.StatusCaptured:
+				if err := u.orderUC.SettlePayment(ctx, p); err != nil {
+					return err
+				}
+				continue
+			case payment.StatusRefunded:
+				// A refund settles every payment of the order at once.
+				if settled[p.OrderID] {
+					continue
+				}
+				settled[p.OrderID] = true
+				_, err := u.orderUC.SettleRefund(ctx, p.OrderID)
+				if err == nil {
+					continue
+				}
+				if !errors.Is(err, order.ErrOrderNotFound) {
+					return err
+				}
+			case payment.StatusFailed:
+				err := u.orderUC.SettleFailure(ctx, p)
+				if err == nil {
+					continue
+				}
+				if !errors.Is(err, order.ErrOrderNotFound) {
+					return err
+				}
+			}
+		}
+
+		if err := u.paymentRepo.UpdatePaymentStatus(ctx, p.ID, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,385 @@
+package paymentusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) RecordPayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByType(ctx context.Context, userID string, pType payment.PaymentType) ([]*payment.Payment, error) {
+	args := m.Called(ctx, userID, pType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetAllPlatformFees(ctx context.Context) (money.Totals, money.Totals, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(money.Totals), args.Get(1).(money.Totals), args.Error(2)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByChargeID(ctx context.Context, chargeID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, chargeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*payment.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*payment.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) UpdatePaymentStatus(ctx context.Context, paymentID string, status payment.Status) error {
+	args := m.Called(ctx, paymentID, status)
+	return args.Error(0)
+}
+
+type MockEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventRepository) HasProcessedEvent(ctx context.Context, eventID string) (bool, error) {
+	args := m.Called(ctx, eventID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEventRepository) RecordEvent(ctx context.Context, e *payment.WebhookEvent) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+type MockOrderUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOrderUseCase) PurchaseBundle(ctx context.Context, bundleID string, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID, resellerID, currency, delivery)
+	if args.Get(0) == nil {
+		return nil, nil, nil, args.Error(3)
+	}
+	return args.Get(0).(*order.Order), args.Get(1).(*payment.Payment), args.Get(2).(*warehouse.WarehouseItem), args.Error(3)
+}
+
+func (m *MockOrderUseCase) GetDashboardMetrics(ctx context.Context, supplierID string) (*order.DashboardMetrics, error) {
+	args := m.Called(ctx, supplierID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.DashboardMetrics), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.ResellerMetrics), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
+}
+
+func (m *MockOrderUseCase) GetOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
+}
+
+func (m *MockOrderUseCase) GetOrdersByConsumer(ctx context.Context, consumerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, map[string]string, error) {
+	args := m.Called(ctx, consumerID, req)
+	if args.Get(0) == nil {
+		return nil, nil, nil, args.Error(3)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Get(2).(map[string]string), args.Error(3)
+}
+
+func (m *MockOrderUseCase) PurchaseProducts(ctx context.Context, consumerID string, products []*product.Product, currency money.Currency, delivery shipping.Selection) ([]*order.Order, []*payment.Payment, error) {
+	args := m.Called(ctx, consumerID, products, currency, delivery)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*order.Order), args.Get(1).([]*payment.Payment), args.Error(2)
+}
+
+func (m *MockOrderUseCase) MarkOrderProcessing(ctx context.Context, orderID string, sellerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) MarkOrderShipped(ctx context.Context, orderID string, sellerID string, trackingNumber string, carrier string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID, trackingNumber, carrier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ConfirmDelivery(ctx context.Context, orderID string, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RecordDelivery(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) CancelOrder(ctx context.Context, orderID string, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RefundOrder(ctx context.Context, orderID string, amount money.Money) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettlePayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCase) SettleRefund(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettleFailure(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+// PaymentUsecaseTestSuite is the test suite for payment usecase
+type PaymentUsecaseTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	paymentRepo *MockPaymentRepository
+	eventRepo   *MockEventRepository
+	orderUC     *MockOrderUseCase
+	usecase     payment.Usecase
+}
+
+func (suite *PaymentUsecaseTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.paymentRepo = new(MockPaymentRepository)
+	suite.eventRepo = new(MockEventRepository)
+	suite.orderUC = new(MockOrderUseCase)
+	suite.usecase = NewPaymentUsecase(suite.paymentRepo, suite.eventRepo, suite.orderUC)
+}
+
+func (suite *PaymentUsecaseTestSuite) TearDownTest() {
+	suite.paymentRepo.AssertExpectations(suite.T())
+	suite.eventRepo.AssertExpectations(suite.T())
+	suite.orderUC.AssertExpectations(suite.T())
+}
+
+func TestPaymentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PaymentUsecaseTestSuite))
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_CapturedSettlesPayment() {
+	e := &payment.WebhookEvent{ID: "evt_1", ChargeID: "pi_1", Status: payment.StatusCaptured}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", FromUserID: "consumer1", ToUserID: "reseller1", Status: payment.StatusAuthorized}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_1").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{p}, nil)
+	suite.orderUC.On("SettlePayment", suite.ctx, p).Return(nil)
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	err := suite.usecase.HandleWebhookEvent(suite.ctx, e)
+
+	suite.NoError(err)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_FailedFailsOrder() {
+	e := &payment.WebhookEvent{ID: "evt_1", ChargeID: "pi_1", Status: payment.StatusFailed}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", FromUserID: "consumer1", ToUserID: "reseller1", Status: payment.StatusAuthorized}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_1").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{p}, nil)
+	suite.orderUC.On("SettleFailure", suite.ctx, p).Return(nil)
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	err := suite.usecase.HandleWebhookEvent(suite.ctx, e)
+
+	suite.NoError(err)
+	suite.paymentRepo.AssertNotCalled(suite.T(), "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_RefundSettlesOrderOnce() {
+	e := &payment.WebhookEvent{ID: "evt_refund", ChargeID: "pi_1", Status: payment.StatusRefunded}
+	goods := &payment.Payment{ID: "pay1", OrderID: "order1", Status: payment.StatusCaptured}
+	other := &payment.Payment{ID: "pay2", OrderID: "order1", Status: payment.StatusCaptured}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_refund").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{goods, other}, nil)
+	suite.orderUC.On("SettleRefund", suite.ctx, "order1").Return(&order.Order{ID: "order1", Status: order.Delivered}, nil).Once()
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	suite.NoError(suite.usecase.HandleWebhookEvent(suite.ctx, e))
+	suite.paymentRepo.AssertNotCalled(suite.T(), "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_RefundForMissingOrder() {
+	e := &payment.WebhookEvent{ID: "evt_refund", ChargeID: "pi_1", Status: payment.StatusRefunded}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", Status: payment.StatusCaptured}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_refund").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{p}, nil)
+	suite.orderUC.On("SettleRefund", suite.ctx, "order1").Return(nil, order.ErrOrderNotFound)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusRefunded).Return(nil)
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	suite.NoError(suite.usecase.HandleWebhookEvent(suite.ctx, e))
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_Replay() {
+	e := &payment.WebhookEvent{ID: "evt_1", ChargeID: "pi_1", Status: payment.StatusRefunded}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_1").Return(true, nil)
+
+	err := suite.usecase.HandleWebhookEvent(suite.ctx, e)
+
+	suite.NoError(err)
+	suite.paymentRepo.AssertNotCalled(suite.T(), "GetPaymentsByChargeID", mock.Anything, mock.Anything)
+	suite.eventRepo.AssertNotCalled(suite.T(), "RecordEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_OutOfOrderIsIgnored() {
+	// The authorization notice arrives after the payment was already captured.
+	e := &payment.WebhookEvent{ID: "evt_auth", ChargeID: "pi_1", Status: payment.StatusAuthorized}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", Status: payment.StatusCaptured}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_auth").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{p}, nil)
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	err := suite.usecase.HandleWebhookEvent(suite.ctx, e)
+
+	suite.NoError(err)
+	suite.paymentRepo.AssertNotCalled(suite.T(), "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+	suite.orderUC.AssertNotCalled(suite.T(), "SettlePayment", mock.Anything, mock.Anything)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_SkipsMissedSteps() {
+	// A refund arrives for a payment whose capture event was never seen.
+	e := &payment.WebhookEvent{ID: "evt_refund", ChargeID: "pi_1", Status: payment.StatusRefunded}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", Status: payment.StatusAuthorized}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_refund").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{p}, nil)
+	suite.orderUC.On("SettleRefund", suite.ctx, "order1").Return(&order.Order{ID: "order1", Status: order.OrderStatusCanceled}, nil)
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	suite.NoError(suite.usecase.HandleWebhookEvent(suite.ctx, e))
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_FailedAfterCaptureIsIgnored() {
+	e := &payment.WebhookEvent{ID: "evt_fail", ChargeID: "pi_1", Status: payment.StatusFailed}
+	p := &payment.Payment{ID: "pay1", OrderID: "order1", Status: payment.StatusCaptured}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_fail").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{p}, nil)
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	suite.NoError(suite.usecase.HandleWebhookEvent(suite.ctx, e))
+	suite.paymentRepo.AssertNotCalled(suite.T(), "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_UnknownChargeIsRetried() {
+	e := &payment.WebhookEvent{ID: "evt_1", ChargeID: "pi_new", Status: payment.StatusCaptured}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_1").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_new").Return([]*payment.Payment{}, nil)
+
+	err := suite.usecase.HandleWebhookEvent(suite.ctx, e)
+
+	suite.ErrorIs(err, payment.ErrUnknownCharge)
+	suite.eventRepo.AssertNotCalled(suite.T(), "RecordEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_UpdateFailureIsNotRecorded() {
+	e := &payment.WebhookEvent{ID: "evt_1", ChargeID: "pi_1", Status: payment.StatusCaptured}
+	p := &payment.Payment{ID: "pay1", Status: payment.StatusAuthorized}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_1").Return(false, nil)
+	suite.paymentRepo.On("GetPaymentsByChargeID", suite.ctx, "pi_1").Return([]*payment.Payment{p}, nil)
+	suite.paymentRepo.On("UpdatePaymentStatus", suite.ctx, "pay1", payment.StatusCaptured).Return(errors.New("write failed"))
+
+	err := suite.usecase.HandleWebhookEvent(suite.ctx, e)
+
+	suite.EqualError(err, "write failed")
+	suite.eventRepo.AssertNotCalled(suite.T(), "RecordEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *PaymentUsecaseTestSuite) TestHandleWebhookEvent_UntrackedType() {
+	e := &payment.WebhookEvent{ID: "evt_1", Type: "customer.created"}
+	suite.eventRepo.On("HasProcessedEvent", suite.ctx, "evt_1").Return(false, nil)
+	suite.eventRepo.On("RecordEvent", suite.ctx, e).Return(nil)
+
+	suite.NoError(suite.usecase.HandleWebhookEvent(suite.ctx, e))
+}
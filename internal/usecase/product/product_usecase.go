@@ -3,23 +3,34 @@ package productusecase
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 )
 
 type productUsecase struct {
 	repo       product.Repository
 	bundleRepo bundle.Repository
+	converter  money.Converter
 }
 
-func NewProductUsecase(repo product.Repository, bundleRepo bundle.Repository) product.Usecase {
+func NewProductUsecase(repo product.Repository, bundleRepo bundle.Repository, converter money.Converter) product.Usecase {
 	return &productUsecase{
 		repo:       repo,
 		bundleRepo: bundleRepo,
+		converter:  converter,
 	}
 }
 func (uc *productUsecase) AddProduct(ctx context.Context, p *product.Product) error {
+	price, err := p.Price.Normalized()
+	if err != nil {
+		return err
+	}
+	p.Price = price
+
 	if p.BundleID != "" {
 		// Fetch bundle
 		b, err := uc.bundleRepo.GetBundleByID(ctx, p.BundleID)
@@ -59,12 +70,39 @@ func (uc *productUsecase) GetProductByTitle(ctx context.Context, title string) (
 	return uc.repo.GetProductByTitle(ctx, title)
 }
 
-func (uc *productUsecase) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
-	return uc.repo.ListProductsByReseller(ctx, resellerID, page, limit)
+func (uc *productUsecase) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	return uc.repo.ListProductsByReseller(ctx, resellerID, req)
+}
+
+func (uc *productUsecase) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	return uc.repo.ListAvailableProducts(ctx, req)
 }
 
-func (uc *productUsecase) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
-	return uc.repo.ListAvailableProducts(ctx, page, limit)
+// SearchProducts finds products on sale. Prices listed in other currencies are
+// compared with the price range, and sorted, at the configured exchange rates.
+func (uc *productUsecase) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Sort == "" {
+		q.Sort = product.SortRelevance
+	}
+	if q.Currency == "" {
+		q.Currency = money.DefaultCurrency
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := q.Request.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	q.Request = req
+
+	factors, err := money.MinorFactors(ctx, uc.converter, q.Currency)
+	if err != nil {
+		return nil, err
+	}
+	q.PriceFactors = factors
+	return uc.repo.SearchProducts(ctx, q)
 }
 
 func (uc *productUsecase) DeleteProduct(ctx context.Context, id string) error {
@@ -72,5 +110,12 @@ func (uc *productUsecase) DeleteProduct(ctx context.Context, id string) error {
 }
 
 func (uc *productUsecase) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	if raw, ok := updates["price"]; ok {
+		price, err := money.FromValue(raw)
+		if err != nil {
+			return err
+		}
+		updates["price"] = price
+	}
 	return uc.repo.UpdateProduct(ctx, id, updates)
 }
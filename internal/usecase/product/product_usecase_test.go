@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -40,20 +43,28 @@ func (m *MockRepository) GetProductByID(ctx context.Context, id string) (*produc
 	return args.Get(0).(*product.Product), args.Error(1)
 }
 
-func (m *MockRepository) ListProductsByReseller(ctx context.Context, resellerID string, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, resellerID, page, limit)
+func (m *MockRepository) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*product.Product), args.Error(1)
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
 }
 
-func (m *MockRepository) ListAvailableProducts(ctx context.Context, page, limit int) ([]*product.Product, error) {
-	args := m.Called(ctx, page, limit)
+func (m *MockRepository) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*product.Product), args.Error(1)
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
+}
+
+func (m *MockRepository) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
 }
 
 func (m *MockRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
@@ -82,6 +93,31 @@ func (m *MockRepository) GetSoldProductsByReseller(ctx context.Context, reseller
 	return args.Get(0).([]*product.Product), args.Error(1)
 }
 
+func (m *MockRepository) Reserve(ctx context.Context, id, userID string, until time.Time) error {
+	args := m.Called(ctx, id, userID, until)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ReleaseReservation(ctx context.Context, id, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) Restock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) MarkAsSold(ctx context.Context, id, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
 type MockBundleRepository struct {
 	mock.Mock
 }
@@ -107,19 +143,28 @@ func (m *MockBundleRepository) GetBundleByTitle(ctx context.Context, title strin
 	return args.Get(0).(*bundle.Bundle), args.Error(1)
 }
 
-func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, supplierID)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, resellerID)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
 func (m *MockBundleRepository) UpdateBundleStatus(ctx context.Context, id string, status string) error {
@@ -132,6 +177,11 @@ func (m *MockBundleRepository) MarkAsPurchased(ctx context.Context, bundleID str
 	return args.Error(0)
 }
 
+func (m *MockBundleRepository) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
 func (m *MockBundleRepository) DeleteBundle(ctx context.Context, bundleID string) error {
 	args := m.Called(ctx, bundleID)
 	return args.Error(0)
@@ -147,19 +197,46 @@ func (m *MockBundleRepository) DecreaseBundleQuantity(ctx context.Context, bundl
 	return args.Error(0)
 }
 
+// staticRates converts with fixed exchange rates.
+type staticRates struct {
+	rates []*money.Rate
+}
+
+func (r *staticRates) Rate(ctx context.Context, from, to money.Currency) (*money.RateSnapshot, error) {
+	if from == to {
+		return nil, nil
+	}
+	for _, rate := range r.rates {
+		if rate.From == from && rate.To == to {
+			return rate.Snapshot(), nil
+		}
+	}
+	return nil, money.ErrRateNotFound
+}
+
+func (r *staticRates) Convert(ctx context.Context, m money.Money, to money.Currency) (money.Money, error) {
+	snapshot, err := r.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return snapshot.Convert(m), nil
+}
+
 // ---------------- Test Suite ----------------
 
 type ProductUsecaseTestSuite struct {
 	suite.Suite
 	mockRepo       *MockRepository
 	mockBundleRepo *MockBundleRepository
+	rates          *staticRates
 	usecase        product.Usecase
 }
 
 func (suite *ProductUsecaseTestSuite) SetupTest() {
 	suite.mockRepo = new(MockRepository)
 	suite.mockBundleRepo = new(MockBundleRepository)
-	suite.usecase = NewProductUsecase(suite.mockRepo, suite.mockBundleRepo)
+	suite.rates = &staticRates{}
+	suite.usecase = NewProductUsecase(suite.mockRepo, suite.mockBundleRepo, suite.rates)
 }
 
 func (suite *ProductUsecaseTestSuite) TestAddProduct_Success() {
@@ -290,10 +367,11 @@ func (suite *ProductUsecaseTestSuite) TestListProductsByReseller_Success() {
 		},
 	}
 
-	suite.mockRepo.On("ListProductsByReseller", ctx, resellerID.Hex(), 1, 10).Return(expectedProducts, nil)
-	products, err := suite.usecase.ListProductsByReseller(ctx, resellerID.Hex(), 1, 10)
+	req := pagination.Request{Limit: 10}
+	suite.mockRepo.On("ListProductsByReseller", ctx, resellerID.Hex(), req).Return(&pagination.Page[*product.Product]{Items: expectedProducts, Total: 2}, nil)
+	page, err := suite.usecase.ListProductsByReseller(ctx, resellerID.Hex(), req)
 	suite.NoError(err)
-	suite.Equal(expectedProducts, products)
+	suite.Equal(expectedProducts, page.Items)
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
@@ -314,10 +392,11 @@ func (suite *ProductUsecaseTestSuite) TestListAvailableProducts_Success() {
 		},
 	}
 
-	suite.mockRepo.On("ListAvailableProducts", ctx, 1, 10).Return(expectedProducts, nil)
-	products, err := suite.usecase.ListAvailableProducts(ctx, 1, 10)
+	req := pagination.Request{Cursor: "next", Limit: 10}
+	suite.mockRepo.On("ListAvailableProducts", ctx, req).Return(&pagination.Page[*product.Product]{Items: expectedProducts, Total: 12}, nil)
+	page, err := suite.usecase.ListAvailableProducts(ctx, req)
 	suite.NoError(err)
-	suite.Equal(expectedProducts, products)
+	suite.Equal(expectedProducts, page.Items)
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
@@ -334,15 +413,69 @@ func (suite *ProductUsecaseTestSuite) TestUpdateProduct_Success() {
 	ctx := context.Background()
 	updates := map[string]interface{}{
 		"title": "Updated Title",
-		"price": 99.99,
+		"price": map[string]interface{}{"amount": 9999.0, "currency": "ETB"},
 	}
 
-	suite.mockRepo.On("UpdateProduct", ctx, "test-id", updates).Return(nil)
+	suite.mockRepo.On("UpdateProduct", ctx, "test-id", map[string]interface{}{
+		"title": "Updated Title",
+		"price": money.New(9999, money.ETB),
+	}).Return(nil)
 	err := suite.usecase.UpdateProduct(ctx, "test-id", updates)
 	suite.NoError(err)
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ProductUsecaseTestSuite) TestUpdateProduct_BarePrice() {
+	ctx := context.Background()
+	err := suite.usecase.UpdateProduct(ctx, "test-id", map[string]interface{}{"price": 99.99})
+	suite.ErrorIs(err, money.ErrInvalidAmount)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateProduct", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUsecaseTestSuite) TestSearchProducts_PriceFactors() {
+	ctx := context.Background()
+	suite.rates.rates = []*money.Rate{{From: money.USD, To: money.ETB, Rate: 57}}
+	result := &product.SearchResult{}
+	suite.mockRepo.On("SearchProducts", ctx, mock.AnythingOfType("*product.SearchQuery")).Return(result, nil)
+
+	q := &product.SearchQuery{Text: "  denim jacket "}
+	got, err := suite.usecase.SearchProducts(ctx, q)
+
+	suite.NoError(err)
+	suite.Same(result, got)
+	suite.Equal("denim jacket", q.Text)
+	suite.Equal(product.SortRelevance, q.Sort)
+	suite.Equal(money.ETB, q.Currency)
+	// Only currencies with a rate to the search currency can be compared
+	suite.Equal(map[money.Currency]float64{money.ETB: 1, money.USD: 57}, q.PriceFactors)
+}
+
+func (suite *ProductUsecaseTestSuite) TestSearchProducts_InvalidRange() {
+	ctx := context.Background()
+	minPrice, maxPrice := money.New(50000, money.ETB), money.New(10000, money.ETB)
+
+	_, err := suite.usecase.SearchProducts(ctx, &product.SearchQuery{MinPrice: &minPrice, MaxPrice: &maxPrice})
+
+	suite.ErrorIs(err, product.ErrInvalidSearch)
+	suite.mockRepo.AssertNotCalled(suite.T(), "SearchProducts", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUsecaseTestSuite) TestSearchProducts_InvalidLimit() {
+	q := &product.SearchQuery{}
+	q.Limit = pagination.MaxLimit + 1
+
+	_, err := suite.usecase.SearchProducts(context.Background(), q)
+
+	suite.ErrorIs(err, pagination.ErrInvalidLimit)
+	suite.mockRepo.AssertNotCalled(suite.T(), "SearchProducts", mock.Anything, mock.Anything)
+}
+
+func (suite *ProductUsecaseTestSuite) TestSearchProducts_UnknownSort() {
+	_, err := suite.usecase.SearchProducts(context.Background(), &product.SearchQuery{Sort: "cheapest"})
+
+	suite.ErrorIs(err, product.ErrInvalidSearch)
+}
+
 func TestProductUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductUsecaseTestSuite))
 }
@@ -0,0 +1,109 @@
+package ratingusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/rating"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+)
+
+type ratingUsecase struct {
+	ratingRepo    rating.Repository
+	orderRepo     order.Repository
+	bundleRepo    bundle.Repository
+	warehouseRepo warehouse.Repository
+}
+
+func NewRatingUsecase(ratingRepo rating.Repository, orderRepo order.Repository, bundleRepo bundle.Repository, warehouseRepo warehouse.Repository) rating.Usecase {
+	return &ratingUsecase{
+		ratingRepo:    ratingRepo,
+		orderRepo:     orderRepo,
+		bundleRepo:    bundleRepo,
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+func (u *ratingUsecase) RateSupplier(ctx context.Context, resellerID, bundleID string, score int, comment string) (*rating.Rating, error) {
+	if score < 1 || score > 5 {
+		return nil, rating.ErrInvalidScore
+	}
+
+	purchase, err := u.orderRepo.GetBundleOrder(ctx, resellerID, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		return nil, rating.ErrBundleNotPurchased
+	}
+
+	existing, err := u.ratingRepo.GetRatingByResellerAndBundle(ctx, resellerID, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, rating.ErrAlreadyRated
+	}
+
+	skipRate, err := u.skipRate(ctx, resellerID, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keyed by reseller and bundle, so a second rating that raced past the check
+	// above is refused when it is stored.
+	r := &rating.Rating{
+		ID:         resellerID + ":" + bundleID,
+		ResellerID: resellerID,
+		SupplierID: purchase.SupplierID,
+		BundleID:   bundleID,
+		Score:      score,
+		Comment:    comment,
+		SkipRate:   skipRate,
+		CreatedAt:  time.Now().Format(time.RFC3339),
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if err := u.ratingRepo.CreateRating(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// skipRate is the share of the bundle's pieces the reseller marked as skipped in
+// their warehouse, i.e. pieces that were not good enough to list.
+func (u *ratingUsecase) skipRate(ctx context.Context, resellerID, bundleID string) (float64, error) {
+	items, err := u.warehouseRepo.GetItemsByBundle(ctx, bundleID)
+	if err != nil {
+		return 0, err
+	}
+
+	total, skipped := 0, 0
+	for _, item := range items {
+		if item.ResellerID != resellerID || !item.IsPiece() {
+			continue
+		}
+		total++
+		if item.Status == warehouse.StatusSkipped {
+			skipped++
+		}
+	}
+
+	// Prefer the bundle's declared piece count when it is known, since the
+	// warehouse only holds documents for pieces that have been handled.
+	if b, err := u.bundleRepo.GetBundleByID(ctx, bundleID); err == nil && b != nil && b.Quantity > total {
+		total = b.Quantity
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(skipped) / float64(total), nil
+}
+
+func (u *ratingUsecase) GetSupplierRatings(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*rating.Rating], error) {
+	return u.ratingRepo.GetRatingsBySupplier(ctx, supplierID, req)
+}
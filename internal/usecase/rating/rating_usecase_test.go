@@ -0,0 +1,364 @@
+package ratingusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/rating"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockRatingRepository struct {
+	mock.Mock
+}
+
+func (m *MockRatingRepository) CreateRating(ctx context.Context, r *rating.Rating) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockRatingRepository) GetRatingByResellerAndBundle(ctx context.Context, resellerID string, bundleID string) (*rating.Rating, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*rating.Rating), args.Error(1)
+}
+
+func (m *MockRatingRepository) GetRatingsBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*rating.Rating], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*rating.Rating]), args.Error(1)
+}
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, o *order.Order) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersByConsumer(ctx context.Context, consumerID string, status order.OrderStatus, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, consumerID, status, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderID string, status order.OrderStatus) error {
+	args := m.Called(ctx, orderID, status)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) TransitionOrderStatus(ctx context.Context, o *order.Order, from order.OrderStatus) error {
+	args := m.Called(ctx, o, from)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) DeleteOrder(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ListOrdersBySupplier(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetBundleOrder(ctx context.Context, resellerID, bundleID string) (*order.Order, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+type MockBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepository) CreateBundle(ctx context.Context, b *bundle.Bundle) error {
+	args := m.Called(ctx, b)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) GetBundleByID(ctx context.Context, id string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
+}
+
+func (m *MockBundleRepository) UpdateBundleStatus(ctx context.Context, id string, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) MarkAsPurchased(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) DeleteBundle(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) UpdateBundle(ctx context.Context, id string, updatedData map[string]interface{}) error {
+	args := m.Called(ctx, id, updatedData)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) DecreaseBundleQuantity(ctx context.Context, bundleID string) error {
+	args := m.Called(ctx, bundleID)
+	return args.Error(0)
+}
+
+func (m *MockBundleRepository) CountBundles(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBundleRepository) GetBundleByTitle(ctx context.Context, title string) (*bundle.Bundle, error) {
+	args := m.Called(ctx, title)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bundle.Bundle), args.Error(1)
+}
+
+type MockWarehouseRepository struct {
+	mock.Mock
+}
+
+func (m *MockWarehouseRepository) AddItem(ctx context.Context, item *warehouse.WarehouseItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockWarehouseRepository) ListItemsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*warehouse.WarehouseItem], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*warehouse.WarehouseItem]), args.Error(1)
+}
+
+func (m *MockWarehouseRepository) GetItemsByBundle(ctx context.Context, bundleID string) ([]*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseRepository) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseRepository) UpdateItemStatus(ctx context.Context, itemID, from string, updates map[string]interface{}) error {
+	args := m.Called(ctx, itemID, from, updates)
+	return args.Error(0)
+}
+
+func (m *MockWarehouseRepository) TakePiece(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockWarehouseRepository) DeleteItem(ctx context.Context, itemID string) error {
+	args := m.Called(ctx, itemID)
+	return args.Error(0)
+}
+
+func (m *MockWarehouseRepository) HasResellerReceivedBundle(ctx context.Context, resellerID string, bundleID string) (bool, error) {
+	args := m.Called(ctx, resellerID, bundleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWarehouseRepository) CountByStatus(ctx context.Context, status string) (int, error) {
+	args := m.Called(ctx, status)
+	return args.Int(0), args.Error(1)
+}
+
+// RatingUsecaseTestSuite is the test suite for rating usecase
+type RatingUsecaseTestSuite struct {
+	suite.Suite
+	ctx           context.Context
+	ratingRepo    *MockRatingRepository
+	orderRepo     *MockOrderRepository
+	bundleRepo    *MockBundleRepository
+	warehouseRepo *MockWarehouseRepository
+	usecase       rating.Usecase
+}
+
+func (suite *RatingUsecaseTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.ratingRepo = new(MockRatingRepository)
+	suite.orderRepo = new(MockOrderRepository)
+	suite.bundleRepo = new(MockBundleRepository)
+	suite.warehouseRepo = new(MockWarehouseRepository)
+	suite.usecase = NewRatingUsecase(suite.ratingRepo, suite.orderRepo, suite.bundleRepo, suite.warehouseRepo)
+}
+
+func (suite *RatingUsecaseTestSuite) TearDownTest() {
+	suite.ratingRepo.AssertExpectations(suite.T())
+	suite.orderRepo.AssertExpectations(suite.T())
+	suite.bundleRepo.AssertExpectations(suite.T())
+	suite.warehouseRepo.AssertExpectations(suite.T())
+}
+
+func TestRatingUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RatingUsecaseTestSuite))
+}
+
+func (suite *RatingUsecaseTestSuite) TestRateSupplier_Success() {
+	purchase := &order.Order{ID: "order1", BundleID: "bundle1", ResellerID: "reseller1", SupplierID: "supplier1", Status: order.OrderStatusCompleted}
+	items := []*warehouse.WarehouseItem{
+		{ID: "w0", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusReceived},
+		{ID: "w1", ResellerID: "reseller1", BundleID: "bundle1", EntryID: "w0", Status: warehouse.StatusListed},
+		{ID: "w2", ResellerID: "reseller1", BundleID: "bundle1", EntryID: "w0", Status: warehouse.StatusSkipped},
+		{ID: "w3", ResellerID: "reseller2", BundleID: "bundle1", EntryID: "w9", Status: warehouse.StatusSkipped},
+	}
+	suite.orderRepo.On("GetBundleOrder", suite.ctx, "reseller1", "bundle1").Return(purchase, nil)
+	suite.ratingRepo.On("GetRatingByResellerAndBundle", suite.ctx, "reseller1", "bundle1").Return(nil, nil)
+	suite.warehouseRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return(items, nil)
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", Quantity: 4}, nil)
+	suite.ratingRepo.On("CreateRating", suite.ctx, mock.AnythingOfType("*rating.Rating")).Return(nil)
+
+	r, err := suite.usecase.RateSupplier(suite.ctx, "reseller1", "bundle1", 4, "Mostly as described")
+
+	suite.NoError(err)
+	suite.Equal("reseller1:bundle1", r.ID)
+	suite.Equal("supplier1", r.SupplierID)
+	suite.Equal(4, r.Score)
+	suite.InDelta(0.25, r.SkipRate, 0.0001)
+}
+
+func (suite *RatingUsecaseTestSuite) TestRateSupplier_NotPurchased() {
+	suite.orderRepo.On("GetBundleOrder", suite.ctx, "reseller1", "bundle1").Return(nil, nil)
+
+	r, err := suite.usecase.RateSupplier(suite.ctx, "reseller1", "bundle1", 5, "")
+
+	suite.Nil(r)
+	suite.ErrorIs(err, rating.ErrBundleNotPurchased)
+}
+
+func (suite *RatingUsecaseTestSuite) TestRateSupplier_AlreadyRated() {
+	purchase := &order.Order{ID: "order1", BundleID: "bundle1", ResellerID: "reseller1", SupplierID: "supplier1"}
+	suite.orderRepo.On("GetBundleOrder", suite.ctx, "reseller1", "bundle1").Return(purchase, nil)
+	suite.ratingRepo.On("GetRatingByResellerAndBundle", suite.ctx, "reseller1", "bundle1").
+		Return(&rating.Rating{ID: "rating1"}, nil)
+
+	_, err := suite.usecase.RateSupplier(suite.ctx, "reseller1", "bundle1", 5, "")
+
+	suite.ErrorIs(err, rating.ErrAlreadyRated)
+	suite.ratingRepo.AssertNotCalled(suite.T(), "CreateRating", mock.Anything, mock.Anything)
+}
+
+// TestRateSupplier_Raced tests that a rating stored while this one was being made is
+// reported as already rated
+func (suite *RatingUsecaseTestSuite) TestRateSupplier_Raced() {
+	purchase := &order.Order{ID: "order1", BundleID: "bundle1", ResellerID: "reseller1", SupplierID: "supplier1"}
+	suite.orderRepo.On("GetBundleOrder", suite.ctx, "reseller1", "bundle1").Return(purchase, nil)
+	suite.ratingRepo.On("GetRatingByResellerAndBundle", suite.ctx, "reseller1", "bundle1").Return(nil, nil)
+	suite.warehouseRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{}, nil)
+	suite.bundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1"}, nil)
+	suite.ratingRepo.On("CreateRating", suite.ctx, mock.AnythingOfType("*rating.Rating")).Return(rating.ErrAlreadyRated)
+
+	_, err := suite.usecase.RateSupplier(suite.ctx, "reseller1", "bundle1", 5, "")
+
+	suite.ErrorIs(err, rating.ErrAlreadyRated)
+}
+
+func (suite *RatingUsecaseTestSuite) TestRateSupplier_InvalidScore() {
+	_, err := suite.usecase.RateSupplier(suite.ctx, "reseller1", "bundle1", 6, "")
+
+	suite.ErrorIs(err, rating.ErrInvalidScore)
+}
+
+func (suite *RatingUsecaseTestSuite) TestRateSupplier_OrderLookupFails() {
+	suite.orderRepo.On("GetBundleOrder", suite.ctx, "reseller1", "bundle1").Return(nil, errors.New("db down"))
+
+	_, err := suite.usecase.RateSupplier(suite.ctx, "reseller1", "bundle1", 3, "")
+
+	suite.EqualError(err, "db down")
+}
+
+func (suite *RatingUsecaseTestSuite) TestGetSupplierRatings() {
+	req := pagination.Request{Limit: 10}
+	page := &pagination.Page[*rating.Rating]{Items: []*rating.Rating{{ID: "r1", SupplierID: "supplier1", Score: 4}}, Total: 1}
+	suite.ratingRepo.On("GetRatingsBySupplier", suite.ctx, "supplier1", req).Return(page, nil)
+
+	result, err := suite.usecase.GetSupplierRatings(suite.ctx, "supplier1", req)
+
+	suite.NoError(err)
+	suite.Equal(page, result)
+}
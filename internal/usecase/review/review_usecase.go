@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/review"
 	"github.com/google/uuid"
 )
@@ -40,7 +41,7 @@ func (u *reviewUsecase) SubmitReview(ctx context.Context, r *review.Review) erro
 	}
 	fmt.Printf("✅ Found order: %+v\n", order)
 
-	if order.Status != "completed" {
+	if order.Status != "delivered" && order.Status != "completed" {
 		fmt.Printf("❌ Order not delivered. Current status: %s\n", order.Status)
 		return errors.New("cannot review before delivery")
 	}
@@ -71,15 +72,15 @@ func (u *reviewUsecase) SubmitReview(ctx context.Context, r *review.Review) erro
 	return nil
 }
 
-func (u *reviewUsecase) GetResellerReviews(ctx context.Context, resellerID string) ([]*review.Review, error) {
+func (u *reviewUsecase) GetResellerReviews(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*review.Review], error) {
 	fmt.Printf("🔍 Fetching reviews for reseller: %s\n", resellerID)
 	
-	reviews, err := u.reviewRepo.GetReviewsByReseller(ctx, resellerID)
+	page, err := u.reviewRepo.ListReviewsByReseller(ctx, resellerID, req)
 	if err != nil {
 		fmt.Printf("❌ Error fetching reseller reviews: %v\n", err)
 		return nil, fmt.Errorf("failed to fetch reseller reviews: %w", err)
 	}
 
-	fmt.Printf("✅ Found %d reviews for reseller\n", len(reviews))
-	return reviews, nil
+	fmt.Printf("✅ Found %d reviews for reseller\n", len(page.Items))
+	return page, nil
 }
@@ -0,0 +1,63 @@
+package shipmentusecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+)
+
+type dispatcher struct {
+	repo     shipment.Repository
+	carriers []shipment.Carrier
+}
+
+// NewDispatcher books parcels with the given carriers. Orders shipped with any
+// other carrier are tracked under the tracking number the seller entered.
+func NewDispatcher(repo shipment.Repository, carriers ...shipment.Carrier) shipment.Dispatcher {
+	return &dispatcher{repo: repo, carriers: carriers}
+}
+
+func (d *dispatcher) carrier(name string) shipment.Carrier {
+	for _, c := range d.carriers {
+		if strings.EqualFold(c.Name(), name) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (d *dispatcher) Dispatch(ctx context.Context, o *order.Order) (*shipment.Shipment, error) {
+	if c := d.carrier(o.Carrier); c != nil {
+		trackingNumber, err := c.Book(ctx, shipment.Parcel{OrderID: o.ID, TrackingNumber: o.TrackingNumber})
+		if err != nil {
+			return nil, fmt.Errorf("booking with %s: %w", c.Name(), err)
+		}
+		o.Carrier = c.Name()
+		o.TrackingNumber = trackingNumber
+	}
+	if o.TrackingNumber == "" {
+		return nil, order.ErrMissingTracking
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	s := &shipment.Shipment{
+		ID:             o.ID,
+		OrderID:        o.ID,
+		SellerID:       o.SellerID(),
+		BuyerID:        o.BuyerID(),
+		Carrier:        o.Carrier,
+		TrackingNumber: o.TrackingNumber,
+		Status:         shipment.LabelCreated,
+		Events:         []shipment.TrackingEvent{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := d.repo.SaveShipment(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
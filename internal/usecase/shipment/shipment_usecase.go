@@ -0,0 +1,109 @@
+package shipmentusecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	OrderUsecase "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/usecase/order"
+)
+
+type shipmentUsecase struct {
+	repo      shipment.Repository
+	orderUC   OrderUsecase.OrderUseCase
+	publisher event.Publisher
+}
+
+func NewShipmentUsecase(repo shipment.Repository, orderUC OrderUsecase.OrderUseCase, publisher event.Publisher) shipment.Usecase {
+	return &shipmentUsecase{
+		repo:      repo,
+		orderUC:   orderUC,
+		publisher: publisher,
+	}
+}
+
+func (u *shipmentUsecase) GetShipment(ctx context.Context, userID, orderID string) (*shipment.Shipment, error) {
+	s, err := u.repo.GetShipmentByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, shipment.ErrShipmentNotFound
+	}
+	if s.BuyerID != userID && s.SellerID != userID {
+		return nil, shipment.ErrNotShipmentParty
+	}
+	return s, nil
+}
+
+func (u *shipmentUsecase) HandleTrackingUpdate(ctx context.Context, up *shipment.Update) error {
+	if err := up.Validate(); err != nil {
+		return err
+	}
+
+	s, err := u.repo.GetShipmentByTracking(ctx, up.Carrier, up.TrackingNumber)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return shipment.ErrUnknownParcel
+	}
+
+	if s.HasEvent(up.Event.ID) {
+		// A redelivered event. If recording the delivery failed the first time,
+		// the retry gets another go at it.
+		if up.Event.Status == shipment.Delivered {
+			return u.recordDelivery(ctx, s)
+		}
+		return nil
+	}
+
+	events := len(s.Events)
+	s.Record(up.Event)
+	s.UpdatedAt = time.Now().Format(time.RFC3339)
+	if err := u.repo.UpdateShipment(ctx, s, events); err != nil {
+		return err
+	}
+
+	u.publisher.Publish(ctx, &event.Event{
+		Type:   event.ShipmentUpdated,
+		UserID: s.BuyerID,
+		Payload: event.ShipmentPayload{
+			OrderID:        s.OrderID,
+			TrackingNumber: s.TrackingNumber,
+			Status:         string(up.Event.Status),
+			Description:    up.Event.Description,
+			Location:       up.Event.Location,
+		},
+	})
+
+	if up.Event.Status == shipment.Delivered {
+		return u.recordDelivery(ctx, s)
+	}
+	return nil
+}
+
+func (u *shipmentUsecase) recordDelivery(ctx context.Context, s *shipment.Shipment) error {
+	_, err := u.orderUC.RecordDelivery(ctx, s.OrderID)
+	return err
+}
+
+// Consume records the updates a carrier pushes over a channel, such as the
+// simulator's, until the channel is closed or the context is cancelled.
+func Consume(ctx context.Context, updates <-chan *shipment.Update, uc shipment.Usecase) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case up, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := uc.HandleTrackingUpdate(ctx, up); err != nil {
+				log.Printf("Failed to record tracking event %s for %s: %v", up.Event.ID, up.TrackingNumber, err)
+			}
+		}
+	}
+}
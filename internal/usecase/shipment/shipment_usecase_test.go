@@ -0,0 +1,398 @@
+package shipmentusecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/event"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/order"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/payment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipment"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
+	eventinfra "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/infrastructure/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockShipmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockShipmentRepository) SaveShipment(ctx context.Context, s *shipment.Shipment) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockShipmentRepository) GetShipmentByOrderID(ctx context.Context, orderID string) (*shipment.Shipment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) GetShipmentByTracking(ctx context.Context, carrier string, trackingNumber string) (*shipment.Shipment, error) {
+	args := m.Called(ctx, carrier, trackingNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipment.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) UpdateShipment(ctx context.Context, s *shipment.Shipment, events int) error {
+	args := m.Called(ctx, s, events)
+	return args.Error(0)
+}
+
+type MockOrderUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOrderUseCase) PurchaseBundle(ctx context.Context, bundleID string, resellerID string, currency money.Currency, delivery shipping.Selection) (*order.Order, *payment.Payment, *warehouse.WarehouseItem, error) {
+	args := m.Called(ctx, bundleID, resellerID, currency, delivery)
+	if args.Get(0) == nil {
+		return nil, nil, nil, args.Error(3)
+	}
+	return args.Get(0).(*order.Order), args.Get(1).(*payment.Payment), args.Get(2).(*warehouse.WarehouseItem), args.Error(3)
+}
+
+func (m *MockOrderUseCase) GetDashboardMetrics(ctx context.Context, supplierID string) (*order.DashboardMetrics, error) {
+	args := m.Called(ctx, supplierID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.DashboardMetrics), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetOrderByID(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetResellerMetrics(ctx context.Context, resellerID string) (*order.ResellerMetrics, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.ResellerMetrics), args.Error(1)
+}
+
+func (m *MockOrderUseCase) GetSoldBundleHistory(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
+}
+
+func (m *MockOrderUseCase) GetOrdersByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Error(2)
+}
+
+func (m *MockOrderUseCase) GetOrdersByConsumer(ctx context.Context, consumerID string, req pagination.Request) (*pagination.Page[*order.Order], map[string]string, map[string]string, error) {
+	args := m.Called(ctx, consumerID, req)
+	if args.Get(0) == nil {
+		return nil, nil, nil, args.Error(3)
+	}
+	return args.Get(0).(*pagination.Page[*order.Order]), args.Get(1).(map[string]string), args.Get(2).(map[string]string), args.Error(3)
+}
+
+func (m *MockOrderUseCase) PurchaseProducts(ctx context.Context, consumerID string, products []*product.Product, currency money.Currency, delivery shipping.Selection) ([]*order.Order, []*payment.Payment, error) {
+	args := m.Called(ctx, consumerID, products, currency, delivery)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*order.Order), args.Get(1).([]*payment.Payment), args.Error(2)
+}
+
+func (m *MockOrderUseCase) MarkOrderProcessing(ctx context.Context, orderID string, sellerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) MarkOrderShipped(ctx context.Context, orderID string, sellerID string, trackingNumber string, carrier string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, sellerID, trackingNumber, carrier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ConfirmDelivery(ctx context.Context, orderID string, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RecordDelivery(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) CancelOrder(ctx context.Context, orderID string, buyerID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID, buyerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) ForceCancelOrder(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) RefundOrder(ctx context.Context, orderID string, amount money.Money) (*payment.Payment, error) {
+	args := m.Called(ctx, orderID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*payment.Payment), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettlePayment(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockOrderUseCase) SettleRefund(ctx context.Context, orderID string) (*order.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*order.Order), args.Error(1)
+}
+
+func (m *MockOrderUseCase) SettleFailure(ctx context.Context, p *payment.Payment) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+// stubCarrier books every parcel, issuing issued as the tracking number when the
+// seller did not enter one.
+type stubCarrier struct {
+	issued string
+	err    error
+}
+
+func (c *stubCarrier) Name() string {
+	return "simulator"
+}
+
+func (c *stubCarrier) Book(ctx context.Context, p shipment.Parcel) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	if p.TrackingNumber != "" {
+		return p.TrackingNumber, nil
+	}
+	return c.issued, nil
+}
+
+type ShipmentUsecaseTestSuite struct {
+	suite.Suite
+	repo       *MockShipmentRepository
+	orderUC    *MockOrderUseCase
+	hub        event.Hub
+	usecase    shipment.Usecase
+	dispatcher shipment.Dispatcher
+	carrier    *stubCarrier
+	ctx        context.Context
+}
+
+func (suite *ShipmentUsecaseTestSuite) SetupTest() {
+	suite.repo = new(MockShipmentRepository)
+	suite.orderUC = new(MockOrderUseCase)
+	suite.hub = eventinfra.NewHub()
+	suite.carrier = &stubCarrier{issued: "SIM0000000001"}
+	suite.usecase = NewShipmentUsecase(suite.repo, suite.orderUC, suite.hub)
+	suite.dispatcher = NewDispatcher(suite.repo, suite.carrier)
+	suite.ctx = context.Background()
+}
+
+func (suite *ShipmentUsecaseTestSuite) TearDownTest() {
+	suite.repo.AssertExpectations(suite.T())
+	suite.orderUC.AssertExpectations(suite.T())
+}
+
+func TestShipmentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ShipmentUsecaseTestSuite))
+}
+
+func testShipment() *shipment.Shipment {
+	return &shipment.Shipment{
+		ID:             "order1",
+		OrderID:        "order1",
+		SellerID:       "reseller1",
+		BuyerID:        "consumer1",
+		Carrier:        "simulator",
+		TrackingNumber: "SIM0000000001",
+		Status:         shipment.LabelCreated,
+		Events:         []shipment.TrackingEvent{},
+	}
+}
+
+func trackingUpdate(id string, status shipment.Status, at string) *shipment.Update {
+	return &shipment.Update{
+		Carrier:        "simulator",
+		TrackingNumber: "SIM0000000001",
+		Event:          shipment.TrackingEvent{ID: id, Status: status, Description: string(status), OccurredAt: at},
+	}
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestDispatch_CarrierIssuesTracking() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", Carrier: "Simulator"}
+	suite.repo.On("SaveShipment", suite.ctx, mock.AnythingOfType("*shipment.Shipment")).Return(nil)
+
+	s, err := suite.dispatcher.Dispatch(suite.ctx, o)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "SIM0000000001", o.TrackingNumber)
+	assert.Equal(suite.T(), "simulator", o.Carrier)
+	assert.Equal(suite.T(), "order1", s.ID)
+	assert.Equal(suite.T(), "reseller1", s.SellerID)
+	assert.Equal(suite.T(), "consumer1", s.BuyerID)
+	assert.NotNil(suite.T(), s.Events)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestDispatch_OtherCarrierNeedsTracking() {
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", Carrier: "DHL"}
+
+	_, err := suite.dispatcher.Dispatch(suite.ctx, o)
+
+	assert.ErrorIs(suite.T(), err, order.ErrMissingTracking)
+	suite.repo.AssertNotCalled(suite.T(), "SaveShipment", mock.Anything, mock.Anything)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestDispatch_BookingFails() {
+	suite.carrier.err = errors.New("carrier unavailable")
+	o := &order.Order{ID: "order1", ResellerID: "reseller1", ConsumerID: "consumer1", Carrier: "simulator"}
+
+	_, err := suite.dispatcher.Dispatch(suite.ctx, o)
+
+	assert.Error(suite.T(), err)
+	suite.repo.AssertNotCalled(suite.T(), "SaveShipment", mock.Anything, mock.Anything)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestGetShipment_NotParty() {
+	suite.repo.On("GetShipmentByOrderID", suite.ctx, "order1").Return(testShipment(), nil)
+
+	_, err := suite.usecase.GetShipment(suite.ctx, "someone", "order1")
+
+	assert.ErrorIs(suite.T(), err, shipment.ErrNotShipmentParty)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestGetShipment_NotFound() {
+	suite.repo.On("GetShipmentByOrderID", suite.ctx, "order1").Return(nil, nil)
+
+	_, err := suite.usecase.GetShipment(suite.ctx, "consumer1", "order1")
+
+	assert.ErrorIs(suite.T(), err, shipment.ErrShipmentNotFound)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestHandleTrackingUpdate_AppendsAndNotifies() {
+	s := testShipment()
+	suite.repo.On("GetShipmentByTracking", suite.ctx, "simulator", "SIM0000000001").Return(s, nil)
+	suite.repo.On("UpdateShipment", suite.ctx, s, 0).Return(nil)
+	events, unsubscribe := suite.hub.Subscribe("consumer1")
+	defer unsubscribe()
+
+	err := suite.usecase.HandleTrackingUpdate(suite.ctx, trackingUpdate("e1", shipment.InTransit, "2025-01-02T10:00:00Z"))
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), shipment.InTransit, s.Status)
+	suite.Require().Len(s.Events, 1)
+	suite.Require().Len(events, 1)
+	e := <-events
+	assert.Equal(suite.T(), event.ShipmentUpdated, e.Type)
+	assert.Equal(suite.T(), "in_transit", e.Payload.(event.ShipmentPayload).Status)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestHandleTrackingUpdate_OutOfOrder() {
+	s := testShipment()
+	s.Events = []shipment.TrackingEvent{{ID: "e2", Status: shipment.OutForDelivery, OccurredAt: "2025-01-03T08:00:00Z"}}
+	s.Status = shipment.OutForDelivery
+	suite.repo.On("GetShipmentByTracking", suite.ctx, "simulator", "SIM0000000001").Return(s, nil)
+	suite.repo.On("UpdateShipment", suite.ctx, s, 1).Return(nil)
+
+	err := suite.usecase.HandleTrackingUpdate(suite.ctx, trackingUpdate("e1", shipment.InTransit, "2025-01-02T10:00:00Z"))
+
+	// A late event slots into the timeline without moving the status back
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "e1", s.Events[0].ID)
+	assert.Equal(suite.T(), shipment.OutForDelivery, s.Status)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestHandleTrackingUpdate_Duplicate() {
+	s := testShipment()
+	s.Events = []shipment.TrackingEvent{{ID: "e1", Status: shipment.InTransit, OccurredAt: "2025-01-02T10:00:00Z"}}
+	suite.repo.On("GetShipmentByTracking", suite.ctx, "simulator", "SIM0000000001").Return(s, nil)
+
+	err := suite.usecase.HandleTrackingUpdate(suite.ctx, trackingUpdate("e1", shipment.InTransit, "2025-01-02T10:00:00Z"))
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateShipment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestHandleTrackingUpdate_DeliveredMovesOrder() {
+	s := testShipment()
+	suite.repo.On("GetShipmentByTracking", suite.ctx, "simulator", "SIM0000000001").Return(s, nil)
+	suite.repo.On("UpdateShipment", suite.ctx, s, 0).Return(nil)
+	suite.orderUC.On("RecordDelivery", suite.ctx, "order1").Return(&order.Order{ID: "order1", Status: order.Delivered}, nil)
+
+	err := suite.usecase.HandleTrackingUpdate(suite.ctx, trackingUpdate("e4", shipment.Delivered, "2025-01-04T12:00:00Z"))
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), shipment.Delivered, s.Status)
+	assert.Equal(suite.T(), "2025-01-04T12:00:00Z", s.DeliveredAt)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestHandleTrackingUpdate_RedeliveredDeliveryRetriesOrder() {
+	s := testShipment()
+	s.Events = []shipment.TrackingEvent{{ID: "e4", Status: shipment.Delivered, OccurredAt: "2025-01-04T12:00:00Z"}}
+	suite.repo.On("GetShipmentByTracking", suite.ctx, "simulator", "SIM0000000001").Return(s, nil)
+	suite.orderUC.On("RecordDelivery", suite.ctx, "order1").Return(&order.Order{ID: "order1", Status: order.Delivered}, nil)
+
+	err := suite.usecase.HandleTrackingUpdate(suite.ctx, trackingUpdate("e4", shipment.Delivered, "2025-01-04T12:00:00Z"))
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateShipment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestHandleTrackingUpdate_UnknownParcel() {
+	suite.repo.On("GetShipmentByTracking", suite.ctx, "simulator", "SIM0000000001").Return(nil, nil)
+
+	err := suite.usecase.HandleTrackingUpdate(suite.ctx, trackingUpdate("e1", shipment.InTransit, "2025-01-02T10:00:00Z"))
+
+	assert.ErrorIs(suite.T(), err, shipment.ErrUnknownParcel)
+}
+
+func (suite *ShipmentUsecaseTestSuite) TestHandleTrackingUpdate_InvalidEvent() {
+	err := suite.usecase.HandleTrackingUpdate(suite.ctx, trackingUpdate("e1", "lost", "yesterday"))
+
+	assert.ErrorIs(suite.T(), err, shipment.ErrInvalidEvent)
+}
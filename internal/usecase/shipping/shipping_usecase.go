@@ -0,0 +1,155 @@
+package shippingusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type shippingUsecase struct {
+	addressRepo shipping.AddressRepository
+	rates       *shipping.RateTable
+}
+
+func NewShippingUsecase(addressRepo shipping.AddressRepository, rates *shipping.RateTable) shipping.Usecase {
+	return &shippingUsecase{
+		addressRepo: addressRepo,
+		rates:       rates,
+	}
+}
+
+// AddAddress saves a new address book entry. A user's first address becomes their
+// default.
+func (u *shippingUsecase) AddAddress(ctx context.Context, userID string, a *shipping.Address) (*shipping.Address, error) {
+	a.Normalize()
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.addressRepo.ListAddressesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	a.ID = primitive.NewObjectID().Hex()
+	a.UserID = userID
+	a.IsDefault = a.IsDefault || len(existing) == 0
+	a.CreatedAt = time.Now().Format(time.RFC3339)
+	if err := u.addressRepo.CreateAddress(ctx, a); err != nil {
+		return nil, err
+	}
+	if a.IsDefault && len(existing) > 0 {
+		if err := u.addressRepo.ClearDefault(ctx, userID, a.ID); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func (u *shippingUsecase) ListAddresses(ctx context.Context, userID string) ([]*shipping.Address, error) {
+	return u.addressRepo.ListAddressesByUser(ctx, userID)
+}
+
+// UpdateAddress replaces the entry's details. The default can be moved to another
+// address but not cleared, so a user with addresses always has a default.
+func (u *shippingUsecase) UpdateAddress(ctx context.Context, userID, id string, a *shipping.Address) (*shipping.Address, error) {
+	existing, err := u.ownAddress(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	a.Normalize()
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	a.ID = existing.ID
+	a.UserID = existing.UserID
+	a.CreatedAt = existing.CreatedAt
+	a.IsDefault = a.IsDefault || existing.IsDefault
+	if err := u.addressRepo.UpdateAddress(ctx, a); err != nil {
+		return nil, err
+	}
+	if a.IsDefault && !existing.IsDefault {
+		if err := u.addressRepo.ClearDefault(ctx, userID, a.ID); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// DeleteAddress removes the entry. Deleting the default hands it to the user's
+// oldest remaining address.
+func (u *shippingUsecase) DeleteAddress(ctx context.Context, userID, id string) error {
+	existing, err := u.ownAddress(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := u.addressRepo.DeleteAddress(ctx, userID, id); err != nil {
+		return err
+	}
+	if !existing.IsDefault {
+		return nil
+	}
+
+	remaining, err := u.addressRepo.ListAddressesByUser(ctx, userID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+	next := remaining[0]
+	for _, a := range remaining[1:] {
+		if a.CreatedAt < next.CreatedAt {
+			next = a
+		}
+	}
+	next.IsDefault = true
+	return u.addressRepo.UpdateAddress(ctx, next)
+}
+
+func (u *shippingUsecase) Options(ctx context.Context, userID, addressID string, items int) ([]*shipping.Quote, error) {
+	a, err := u.ownAddress(ctx, userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	quotes := []*shipping.Quote{}
+	for _, option := range []shipping.Option{shipping.Standard, shipping.Express} {
+		q, err := u.rates.Quote(a, option, items, now)
+		if err == shipping.ErrOptionUnavailable {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, q)
+	}
+	return quotes, nil
+}
+
+func (u *shippingUsecase) Quote(ctx context.Context, buyerID string, sel shipping.Selection, items int) (*shipping.Quote, error) {
+	if sel.AddressID == "" {
+		return nil, shipping.ErrAddressRequired
+	}
+	if !sel.Option.Valid() {
+		return nil, shipping.ErrInvalidOption
+	}
+	a, err := u.ownAddress(ctx, buyerID, sel.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	return u.rates.Quote(a, sel.Option, items, time.Now())
+}
+
+// ownAddress loads one of the user's addresses. Other users' addresses are
+// reported as missing.
+func (u *shippingUsecase) ownAddress(ctx context.Context, userID, id string) (*shipping.Address, error) {
+	a, err := u.addressRepo.GetAddressByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil || a.UserID != userID {
+		return nil, shipping.ErrAddressNotFound
+	}
+	return a, nil
+}
@@ -0,0 +1,193 @@
+package shippingusecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/shipping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockAddressRepository struct {
+	mock.Mock
+}
+
+func (m *MockAddressRepository) CreateAddress(ctx context.Context, a *shipping.Address) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAddressRepository) GetAddressByID(ctx context.Context, id string) (*shipping.Address, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shipping.Address), args.Error(1)
+}
+
+func (m *MockAddressRepository) ListAddressesByUser(ctx context.Context, userID string) ([]*shipping.Address, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shipping.Address), args.Error(1)
+}
+
+func (m *MockAddressRepository) UpdateAddress(ctx context.Context, a *shipping.Address) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAddressRepository) DeleteAddress(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAddressRepository) ClearDefault(ctx context.Context, userID, exceptID string) error {
+	args := m.Called(ctx, userID, exceptID)
+	return args.Error(0)
+}
+
+type ShippingUsecaseTestSuite struct {
+	suite.Suite
+	repo    *MockAddressRepository
+	usecase shipping.Usecase
+	ctx     context.Context
+}
+
+func (suite *ShippingUsecaseTestSuite) SetupTest() {
+	suite.repo = new(MockAddressRepository)
+	suite.usecase = NewShippingUsecase(suite.repo, shipping.DefaultRateTable())
+	suite.ctx = context.Background()
+}
+
+func TestShippingUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ShippingUsecaseTestSuite))
+}
+
+func testAddress(id, city, country string) *shipping.Address {
+	return &shipping.Address{
+		ID:            id,
+		UserID:        "user1",
+		RecipientName: "Sara",
+		Phone:         "+251911000000",
+		Line1:         "Bole Road",
+		City:          city,
+		Country:       country,
+		CreatedAt:     "2025-01-01T00:00:00Z",
+	}
+}
+
+func (suite *ShippingUsecaseTestSuite) TestAddAddress_FirstBecomesDefault() {
+	suite.repo.On("ListAddressesByUser", suite.ctx, "user1").Return([]*shipping.Address{}, nil)
+	suite.repo.On("CreateAddress", suite.ctx, mock.AnythingOfType("*shipping.Address")).Return(nil)
+
+	a := testAddress("", " Addis Ababa ", "et")
+	got, err := suite.usecase.AddAddress(suite.ctx, "user1", a)
+
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), got.ID)
+	assert.True(suite.T(), got.IsDefault)
+	assert.Equal(suite.T(), "Addis Ababa", got.City)
+	assert.Equal(suite.T(), "ET", got.Country)
+	suite.repo.AssertNotCalled(suite.T(), "ClearDefault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShippingUsecaseTestSuite) TestAddAddress_NewDefaultClearsOthers() {
+	suite.repo.On("ListAddressesByUser", suite.ctx, "user1").Return([]*shipping.Address{testAddress("a1", "Addis Ababa", "ET")}, nil)
+	suite.repo.On("CreateAddress", suite.ctx, mock.AnythingOfType("*shipping.Address")).Return(nil)
+	suite.repo.On("ClearDefault", suite.ctx, "user1", mock.AnythingOfType("string")).Return(nil)
+
+	a := testAddress("", "Hawassa", "ET")
+	a.IsDefault = true
+	_, err := suite.usecase.AddAddress(suite.ctx, "user1", a)
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *ShippingUsecaseTestSuite) TestAddAddress_Incomplete() {
+	a := testAddress("", "", "ET")
+	_, err := suite.usecase.AddAddress(suite.ctx, "user1", a)
+
+	assert.ErrorIs(suite.T(), err, shipping.ErrIncompleteAddress)
+	suite.repo.AssertNotCalled(suite.T(), "CreateAddress", mock.Anything, mock.Anything)
+}
+
+func (suite *ShippingUsecaseTestSuite) TestUpdateAddress_OtherUsersAddress() {
+	a := testAddress("a1", "Addis Ababa", "ET")
+	a.UserID = "user2"
+	suite.repo.On("GetAddressByID", suite.ctx, "a1").Return(a, nil)
+
+	_, err := suite.usecase.UpdateAddress(suite.ctx, "user1", "a1", testAddress("", "Adama", "ET"))
+
+	assert.ErrorIs(suite.T(), err, shipping.ErrAddressNotFound)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateAddress", mock.Anything, mock.Anything)
+}
+
+func (suite *ShippingUsecaseTestSuite) TestDeleteAddress_PromotesOldestRemaining() {
+	deleted := testAddress("a1", "Addis Ababa", "ET")
+	deleted.IsDefault = true
+	newer := testAddress("a3", "Adama", "ET")
+	newer.CreatedAt = "2025-03-01T00:00:00Z"
+	older := testAddress("a2", "Hawassa", "ET")
+	older.CreatedAt = "2025-02-01T00:00:00Z"
+	suite.repo.On("GetAddressByID", suite.ctx, "a1").Return(deleted, nil)
+	suite.repo.On("DeleteAddress", suite.ctx, "user1", "a1").Return(nil)
+	suite.repo.On("ListAddressesByUser", suite.ctx, "user1").Return([]*shipping.Address{newer, older}, nil)
+	suite.repo.On("UpdateAddress", suite.ctx, mock.MatchedBy(func(a *shipping.Address) bool {
+		return a.ID == "a2" && a.IsDefault
+	})).Return(nil)
+
+	err := suite.usecase.DeleteAddress(suite.ctx, "user1", "a1")
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *ShippingUsecaseTestSuite) TestQuote_ZonesAndWeight() {
+	suite.repo.On("GetAddressByID", suite.ctx, "local").Return(testAddress("local", "addis ababa", "ET"), nil)
+	suite.repo.On("GetAddressByID", suite.ctx, "domestic").Return(testAddress("domestic", "Hawassa", "ET"), nil)
+	suite.repo.On("GetAddressByID", suite.ctx, "abroad").Return(testAddress("abroad", "Nairobi", "KE"), nil)
+
+	q, err := suite.usecase.Quote(suite.ctx, "user1", shipping.Selection{AddressID: "local", Option: shipping.Standard}, 1)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shipping.ZoneLocal, q.Zone)
+	assert.Equal(suite.T(), money.New(10000, money.ETB), q.Cost)
+	assert.NotEmpty(suite.T(), q.EstimatedDelivery)
+
+	// 8 items weigh 4kg.
+	q, err = suite.usecase.Quote(suite.ctx, "user1", shipping.Selection{AddressID: "domestic", Option: shipping.Express}, 8)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shipping.ZoneDomestic, q.Zone)
+	assert.Equal(suite.T(), money.New(80000, money.ETB), q.Cost)
+
+	// 50 items weigh 25kg: the 20kg band plus 5 started kilograms.
+	q, err = suite.usecase.Quote(suite.ctx, "user1", shipping.Selection{AddressID: "abroad", Option: shipping.Standard}, 50)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shipping.ZoneInternational, q.Zone)
+	assert.Equal(suite.T(), money.New(1800000+5*85000, money.ETB), q.Cost)
+	assert.Equal(suite.T(), 14, q.EstimatedDays)
+}
+
+func (suite *ShippingUsecaseTestSuite) TestQuote_RequiresSelection() {
+	_, err := suite.usecase.Quote(suite.ctx, "user1", shipping.Selection{Option: shipping.Standard}, 1)
+	assert.ErrorIs(suite.T(), err, shipping.ErrAddressRequired)
+
+	_, err = suite.usecase.Quote(suite.ctx, "user1", shipping.Selection{AddressID: "a1", Option: "overnight"}, 1)
+	assert.ErrorIs(suite.T(), err, shipping.ErrInvalidOption)
+}
+
+func (suite *ShippingUsecaseTestSuite) TestOptions_ListsBothOptions() {
+	suite.repo.On("GetAddressByID", suite.ctx, "a1").Return(testAddress("a1", "Addis Ababa", "ET"), nil)
+
+	quotes, err := suite.usecase.Options(suite.ctx, "user1", "a1", 3)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), quotes, 2)
+	assert.Equal(suite.T(), shipping.Standard, quotes[0].Option)
+	assert.Equal(suite.T(), shipping.Express, quotes[1].Option)
+}
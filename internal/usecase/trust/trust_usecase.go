@@ -10,6 +10,10 @@ import (
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 )
 
+// breakdownPenaltyScale is the rating penalty for a bundle whose contents
+// missed the estimated breakdown entirely. Smaller deviations scale down.
+const breakdownPenaltyScale = 5.0
+
 type trustUsecase struct {
 	productRepo product.Repository
 	bundleRepo  bundle.Repository
@@ -220,3 +224,19 @@ func (uc *trustUsecase) UpdateResellerTrustScoreOnNewRating(
 
 	return err
 }
+
+func (uc *trustUsecase) ApplyDisputePenalty(ctx context.Context, sellerID string, penalty float64) error {
+	seller, err := uc.userRepo.GetByID(ctx, sellerID)
+	if err != nil {
+		return err
+	}
+	if seller.Role == string(user.RoleSupplier) {
+		return uc.UpdateSupplierTrustScoreOnNewRating(ctx, sellerID, 0, penalty)
+	}
+	return uc.UpdateResellerTrustScoreOnNewRating(ctx, sellerID, 0, penalty)
+}
+
+func (uc *trustUsecase) ApplyBreakdownDeviation(ctx context.Context, supplierID string, deviationPercent float64) error {
+	penalty := math.Min(math.Abs(deviationPercent)/100, 1) * breakdownPenaltyScale
+	return uc.UpdateSupplierTrustScoreOnNewRating(ctx, supplierID, 0, penalty)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 )
 
@@ -51,12 +52,12 @@ func (m *mockUserRepo) FindUserByUsername(ctx context.Context, username string)
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
-func (m *mockUserRepo) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
-	args := m.Called(ctx)
+func (m *mockUserRepo) ListBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*user.User), args.Error(1)
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
 }
 
 func (m *mockUserRepo) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
@@ -67,12 +68,12 @@ func (m *mockUserRepo) GetUserByEmail(ctx context.Context, email string) (*user.
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
-func (m *mockUserRepo) ListUsersByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
-	args := m.Called(ctx, role)
+func (m *mockUserRepo) ListUsersByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	args := m.Called(ctx, roles, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*user.User), args.Error(1)
+	return args.Get(0).(*pagination.Page[*user.User]), args.Error(1)
 }
 
 func (m *mockUserRepo) UpdateUser(ctx context.Context, id string, updates map[string]interface{}) error {
@@ -278,4 +279,39 @@ func TestTrustUsecase_UpdateResellerTrustScoreOnNewRating(t *testing.T) {
 			assert.Equal(t, tt.expectedCount, reseller.TrustRatedCount)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestTrustUsecase_ApplyDisputePenalty(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	uc := NewTrustUsecase(nil, nil, mockRepo)
+
+	supplierID := primitive.NewObjectID().Hex()
+	supplier := &user.User{ID: supplierID, Role: string(user.RoleSupplier), TrustScore: 100}
+	mockRepo.On("GetByID", mock.Anything, supplierID).Return(supplier, nil)
+	mockRepo.On("UpdateTrustData", mock.Anything, supplier).Return(nil)
+
+	err := uc.ApplyDisputePenalty(context.Background(), supplierID, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 50, supplier.TrustScore)
+	assert.Equal(t, 5.0, supplier.TrustTotalError)
+	assert.Equal(t, 1, supplier.TrustRatedCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTrustUsecase_ApplyBreakdownDeviation(t *testing.T) {
+	mockRepo := new(mockUserRepo)
+	uc := NewTrustUsecase(nil, nil, mockRepo)
+
+	supplierID := primitive.NewObjectID().Hex()
+	supplier := &user.User{ID: supplierID, Role: string(user.RoleSupplier), TrustScore: 100}
+	mockRepo.On("GetByID", mock.Anything, supplierID).Return(supplier, nil)
+	mockRepo.On("UpdateTrustData", mock.Anything, supplier).Return(nil)
+
+	// Half the estimated pieces were off, which counts as half the full penalty
+	err := uc.ApplyBreakdownDeviation(context.Background(), supplierID, 50)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 75, supplier.TrustScore)
+	assert.Equal(t, 2.5, supplier.TrustTotalError)
+	mockRepo.AssertExpectations(t)
+}
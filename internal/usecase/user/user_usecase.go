@@ -3,6 +3,7 @@ package userusecase
 import (
 	"context"
 
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/user"
 )
 
@@ -22,8 +23,8 @@ func (uc *userUsecase) GetByEmail(ctx context.Context, email string) (*user.User
 	return uc.repo.GetUserByEmail(ctx, email)
 }
 
-func (uc *userUsecase) ListByRole(ctx context.Context, role user.Role) ([]*user.User, error) {
-	return uc.repo.ListUsersByRole(ctx, role)
+func (uc *userUsecase) ListByRole(ctx context.Context, roles []user.Role, req pagination.Request) (*pagination.Page[*user.User], error) {
+	return uc.repo.ListUsersByRole(ctx, roles, req)
 }
 
 func (uc *userUsecase) Update(ctx context.Context, id string, updates map[string]interface{}) error {
@@ -33,6 +34,6 @@ func (uc *userUsecase) Update(ctx context.Context, id string, updates map[string
 func (uc *userUsecase) Delete(ctx context.Context, id string) error {
 	return uc.repo.DeleteUser(ctx, id)
 }
-func (u *userUsecase) GetBlacklistedUsers(ctx context.Context) ([]*user.User, error) {
-	return u.repo.GetBlacklistedUsers(ctx)
+func (u *userUsecase) GetBlacklistedUsers(ctx context.Context, req pagination.Request) (*pagination.Page[*user.User], error) {
+	return u.repo.ListBlacklistedUsers(ctx, req)
 }
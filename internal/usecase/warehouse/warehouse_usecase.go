@@ -2,37 +2,50 @@ package warehouse_usecase
 
 import (
 	"context"
+	"log"
+	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/transaction"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/trust"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type WarehouseUseCase interface {
-	GetWarehouseItems(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error)
+	GetWarehouseItems(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*models.WarehouseItemResponse], error)
 }
 
 type warehouseUseCaseImpl struct {
 	warehouseRepo warehouse.Repository
 	bundleRepo    bundle.Repository
+	productRepo   product.Repository
+	trustUC       trust.Usecase
+	txManager     transaction.Manager
 }
 
-func NewWarehouseUseCase(warehouseRepo warehouse.Repository, bundleRepo bundle.Repository) warehouse.WarehouseUseCase {
+func NewWarehouseUseCase(warehouseRepo warehouse.Repository, bundleRepo bundle.Repository, productRepo product.Repository, trustUC trust.Usecase, txManager transaction.Manager) warehouse.WarehouseUseCase {
 	return &warehouseUseCaseImpl{
 		warehouseRepo: warehouseRepo,
 		bundleRepo:    bundleRepo,
+		productRepo:   productRepo,
+		trustUC:       trustUC,
+		txManager:     txManager,
 	}
 }
-func (uc *warehouseUseCaseImpl) GetWarehouseItems(ctx context.Context, resellerID string) ([]*models.WarehouseItemResponse, error) {
+func (uc *warehouseUseCaseImpl) GetWarehouseItems(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*models.WarehouseItemResponse], error) {
 	// Get the warehouse items by reseller
-	items, err := uc.warehouseRepo.GetItemsByReseller(ctx, resellerID)
+	items, err := uc.warehouseRepo.ListItemsByReseller(ctx, resellerID, req)
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialize responses as empty slice
-	responses := make([]*models.WarehouseItemResponse, 0)
-	for _, item := range items {
+	// Items whose bundle is gone are left out, so a page can come up short.
+	responses := make([]*models.WarehouseItemResponse, 0, len(items.Items))
+	for _, item := range items.Items {
 		bundle, err := uc.bundleRepo.GetBundleByID(ctx, item.BundleID)
 		if err != nil || bundle == nil {
 			continue // skip if bundle not found
@@ -51,5 +64,276 @@ func (uc *warehouseUseCaseImpl) GetWarehouseItems(ctx context.Context, resellerI
 		})
 	}
 
-	return responses, nil
+	return &pagination.Page[*models.WarehouseItemResponse]{
+		Items:      responses,
+		NextCursor: items.NextCursor,
+		Total:      items.Total,
+	}, nil
+}
+
+func (uc *warehouseUseCaseImpl) ReceiveBundle(ctx context.Context, resellerID, itemID string) (*warehouse.WarehouseItem, error) {
+	item, err := uc.resellerEntry(ctx, resellerID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != warehouse.StatusPending {
+		return nil, warehouse.ErrNotReceivable
+	}
+
+	receivedAt := time.Now().Format(time.RFC3339)
+	if err := uc.warehouseRepo.UpdateItemStatus(ctx, item.ID, warehouse.StatusPending, map[string]interface{}{
+		"status":      warehouse.StatusReceived,
+		"received_at": receivedAt,
+	}); err != nil {
+		return nil, err
+	}
+	item.Status = warehouse.StatusReceived
+	item.ReceivedAt = receivedAt
+	return item, nil
+}
+
+func (uc *warehouseUseCaseImpl) UnpackPiece(ctx context.Context, resellerID, itemID, productID string) (*warehouse.WarehouseItem, error) {
+	item, err := uc.unpackingEntry(ctx, resellerID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := uc.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || p.BundleID != item.BundleID || p.ResellerID.Hex() != resellerID {
+		return nil, warehouse.ErrProductNotFromBundle
+	}
+	pieces, err := uc.warehouseRepo.GetItemsByBundle(ctx, item.BundleID)
+	if err != nil {
+		return nil, err
+	}
+	for _, piece := range pieces {
+		if piece.EntryID == item.ID && piece.ProductID == productID {
+			return nil, warehouse.ErrProductAlreadyUnpacked
+		}
+	}
+
+	return uc.recordPiece(ctx, item, &warehouse.WarehouseItem{
+		Status:    warehouse.StatusListed,
+		ProductID: productID,
+		Type:      p.Type,
+	}, nil)
+}
+
+func (uc *warehouseUseCaseImpl) ListProduct(ctx context.Context, resellerID string, p *product.Product) (*warehouse.WarehouseItem, error) {
+	item, err := uc.bundleEntry(ctx, resellerID, p.BundleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUnpacking(item); err != nil {
+		return nil, err
+	}
+	price, err := p.Price.Normalized()
+	if err != nil {
+		return nil, err
+	}
+	p.Price = price
+
+	return uc.recordPiece(ctx, item, &warehouse.WarehouseItem{
+		Status:    warehouse.StatusListed,
+		ProductID: p.ID,
+		Type:      p.Type,
+	}, func(txCtx context.Context) error {
+		return uc.productRepo.AddProduct(txCtx, p)
+	})
+}
+
+func (uc *warehouseUseCaseImpl) SkipPiece(ctx context.Context, resellerID, itemID string, skip warehouse.Skip) (*warehouse.WarehouseItem, error) {
+	if !skip.Reason.Valid() {
+		return nil, warehouse.ErrInvalidSkipReason
+	}
+	item, err := uc.unpackingEntry(ctx, resellerID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.recordPiece(ctx, item, &warehouse.WarehouseItem{
+		Status:     warehouse.StatusSkipped,
+		Type:       skip.Type,
+		SkipReason: skip.Reason,
+		SkipNote:   skip.Note,
+	}, nil)
+}
+
+// resellerEntry loads a bundle entry from the reseller's warehouse.
+func (uc *warehouseUseCaseImpl) resellerEntry(ctx context.Context, resellerID, itemID string) (*warehouse.WarehouseItem, error) {
+	item, err := uc.warehouseRepo.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || item.IsPiece() {
+		return nil, warehouse.ErrItemNotFound
+	}
+	if item.ResellerID != resellerID {
+		return nil, warehouse.ErrNotItemOwner
+	}
+	return item, nil
+}
+
+func (uc *warehouseUseCaseImpl) unpackingEntry(ctx context.Context, resellerID, itemID string) (*warehouse.WarehouseItem, error) {
+	item, err := uc.resellerEntry(ctx, resellerID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUnpacking(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// bundleEntry finds the reseller's entry for a bundle.
+func (uc *warehouseUseCaseImpl) bundleEntry(ctx context.Context, resellerID, bundleID string) (*warehouse.WarehouseItem, error) {
+	items, err := uc.warehouseRepo.GetItemsByBundle(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if !item.IsPiece() && item.ResellerID == resellerID {
+			return item, nil
+		}
+	}
+	return nil, warehouse.ErrNotItemOwner
+}
+
+func checkUnpacking(item *warehouse.WarehouseItem) error {
+	switch {
+	case item.Unpacking():
+		return nil
+	case item.Status == warehouse.StatusClosed:
+		return warehouse.ErrNothingToUnpack
+	default:
+		return warehouse.ErrNotUnpacking
+	}
+}
+
+// recordPiece takes one piece off the entry's remaining count, saves the piece
+// and carries the new count over to the bundle. The entry is closed once no
+// pieces remain. The writes share a transaction, so a piece is never counted
+// without being saved. save, if set, runs in the same transaction once the
+// piece is taken.
+func (uc *warehouseUseCaseImpl) recordPiece(ctx context.Context, item *warehouse.WarehouseItem, piece *warehouse.WarehouseItem, save func(context.Context) error) (*warehouse.WarehouseItem, error) {
+	var entry *warehouse.WarehouseItem
+	err := uc.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		entry, err = uc.warehouseRepo.TakePiece(txCtx, item.ID)
+		if err != nil {
+			return err
+		}
+		if save != nil {
+			if err := save(txCtx); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now().Format(time.RFC3339)
+		piece.ID = primitive.NewObjectID().Hex()
+		piece.EntryID = entry.ID
+		piece.ResellerID = entry.ResellerID
+		piece.BundleID = entry.BundleID
+		piece.Grade = entry.Grade
+		if piece.Type == "" {
+			piece.Type = entry.Type
+		}
+		piece.SortingLevel = entry.SortingLevel
+		piece.CreatedAt = now
+		if err := uc.warehouseRepo.AddItem(txCtx, piece); err != nil {
+			return err
+		}
+
+		if err := uc.bundleRepo.UpdateBundle(txCtx, entry.BundleID, map[string]interface{}{
+			"remaining_item_count": entry.RemainingItemCount,
+		}); err != nil {
+			return err
+		}
+
+		if entry.RemainingItemCount > 0 {
+			return nil
+		}
+		if err := uc.warehouseRepo.UpdateItemStatus(txCtx, entry.ID, entry.Status, map[string]interface{}{
+			"status":    warehouse.StatusClosed,
+			"closed_at": now,
+		}); err != nil {
+			return err
+		}
+		entry.Status = warehouse.StatusClosed
+		entry.ClosedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Status == warehouse.StatusClosed {
+		uc.rateBreakdown(ctx, entry.BundleID)
+	}
+	return entry, nil
+}
+
+func (uc *warehouseUseCaseImpl) GetBundleBreakdown(ctx context.Context, bundleID string) (*bundle.Breakdown, error) {
+	b, err := uc.bundleRepo.GetBundleByID(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.breakdown(ctx, b)
+}
+
+func (uc *warehouseUseCaseImpl) breakdown(ctx context.Context, b *bundle.Bundle) (*bundle.Breakdown, error) {
+	products, err := uc.productRepo.GetProductsByBundleID(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.warehouseRepo.GetItemsByBundle(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := make(map[string]int)
+	for _, p := range products {
+		actual[p.Type]++
+	}
+	complete := false
+	for _, item := range items {
+		switch {
+		case item.IsPiece() && item.Status == warehouse.StatusSkipped:
+			actual[item.Type]++
+		case item.Status == warehouse.StatusClosed:
+			complete = true
+		}
+	}
+
+	breakdown := bundle.CompareBreakdown(b.EstimatedBreakdown, actual)
+	breakdown.Complete = complete
+	return breakdown, nil
+}
+
+// rateBreakdown feeds a closed bundle's deviation from its estimated breakdown
+// into the supplier's trust score. The pieces are already recorded, so failures
+// are only logged.
+func (uc *warehouseUseCaseImpl) rateBreakdown(ctx context.Context, bundleID string) {
+	if uc.trustUC == nil {
+		return
+	}
+	b, err := uc.bundleRepo.GetBundleByID(ctx, bundleID)
+	if err != nil || b == nil {
+		log.Printf("Failed to load bundle %s for its breakdown: %v", bundleID, err)
+		return
+	}
+	breakdown, err := uc.breakdown(ctx, b)
+	if err != nil {
+		log.Printf("Failed to compute breakdown of bundle %s: %v", bundleID, err)
+		return
+	}
+	if breakdown.DeviationPercent == nil {
+		return
+	}
+	if err := uc.trustUC.ApplyBreakdownDeviation(ctx, b.SupplierID, *breakdown.DeviationPercent); err != nil {
+		log.Printf("Failed to apply breakdown deviation of bundle %s: %v", bundleID, err)
+	}
 }
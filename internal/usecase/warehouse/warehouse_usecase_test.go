@@ -7,9 +7,13 @@ import (
 	"time"
 
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/pagination"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/product"
 	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/warehouse"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockRepository is a mock implementation of the warehouse.Repository interface
@@ -22,12 +26,12 @@ func (m *MockRepository) AddItem(ctx context.Context, item *warehouse.WarehouseI
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetItemsByReseller(ctx context.Context, resellerID string) ([]*warehouse.WarehouseItem, error) {
-	args := m.Called(ctx, resellerID)
+func (m *MockRepository) ListItemsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*warehouse.WarehouseItem], error) {
+	args := m.Called(ctx, resellerID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
+	return args.Get(0).(*pagination.Page[*warehouse.WarehouseItem]), args.Error(1)
 }
 
 func (m *MockRepository) GetItemsByBundle(ctx context.Context, bundleID string) ([]*warehouse.WarehouseItem, error) {
@@ -38,14 +42,25 @@ func (m *MockRepository) GetItemsByBundle(ctx context.Context, bundleID string)
 	return args.Get(0).([]*warehouse.WarehouseItem), args.Error(1)
 }
 
-func (m *MockRepository) MarkItemAsListed(ctx context.Context, itemID string) error {
+func (m *MockRepository) GetItemByID(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
 	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
+}
+
+func (m *MockRepository) UpdateItemStatus(ctx context.Context, itemID, from string, updates map[string]interface{}) error {
+	args := m.Called(ctx, itemID, from, updates)
 	return args.Error(0)
 }
 
-func (m *MockRepository) MarkItemAsSkipped(ctx context.Context, itemID string) error {
+func (m *MockRepository) TakePiece(ctx context.Context, itemID string) (*warehouse.WarehouseItem, error) {
 	args := m.Called(ctx, itemID)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*warehouse.WarehouseItem), args.Error(1)
 }
 
 func (m *MockRepository) DeleteItem(ctx context.Context, itemID string) error {
@@ -104,19 +119,28 @@ func (m *MockBundleRepository) DeleteBundle(ctx context.Context, bundleID string
 	return args.Error(0)
 }
 
-func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleRepository) ListAvailableBundles(ctx context.Context, q *bundle.Query) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, supplierID)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleRepository) ListBundles(ctx context.Context, supplierID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, supplierID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
-func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string) ([]*bundle.Bundle, error) {
-	args := m.Called(ctx, resellerID)
-	return args.Get(0).([]*bundle.Bundle), args.Error(1)
+func (m *MockBundleRepository) ListPurchasedByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*bundle.Bundle], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*bundle.Bundle]), args.Error(1)
 }
 
 func (m *MockBundleRepository) MarkAsPurchased(ctx context.Context, bundleID, resellerID string) error {
@@ -124,6 +148,11 @@ func (m *MockBundleRepository) MarkAsPurchased(ctx context.Context, bundleID, re
 	return args.Error(0)
 }
 
+func (m *MockBundleRepository) ReleasePurchase(ctx context.Context, bundleID string, resellerID string) error {
+	args := m.Called(ctx, bundleID, resellerID)
+	return args.Error(0)
+}
+
 func (m *MockBundleRepository) UpdateBundle(ctx context.Context, bundleID string, updates map[string]interface{}) error {
 	args := m.Called(ctx, bundleID, updates)
 	return args.Error(0)
@@ -134,11 +163,146 @@ func (m *MockBundleRepository) UpdateBundleStatus(ctx context.Context, bundleID
 	return args.Error(0)
 }
 
+// MockProductRepository is a mock implementation of the product.Repository interface
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) AddProduct(ctx context.Context, p *product.Product) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetProductByID(ctx context.Context, id string) (*product.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetProductByTitle(ctx context.Context, title string) (*product.Product, error) {
+	args := m.Called(ctx, title)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) ListProductsByReseller(ctx context.Context, resellerID string, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, resellerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
+}
+
+func (m *MockProductRepository) ListAvailableProducts(ctx context.Context, req pagination.Request) (*pagination.Page[*product.Product], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pagination.Page[*product.Product]), args.Error(1)
+}
+
+func (m *MockProductRepository) SearchProducts(ctx context.Context, q *product.SearchQuery) (*product.SearchResult, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchResult), args.Error(1)
+}
+
+func (m *MockProductRepository) DeleteProduct(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) UpdateProduct(ctx context.Context, id string, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetProductsByBundleID(ctx context.Context, bundleID string) ([]*product.Product, error) {
+	args := m.Called(ctx, bundleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetSoldProductsByReseller(ctx context.Context, resellerID string) ([]*product.Product, error) {
+	args := m.Called(ctx, resellerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) Reserve(ctx context.Context, id string, userID string, until time.Time) error {
+	args := m.Called(ctx, id, userID, until)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) ReleaseReservation(ctx context.Context, id string, userID string) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductRepository) MarkAsSold(ctx context.Context, id string, buyerID string) error {
+	args := m.Called(ctx, id, buyerID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Restock(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockTrustUsecase is a mock implementation of the trust.Usecase interface
+type MockTrustUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTrustUsecase) UpdateSupplierTrustScoreOnNewRating(ctx context.Context, supplierID string, declaredRating float64, productRating float64) error {
+	args := m.Called(ctx, supplierID, declaredRating, productRating)
+	return args.Error(0)
+}
+
+func (m *MockTrustUsecase) UpdateResellerTrustScoreOnNewRating(ctx context.Context, resellerID string, declaredRating float64, productRating float64) error {
+	args := m.Called(ctx, resellerID, declaredRating, productRating)
+	return args.Error(0)
+}
+
+func (m *MockTrustUsecase) ApplyDisputePenalty(ctx context.Context, sellerID string, penalty float64) error {
+	args := m.Called(ctx, sellerID, penalty)
+	return args.Error(0)
+}
+
+func (m *MockTrustUsecase) ApplyBreakdownDeviation(ctx context.Context, supplierID string, deviationPercent float64) error {
+	args := m.Called(ctx, supplierID, deviationPercent)
+	return args.Error(0)
+}
+
+// passthroughTxManager runs the callback directly; the repositories are mocked.
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 // WarehouseUsecaseTestSuite is the test suite for warehouse usecase
 type WarehouseUsecaseTestSuite struct {
 	suite.Suite
 	mockRepo       *MockRepository
 	mockBundleRepo *MockBundleRepository
+	mockProdRepo   *MockProductRepository
+	mockTrustUC    *MockTrustUsecase
 	usecase        warehouse.WarehouseUseCase
 	ctx            context.Context
 }
@@ -147,13 +311,15 @@ type WarehouseUsecaseTestSuite struct {
 func (suite *WarehouseUsecaseTestSuite) SetupTest() {
 	suite.mockRepo = new(MockRepository)
 	suite.mockBundleRepo = new(MockBundleRepository)
-	suite.usecase = NewWarehouseUseCase(suite.mockRepo, suite.mockBundleRepo)
+	suite.mockProdRepo = new(MockProductRepository)
+	suite.mockTrustUC = new(MockTrustUsecase)
+	suite.usecase = NewWarehouseUseCase(suite.mockRepo, suite.mockBundleRepo, suite.mockProdRepo, suite.mockTrustUC, passthroughTxManager{})
 	suite.ctx = context.Background()
 }
 
 // TestNewWarehouseUseCase tests the constructor
 func (suite *WarehouseUsecaseTestSuite) TestNewWarehouseUseCase() {
-	useCase := NewWarehouseUseCase(suite.mockRepo, suite.mockBundleRepo)
+	useCase := NewWarehouseUseCase(suite.mockRepo, suite.mockBundleRepo, suite.mockProdRepo, suite.mockTrustUC, passthroughTxManager{})
 	suite.NotNil(useCase)
 }
 
@@ -179,21 +345,22 @@ func (suite *WarehouseUsecaseTestSuite) TestGetWarehouseItems_Success() {
 		RemainingItemCount: 10,
 	}
 
-	suite.mockRepo.On("GetItemsByReseller", suite.ctx, resellerID).Return(items, nil)
+	suite.mockRepo.On("ListItemsByReseller", suite.ctx, resellerID, pagination.Request{}).
+		Return(&pagination.Page[*warehouse.WarehouseItem]{Items: items, Total: int64(len(items))}, nil)
 	suite.mockBundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(bundle, nil)
 
 	// Act
-	responses, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID)
+	page, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID, pagination.Request{})
 
 	// Assert
 	suite.NoError(err)
-	suite.NotNil(responses)
-	suite.Len(responses, 1)
-	suite.Equal("item1", responses[0].ID)
-	suite.Equal("Test Bundle", responses[0].Title)
-	suite.Equal("image.jpg", responses[0].SampleImage)
-	suite.Equal(float64(4), responses[0].DeclaredRating)
-	suite.Equal(10, responses[0].RemainingItems)
+	suite.NotNil(page)
+	suite.Len(page.Items, 1)
+	suite.Equal("item1", page.Items[0].ID)
+	suite.Equal("Test Bundle", page.Items[0].Title)
+	suite.Equal("image.jpg", page.Items[0].SampleImage)
+	suite.Equal(float64(4), page.Items[0].DeclaredRating)
+	suite.Equal(10, page.Items[0].RemainingItems)
 	suite.mockRepo.AssertExpectations(suite.T())
 	suite.mockBundleRepo.AssertExpectations(suite.T())
 }
@@ -213,16 +380,17 @@ func (suite *WarehouseUsecaseTestSuite) TestGetWarehouseItems_WithMissingBundle(
 		},
 	}
 
-	suite.mockRepo.On("GetItemsByReseller", suite.ctx, resellerID).Return(items, nil)
+	suite.mockRepo.On("ListItemsByReseller", suite.ctx, resellerID, pagination.Request{}).
+		Return(&pagination.Page[*warehouse.WarehouseItem]{Items: items, Total: int64(len(items))}, nil)
 	suite.mockBundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(nil, errors.New("bundle not found"))
 
 	// Act
-	responses, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID)
+	page, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID, pagination.Request{})
 
 	// Assert
 	suite.NoError(err)
-	suite.NotNil(responses)
-	suite.Empty(responses)
+	suite.NotNil(page)
+	suite.Empty(page.Items)
 	suite.mockRepo.AssertExpectations(suite.T())
 	suite.mockBundleRepo.AssertExpectations(suite.T())
 }
@@ -233,15 +401,16 @@ func (suite *WarehouseUsecaseTestSuite) TestGetWarehouseItems_EmptyList() {
 	resellerID := "reseller1"
 	items := []*warehouse.WarehouseItem{}
 
-	suite.mockRepo.On("GetItemsByReseller", suite.ctx, resellerID).Return(items, nil)
+	suite.mockRepo.On("ListItemsByReseller", suite.ctx, resellerID, pagination.Request{}).
+		Return(&pagination.Page[*warehouse.WarehouseItem]{Items: items, Total: int64(len(items))}, nil)
 
 	// Act
-	responses, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID)
+	page, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID, pagination.Request{})
 
 	// Assert
 	suite.NoError(err)
-	suite.NotNil(responses)
-	suite.Empty(responses)
+	suite.NotNil(page)
+	suite.Empty(page.Items)
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
@@ -251,15 +420,15 @@ func (suite *WarehouseUsecaseTestSuite) TestGetWarehouseItems_RepositoryError()
 	resellerID := "reseller1"
 	expectedErr := errors.New("database error")
 
-	suite.mockRepo.On("GetItemsByReseller", suite.ctx, resellerID).Return(nil, expectedErr)
+	suite.mockRepo.On("ListItemsByReseller", suite.ctx, resellerID, pagination.Request{}).Return(nil, expectedErr)
 
 	// Act
-	responses, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID)
+	page, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID, pagination.Request{})
 
 	// Assert
 	suite.Error(err)
 	suite.Equal(expectedErr, err)
-	suite.Nil(responses)
+	suite.Nil(page)
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
@@ -300,23 +469,301 @@ func (suite *WarehouseUsecaseTestSuite) TestGetWarehouseItems_MultipleItems() {
 		RemainingItemCount: 5,
 	}
 
-	suite.mockRepo.On("GetItemsByReseller", suite.ctx, resellerID).Return(items, nil)
+	suite.mockRepo.On("ListItemsByReseller", suite.ctx, resellerID, pagination.Request{}).
+		Return(&pagination.Page[*warehouse.WarehouseItem]{Items: items, Total: int64(len(items))}, nil)
 	suite.mockBundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(bundle1, nil)
 	suite.mockBundleRepo.On("GetBundleByID", suite.ctx, "bundle2").Return(bundle2, nil)
 
 	// Act
-	responses, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID)
+	page, err := suite.usecase.GetWarehouseItems(suite.ctx, resellerID, pagination.Request{})
+
+	// Assert
+	suite.NoError(err)
+	suite.NotNil(page)
+	suite.Len(page.Items, 2)
+	suite.Equal(int64(2), page.Total)
+	suite.Equal("item1", page.Items[0].ID)
+	suite.Equal("Test Bundle 1", page.Items[0].Title)
+	suite.Equal("item2", page.Items[1].ID)
+	suite.Equal("Test Bundle 2", page.Items[1].Title)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockBundleRepo.AssertExpectations(suite.T())
+}
+
+// TestReceiveBundle_Success tests that a pending bundle can be received
+func (suite *WarehouseUsecaseTestSuite) TestReceiveBundle_Success() {
+	// Arrange
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusPending}
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+	suite.mockRepo.On("UpdateItemStatus", suite.ctx, "item1", warehouse.StatusPending, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["status"] == warehouse.StatusReceived && updates["received_at"] != ""
+	})).Return(nil)
+
+	// Act
+	item, err := suite.usecase.ReceiveBundle(suite.ctx, "reseller1", "item1")
+
+	// Assert
+	suite.NoError(err)
+	suite.Equal(warehouse.StatusReceived, item.Status)
+	suite.NotEmpty(item.ReceivedAt)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestReceiveBundle_NotOwner tests that a reseller cannot receive another reseller's bundle
+func (suite *WarehouseUsecaseTestSuite) TestReceiveBundle_NotOwner() {
+	// Arrange
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller2", BundleID: "bundle1", Status: warehouse.StatusPending}
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+
+	// Act
+	_, err := suite.usecase.ReceiveBundle(suite.ctx, "reseller1", "item1")
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrNotItemOwner)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateItemStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestReceiveBundle_AlreadyReceived tests that a bundle is only received once
+func (suite *WarehouseUsecaseTestSuite) TestReceiveBundle_AlreadyReceived() {
+	// Arrange
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusReceived}
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+
+	// Act
+	_, err := suite.usecase.ReceiveBundle(suite.ctx, "reseller1", "item1")
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrNotReceivable)
+}
+
+// TestUnpackPiece_ClosesBundle tests that listing the last piece syncs the bundle and closes the entry
+func (suite *WarehouseUsecaseTestSuite) TestUnpackPiece_ClosesBundle() {
+	// Arrange
+	resellerID := primitive.NewObjectID()
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: resellerID.Hex(), BundleID: "bundle1", Status: warehouse.StatusReceived, RemainingItemCount: 1, Grade: "A"}
+	after := *entry
+	after.RemainingItemCount = 0
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+	suite.mockProdRepo.On("GetProductByID", suite.ctx, "product1").Return(&product.Product{ID: "product1", ResellerID: resellerID, BundleID: "bundle1", Type: "jackets"}, nil)
+	suite.mockRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{entry}, nil)
+	suite.mockRepo.On("TakePiece", suite.ctx, "item1").Return(&after, nil)
+	suite.mockRepo.On("AddItem", suite.ctx, mock.MatchedBy(func(piece *warehouse.WarehouseItem) bool {
+		return piece.EntryID == "item1" && piece.Status == warehouse.StatusListed && piece.ProductID == "product1" && piece.Type == "jackets" && piece.Grade == "A"
+	})).Return(nil)
+	// Closing the bundle rates how its contents matched the estimate
+	suite.mockBundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(&bundle.Bundle{ID: "bundle1", SupplierID: "supplier1", EstimatedBreakdown: map[string]int{"jackets": 2}}, nil)
+	suite.mockProdRepo.On("GetProductsByBundleID", suite.ctx, "bundle1").Return([]*product.Product{{ID: "product1", Type: "jackets"}}, nil)
+	suite.mockTrustUC.On("ApplyBreakdownDeviation", suite.ctx, "supplier1", 50.0).Return(nil)
+	suite.mockBundleRepo.On("UpdateBundle", suite.ctx, "bundle1", map[string]interface{}{"remaining_item_count": 0}).Return(nil)
+	suite.mockRepo.On("UpdateItemStatus", suite.ctx, "item1", warehouse.StatusReceived, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["status"] == warehouse.StatusClosed
+	})).Return(nil)
+
+	// Act
+	item, err := suite.usecase.UnpackPiece(suite.ctx, resellerID.Hex(), "item1", "product1")
 
 	// Assert
 	suite.NoError(err)
-	suite.NotNil(responses)
-	suite.Len(responses, 2)
-	suite.Equal("item1", responses[0].ID)
-	suite.Equal("Test Bundle 1", responses[0].Title)
-	suite.Equal("item2", responses[1].ID)
-	suite.Equal("Test Bundle 2", responses[1].Title)
+	suite.Equal(warehouse.StatusClosed, item.Status)
+	suite.Equal(0, item.RemainingItemCount)
 	suite.mockRepo.AssertExpectations(suite.T())
 	suite.mockBundleRepo.AssertExpectations(suite.T())
+	suite.mockTrustUC.AssertExpectations(suite.T())
+}
+
+// TestUnpackPiece_ProductFromAnotherBundle tests that a piece must be listed as a product of its own bundle
+func (suite *WarehouseUsecaseTestSuite) TestUnpackPiece_ProductFromAnotherBundle() {
+	// Arrange
+	resellerID := primitive.NewObjectID()
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: resellerID.Hex(), BundleID: "bundle1", Status: warehouse.StatusReceived, RemainingItemCount: 3}
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+	suite.mockProdRepo.On("GetProductByID", suite.ctx, "product1").Return(&product.Product{ID: "product1", ResellerID: resellerID, BundleID: "bundle2"}, nil)
+
+	// Act
+	_, err := suite.usecase.UnpackPiece(suite.ctx, resellerID.Hex(), "item1", "product1")
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrProductNotFromBundle)
+	suite.mockRepo.AssertNotCalled(suite.T(), "TakePiece", mock.Anything, mock.Anything)
+}
+
+// TestUnpackPiece_AlreadyUnpacked tests that a product cannot account for two pieces
+func (suite *WarehouseUsecaseTestSuite) TestUnpackPiece_AlreadyUnpacked() {
+	// Arrange
+	resellerID := primitive.NewObjectID()
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: resellerID.Hex(), BundleID: "bundle1", Status: warehouse.StatusReceived, RemainingItemCount: 3}
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+	suite.mockProdRepo.On("GetProductByID", suite.ctx, "product1").Return(&product.Product{ID: "product1", ResellerID: resellerID, BundleID: "bundle1"}, nil)
+	suite.mockRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{
+		entry,
+		{ID: "piece1", EntryID: "item1", BundleID: "bundle1", Status: warehouse.StatusListed, ProductID: "product1"},
+	}, nil)
+
+	// Act
+	_, err := suite.usecase.UnpackPiece(suite.ctx, resellerID.Hex(), "item1", "product1")
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrProductAlreadyUnpacked)
+	suite.mockRepo.AssertNotCalled(suite.T(), "TakePiece", mock.Anything, mock.Anything)
+}
+
+// TestListProduct_LegacyEntry tests that a new product is saved as a piece of a bundle
+// stored before bundles were received
+func (suite *WarehouseUsecaseTestSuite) TestListProduct_LegacyEntry() {
+	// Arrange
+	resellerID := primitive.NewObjectID()
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: resellerID.Hex(), BundleID: "bundle1", Status: warehouse.StatusArrived, RemainingItemCount: 3}
+	after := *entry
+	after.RemainingItemCount = 2
+	p := &product.Product{ID: "product1", ResellerID: resellerID, BundleID: "bundle1", Type: "jeans", Price: money.Money{Amount: 2500, Currency: "usd"}}
+	suite.mockRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{
+		{ID: "item0", ResellerID: "someone-else", BundleID: "bundle1", Status: warehouse.StatusClosed},
+		entry,
+	}, nil)
+	suite.mockRepo.On("TakePiece", suite.ctx, "item1").Return(&after, nil)
+	suite.mockProdRepo.On("AddProduct", suite.ctx, p).Return(nil)
+	suite.mockRepo.On("AddItem", suite.ctx, mock.MatchedBy(func(piece *warehouse.WarehouseItem) bool {
+		return piece.EntryID == "item1" && piece.Status == warehouse.StatusListed && piece.ProductID == "product1" && piece.Type == "jeans"
+	})).Return(nil)
+	suite.mockBundleRepo.On("UpdateBundle", suite.ctx, "bundle1", map[string]interface{}{"remaining_item_count": 2}).Return(nil)
+
+	// Act
+	item, err := suite.usecase.ListProduct(suite.ctx, resellerID.Hex(), p)
+
+	// Assert
+	suite.NoError(err)
+	suite.Equal(2, item.RemainingItemCount)
+	suite.Equal(money.USD, p.Price.Currency)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockProdRepo.AssertExpectations(suite.T())
+	suite.mockBundleRepo.AssertExpectations(suite.T())
+}
+
+// TestListProduct_BundleClosed tests that no product can be made from a bundle whose
+// pieces are all accounted for
+func (suite *WarehouseUsecaseTestSuite) TestListProduct_BundleClosed() {
+	// Arrange
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusClosed}
+	suite.mockRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{entry}, nil)
+
+	// Act
+	_, err := suite.usecase.ListProduct(suite.ctx, "reseller1", &product.Product{ID: "product1", BundleID: "bundle1"})
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrNothingToUnpack)
+	suite.mockRepo.AssertNotCalled(suite.T(), "TakePiece", mock.Anything, mock.Anything)
+	suite.mockProdRepo.AssertNotCalled(suite.T(), "AddProduct", mock.Anything, mock.Anything)
+}
+
+// TestSkipPiece_Success tests that a skipped piece is recorded with its reason
+func (suite *WarehouseUsecaseTestSuite) TestSkipPiece_Success() {
+	// Arrange
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusReceived, RemainingItemCount: 4}
+	after := *entry
+	after.RemainingItemCount = 3
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+	suite.mockRepo.On("TakePiece", suite.ctx, "item1").Return(&after, nil)
+	suite.mockRepo.On("AddItem", suite.ctx, mock.MatchedBy(func(piece *warehouse.WarehouseItem) bool {
+		return piece.Status == warehouse.StatusSkipped && piece.SkipReason == warehouse.SkipStained && piece.Type == "shirts" && piece.SkipNote == "wine on the collar"
+	})).Return(nil)
+	suite.mockBundleRepo.On("UpdateBundle", suite.ctx, "bundle1", map[string]interface{}{"remaining_item_count": 3}).Return(nil)
+
+	// Act
+	item, err := suite.usecase.SkipPiece(suite.ctx, "reseller1", "item1", warehouse.Skip{Reason: warehouse.SkipStained, Type: "shirts", Note: "wine on the collar"})
+
+	// Assert
+	suite.NoError(err)
+	suite.Equal(warehouse.StatusReceived, item.Status)
+	suite.Equal(3, item.RemainingItemCount)
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockBundleRepo.AssertExpectations(suite.T())
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateItemStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSkipPiece_SaveFails tests that a piece that could not be saved is reported and goes
+// no further than the failed write, so the transaction rolls the count back
+func (suite *WarehouseUsecaseTestSuite) TestSkipPiece_SaveFails() {
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusReceived, RemainingItemCount: 1}
+	after := *entry
+	after.RemainingItemCount = 0
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+	suite.mockRepo.On("TakePiece", suite.ctx, "item1").Return(&after, nil)
+	suite.mockRepo.On("AddItem", suite.ctx, mock.AnythingOfType("*warehouse.WarehouseItem")).Return(errors.New("write failed"))
+
+	_, err := suite.usecase.SkipPiece(suite.ctx, "reseller1", "item1", warehouse.Skip{Reason: warehouse.SkipDamaged})
+
+	suite.EqualError(err, "write failed")
+	suite.mockBundleRepo.AssertNotCalled(suite.T(), "UpdateBundle", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateItemStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockTrustUC.AssertNotCalled(suite.T(), "ApplyBreakdownDeviation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSkipPiece_InvalidReason tests that an unknown skip reason is rejected
+func (suite *WarehouseUsecaseTestSuite) TestSkipPiece_InvalidReason() {
+	// Act
+	_, err := suite.usecase.SkipPiece(suite.ctx, "reseller1", "item1", warehouse.Skip{Reason: "ugly"})
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrInvalidSkipReason)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetItemByID", mock.Anything, mock.Anything)
+}
+
+// TestSkipPiece_NotReceived tests that a bundle cannot be unpacked before it is received
+func (suite *WarehouseUsecaseTestSuite) TestSkipPiece_NotReceived() {
+	// Arrange
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusPending, RemainingItemCount: 4}
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+
+	// Act
+	_, err := suite.usecase.SkipPiece(suite.ctx, "reseller1", "item1", warehouse.Skip{Reason: warehouse.SkipDamaged})
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrNotUnpacking)
+}
+
+// TestSkipPiece_NothingLeft tests that a piece cannot be recorded once every piece is accounted for
+func (suite *WarehouseUsecaseTestSuite) TestSkipPiece_NothingLeft() {
+	// Arrange
+	entry := &warehouse.WarehouseItem{ID: "item1", ResellerID: "reseller1", BundleID: "bundle1", Status: warehouse.StatusReceived, RemainingItemCount: 1}
+	suite.mockRepo.On("GetItemByID", suite.ctx, "item1").Return(entry, nil)
+	// Another request took the last piece first
+	suite.mockRepo.On("TakePiece", suite.ctx, "item1").Return(nil, warehouse.ErrNothingToUnpack)
+
+	// Act
+	_, err := suite.usecase.SkipPiece(suite.ctx, "reseller1", "item1", warehouse.Skip{Reason: warehouse.SkipWrongSize})
+
+	// Assert
+	suite.ErrorIs(err, warehouse.ErrNothingToUnpack)
+	suite.mockRepo.AssertNotCalled(suite.T(), "AddItem", mock.Anything, mock.Anything)
+}
+
+// TestGetBundleBreakdown tests that products and skipped pieces are counted by category
+func (suite *WarehouseUsecaseTestSuite) TestGetBundleBreakdown() {
+	// Arrange
+	b := &bundle.Bundle{ID: "bundle1", EstimatedBreakdown: map[string]int{"jackets": 2, "jeans": 2}}
+	suite.mockBundleRepo.On("GetBundleByID", suite.ctx, "bundle1").Return(b, nil)
+	suite.mockProdRepo.On("GetProductsByBundleID", suite.ctx, "bundle1").Return([]*product.Product{
+		{ID: "p1", Type: "jackets"},
+		{ID: "p2", Type: "jeans"},
+	}, nil)
+	suite.mockRepo.On("GetItemsByBundle", suite.ctx, "bundle1").Return([]*warehouse.WarehouseItem{
+		{ID: "item1", BundleID: "bundle1", Status: warehouse.StatusReceived},
+		{ID: "piece1", EntryID: "item1", BundleID: "bundle1", Status: warehouse.StatusListed, ProductID: "p1", Type: "jackets"},
+		{ID: "piece2", EntryID: "item1", BundleID: "bundle1", Status: warehouse.StatusSkipped, Type: "jackets"},
+	}, nil)
+
+	// Act
+	breakdown, err := suite.usecase.GetBundleBreakdown(suite.ctx, "bundle1")
+
+	// Assert
+	suite.NoError(err)
+	suite.Equal(3, breakdown.Unpacked)
+	suite.False(breakdown.Complete)
+	suite.Require().Len(breakdown.Categories, 2)
+	suite.Equal(2, breakdown.Categories[0].Actual)
+	suite.Equal(1, breakdown.Categories[1].Actual)
+	suite.Require().NotNil(breakdown.DeviationPercent)
+	suite.InDelta(25.0, *breakdown.DeviationPercent, 0.001)
 }
 
 // TestWarehouseUsecase runs the test suite
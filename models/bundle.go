@@ -1,11 +1,16 @@
 package models
 
+import (
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/bundle"
+	"github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+)
+
 type CreateBundleRequest struct {
 	Title              string         `json:"title" binding:"required"`
 	SampleImage        string         `json:"sample_image"`
 	NumberOfItems      int            `json:"number_of_items" binding:"required"`
 	Grade              string         `json:"grade" binding:"required"`
-	Price              float64        `json:"price" binding:"required"`
+	Price              money.Money    `json:"price"`
 	Description        string         `json:"description"`
 	SizeRange          string         `json:"size_range"`
 	Type               string         `json:"type" binding:"required"`
@@ -22,7 +27,8 @@ type BundleResponse struct {
 	Description        string         `json:"description"`
 	SizeRange          string         `json:"size_range"`
 	Type               string         `json:"type"`
-	Price              float64        `json:"price"`
+	Price              money.Money    `json:"price"`
+	DisplayPrice       *money.Money   `json:"display_price,omitempty"`
 	Status             string         `json:"status"`
 	EstimatedBreakdown map[string]int `json:"estimated_breakdown"`
 	DeclaredRating     int            `json:"declared_rating"`
@@ -30,21 +36,30 @@ type BundleResponse struct {
 	CreatedAt          string         `json:"created_at,omitempty"`
 }
 
+// AvailableBundleResponse is a marketplace listing, with its price converted to the
+// currency the reseller asked for when a rate is available.
+type AvailableBundleResponse struct {
+	*bundle.Bundle
+	DisplayPrice *money.Money `json:"display_price,omitempty"`
+}
+
 type BundleDetailResponse struct {
 	Bundle struct {
-		ID                 string         `json:"id"`
-		Title              string         `json:"title"`
-		Description        string         `json:"description"`
-		SampleImage        string         `json:"sample_image"`
-		Quantity           int            `json:"quantity"`
-		Grade              string         `json:"grade"`
-		SortingLevel       string         `json:"sorting_level"`
-		EstimatedBreakdown map[string]int `json:"estimated_breakdown"`
-		Type               string         `json:"type"`
-		Price              float64        `json:"price"`
-		Status             string         `json:"status"`
-		DeclaredRating     int            `json:"declared_rating"`
-		RemainingItemCount int            `json:"remaining_item_count"`
+		ID                 string            `json:"id"`
+		Title              string            `json:"title"`
+		Description        string            `json:"description"`
+		SampleImage        string            `json:"sample_image"`
+		Quantity           int               `json:"quantity"`
+		Grade              string            `json:"grade"`
+		SortingLevel       string            `json:"sorting_level"`
+		EstimatedBreakdown map[string]int    `json:"estimated_breakdown"`
+		ActualBreakdown    *bundle.Breakdown `json:"actual_breakdown"`
+		Type               string            `json:"type"`
+		Price              money.Money       `json:"price"`
+		DisplayPrice       *money.Money      `json:"display_price,omitempty"`
+		Status             string            `json:"status"`
+		DeclaredRating     int               `json:"declared_rating"`
+		RemainingItemCount int               `json:"remaining_item_count"`
 	} `json:"bundle"`
 	Supplier struct {
 		ID     string  `json:"id"`
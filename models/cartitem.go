@@ -1,43 +1,53 @@
 package models
 
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+
 type CreateCartItemRequest struct {
 	ListingID string `json:"listing_id" binding:"required"`
 }
 
 // CartItemResponse remains the same.
 type CartItemResponse struct {
-	ID        string  `json:"id"`
-	ListingID string  `json:"listing_id"`
-	Title     string  `json:"title"`
-	Price     float64 `json:"price"`
-	ImageURL  string  `json:"image_url"`
-	Grade     string  `json:"grade"`
-	CreatedAt string  `json:"created_at"`
-	Rating    float64 `json:"rating"`
+	ID           string       `json:"id"`
+	ListingID    string       `json:"listing_id"`
+	Title        string       `json:"title"`
+	Price        money.Money  `json:"price"`
+	DisplayPrice *money.Money `json:"display_price,omitempty"`
+	ImageURL     string       `json:"image_url"`
+	Grade        string       `json:"grade"`
+	CreatedAt    string       `json:"created_at"`
+	Rating       float64      `json:"rating"`
+
+	// HeldByOther is set while another consumer is checking the item out.
+	HeldByOther bool `json:"held_by_other"`
 }
 
 type CheckoutItemResponse struct {
-	ListingID string  `json:"listingId"`
-	Title     string  `json:"title"`
-	Price     float64 `json:"price"`
-	SellerID  string  `json:"sellerId"`
-	Status    string  `json:"status"` // "available", "sold"
+	ListingID string      `json:"listingId"`
+	Title     string      `json:"title"`
+	Price     money.Money `json:"price"`
+	SellerID  string      `json:"sellerId"`
+	Status    string      `json:"status"` // "available", "sold"
 }
 
 type CheckoutResponse struct {
-	TotalAmount float64                `json:"totalAmount"`
+	TotalAmount money.Money            `json:"totalAmount"` // what was charged, in the checkout currency
 	Items       []CheckoutItemResponse `json:"items"`
-	PlatformFee float64                `json:"platformFee"` // 2%
-	NetPayable  float64                `json:"netPayable"`  // Total - fee
+	PlatformFee []money.Money          `json:"platformFee"` // per listing currency
+	NetPayable  []money.Money          `json:"netPayable"`  // Total - fee, per listing currency
+	OrderIDs    []string               `json:"orderIds"`    // one order per reseller and listing currency
+
+	ShippingCost      []money.Money `json:"shippingCost"`      // per listing currency, included in TotalAmount
+	EstimatedDelivery string        `json:"estimatedDelivery"` // when the last of the orders should arrive
 }
 
 type PaymentRecord struct {
-	ID             string  `json:"id"`
-	BuyerID        string  `json:"buyerId"`
-	SellerID       string  `json:"sellerId"`
-	ProductID      string  `json:"productId"`
-	Amount         float64 `json:"amount"`
-	PlatformFee    float64 `json:"platformFee"`    // 2% fee for Admin
-	SellerEarnings float64 `json:"sellerEarnings"` // Amount - PlatformFee
-	Status         string  `json:"status"`         // "paid"
+	ID             string      `json:"id"`
+	BuyerID        string      `json:"buyerId"`
+	SellerID       string      `json:"sellerId"`
+	ProductID      string      `json:"productId"`
+	Amount         money.Money `json:"amount"`
+	PlatformFee    money.Money `json:"platformFee"`    // 2% fee for Admin
+	SellerEarnings money.Money `json:"sellerEarnings"` // Amount - PlatformFee
+	Status         string      `json:"status"`         // "paid"
 }
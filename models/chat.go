@@ -11,6 +11,12 @@ type MessageResponse struct {
 	SenderID   string `json:"sender_id"`
 	ReceiverID string `json:"receiver_id"`
 	Content    string `json:"content"`
+	RelatedTo  string `json:"related_to,omitempty"`
 	Timestamp  string `json:"timestamp"`
 	IsRead     bool   `json:"is_read"`
 }
+
+type ConversationResponse struct {
+	CounterpartyID string          `json:"counterparty_id"`
+	LastMessage    MessageResponse `json:"last_message"`
+}
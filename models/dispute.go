@@ -0,0 +1,22 @@
+package models
+
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+
+type OpenDisputeRequest struct {
+	OrderID         string      `json:"order_id" binding:"required"`
+	Reason          string      `json:"reason" binding:"required"`
+	Description     string      `json:"description"`
+	Photos          []string    `json:"photos"`
+	RequestedRemedy string      `json:"requested_remedy" binding:"required"`
+	RequestedAmount money.Money `json:"requested_amount"`
+}
+
+type DisputeReplyRequest struct {
+	Response string `json:"response" binding:"required"`
+}
+
+type ResolveDisputeRequest struct {
+	Outcome      string      `json:"outcome" binding:"required"`
+	RefundAmount money.Money `json:"refund_amount"`
+	Note         string      `json:"note"`
+}
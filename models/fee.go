@@ -0,0 +1,14 @@
+package models
+
+type FeeRuleRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	PaymentType string  `json:"payment_type"`
+	SellerTier  string  `json:"seller_tier"`
+	Category    string  `json:"category"`
+	Currency    string  `json:"currency"`
+	Percent     float64 `json:"percent"`
+	Flat        int64   `json:"flat"` // in minor units of Currency
+	MinFee      int64   `json:"min_fee"`
+	MaxFee      int64   `json:"max_fee"`
+	Priority    int     `json:"priority"`
+}
@@ -0,0 +1,7 @@
+package models
+
+type ExchangeRateRequest struct {
+	From string  `json:"from" binding:"required"`
+	To   string  `json:"to" binding:"required"`
+	Rate float64 `json:"rate" binding:"required"`
+}
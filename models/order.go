@@ -1,16 +1,24 @@
 package models
 
+import "github.com/Zeamanuel-Admasu/afro-vintage-backend/internal/domain/money"
+
 type OrderRequest struct {
 	ProductID string `json:"product_id"`
 }
 
 type OrderResponse struct {
-	ID                    string  `json:"id"`
-	BuyerID               string  `json:"buyer_id"`
-	ProductTitle          string  `json:"product_title"`
-	Status                string  `json:"status"`
-	TotalPrice            float64 `json:"total_price"`
-	CreatedAt             string  `json:"created_at"`
-	ImageURL              string  `json:"image_url"`
-	EstimatedDeliveryTime string  `json:"estimated_delivery_time"`
+	ID                    string      `json:"id"`
+	BuyerID               string      `json:"buyer_id"`
+	ProductTitle          string      `json:"product_title"`
+	Status                string      `json:"status"`
+	TotalPrice            money.Money `json:"total_price"`
+	CreatedAt             string      `json:"created_at"`
+	ImageURL              string      `json:"image_url"`
+	EstimatedDeliveryTime string      `json:"estimated_delivery_time"`
+}
+
+type ShipOrderRequest struct {
+	// TrackingNumber may be left out for carriers that issue their own.
+	TrackingNumber string `json:"tracking_number"`
+	Carrier        string `json:"carrier" binding:"required"`
 }
@@ -8,10 +8,12 @@ type RatingRequest struct {
 }
 
 type RatingResponse struct {
-	ID        string `json:"id"`
-	RaterID   string `json:"rater_id"`
-	RateeID   string `json:"ratee_id"`
-	Score     int    `json:"score"`
-	Comment   string `json:"comment"`
-	CreatedAt string `json:"created_at"`
+	ID        string  `json:"id"`
+	RaterID   string  `json:"rater_id"`
+	RateeID   string  `json:"ratee_id"`
+	BundleID  string  `json:"bundle_id"`
+	Score     int     `json:"score"`
+	Comment   string  `json:"comment"`
+	SkipRate  float64 `json:"skip_rate"`
+	CreatedAt string  `json:"created_at"`
 }
@@ -0,0 +1,21 @@
+package models
+
+type AddressRequest struct {
+	Label         string `json:"label"`
+	RecipientName string `json:"recipient_name" binding:"required"`
+	Phone         string `json:"phone" binding:"required"`
+	Line1         string `json:"line1" binding:"required"`
+	Line2         string `json:"line2"`
+	City          string `json:"city" binding:"required"`
+	Region        string `json:"region"`
+	PostalCode    string `json:"postal_code"`
+	Country       string `json:"country" binding:"required"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+// DeliveryRequest is the address book entry and delivery option a checkout ships
+// with.
+type DeliveryRequest struct {
+	AddressID      string `json:"address_id" binding:"required"`
+	DeliveryOption string `json:"delivery_option" binding:"required"`
+}
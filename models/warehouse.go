@@ -11,3 +11,14 @@ type WarehouseItemResponse struct {
 	DeclaredRating float64 `json:"declared_rating"`
 	RemainingItems int     `json:"remaining_items"`
 }
+
+type UnpackPieceRequest struct {
+	ProductID string `json:"product_id" binding:"required"`
+}
+
+type SkipPieceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	// Type is the piece's category, e.g. jackets, for the bundle's breakdown.
+	Type string `json:"type"`
+	Note string `json:"note"`
+}